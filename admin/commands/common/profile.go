@@ -0,0 +1,237 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/utils/debug"
+)
+
+const (
+	// defaultCPUProfileDuration is the duration of CPU profiles if none is given
+	defaultCPUProfileDuration = 10 * time.Second
+	// maxCPUProfileDuration is the upper bound for the duration of CPU profiles
+	maxCPUProfileDuration = 5 * time.Minute
+)
+
+// profiles are the profiles which can be captured with the ProfileCommand.
+var profiles = map[string]struct{}{
+	"cpu":       {},
+	"heap":      {},
+	"allocs":    {},
+	"goroutine": {},
+	"block":     {},
+	"mutex":     {},
+}
+
+var _ commands.AdminCommand = (*ProfileCommand)(nil)
+
+// ProfileCommand captures runtime profiles on demand and stores them in the profiler
+// directory. The request fields are:
+//   - "profiles": the profiles to capture, any of "cpu", "heap", "allocs", "goroutine", "block" and "mutex"
+//   - "cpu-duration": the duration of the CPU profile (optional, default 10s, at most 5m)
+//   - "binstat": whether to also write a snapshot of the binstat bins (optional)
+//
+// The response maps the captured profiles to the paths of their files. Block and mutex
+// profiles are empty unless enabled with the SetProfilerRatesCommand.
+type ProfileCommand struct {
+	dir string
+}
+
+type profileRequest struct {
+	profiles    []string
+	cpuDuration time.Duration
+	binstat     bool
+}
+
+func NewProfileCommand(dir string) *ProfileCommand {
+	return &ProfileCommand{
+		dir: dir,
+	}
+}
+
+func (p *ProfileCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*profileRequest)
+
+	files := make(map[string]interface{}, len(data.profiles)+1)
+	cpu := false
+	for _, profile := range data.profiles {
+		// the CPU profile takes a while, the others are captured first
+		if profile == "cpu" {
+			cpu = true
+			continue
+		}
+		path, err := debug.WriteProfile(p.dir, profile)
+		if err != nil {
+			return nil, err
+		}
+		files[profile] = path
+	}
+
+	if data.binstat {
+		path, err := debug.WriteBinstat(p.dir)
+		if err != nil {
+			return nil, err
+		}
+		files["binstat"] = path
+	}
+
+	if cpu {
+		path, err := debug.WriteCPUProfile(ctx, p.dir, data.cpuDuration)
+		if err != nil {
+			return nil, err
+		}
+		files["cpu"] = path
+	}
+
+	return files, nil
+}
+
+func (p *ProfileCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return errors.New("wrong input format: expected JSON")
+	}
+
+	data := &profileRequest{
+		cpuDuration: defaultCPUProfileDuration,
+	}
+
+	list, ok := input["profiles"].([]interface{})
+	if !ok || len(list) == 0 {
+		return errors.New("the \"profiles\" field must be a non-empty list")
+	}
+	seen := make(map[string]struct{}, len(list))
+	for _, item := range list {
+		profile, ok := item.(string)
+		if !ok {
+			return fmt.Errorf("invalid value for \"profiles\": expected a string, but got: %v", item)
+		}
+		if _, ok := profiles[profile]; !ok {
+			return fmt.Errorf("invalid value for \"profiles\": unknown profile %q", profile)
+		}
+		if _, ok := seen[profile]; ok {
+			continue
+		}
+		seen[profile] = struct{}{}
+		data.profiles = append(data.profiles, profile)
+	}
+
+	if value, ok := input["cpu-duration"]; ok {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("invalid value for \"cpu-duration\": expected a duration string, but got: %v", value)
+		}
+		duration, err := time.ParseDuration(str)
+		if err != nil {
+			return fmt.Errorf("invalid value for \"cpu-duration\": %w", err)
+		}
+		if duration <= 0 || duration > maxCPUProfileDuration {
+			return fmt.Errorf("invalid value for \"cpu-duration\": must be between 0 and %s", maxCPUProfileDuration)
+		}
+		data.cpuDuration = duration
+	}
+
+	if value, ok := input["binstat"]; ok {
+		data.binstat, ok = value.(bool)
+		if !ok {
+			return fmt.Errorf("invalid value for \"binstat\": expected a bool, but got: %v", value)
+		}
+	}
+
+	req.ValidatorData = data
+	return nil
+}
+
+var _ commands.AdminCommand = (*SetProfilerRatesCommand)(nil)
+
+// SetProfilerRatesCommand sets the sampling rates of the mutex and block profiles at runtime,
+// both are disabled by default. All fields of the request are optional:
+//   - "mutex-fraction": on average 1/fraction of the mutex contention events are reported, 0 disables the profile
+//   - "block-rate": one blocking event per rate nanoseconds spent blocked is reported, 0 disables the profile
+//
+// The response contains the previous values of the rates.
+type SetProfilerRatesCommand struct {
+	mu sync.Mutex
+	// blockRate is the last block profile rate, the runtime does not expose the current rate
+	blockRate int
+}
+
+type profilerRates struct {
+	mutexFraction *int
+	blockRate     *int
+}
+
+func NewSetProfilerRatesCommand() *SetProfilerRatesCommand {
+	return &SetProfilerRatesCommand{}
+}
+
+func (s *SetProfilerRatesCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	rates := req.ValidatorData.(*profilerRates)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// a negative fraction only reads the current fraction
+	previous := map[string]interface{}{
+		"mutex-fraction": runtime.SetMutexProfileFraction(-1),
+		"block-rate":     s.blockRate,
+	}
+	if rates.mutexFraction != nil {
+		runtime.SetMutexProfileFraction(*rates.mutexFraction)
+	}
+	if rates.blockRate != nil {
+		runtime.SetBlockProfileRate(*rates.blockRate)
+		s.blockRate = *rates.blockRate
+	}
+
+	return previous, nil
+}
+
+func (s *SetProfilerRatesCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return errors.New("wrong input format: expected JSON")
+	}
+
+	rates := &profilerRates{}
+	for field, value := range input {
+		if field != "mutex-fraction" && field != "block-rate" {
+			return fmt.Errorf("unknown field %q", field)
+		}
+		rate, err := parseRate(field, value)
+		if err != nil {
+			return err
+		}
+		if field == "mutex-fraction" {
+			rates.mutexFraction = &rate
+		} else {
+			rates.blockRate = &rate
+		}
+	}
+	if rates.mutexFraction == nil && rates.blockRate == nil {
+		return errors.New("at least one of \"mutex-fraction\" and \"block-rate\" must be set")
+	}
+
+	req.ValidatorData = rates
+	return nil
+}
+
+// parseRate parses a non-negative integer rate, JSON numbers are decoded as float64.
+func parseRate(field string, value interface{}) (int, error) {
+	number, ok := value.(float64)
+	if !ok {
+		return 0, fmt.Errorf("invalid value for %q: expected a number, but got: %v", field, value)
+	}
+	rate := int(number)
+	if float64(rate) != number || rate < 0 {
+		return 0, fmt.Errorf("invalid value for %q: must be a non-negative integer", field)
+	}
+	return rate, nil
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestProfile(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		command := NewProfileCommand(t.TempDir())
+
+		for _, data := range []interface{}{
+			"heap",
+			map[string]interface{}{},
+			map[string]interface{}{"profiles": []interface{}{}},
+			map[string]interface{}{"profiles": []interface{}{"trace"}},
+			map[string]interface{}{"profiles": []interface{}{1}},
+			map[string]interface{}{"profiles": []interface{}{"cpu"}, "cpu-duration": 10},
+			map[string]interface{}{"profiles": []interface{}{"cpu"}, "cpu-duration": "1h"},
+			map[string]interface{}{"profiles": []interface{}{"heap"}, "binstat": "yes"},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}), data)
+		}
+	})
+
+	t.Run("capture profiles", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			command := NewProfileCommand(dir)
+
+			req := &admin.CommandRequest{Data: map[string]interface{}{
+				"profiles":     []interface{}{"heap", "goroutine", "cpu", "heap"},
+				"cpu-duration": "10ms",
+				"binstat":      true,
+			}}
+			require.NoError(t, command.Validator(req))
+			result, err := command.Handler(context.Background(), req)
+			require.NoError(t, err)
+
+			files := result.(map[string]interface{})
+			require.Len(t, files, 4)
+			for _, profile := range []string{"heap", "goroutine", "cpu", "binstat"} {
+				info, err := os.Stat(files[profile].(string))
+				require.NoError(t, err, profile)
+				assert.Positive(t, info.Size(), profile)
+			}
+		})
+	})
+}
+
+func TestSetProfilerRates(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		command := NewSetProfilerRatesCommand()
+
+		for _, data := range []interface{}{
+			"1",
+			map[string]interface{}{},
+			map[string]interface{}{"unknown": float64(1)},
+			map[string]interface{}{"mutex-fraction": "1"},
+			map[string]interface{}{"mutex-fraction": float64(-1)},
+			map[string]interface{}{"block-rate": float64(1.5)},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}), data)
+		}
+	})
+
+	t.Run("set rates", func(t *testing.T) {
+		command := NewSetProfilerRatesCommand()
+		initialFraction := runtime.SetMutexProfileFraction(-1)
+
+		set := func(data map[string]interface{}) map[string]interface{} {
+			req := &admin.CommandRequest{Data: data}
+			require.NoError(t, command.Validator(req))
+			result, err := command.Handler(context.Background(), req)
+			require.NoError(t, err)
+			return result.(map[string]interface{})
+		}
+
+		previous := set(map[string]interface{}{"mutex-fraction": float64(5), "block-rate": float64(100)})
+		assert.Equal(t, initialFraction, previous["mutex-fraction"])
+		assert.Equal(t, 0, previous["block-rate"])
+		assert.Equal(t, 5, runtime.SetMutexProfileFraction(-1))
+
+		// restore the defaults, returning the rates set before
+		previous = set(map[string]interface{}{"mutex-fraction": float64(initialFraction), "block-rate": float64(0)})
+		assert.Equal(t, 5, previous["mutex-fraction"])
+		assert.Equal(t, 100, previous["block-rate"])
+	})
+}
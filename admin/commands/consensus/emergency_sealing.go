@@ -0,0 +1,79 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+)
+
+var _ commands.AdminCommand = (*GetEmergencySealingCommand)(nil)
+
+// GetEmergencySealingCommand returns whether emergency sealing is active and its thresholds.
+type GetEmergencySealingCommand struct {
+	emergencySealing *approvals.EmergencySealing
+}
+
+func NewGetEmergencySealingCommand(emergencySealing *approvals.EmergencySealing) *GetEmergencySealingCommand {
+	return &GetEmergencySealingCommand{
+		emergencySealing: emergencySealing,
+	}
+}
+
+func (g *GetEmergencySealingCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	return emergencySealingToMap(g.emergencySealing.Config()), nil
+}
+
+func (g *GetEmergencySealingCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
+
+var _ commands.AdminCommand = (*SetEmergencySealingCommand)(nil)
+
+// SetEmergencySealingCommand activates or deactivates emergency sealing at runtime.
+// The request data is a bool, true activates emergency sealing.
+type SetEmergencySealingCommand struct {
+	log              zerolog.Logger
+	emergencySealing *approvals.EmergencySealing
+}
+
+func NewSetEmergencySealingCommand(log zerolog.Logger, emergencySealing *approvals.EmergencySealing) *SetEmergencySealingCommand {
+	return &SetEmergencySealingCommand{
+		log:              log.With().Str("admin_command", "set-emergency-sealing").Logger(),
+		emergencySealing: emergencySealing,
+	}
+}
+
+func (s *SetEmergencySealingCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	active := req.ValidatorData.(bool)
+
+	previous := s.emergencySealing.SetActive(active)
+	if previous != active {
+		s.log.Warn().
+			Bool("active", active).
+			Msg("emergency sealing (de)activated")
+	}
+
+	return emergencySealingToMap(s.emergencySealing.Config()), nil
+}
+
+func (s *SetEmergencySealingCommand) Validator(req *admin.CommandRequest) error {
+	active, ok := req.Data.(bool)
+	if !ok {
+		return errors.New("the input must be a bool")
+	}
+	req.ValidatorData = active
+	return nil
+}
+
+func emergencySealingToMap(config approvals.EmergencySealingConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"active":                    config.Active,
+		"unsealed-height-threshold": config.UnsealedHeightThreshold,
+		"required-receipts":         config.RequiredReceipts,
+	}
+}
@@ -0,0 +1,62 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	"github.com/onflow/flow-go/module/metrics"
+	storage "github.com/onflow/flow-go/storage/mock"
+)
+
+func newEmergencySealing() *approvals.EmergencySealing {
+	return approvals.NewEmergencySealing(approvals.DefaultEmergencySealingConfig(), &storage.ExecutionReceipts{}, metrics.NewNoopCollector())
+}
+
+func TestGetEmergencySealing(t *testing.T) {
+	command := NewGetEmergencySealingCommand(newEmergencySealing())
+
+	req := &admin.CommandRequest{}
+	require.NoError(t, command.Validator(req))
+	result, err := command.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	config := result.(map[string]interface{})
+	assert.Equal(t, false, config["active"])
+	assert.Equal(t, uint64(approvals.DefaultEmergencySealingThreshold), config["unsealed-height-threshold"])
+	assert.Equal(t, uint(approvals.DefaultEmergencySealingRequiredReceipts), config["required-receipts"])
+}
+
+func TestSetEmergencySealing(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		command := NewSetEmergencySealingCommand(zerolog.Nop(), newEmergencySealing())
+
+		for _, data := range []interface{}{
+			"true",
+			1,
+			map[string]interface{}{"active": true},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}), data)
+		}
+	})
+
+	t.Run("activate and deactivate", func(t *testing.T) {
+		emergencySealing := newEmergencySealing()
+		command := NewSetEmergencySealingCommand(zerolog.Nop(), emergencySealing)
+
+		for _, active := range []bool{true, false} {
+			req := &admin.CommandRequest{Data: active}
+			require.NoError(t, command.Validator(req))
+			result, err := command.Handler(context.Background(), req)
+			require.NoError(t, err)
+
+			assert.Equal(t, active, result.(map[string]interface{})["active"])
+			assert.Equal(t, active, emergencySealing.Active())
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/module"
+)
+
+const (
+	// maxReplicaTimeout is the upper bound for the replica timeouts which can be set at runtime
+	maxReplicaTimeout = 10 * time.Minute
+	// maxBlockRateDelay is the upper bound for the block rate delay which can be set at runtime
+	maxBlockRateDelay = 10 * time.Second
+)
+
+var _ commands.AdminCommand = (*GetTimeoutConfigCommand)(nil)
+
+// GetTimeoutConfigCommand returns the current configuration of the HotStuff pacemaker timeouts.
+type GetTimeoutConfigCommand struct {
+	controller *timeout.Controller
+}
+
+func NewGetTimeoutConfigCommand(controller *timeout.Controller) *GetTimeoutConfigCommand {
+	return &GetTimeoutConfigCommand{
+		controller: controller,
+	}
+}
+
+func (g *GetTimeoutConfigCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	return configToMap(g.controller.Config()), nil
+}
+
+func (g *GetTimeoutConfigCommand) Validator(req *admin.CommandRequest) error {
+	return nil
+}
+
+var _ commands.AdminCommand = (*SetTimeoutConfigCommand)(nil)
+
+// SetTimeoutConfigCommand updates the configuration of the HotStuff pacemaker timeouts at runtime.
+// All fields of the request are optional, fields which are not set keep their current value:
+//   - "replica-timeout": the current replica timeout (duration, e.g. "2s")
+//   - "min-replica-timeout": the lower bound of the replica timeout (duration)
+//   - "block-rate-delay": the delay for broadcasting block proposals (duration, e.g. "500ms")
+//   - "timeout-increase-factor": multiplicative increase of the timeout on timeouts
+//   - "timeout-decrease-factor": multiplicative decrease of the timeout on progress
+//   - "vote-aggregation-timeout-fraction": fraction of the timeout reserved for vote aggregation
+type SetTimeoutConfigCommand struct {
+	log        zerolog.Logger
+	controller *timeout.Controller
+	metrics    module.HotstuffMetrics
+}
+
+func NewSetTimeoutConfigCommand(log zerolog.Logger, controller *timeout.Controller, metrics module.HotstuffMetrics) *SetTimeoutConfigCommand {
+	return &SetTimeoutConfigCommand{
+		log:        log.With().Str("admin_command", "set-hotstuff-timeout-config").Logger(),
+		controller: controller,
+		metrics:    metrics,
+	}
+}
+
+// timeoutConfigUpdate holds the validated fields of a set-hotstuff-timeout-config request.
+type timeoutConfigUpdate struct {
+	replicaTimeout                 *time.Duration
+	minReplicaTimeout              *time.Duration
+	blockRateDelay                 *time.Duration
+	timeoutIncrease                *float64
+	timeoutDecrease                *float64
+	voteAggregationTimeoutFraction *float64
+}
+
+func (s *SetTimeoutConfigCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	update := req.ValidatorData.(*timeoutConfigUpdate)
+
+	old := s.controller.Config()
+	cfg, err := s.controller.UpdateConfig(func(cfg *timeout.Config) {
+		if update.replicaTimeout != nil {
+			cfg.ReplicaTimeout = float64(update.replicaTimeout.Milliseconds())
+		}
+		if update.minReplicaTimeout != nil {
+			cfg.MinReplicaTimeout = float64(update.minReplicaTimeout.Milliseconds())
+		}
+		if update.blockRateDelay != nil {
+			cfg.BlockRateDelayMS = float64(update.blockRateDelay.Milliseconds())
+		}
+		if update.timeoutIncrease != nil {
+			cfg.TimeoutIncrease = *update.timeoutIncrease
+		}
+		if update.timeoutDecrease != nil {
+			cfg.TimeoutDecrease = *update.timeoutDecrease
+		}
+		if update.voteAggregationTimeoutFraction != nil {
+			cfg.VoteAggregationTimeoutFraction = *update.voteAggregationTimeoutFraction
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout configuration: %w", err)
+	}
+
+	s.metrics.CountTimeoutConfigUpdate()
+	s.metrics.SetMinTimeout(msToDuration(cfg.MinReplicaTimeout))
+	s.metrics.SetBlockRateDelay(msToDuration(cfg.BlockRateDelayMS))
+
+	s.log.Info().
+		Interface("old_config", configToMap(old)).
+		Interface("new_config", configToMap(cfg)).
+		Msg("hotstuff timeout configuration updated")
+
+	return configToMap(cfg), nil
+}
+
+func (s *SetTimeoutConfigCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return errors.New("wrong input format: expected JSON")
+	}
+	if len(input) == 0 {
+		return errors.New("at least one configuration field must be set")
+	}
+
+	update := &timeoutConfigUpdate{}
+	for field, value := range input {
+		var err error
+		switch field {
+		case "replica-timeout":
+			update.replicaTimeout, err = parseDuration(field, value, maxReplicaTimeout)
+		case "min-replica-timeout":
+			update.minReplicaTimeout, err = parseDuration(field, value, maxReplicaTimeout)
+		case "block-rate-delay":
+			update.blockRateDelay, err = parseDuration(field, value, maxBlockRateDelay)
+		case "timeout-increase-factor":
+			update.timeoutIncrease, err = parseFloat(field, value)
+		case "timeout-decrease-factor":
+			update.timeoutDecrease, err = parseFloat(field, value)
+		case "vote-aggregation-timeout-fraction":
+			update.voteAggregationTimeoutFraction, err = parseFloat(field, value)
+		default:
+			err = fmt.Errorf("unknown field %q", field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	req.ValidatorData = update
+	return nil
+}
+
+func parseDuration(field string, value interface{}, max time.Duration) (*time.Duration, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid value for %q: expected a duration string, but got: %v", field, value)
+	}
+	duration, err := time.ParseDuration(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %q: %w", field, err)
+	}
+	if duration < 0 || duration > max {
+		return nil, fmt.Errorf("invalid value for %q: must be between 0 and %s", field, max)
+	}
+	return &duration, nil
+}
+
+func parseFloat(field string, value interface{}) (*float64, error) {
+	f, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("invalid value for %q: expected a number, but got: %v", field, value)
+	}
+	return &f, nil
+}
+
+func msToDuration(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+func configToMap(cfg timeout.Config) map[string]interface{} {
+	return map[string]interface{}{
+		"replica-timeout":                   msToDuration(cfg.ReplicaTimeout).String(),
+		"min-replica-timeout":               msToDuration(cfg.MinReplicaTimeout).String(),
+		"block-rate-delay":                  msToDuration(cfg.BlockRateDelayMS).String(),
+		"timeout-increase-factor":           cfg.TimeoutIncrease,
+		"timeout-decrease-factor":           cfg.TimeoutDecrease,
+		"vote-aggregation-timeout-fraction": cfg.VoteAggregationTimeoutFraction,
+	}
+}
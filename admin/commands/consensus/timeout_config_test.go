@@ -0,0 +1,93 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+func newController(t *testing.T) *timeout.Controller {
+	cfg, err := timeout.NewConfig(10*time.Second, 2*time.Second, 0.5, 2, 0.7, 500*time.Millisecond)
+	require.NoError(t, err)
+	return timeout.NewController(cfg)
+}
+
+func TestGetTimeoutConfig(t *testing.T) {
+	command := NewGetTimeoutConfigCommand(newController(t))
+
+	req := &admin.CommandRequest{}
+	require.NoError(t, command.Validator(req))
+	result, err := command.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	config := result.(map[string]interface{})
+	assert.Equal(t, "10s", config["replica-timeout"])
+	assert.Equal(t, "2s", config["min-replica-timeout"])
+	assert.Equal(t, "500ms", config["block-rate-delay"])
+	assert.Equal(t, 2.0, config["timeout-increase-factor"])
+}
+
+func TestSetTimeoutConfig(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		command := NewSetTimeoutConfigCommand(zerolog.Nop(), newController(t), metrics.NewNoopCollector())
+
+		for _, data := range []interface{}{
+			"foo",
+			map[string]interface{}{},
+			map[string]interface{}{"unknown": "1s"},
+			map[string]interface{}{"block-rate-delay": 500},
+			map[string]interface{}{"block-rate-delay": "fast"},
+			map[string]interface{}{"block-rate-delay": "-1s"},
+			map[string]interface{}{"block-rate-delay": "1h"},
+			map[string]interface{}{"timeout-increase-factor": "2"},
+		} {
+			assert.Error(t, command.Validator(&admin.CommandRequest{Data: data}), data)
+		}
+	})
+
+	t.Run("valid update", func(t *testing.T) {
+		controller := newController(t)
+		command := NewSetTimeoutConfigCommand(zerolog.Nop(), controller, metrics.NewNoopCollector())
+
+		req := &admin.CommandRequest{Data: map[string]interface{}{
+			"block-rate-delay":        "1s",
+			"min-replica-timeout":     "3s",
+			"timeout-increase-factor": 1.5,
+		}}
+		require.NoError(t, command.Validator(req))
+		_, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Second, controller.BlockRateDelay())
+		cfg := controller.Config()
+		assert.Equal(t, float64(3000), cfg.MinReplicaTimeout)
+		assert.Equal(t, 1.5, cfg.TimeoutIncrease)
+		// unchanged fields keep their value
+		assert.Equal(t, float64(10000), cfg.ReplicaTimeout)
+		assert.Equal(t, 0.7, cfg.TimeoutDecrease)
+	})
+
+	t.Run("inconsistent update is rejected", func(t *testing.T) {
+		controller := newController(t)
+		command := NewSetTimeoutConfigCommand(zerolog.Nop(), controller, metrics.NewNoopCollector())
+
+		req := &admin.CommandRequest{Data: map[string]interface{}{
+			"block-rate-delay":        "1s",
+			"timeout-decrease-factor": 1.5,
+		}}
+		require.NoError(t, command.Validator(req))
+		_, err := command.Handler(context.Background(), req)
+		require.Error(t, err)
+
+		// the configuration is left unchanged
+		assert.Equal(t, 500*time.Millisecond, controller.BlockRateDelay())
+	})
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*CompareReceiptsCommand)(nil)
+
+type receiptComparison struct {
+	ExecutorID  flow.Identifier
+	ReceiptID   flow.Identifier
+	ResultID    flow.Identifier
+	Matches     bool
+	Differences []string `json:",omitempty"`
+}
+
+type receiptsComparison struct {
+	BlockID       flow.Identifier
+	BlockHeight   uint64
+	LocalResultID flow.Identifier
+	Receipts      []*receiptComparison
+}
+
+// CompareReceiptsCommand compares the execution result this node computed for a block with the
+// results of all receipts for the block known to this node, including the receipts of other
+// execution nodes. The request data is a JSON object with the block, e.g. {"block": "sealed"}.
+// For every result which differs from the local one, the differing fields are listed.
+type CompareReceiptsCommand struct {
+	state      protocol.State
+	receipts   storage.ExecutionReceipts
+	myReceipts storage.MyExecutionReceipts
+}
+
+func (c *CompareReceiptsCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	header, err := getBlockHeader(c.state, req.ValidatorData.(*blocksRequest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: %w", err)
+	}
+	blockID := header.ID()
+
+	myReceipt, err := c.myReceipts.MyReceipt(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get own receipt for block %v: %w", blockID, err)
+	}
+	localResult := &myReceipt.ExecutionResult
+	localResultID := localResult.ID()
+
+	receipts, err := c.receipts.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipts for block %v: %w", blockID, err)
+	}
+
+	comparison := &receiptsComparison{
+		BlockID:       blockID,
+		BlockHeight:   header.Height,
+		LocalResultID: localResultID,
+		Receipts:      make([]*receiptComparison, 0, len(receipts)),
+	}
+
+	for _, receipt := range receipts {
+		resultID := receipt.ExecutionResult.ID()
+		rc := &receiptComparison{
+			ExecutorID: receipt.ExecutorID,
+			ReceiptID:  receipt.ID(),
+			ResultID:   resultID,
+			Matches:    resultID == localResultID,
+		}
+		if !rc.Matches {
+			rc.Differences = compareResults(localResult, &receipt.ExecutionResult)
+		}
+		comparison.Receipts = append(comparison.Receipts, rc)
+	}
+
+	return convertToMap(comparison)
+}
+
+func (c *CompareReceiptsCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	block, ok := input["block"]
+	if !ok {
+		return errors.New("the \"block\" field is required")
+	}
+	br, err := parseBlocksRequest(block)
+	if err != nil {
+		return err
+	}
+
+	req.ValidatorData = br
+
+	return nil
+}
+
+func NewCompareReceiptsCommand(state protocol.State, receipts storage.ExecutionReceipts, myReceipts storage.MyExecutionReceipts) commands.AdminCommand {
+	return &CompareReceiptsCommand{
+		state,
+		receipts,
+		myReceipts,
+	}
+}
+
+// compareResults describes the differences of the other result from the local one
+func compareResults(local *flow.ExecutionResult, other *flow.ExecutionResult) []string {
+	var differences []string
+	differ := func(format string, args ...interface{}) {
+		differences = append(differences, fmt.Sprintf(format, args...))
+	}
+
+	if local.PreviousResultID != other.PreviousResultID {
+		differ("previous result ID: local %v, other %v", local.PreviousResultID, other.PreviousResultID)
+	}
+
+	if len(local.Chunks) != len(other.Chunks) {
+		differ("number of chunks: local %d, other %d", len(local.Chunks), len(other.Chunks))
+	}
+	for i := 0; i < len(local.Chunks) && i < len(other.Chunks); i++ {
+		l, o := local.Chunks[i], other.Chunks[i]
+		if l.CollectionIndex != o.CollectionIndex {
+			differ("chunk %d collection index: local %d, other %d", i, l.CollectionIndex, o.CollectionIndex)
+		}
+		if l.StartState != o.StartState {
+			differ("chunk %d start state: local %x, other %x", i, l.StartState, o.StartState)
+		}
+		if l.EndState != o.EndState {
+			differ("chunk %d end state: local %x, other %x", i, l.EndState, o.EndState)
+		}
+		if l.EventCollection != o.EventCollection {
+			differ("chunk %d event collection: local %v, other %v", i, l.EventCollection, o.EventCollection)
+		}
+		if l.NumberOfTransactions != o.NumberOfTransactions {
+			differ("chunk %d number of transactions: local %d, other %d", i, l.NumberOfTransactions, o.NumberOfTransactions)
+		}
+		if l.TotalComputationUsed != o.TotalComputationUsed {
+			differ("chunk %d computation used: local %d, other %d", i, l.TotalComputationUsed, o.TotalComputationUsed)
+		}
+	}
+
+	if len(local.ServiceEvents) != len(other.ServiceEvents) {
+		differ("number of service events: local %d, other %d", len(local.ServiceEvents), len(other.ServiceEvents))
+	}
+	for i := 0; i < len(local.ServiceEvents) && i < len(other.ServiceEvents); i++ {
+		equal, err := local.ServiceEvents[i].EqualTo(&other.ServiceEvents[i])
+		if err != nil {
+			differ("service event %d: %v", i, err)
+		} else if !equal {
+			differ("service event %d: local %s, other %s", i, local.ServiceEvents[i].Type, other.ServiceEvents[i].Type)
+		}
+	}
+
+	return differences
+}
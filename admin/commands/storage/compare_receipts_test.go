@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type CompareReceiptsSuite struct {
+	suite.Suite
+
+	command    commands.AdminCommand
+	state      *protocolmock.State
+	receipts   *storagemock.ExecutionReceipts
+	myReceipts *storagemock.MyExecutionReceipts
+
+	sealed    *flow.Block
+	myReceipt *flow.ExecutionReceipt
+	matching  *flow.ExecutionReceipt
+	differing *flow.ExecutionReceipt
+}
+
+func TestCompareReceipts(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CompareReceiptsSuite))
+}
+
+func (suite *CompareReceiptsSuite) SetupTest() {
+	suite.state = new(protocolmock.State)
+	suite.receipts = new(storagemock.ExecutionReceipts)
+	suite.myReceipts = new(storagemock.MyExecutionReceipts)
+
+	sealed := unittest.BlockFixture()
+	suite.sealed = &sealed
+	blockID := sealed.ID()
+
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&sealed), unittest.WithChunks(3))
+	suite.myReceipt = unittest.ExecutionReceiptFixture(unittest.WithResult(result))
+	suite.matching = unittest.ExecutionReceiptFixture(unittest.WithResult(result))
+
+	// the other result differs in the end state of the second chunk and the start state of the third chunk
+	differingResult := *result
+	differingResult.Chunks = make(flow.ChunkList, len(result.Chunks))
+	for i, chunk := range result.Chunks {
+		c := *chunk
+		differingResult.Chunks[i] = &c
+	}
+	differingState := unittest.StateCommitmentFixture()
+	differingResult.Chunks[1].EndState = differingState
+	differingResult.Chunks[2].StartState = differingState
+	suite.differing = unittest.ExecutionReceiptFixture(unittest.WithResult(&differingResult))
+
+	suite.state.On("Sealed").Return(createSnapshot(sealed.Header))
+	suite.myReceipts.On("MyReceipt", blockID).Return(suite.myReceipt, nil)
+	suite.receipts.On("ByBlockID", blockID).Return(
+		flow.ExecutionReceiptList{suite.myReceipt, suite.matching, suite.differing}, nil,
+	)
+
+	suite.command = NewCompareReceiptsCommand(suite.state, suite.receipts, suite.myReceipts)
+}
+
+func (suite *CompareReceiptsSuite) TestValidateInvalidBlock() {
+	assert.Error(suite.T(), suite.command.Validator(&admin.CommandRequest{
+		Data: map[string]interface{}{},
+	}))
+	assert.Error(suite.T(), suite.command.Validator(&admin.CommandRequest{
+		Data: map[string]interface{}{
+			"block": "uhznms",
+		},
+	}))
+	assert.Error(suite.T(), suite.command.Validator(&admin.CommandRequest{
+		Data: map[string]interface{}{
+			"block": -1,
+		},
+	}))
+}
+
+func (suite *CompareReceiptsSuite) TestCompareReceipts() {
+	req := &admin.CommandRequest{
+		Data: map[string]interface{}{
+			"block": "sealed",
+		},
+	}
+	require.NoError(suite.T(), suite.command.Validator(req))
+	result, err := suite.command.Handler(context.Background(), req)
+	require.NoError(suite.T(), err)
+
+	var comparison receiptsComparison
+	data, err := json.Marshal(result)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), json.Unmarshal(data, &comparison))
+
+	assert.Equal(suite.T(), suite.sealed.ID(), comparison.BlockID)
+	assert.Equal(suite.T(), suite.sealed.Header.Height, comparison.BlockHeight)
+	assert.Equal(suite.T(), suite.myReceipt.ExecutionResult.ID(), comparison.LocalResultID)
+	require.Len(suite.T(), comparison.Receipts, 3)
+
+	for i, receipt := range []*flow.ExecutionReceipt{suite.myReceipt, suite.matching} {
+		assert.Equal(suite.T(), receipt.ExecutorID, comparison.Receipts[i].ExecutorID)
+		assert.Equal(suite.T(), receipt.ID(), comparison.Receipts[i].ReceiptID)
+		assert.True(suite.T(), comparison.Receipts[i].Matches)
+		assert.Empty(suite.T(), comparison.Receipts[i].Differences)
+	}
+
+	differing := comparison.Receipts[2]
+	assert.Equal(suite.T(), suite.differing.ExecutorID, differing.ExecutorID)
+	assert.Equal(suite.T(), suite.differing.ExecutionResult.ID(), differing.ResultID)
+	assert.False(suite.T(), differing.Matches)
+	require.Len(suite.T(), differing.Differences, 2)
+	assert.Contains(suite.T(), differing.Differences[0], "chunk 1 end state")
+	assert.Contains(suite.T(), differing.Differences[1], "chunk 2 start state")
+}
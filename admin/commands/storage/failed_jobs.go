@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*ListFailedJobsCommand)(nil)
+
+// ListFailedJobsCommand lists the jobs which job consumers failed to process even after retrying.
+// The request data is a JSON object with the name of the consumer, e.g. {"consumer": "chunk"}.
+type ListFailedJobsCommand struct {
+	failedJobs map[string]storage.FailedJobs // failed jobs by consumer name
+}
+
+func NewListFailedJobsCommand(failedJobs map[string]storage.FailedJobs) commands.AdminCommand {
+	return &ListFailedJobsCommand{
+		failedJobs: failedJobs,
+	}
+}
+
+func (l *ListFailedJobsCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	failedJobs := req.ValidatorData.(storage.FailedJobs)
+
+	jobs, err := failedJobs.All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failed jobs: %w", err)
+	}
+
+	return convertToInterfaceList(jobs)
+}
+
+func (l *ListFailedJobsCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	failedJobs, err := parseConsumer(input, l.failedJobs)
+	if err != nil {
+		return err
+	}
+
+	req.ValidatorData = failedJobs
+	return nil
+}
+
+var _ commands.AdminCommand = (*RequeueFailedJobCommand)(nil)
+
+// RequeueFailedJobCommand re-queues a failed job, so that the job consumer processes it again the
+// next time it checks for jobs. The request data is a JSON object with the name of the consumer and
+// the index of the job, e.g. {"consumer": "chunk", "index": 42}.
+type RequeueFailedJobCommand struct {
+	failedJobs map[string]storage.FailedJobs // failed jobs by consumer name
+}
+
+func NewRequeueFailedJobCommand(failedJobs map[string]storage.FailedJobs) commands.AdminCommand {
+	return &RequeueFailedJobCommand{
+		failedJobs: failedJobs,
+	}
+}
+
+type requeueFailedJobRequest struct {
+	failedJobs storage.FailedJobs
+	index      uint64
+}
+
+func (r *RequeueFailedJobCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*requeueFailedJobRequest)
+
+	err := data.failedJobs.Requeue(data.index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to requeue job: %w", err)
+	}
+
+	job, err := data.failedJobs.ByIndex(data.index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get requeued job: %w", err)
+	}
+
+	return convertToMap(job)
+}
+
+func (r *RequeueFailedJobCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	failedJobs, err := parseConsumer(input, r.failedJobs)
+	if err != nil {
+		return err
+	}
+
+	index, ok := input["index"]
+	if !ok {
+		return fmt.Errorf("the \"index\" field is required")
+	}
+	n, ok := index.(float64)
+	if !ok || n < 0 || math.Trunc(n) != n {
+		return fmt.Errorf("invalid value for \"index\": expected a non-negative integer, but got: %v", index)
+	}
+
+	req.ValidatorData = &requeueFailedJobRequest{
+		failedJobs: failedJobs,
+		index:      uint64(n),
+	}
+	return nil
+}
+
+// parseConsumer returns the failed jobs of the consumer named in the "consumer" field of the input.
+func parseConsumer(input map[string]interface{}, failedJobs map[string]storage.FailedJobs) (storage.FailedJobs, error) {
+	names := make([]string, 0, len(failedJobs))
+	for name := range failedJobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	consumer, ok := input["consumer"]
+	if !ok {
+		return nil, fmt.Errorf("the \"consumer\" field is required, expected one of %v", names)
+	}
+	name, ok := consumer.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid value for \"consumer\": expected one of %v, but got: %v", names, consumer)
+	}
+	jobs, ok := failedJobs[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid value for \"consumer\": expected one of %v, but got: %v", names, name)
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestFailedJobs(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		chunkJobs := bstorage.NewFailedJobs(db, "chunk")
+		failedJobs := map[string]storage.FailedJobs{
+			"chunk": chunkJobs,
+			"block": bstorage.NewFailedJobs(db, "block"),
+		}
+		list := NewListFailedJobsCommand(failedJobs)
+		requeue := NewRequeueFailedJobCommand(failedJobs)
+
+		job := &storage.FailedJob{
+			Index:    7,
+			JobID:    "job",
+			Error:    "could not process job",
+			Attempts: 5,
+			FailedAt: time.Unix(1600000000, 0).UTC(),
+		}
+		require.NoError(t, chunkJobs.Store(job))
+
+		req := &admin.CommandRequest{Data: map[string]interface{}{"consumer": "chunk"}}
+		require.NoError(t, list.Validator(req))
+		result, err := list.Handler(context.Background(), req)
+		require.NoError(t, err)
+		expected, err := convertToInterfaceList([]*storage.FailedJob{job})
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+
+		req = &admin.CommandRequest{Data: map[string]interface{}{"consumer": "block"}}
+		require.NoError(t, list.Validator(req))
+		result, err = list.Handler(context.Background(), req)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+
+		req = &admin.CommandRequest{Data: map[string]interface{}{"consumer": "chunk", "index": float64(7)}}
+		require.NoError(t, requeue.Validator(req))
+		result, err = requeue.Handler(context.Background(), req)
+		require.NoError(t, err)
+		job.Requeued = true
+		expectedJob, err := convertToMap(job)
+		require.NoError(t, err)
+		assert.Equal(t, expectedJob, result)
+
+		// jobs which have not failed can not be re-queued
+		req = &admin.CommandRequest{Data: map[string]interface{}{"consumer": "chunk", "index": float64(8)}}
+		require.NoError(t, requeue.Validator(req))
+		_, err = requeue.Handler(context.Background(), req)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
+
+func TestFailedJobsValidator(t *testing.T) {
+	failedJobs := map[string]storage.FailedJobs{"chunk": nil}
+	list := NewListFailedJobsCommand(failedJobs)
+	requeue := NewRequeueFailedJobCommand(failedJobs)
+
+	require.Error(t, list.Validator(&admin.CommandRequest{Data: "chunk"}))
+	require.Error(t, list.Validator(&admin.CommandRequest{Data: map[string]interface{}{}}))
+	require.Error(t, list.Validator(&admin.CommandRequest{Data: map[string]interface{}{"consumer": "block"}}))
+	require.Error(t, list.Validator(&admin.CommandRequest{Data: map[string]interface{}{"consumer": 1}}))
+
+	require.Error(t, requeue.Validator(&admin.CommandRequest{Data: map[string]interface{}{"consumer": "chunk"}}))
+	require.Error(t, requeue.Validator(&admin.CommandRequest{Data: map[string]interface{}{"consumer": "chunk", "index": float64(-1)}}))
+	require.Error(t, requeue.Validator(&admin.CommandRequest{Data: map[string]interface{}{"consumer": "chunk", "index": 1.5}}))
+	require.Error(t, requeue.Validator(&admin.CommandRequest{Data: map[string]interface{}{"consumer": "chunk", "index": "1"}}))
+}
@@ -46,6 +46,33 @@ func parseN(m interface{}) (uint64, error) {
 	return uint64(n), nil
 }
 
+// parseIdentifier parses the value of the given field as an ID of the entity named like the field
+func parseIdentifier(field string, value interface{}) (flow.Identifier, error) {
+	errInvalidValue := fmt.Errorf("invalid value for %q: expected a %s ID represented as a 64 character long hex string, but got: %v", field, field, value)
+	s, ok := value.(string)
+	if !ok {
+		return flow.ZeroID, errInvalidValue
+	}
+	id, err := flow.HexStringToIdentifier(s)
+	if err != nil {
+		return flow.ZeroID, errInvalidValue
+	}
+	return id, nil
+}
+
+// parseFlag returns the boolean value of the given optional field, which is false if the field is missing
+func parseFlag(input map[string]interface{}, field string) (bool, error) {
+	value, ok := input[field]
+	if !ok {
+		return false, nil
+	}
+	flag, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("invalid value for %q: expected a boolean, but got: %v", field, value)
+	}
+	return flag, nil
+}
+
 func parseBlocksRequest(block interface{}) (*blocksRequest, error) {
 	errInvalidBlockValue := fmt.Errorf("invalid value for \"block\": expected %q, %q, block ID, or block height, but got: %v", FINAL, SEALED, block)
 	req := &blocksRequest{}
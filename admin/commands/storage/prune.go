@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+var _ commands.AdminCommand = (*PruneCommand)(nil)
+
+// PruneCommand triggers pruning of the historical data below the configured retention
+// on demand, without waiting for the next periodic pruning run.
+type PruneCommand struct {
+	pruner *bstorage.Pruner
+}
+
+func NewPruneCommand(pruner *bstorage.Pruner) commands.AdminCommand {
+	return &PruneCommand{
+		pruner: pruner,
+	}
+}
+
+func (p *PruneCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	result, err := p.pruner.Prune(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune storage: %w", err)
+	}
+	return convertToMap(result)
+}
+
+// Validator accepts requests without data, the retention is taken from the node configuration.
+func (p *PruneCommand) Validator(req *admin.CommandRequest) error {
+	if req.Data == nil {
+		return nil
+	}
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+	if len(input) > 0 {
+		return fmt.Errorf("unexpected fields: the prune command does not take any input")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/module/metrics"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPrune(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		require.NoError(t, db.Update(operation.InsertRootHeight(10)))
+		require.NoError(t, db.Update(operation.InsertSealedHeight(20)))
+
+		config := bstorage.DefaultPrunerConfig()
+		config.RetentionHeights = 100
+		pruner, err := bstorage.NewPruner(unittest.Logger(), db, metrics.NewNoopCollector(), config)
+		require.NoError(t, err)
+		command := NewPruneCommand(pruner)
+
+		req := &admin.CommandRequest{}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		// nothing to prune, as all sealed heights are within the retention
+		resultMap, err := convertToMap(&bstorage.PruneResult{})
+		require.NoError(t, err)
+		assert.Equal(t, resultMap, result)
+	})
+}
+
+func TestPruneValidator(t *testing.T) {
+	command := NewPruneCommand(nil)
+
+	require.NoError(t, command.Validator(&admin.CommandRequest{Data: map[string]interface{}{}}))
+	require.Error(t, command.Validator(&admin.CommandRequest{Data: map[string]interface{}{"heights": 10}}))
+	require.Error(t, command.Validator(&admin.CommandRequest{Data: "prune"}))
+}
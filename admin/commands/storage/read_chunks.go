@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*ReadChunkDataPackCommand)(nil)
+
+type readChunkDataPackRequest struct {
+	chunkID      flow.Identifier
+	includeProof bool
+}
+
+type chunkDataPackInfo struct {
+	ChunkID    flow.Identifier
+	StartState flow.StateCommitment
+	ProofSize  int
+	Proof      flow.StorageProof `json:",omitempty"`
+	Collection *flow.Collection
+}
+
+// ReadChunkDataPackCommand reads the chunk data pack of a chunk executed by this node.
+// The request data is a JSON object with the chunk ID, e.g. {"chunk": "<chunk ID>"}.
+// The proof is only included if requested with {"proof": true}, as it can be large.
+type ReadChunkDataPackCommand struct {
+	chunkDataPacks storage.ChunkDataPacks
+}
+
+func (r *ReadChunkDataPackCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*readChunkDataPackRequest)
+
+	chunkDataPack, err := r.chunkDataPacks.ByChunkID(data.chunkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunk data pack by chunk ID: %w", err)
+	}
+
+	info := &chunkDataPackInfo{
+		ChunkID:    chunkDataPack.ChunkID,
+		StartState: chunkDataPack.StartState,
+		ProofSize:  len(chunkDataPack.Proof),
+		Collection: chunkDataPack.Collection,
+	}
+	if data.includeProof {
+		info.Proof = chunkDataPack.Proof
+	}
+
+	return convertToMap(info)
+}
+
+func (r *ReadChunkDataPackCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	data := &readChunkDataPackRequest{}
+
+	chunk, ok := input["chunk"]
+	if !ok {
+		return errors.New("the \"chunk\" field is required")
+	}
+	chunkID, err := parseIdentifier("chunk", chunk)
+	if err != nil {
+		return err
+	}
+	data.chunkID = chunkID
+
+	data.includeProof, err = parseFlag(input, "proof")
+	if err != nil {
+		return err
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+func NewReadChunkDataPackCommand(chunkDataPacks storage.ChunkDataPacks) commands.AdminCommand {
+	return &ReadChunkDataPackCommand{
+		chunkDataPacks,
+	}
+}
+
+var _ commands.AdminCommand = (*ReadResultChunksCommand)(nil)
+
+type readResultChunksRequest struct {
+	requestType         readResultsRequestType
+	value               interface{}
+	includeTransactions bool
+}
+
+type chunkInfo struct {
+	Index                uint64
+	ChunkID              flow.Identifier
+	CollectionIndex      uint
+	StartState           flow.StateCommitment
+	EndState             flow.StateCommitment
+	EventCollection      flow.Identifier
+	NumberOfTransactions uint64
+	TotalComputationUsed uint64
+	Transactions         []flow.Identifier `json:",omitempty"`
+}
+
+type resultChunks struct {
+	ResultID         flow.Identifier
+	BlockID          flow.Identifier
+	PreviousResultID flow.Identifier
+	Chunks           []*chunkInfo
+}
+
+// ReadResultChunksCommand lists the chunks of an execution result, selected either by result ID,
+// e.g. {"result": "<result ID>"}, or by the block the result is for, e.g. {"block": "final"}.
+// With {"transactions": true}, the IDs of the transactions of every chunk are read from the
+// chunk data packs of this node and included.
+type ReadResultChunksCommand struct {
+	state          protocol.State
+	results        storage.ExecutionResults
+	chunkDataPacks storage.ChunkDataPacks
+}
+
+func (r *ReadResultChunksCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*readResultChunksRequest)
+
+	var result *flow.ExecutionResult
+	var err error
+
+	switch data.requestType {
+	case readResultsRequestByID:
+		result, err = r.results.ByID(data.value.(flow.Identifier))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get result by ID: %w", err)
+		}
+	case readResultsRequestByBlock:
+		header, err := getBlockHeader(r.state, data.value.(*blocksRequest))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block header: %w", err)
+		}
+		result, err = r.results.ByBlockID(header.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get result by block ID: %w", err)
+		}
+	}
+
+	rc := &resultChunks{
+		ResultID:         result.ID(),
+		BlockID:          result.BlockID,
+		PreviousResultID: result.PreviousResultID,
+		Chunks:           make([]*chunkInfo, 0, len(result.Chunks)),
+	}
+
+	for _, chunk := range result.Chunks {
+		info := &chunkInfo{
+			Index:                chunk.Index,
+			ChunkID:              chunk.ID(),
+			CollectionIndex:      chunk.CollectionIndex,
+			StartState:           chunk.StartState,
+			EndState:             chunk.EndState,
+			EventCollection:      chunk.EventCollection,
+			NumberOfTransactions: chunk.NumberOfTransactions,
+			TotalComputationUsed: chunk.TotalComputationUsed,
+		}
+
+		if data.includeTransactions {
+			chunkDataPack, err := r.chunkDataPacks.ByChunkID(info.ChunkID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get chunk data pack of chunk %d: %w", chunk.Index, err)
+			}
+			info.Transactions = []flow.Identifier{}
+			if chunkDataPack.Collection != nil {
+				for _, tx := range chunkDataPack.Collection.Transactions {
+					info.Transactions = append(info.Transactions, tx.ID())
+				}
+			}
+		}
+
+		rc.Chunks = append(rc.Chunks, info)
+	}
+
+	return convertToMap(rc)
+}
+
+func (r *ReadResultChunksCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	data := &readResultChunksRequest{}
+
+	if result, ok := input["result"]; ok {
+		resultID, err := parseIdentifier("result", result)
+		if err != nil {
+			return err
+		}
+		data.requestType = readResultsRequestByID
+		data.value = resultID
+	} else if block, ok := input["block"]; ok {
+		br, err := parseBlocksRequest(block)
+		if err != nil {
+			return err
+		}
+		data.requestType = readResultsRequestByBlock
+		data.value = br
+	} else {
+		return errors.New("either \"block\" or \"result\" field is required")
+	}
+
+	var err error
+	data.includeTransactions, err = parseFlag(input, "transactions")
+	if err != nil {
+		return err
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+func NewReadResultChunksCommand(state protocol.State, results storage.ExecutionResults, chunkDataPacks storage.ChunkDataPacks) commands.AdminCommand {
+	return &ReadResultChunksCommand{
+		state,
+		results,
+		chunkDataPacks,
+	}
+}
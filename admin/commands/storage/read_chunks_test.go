@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/invalid"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type ReadChunksSuite struct {
+	suite.Suite
+
+	readChunkDataPack commands.AdminCommand
+	readResultChunks  commands.AdminCommand
+
+	state          *protocolmock.State
+	results        *storagemock.ExecutionResults
+	chunkDataPacks *storagemock.ChunkDataPacks
+
+	final  *flow.Block
+	result *flow.ExecutionResult
+	packs  map[flow.Identifier]*flow.ChunkDataPack // chunk data packs by chunk ID
+}
+
+func TestReadChunks(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ReadChunksSuite))
+}
+
+func (suite *ReadChunksSuite) SetupTest() {
+	suite.state = new(protocolmock.State)
+	suite.results = new(storagemock.ExecutionResults)
+	suite.chunkDataPacks = new(storagemock.ChunkDataPacks)
+
+	final := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&final), unittest.WithChunks(3))
+	suite.final = &final
+	suite.result = result
+
+	packs := make(map[flow.Identifier]*flow.ChunkDataPack)
+	for _, chunk := range result.Chunks {
+		chunkID := chunk.ID()
+		packs[chunkID] = unittest.ChunkDataPackFixture(chunkID)
+	}
+	suite.packs = packs
+
+	suite.state.On("Final").Return(createSnapshot(final.Header))
+	suite.state.On("AtBlockID", mock.Anything).Return(
+		func(blockID flow.Identifier) protocol.Snapshot {
+			if blockID == final.ID() {
+				return createSnapshot(final.Header)
+			}
+			return invalid.NewSnapshot(fmt.Errorf("invalid block ID: %v", blockID))
+		},
+	)
+
+	suite.results.On("ByID", result.ID()).Return(result, nil)
+	suite.results.On("ByBlockID", final.ID()).Return(result, nil)
+
+	suite.chunkDataPacks.On("ByChunkID", mock.Anything).Return(
+		func(chunkID flow.Identifier) *flow.ChunkDataPack {
+			return packs[chunkID]
+		},
+		func(chunkID flow.Identifier) error {
+			if _, ok := packs[chunkID]; !ok {
+				return fmt.Errorf("chunk data pack %v not found", chunkID)
+			}
+			return nil
+		},
+	)
+
+	suite.readChunkDataPack = NewReadChunkDataPackCommand(suite.chunkDataPacks)
+	suite.readResultChunks = NewReadResultChunksCommand(suite.state, suite.results, suite.chunkDataPacks)
+}
+
+func (suite *ReadChunksSuite) run(command commands.AdminCommand, reqData map[string]interface{}, target interface{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := &admin.CommandRequest{
+		Data: reqData,
+	}
+	require.NoError(suite.T(), command.Validator(req))
+	result, err := command.Handler(ctx, req)
+	require.NoError(suite.T(), err)
+
+	data, err := json.Marshal(result)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), json.Unmarshal(data, target))
+}
+
+func (suite *ReadChunksSuite) TestValidateInvalidChunkID() {
+	for _, chunk := range []interface{}{true, "", "uhznms", "deadbeef", 1} {
+		assert.Error(suite.T(), suite.readChunkDataPack.Validator(&admin.CommandRequest{
+			Data: map[string]interface{}{
+				"chunk": chunk,
+			},
+		}))
+	}
+	assert.Error(suite.T(), suite.readChunkDataPack.Validator(&admin.CommandRequest{
+		Data: map[string]interface{}{},
+	}))
+}
+
+func (suite *ReadChunksSuite) TestValidateInvalidFlag() {
+	chunkID := suite.result.Chunks[0].ID()
+	assert.Error(suite.T(), suite.readChunkDataPack.Validator(&admin.CommandRequest{
+		Data: map[string]interface{}{
+			"chunk": chunkID.String(),
+			"proof": "yes",
+		},
+	}))
+	assert.Error(suite.T(), suite.readResultChunks.Validator(&admin.CommandRequest{
+		Data: map[string]interface{}{
+			"block":        "final",
+			"transactions": 1,
+		},
+	}))
+}
+
+func (suite *ReadChunksSuite) TestValidateMissingResult() {
+	assert.Error(suite.T(), suite.readResultChunks.Validator(&admin.CommandRequest{
+		Data: map[string]interface{}{
+			"transactions": true,
+		},
+	}))
+}
+
+func (suite *ReadChunksSuite) TestReadChunkDataPack() {
+	chunkID := suite.result.Chunks[0].ID()
+	expected := suite.packs[chunkID]
+
+	var info chunkDataPackInfo
+	suite.run(suite.readChunkDataPack, map[string]interface{}{
+		"chunk": chunkID.String(),
+	}, &info)
+
+	assert.Equal(suite.T(), chunkID, info.ChunkID)
+	assert.Equal(suite.T(), expected.StartState, info.StartState)
+	assert.Equal(suite.T(), len(expected.Proof), info.ProofSize)
+	assert.Empty(suite.T(), info.Proof)
+	assert.Equal(suite.T(), expected.Collection.ID(), info.Collection.ID())
+
+	suite.run(suite.readChunkDataPack, map[string]interface{}{
+		"chunk": chunkID.String(),
+		"proof": true,
+	}, &info)
+	assert.Equal(suite.T(), expected.Proof, info.Proof)
+}
+
+func (suite *ReadChunksSuite) TestReadResultChunks() {
+	for _, reqData := range []map[string]interface{}{
+		{"result": suite.result.ID().String()},
+		{"block": "final"},
+		{"block": suite.final.ID().String()},
+	} {
+		var rc resultChunks
+		suite.run(suite.readResultChunks, reqData, &rc)
+
+		assert.Equal(suite.T(), suite.result.ID(), rc.ResultID)
+		assert.Equal(suite.T(), suite.result.BlockID, rc.BlockID)
+		require.Len(suite.T(), rc.Chunks, len(suite.result.Chunks))
+		for i, chunk := range suite.result.Chunks {
+			assert.Equal(suite.T(), chunk.ID(), rc.Chunks[i].ChunkID)
+			assert.Equal(suite.T(), chunk.StartState, rc.Chunks[i].StartState)
+			assert.Equal(suite.T(), chunk.EndState, rc.Chunks[i].EndState)
+			assert.Equal(suite.T(), chunk.EventCollection, rc.Chunks[i].EventCollection)
+			assert.Empty(suite.T(), rc.Chunks[i].Transactions)
+		}
+	}
+}
+
+func (suite *ReadChunksSuite) TestReadResultChunksWithTransactions() {
+	var rc resultChunks
+	suite.run(suite.readResultChunks, map[string]interface{}{
+		"block":        "final",
+		"transactions": true,
+	}, &rc)
+
+	require.Len(suite.T(), rc.Chunks, len(suite.result.Chunks))
+	for _, chunk := range rc.Chunks {
+		collection := suite.packs[chunk.ChunkID].Collection
+		assert.Equal(suite.T(), collection.Light().Transactions, chunk.Transactions)
+	}
+}
+
+func (suite *ReadChunksSuite) TestReadResultChunksMissingChunkDataPack() {
+	delete(suite.packs, suite.result.Chunks[1].ID())
+
+	req := &admin.CommandRequest{
+		Data: map[string]interface{}{
+			"block":        "final",
+			"transactions": true,
+		},
+	}
+	require.NoError(suite.T(), suite.readResultChunks.Validator(req))
+	_, err := suite.readResultChunks.Handler(context.Background(), req)
+	assert.Error(suite.T(), err)
+}
@@ -12,6 +12,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/onflow/flow-go/admin/commands"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff"
@@ -96,11 +98,13 @@ type AccessNodeConfig struct {
 	rpcConf                      rpc.Config
 	ExecutionNodeAddress         string // deprecated
 	HistoricalAccessRPCs         []access.AccessAPIClient
+	HistoricalSporks             []backend.HistoricalSpork
 	logTxTimeToFinalized         bool
 	logTxTimeToExecuted          bool
 	logTxTimeToFinalizedExecuted bool
 	retryEnabled                 bool
 	rpcMetricsEnabled            bool
+	pruningConfig                storage.PrunerConfig
 	baseOptions                  []cmd.Option
 }
 
@@ -110,17 +114,21 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		collectionGRPCPort: 9000,
 		executionGRPCPort:  9000,
 		rpcConf: rpc.Config{
-			UnsecureGRPCListenAddr:    "0.0.0.0:9000",
-			SecureGRPCListenAddr:      "0.0.0.0:9001",
-			HTTPListenAddr:            "0.0.0.0:8000",
-			RESTListenAddr:            "",
-			CollectionAddr:            "",
-			HistoricalAccessAddrs:     "",
-			CollectionClientTimeout:   3 * time.Second,
-			ExecutionClientTimeout:    3 * time.Second,
-			MaxHeightRange:            backend.DefaultMaxHeightRange,
-			PreferredExecutionNodeIDs: nil,
-			FixedExecutionNodeIDs:     nil,
+			UnsecureGRPCListenAddr:     "0.0.0.0:9000",
+			SecureGRPCListenAddr:       "0.0.0.0:9001",
+			HTTPListenAddr:             "0.0.0.0:8000",
+			RESTListenAddr:             "",
+			CollectionAddr:             "",
+			HistoricalAccessAddrs:      "",
+			HistoricalSporks:           "",
+			CollectionClientTimeout:    3 * time.Second,
+			ExecutionClientTimeout:     3 * time.Second,
+			MaxHeightRange:             backend.DefaultMaxHeightRange,
+			PreferredExecutionNodeIDs:  nil,
+			FixedExecutionNodeIDs:      nil,
+			ConnectionPool:             backend.DefaultConnectionPoolConfig(),
+			CircuitBreaker:             backend.DefaultCircuitBreakerConfig(),
+			RequiredExecutionAgreement: 0,
 		},
 		ExecutionNodeAddress:         "localhost:9000",
 		logTxTimeToFinalized:         false,
@@ -136,6 +144,7 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		bootstrapNodeAddresses:       []string{},
 		bootstrapNodePublicKeys:      []string{},
 		supportsUnstakedFollower:     false,
+		pruningConfig:                storage.DefaultPrunerConfig(),
 	}
 }
 
@@ -160,10 +169,12 @@ type FlowAccessNodeBuilder struct {
 	BlocksToMarkExecuted       *stdmap.Times
 	TransactionMetrics         module.TransactionMetrics
 	PingMetrics                module.PingMetrics
+	AccessMetrics              module.AccessMetrics
 	Committee                  hotstuff.Committee
 	Finalized                  *flow.Header
 	Pending                    []*flow.Header
 	FollowerCore               module.HotStuffFollower
+	Pruner                     *storage.Pruner
 	// for the untsaked access node, the sync engine participants provider is the libp2p peer store which is not
 	// available until after the network has started. Hence, a factory function that needs to be called just before
 	// creating the sync engine
@@ -351,6 +362,20 @@ func (builder *FlowAccessNodeBuilder) BuildConsensusFollower() AccessNodeBuilder
 func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 	anb.
 		BuildConsensusFollower().
+		PostInit(func(builder cmd.NodeBuilder, node *cmd.NodeConfig) {
+			// the pruner is created before the admin commands, which trigger pruning on demand
+			anb.pruningConfig.Events = true
+			anb.pruningConfig.TransactionResults = true
+			anb.pruningConfig.Collections = true
+			var err error
+			anb.Pruner, err = storage.NewPruner(node.Logger, node.DB, node.Metrics.Pruner, anb.pruningConfig)
+			if err != nil {
+				node.Logger.Fatal().Err(err).Msg("could not create storage pruner")
+			}
+		}).
+		AdminCommand("prune-storage", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewPruneCommand(anb.Pruner)
+		}).
 		Module("collection node client", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			// collection node address is optional (if not specified, collection nodes will be chosen at random)
 			if strings.TrimSpace(anb.rpcConf.CollectionAddr) == "" {
@@ -374,21 +399,57 @@ func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 			return nil
 		}).
 		Module("historical access node clients", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
-			addrs := strings.Split(anb.rpcConf.HistoricalAccessAddrs, ",")
-			for _, addr := range addrs {
-				if strings.TrimSpace(addr) == "" {
-					continue
+			// the client of each historical access node, as an access node can be given by both flags
+			clients := make(map[string]access.AccessAPIClient)
+			historicalAccessRPC := func(addr string) (access.AccessAPIClient, error) {
+				if client, ok := clients[addr]; ok {
+					return client, nil
 				}
-				node.Logger.Info().Str("access_nodes", addr).Msg("historical access node addresses")
 
 				historicalAccessRPCConn, err := grpc.Dial(
 					addr,
 					grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
 					grpc.WithInsecure())
+				if err != nil {
+					return nil, err
+				}
+				client := access.NewAccessAPIClient(historicalAccessRPCConn)
+				clients[addr] = client
+				// transactions are looked up on all historical access nodes
+				anb.HistoricalAccessRPCs = append(anb.HistoricalAccessRPCs, client)
+				return client, nil
+			}
+
+			addrs := strings.Split(anb.rpcConf.HistoricalAccessAddrs, ",")
+			for _, addr := range addrs {
+				addr = strings.TrimSpace(addr)
+				if addr == "" {
+					continue
+				}
+				node.Logger.Info().Str("access_nodes", addr).Msg("historical access node addresses")
+
+				_, err := historicalAccessRPC(addr)
+				if err != nil {
+					return err
+				}
+			}
+
+			sporks, err := backend.ParseHistoricalSporks(anb.rpcConf.HistoricalSporks)
+			if err != nil {
+				return fmt.Errorf("invalid historical sporks: %w", err)
+			}
+			for _, spork := range sporks {
+				node.Logger.Info().
+					Str("access_node", spork.Address).
+					Uint64("root_height", spork.RootHeight).
+					Uint64("end_height", spork.EndHeight).
+					Msg("historical spork access node address")
+
+				spork.Client, err = historicalAccessRPC(spork.Address)
 				if err != nil {
 					return err
 				}
-				anb.HistoricalAccessRPCs = append(anb.HistoricalAccessRPCs, access.NewAccessAPIClient(historicalAccessRPCConn))
+				anb.HistoricalSporks = append(anb.HistoricalSporks, spork)
 			}
 			return nil
 		}).
@@ -421,6 +482,10 @@ func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 			anb.PingMetrics = metrics.NewPingCollector()
 			return nil
 		}).
+		Module("access metrics", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
+			anb.AccessMetrics = metrics.NewAccessCollector()
+			return nil
+		}).
 		Module("server certificate", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			// generate the server certificate that will be served by the GRPC server
 			x509Certificate, err := grpcutils.X509Certificate(node.NetworkKey)
@@ -438,6 +503,7 @@ func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 				anb.rpcConf,
 				anb.CollectionRPC,
 				anb.HistoricalAccessRPCs,
+				anb.HistoricalSporks,
 				node.Storage.Blocks,
 				node.Storage.Headers,
 				node.Storage.Collections,
@@ -446,6 +512,7 @@ func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 				node.Storage.Results,
 				node.RootChainID,
 				anb.TransactionMetrics,
+				anb.AccessMetrics,
 				anb.collectionGRPCPort,
 				anb.executionGRPCPort,
 				anb.retryEnabled,
@@ -487,6 +554,9 @@ func (anb *FlowAccessNodeBuilder) Build() AccessNodeBuilder {
 			// order for it to properly start and shut down, we should still return it as its own engine here, so it can
 			// be handled by the scaffold.
 			return anb.RequestEng, nil
+		}).
+		Component("storage pruner", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			return anb.Pruner, nil
 		})
 
 	return anb
@@ -557,11 +627,18 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.StringVarP(&builder.rpcConf.CollectionAddr, "static-collection-ingress-addr", "", defaultConfig.rpcConf.CollectionAddr, "the address (of the collection node) to send transactions to")
 		flags.StringVarP(&builder.ExecutionNodeAddress, "script-addr", "s", defaultConfig.ExecutionNodeAddress, "the address (of the execution node) forward the script to")
 		flags.StringVarP(&builder.rpcConf.HistoricalAccessAddrs, "historical-access-addr", "", defaultConfig.rpcConf.HistoricalAccessAddrs, "comma separated rpc addresses for historical access nodes")
+		flags.StringVar(&builder.rpcConf.HistoricalSporks, "historical-access-sporks", defaultConfig.rpcConf.HistoricalSporks, "comma separated height ranges of previous sporks with the rpc address of the access node serving each spork, requests for blocks of those sporks are routed to their access nodes e.g. 0-99=access-001:9000,100-199=access-002:9000")
 		flags.DurationVar(&builder.rpcConf.CollectionClientTimeout, "collection-client-timeout", defaultConfig.rpcConf.CollectionClientTimeout, "grpc client timeout for a collection node")
 		flags.DurationVar(&builder.rpcConf.ExecutionClientTimeout, "execution-client-timeout", defaultConfig.rpcConf.ExecutionClientTimeout, "grpc client timeout for an execution node")
+		flags.DurationVar(&builder.rpcConf.ConnectionPool.MaxIdleTime, "upstream-connection-max-idle-time", defaultConfig.rpcConf.ConnectionPool.MaxIdleTime, "time after which an unused connection to a collection or execution node is closed, 0 disables caching the connections")
+		flags.DurationVar(&builder.rpcConf.ConnectionPool.KeepaliveTime, "upstream-connection-keepalive-time", defaultConfig.rpcConf.ConnectionPool.KeepaliveTime, "time after which a connection to a collection or execution node without activity is pinged, 0 disables keepalive pings")
+		flags.UintVar(&builder.rpcConf.CircuitBreaker.MaxFailures, "upstream-circuit-breaker-max-failures", defaultConfig.rpcConf.CircuitBreaker.MaxFailures, "number of consecutive failed requests after which a collection or execution node is taken out of rotation, 0 disables the circuit breaker")
+		flags.DurationVar(&builder.rpcConf.CircuitBreaker.Backoff, "upstream-circuit-breaker-backoff", defaultConfig.rpcConf.CircuitBreaker.Backoff, "time a failing collection or execution node is taken out of rotation, doubled every time it fails again")
+		flags.DurationVar(&builder.rpcConf.CircuitBreaker.MaxBackoff, "upstream-circuit-breaker-max-backoff", defaultConfig.rpcConf.CircuitBreaker.MaxBackoff, "maximum time a failing collection or execution node is taken out of rotation")
 		flags.UintVar(&builder.rpcConf.MaxHeightRange, "rpc-max-height-range", defaultConfig.rpcConf.MaxHeightRange, "maximum size for height range requests")
 		flags.StringSliceVar(&builder.rpcConf.PreferredExecutionNodeIDs, "preferred-execution-node-ids", defaultConfig.rpcConf.PreferredExecutionNodeIDs, "comma separated list of execution nodes ids to choose from when making an upstream call e.g. b4a4dbdcd443d...,fb386a6a... etc.")
 		flags.StringSliceVar(&builder.rpcConf.FixedExecutionNodeIDs, "fixed-execution-node-ids", defaultConfig.rpcConf.FixedExecutionNodeIDs, "comma separated list of execution nodes ids to choose from when making an upstream call if no matching preferred execution id is found e.g. b4a4dbdcd443d...,fb386a6a... etc.")
+		flags.UintVar(&builder.rpcConf.RequiredExecutionAgreement, "execution-result-agreement", defaultConfig.rpcConf.RequiredExecutionAgreement, "number of execution nodes committed to the same execution result which must return identical responses to script, event and account requests, less than 2 disables the cross-check")
 		flags.BoolVar(&builder.logTxTimeToFinalized, "log-tx-time-to-finalized", defaultConfig.logTxTimeToFinalized, "log transaction time to finalized")
 		flags.BoolVar(&builder.logTxTimeToExecuted, "log-tx-time-to-executed", defaultConfig.logTxTimeToExecuted, "log transaction time to executed")
 		flags.BoolVar(&builder.logTxTimeToFinalizedExecuted, "log-tx-time-to-finalized-executed", defaultConfig.logTxTimeToFinalizedExecuted, "log transaction time to finalized and executed")
@@ -575,6 +652,10 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.StringSliceVar(&builder.bootstrapNodeAddresses, "bootstrap-node-addresses", defaultConfig.bootstrapNodeAddresses, "the network addresses of the bootstrap access node if this is an unstaked access node e.g. access-001.mainnet.flow.org:9653,access-002.mainnet.flow.org:9653")
 		flags.StringSliceVar(&builder.bootstrapNodePublicKeys, "bootstrap-node-public-keys", defaultConfig.bootstrapNodePublicKeys, "the networking public key of the bootstrap access node if this is an unstaked access node (in the same order as the bootstrap node addresses) e.g. \"d57a5e9c5.....\",\"44ded42d....\"")
 		flags.BoolVar(&builder.supportsUnstakedFollower, "supports-unstaked-node", defaultConfig.supportsUnstakedFollower, "true if this staked access node supports unstaked node")
+		flags.Uint64Var(&builder.pruningConfig.RetentionHeights, "pruning-retention-heights", defaultConfig.pruningConfig.RetentionHeights, "number of sealed heights for which historical data is retained (0 disables pruning)")
+		flags.DurationVar(&builder.pruningConfig.RetentionPeriod, "pruning-retention-period", defaultConfig.pruningConfig.RetentionPeriod, "additionally retain historical data of blocks younger than the period")
+		flags.UintVar(&builder.pruningConfig.BatchSize, "pruning-batch-size", defaultConfig.pruningConfig.BatchSize, "maximum number of heights pruned within one database transaction")
+		flags.DurationVar(&builder.pruningConfig.Interval, "pruning-interval", defaultConfig.pruningConfig.Interval, "interval between periodic pruning runs (0 to only prune via the admin command)")
 	})
 }
 
@@ -599,6 +680,7 @@ func (builder *FlowAccessNodeBuilder) initNetwork(nodeID module.Local,
 		topology,
 		p2p.NewChannelSubscriptionManager(middleware),
 		networkMetrics,
+		builder.Tracer,
 		builder.IdentityProvider,
 	)
 	if err != nil {
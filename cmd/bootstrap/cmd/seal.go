@@ -6,7 +6,9 @@ import (
 	"github.com/onflow/flow-go/cmd/bootstrap/run"
 	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/state/protocol"
 )
 
 func constructRootResultAndSeal(
@@ -43,9 +45,18 @@ func constructRootResultAndSeal(
 		RandomSource:       getRandomSource(flagBootstrapRandomSeed),
 	}
 
+	clustering, err := flow.NewClusterList(assignments, participants.Filter(filter.HasRole(flow.RoleCollection)))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not construct clustering")
+	}
+	qcsWithVoterIDs, err := protocol.ClusterQCVoteDatasFromQCs(clustering, clusterQCs)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not convert cluster qcs")
+	}
+
 	epochCommit := &flow.EpochCommit{
 		Counter:            flagEpochCounter,
-		ClusterQCs:         flow.ClusterQCVoteDatasFromQCs(clusterQCs),
+		ClusterQCs:         qcsWithVoterIDs,
 		DKGGroupKey:        dkgData.PubGroupKey,
 		DKGParticipantKeys: dkgData.PubKeyShares,
 	}
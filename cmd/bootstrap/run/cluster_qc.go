@@ -13,13 +13,18 @@ import (
 	"github.com/onflow/flow-go/model/cluster"
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
 	"github.com/onflow/flow-go/module/local"
 	"github.com/onflow/flow-go/module/signature"
 )
 
-func GenerateClusterRootQC(participants []bootstrap.NodeInfo, clusterBlock *cluster.Block) (*flow.QuorumCertificate, error) {
+// GenerateClusterRootQC generates the root QC of a cluster, signed by the given signers. The
+// signers are a subset of the cluster members, e.g. the cluster's nodes whose private keys are
+// available to the bootstrapping. The signer indices of the QC are encoded relative to all
+// members of the cluster in canonical order, like when the QC is decoded.
+func GenerateClusterRootQC(signers []bootstrap.NodeInfo, allCommitteeMembers flow.IdentityList, clusterBlock *cluster.Block) (*flow.QuorumCertificate, error) {
 
-	validators, signers, err := createClusterValidators(participants)
+	validators, signerVerifiers, err := createClusterValidators(signers, allCommitteeMembers)
 	if err != nil {
 		return nil, err
 	}
@@ -33,8 +38,8 @@ func GenerateClusterRootQC(participants []bootstrap.NodeInfo, clusterBlock *clus
 		Timestamp:   clusterBlock.Header.Timestamp,
 	}
 
-	votes := make([]*model.Vote, 0, len(signers))
-	for _, signer := range signers {
+	votes := make([]*model.Vote, 0, len(signerVerifiers))
+	for _, signer := range signerVerifiers {
 		vote, err := signer.CreateVote(&hotBlock)
 		if err != nil {
 			return nil, err
@@ -43,7 +48,7 @@ func GenerateClusterRootQC(participants []bootstrap.NodeInfo, clusterBlock *clus
 	}
 
 	// create the QC from the votes
-	qc, err := signers[0].CreateQC(votes)
+	qc, err := signerVerifiers[0].CreateQC(votes)
 	if err != nil {
 		return nil, err
 	}
@@ -54,10 +59,10 @@ func GenerateClusterRootQC(participants []bootstrap.NodeInfo, clusterBlock *clus
 	return qc, err
 }
 
-func createClusterValidators(participants []bootstrap.NodeInfo) ([]hotstuff.Validator, []hotstuff.SignerVerifier, error) {
+func createClusterValidators(participants []bootstrap.NodeInfo, allCommitteeMembers flow.IdentityList) ([]hotstuff.Validator, []hotstuff.SignerVerifier, error) {
 
 	n := len(participants)
-	identities := bootstrap.ToIdentityList(participants)
+	identities := allCommitteeMembers.Sort(order.Canonical)
 
 	signers := make([]hotstuff.SignerVerifier, n)
 	validators := make([]hotstuff.Validator, n)
@@ -8,6 +8,8 @@ import (
 	model "github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/cluster"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -32,10 +34,33 @@ func TestGenerateClusterRootQC(t *testing.T) {
 	payload := cluster.EmptyPayload(flow.ZeroID)
 	clusterBlock.SetPayload(payload)
 
-	_, err := GenerateClusterRootQC(participants, &clusterBlock)
+	_, err := GenerateClusterRootQC(participants, model.ToIdentityList(participants), &clusterBlock)
 	require.NoError(t, err)
 }
 
+// TestGenerateClusterRootQCWithPartners tests that the signer indices of a root QC signed
+// only by some members of the cluster are encoded relative to all cluster members.
+func TestGenerateClusterRootQCWithPartners(t *testing.T) {
+	participants := createClusterParticipants(t, 3)
+	partner := unittest.IdentityFixture(unittest.WithRole(flow.RoleCollection))
+	members := append(model.ToIdentityList(participants), partner)
+
+	clusterBlock := cluster.Block{
+		Header: &flow.Header{
+			ParentID: flow.ZeroID,
+			View:     42,
+		},
+	}
+	clusterBlock.SetPayload(cluster.EmptyPayload(flow.ZeroID))
+
+	qc, err := GenerateClusterRootQC(participants, members, &clusterBlock)
+	require.NoError(t, err)
+
+	signerIDs, err := signerindices.DecodeToIdentifiers(members.Sort(order.Canonical).NodeIDs(), qc.SignerIndices)
+	require.NoError(t, err)
+	require.ElementsMatch(t, model.ToIdentityList(participants).NodeIDs(), signerIDs)
+}
+
 func createClusterParticipants(t *testing.T, n int) []model.NodeInfo {
 	ids := unittest.IdentityListFixture(n, unittest.WithRole(flow.RoleCollection))
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/onflow/flow-go-sdk/client"
 	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+	"google.golang.org/grpc"
 
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
@@ -61,6 +62,14 @@ func main() {
 		builderExpiryBuffer                    uint
 		builderPayerRateLimit                  float64
 		builderUnlimitedPayers                 []string
+		txPriorityEnabled                      bool
+		txPriorityPayers                       []string
+		ingestValidateAccounts                 bool
+		ingestMinPayerBalance                  uint64
+		ingestAccountCacheSize                 uint
+		ingestAccountCacheTTL                  time.Duration
+		ingestAccountValidationTimeout         time.Duration
+		ingestAccountExecutionNodeAddr         string
 		hotstuffTimeout                        time.Duration
 		hotstuffMinTimeout                     time.Duration
 		hotstuffTimeoutIncreaseFactor          float64
@@ -97,6 +106,10 @@ func main() {
 	nodeBuilder.ExtraFlags(func(flags *pflag.FlagSet) {
 		flags.UintVar(&txLimit, "tx-limit", 50000,
 			"maximum number of transactions in the memory pool")
+		flags.BoolVar(&txPriorityEnabled, "tx-priority-enabled", false,
+			"whether the memory pool orders transactions by priority (priority payers first, then by gas limit) and evicts the lowest priority ones when full")
+		flags.StringSliceVar(&txPriorityPayers, "tx-priority-payers", []string{}, // no priority payers
+			"set of payer addresses whose transactions are prioritized, requires --tx-priority-enabled")
 		flags.StringVarP(&ingressConf.ListenAddr, "ingress-addr", "i", "localhost:9000",
 			"the address the ingress server listens on")
 		flags.BoolVar(&ingressConf.RpcMetricsEnabled, "rpc-metrics-enabled", false,
@@ -115,6 +128,18 @@ func main() {
 			"how many additional cluster members we propagate transactions to")
 		flags.Uint64Var(&ingestConf.MaxAddressIndex, "ingest-max-address-index", flow.DefaultMaxAddressIndex,
 			"the maximum address index allowed in transactions")
+		flags.BoolVar(&ingestValidateAccounts, "ingest-validate-accounts", false,
+			"whether we validate inbound transactions signatures, proposal key sequence number and payer balance against account state")
+		flags.Uint64Var(&ingestMinPayerBalance, "ingest-min-payer-balance", 0,
+			"minimum payer balance required for inbound transactions, 0 disables the balance check, requires --ingest-validate-accounts")
+		flags.UintVar(&ingestAccountCacheSize, "ingest-account-cache-size", 1000,
+			"number of accounts cached for transaction validation")
+		flags.DurationVar(&ingestAccountCacheTTL, "ingest-account-cache-ttl", 10*time.Second,
+			"how long accounts are cached for transaction validation")
+		flags.DurationVar(&ingestAccountValidationTimeout, "ingest-account-validation-timeout", 2*time.Second,
+			"timeout for reading the accounts of a transaction for validation")
+		flags.StringVar(&ingestAccountExecutionNodeAddr, "ingest-account-execution-node-addr", "",
+			"execution node API address to read accounts from for transaction validation, reads from the first access node if not set")
 		flags.UintVar(&builderExpiryBuffer, "builder-expiry-buffer", builder.DefaultExpiryBuffer,
 			"expiry buffer for transactions in proposed collections")
 		flags.Float64Var(&builderPayerRateLimit, "builder-rate-limit", builder.DefaultMaxPayerTransactionRate, // no rate limiting
@@ -182,6 +207,14 @@ func main() {
 		}).
 		Module("transactions mempool", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			create := func() mempool.Transactions { return stdmap.NewTransactions(txLimit) }
+			if txPriorityEnabled {
+				priorityPayers := make([]flow.Address, 0, len(txPriorityPayers))
+				for _, payerStr := range txPriorityPayers {
+					priorityPayers = append(priorityPayers, flow.HexToAddress(payerStr))
+				}
+				priority := stdmap.PriorityByPayerAndGas(priorityPayers...)
+				create = func() mempool.Transactions { return stdmap.NewPriorityTransactions(txLimit, priority) }
+			}
 			pools = epochpool.NewTransactionPools(create)
 			err := node.Metrics.Mempool.Register(metrics.ResourceTransaction, pools.CombinedSize)
 			return err
@@ -330,6 +363,31 @@ func main() {
 			return sync, nil
 		}).
 		Component("ingestion engine", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			var opts []ingest.Option
+			if ingestValidateAccounts {
+				var accounts ingest.AccountProvider
+				if ingestAccountExecutionNodeAddr != "" {
+					conn, err := grpc.Dial(ingestAccountExecutionNodeAddr, grpc.WithInsecure())
+					if err != nil {
+						return nil, fmt.Errorf("could not connect to execution node for account validation: %w", err)
+					}
+					accounts = ingest.NewExecutionAccountProvider(conn, node.State)
+				} else {
+					flowClient, err := common.FlowClient(flowClientConfigs[0])
+					if err != nil {
+						return nil, fmt.Errorf("failed to get flow client connection option for access node (0): %s %w", flowClientConfigs[0].AccessAddress, err)
+					}
+					accounts = ingest.NewAccessAccountProvider(flowClient)
+				}
+
+				cachedAccounts, err := ingest.NewCachedAccountProvider(accounts, ingestAccountCacheSize, ingestAccountCacheTTL)
+				if err != nil {
+					return nil, err
+				}
+				validator := ingest.NewAccountValidator(node.Logger, cachedAccounts, ingestMinPayerBalance, ingestAccountValidationTimeout)
+				opts = append(opts, ingest.WithAccountValidator(validator))
+			}
+
 			ing, err = ingest.New(
 				node.Logger,
 				node.Network,
@@ -340,6 +398,7 @@ func main() {
 				node.RootChainID.Chain(),
 				pools,
 				ingestConf,
+				opts...,
 			)
 			return ing, err
 		}).
@@ -459,7 +518,7 @@ func main() {
 				return nil, err
 			}
 
-			signer := verification.NewSingleSigner(staking, node.Me.NodeID())
+			signer := verification.NewSingleSigner(nil, staking, node.Me.NodeID())
 
 			// construct QC contract client
 			qcContractClients, err := createQCContractClients(node, machineAccountInfo, flowClientConfigs)
@@ -14,6 +14,8 @@ import (
 	"github.com/onflow/flow-go-sdk/client"
 	"github.com/onflow/flow-go-sdk/crypto"
 
+	"github.com/onflow/flow-go/admin/commands"
+	consensusCommands "github.com/onflow/flow-go/admin/commands/consensus"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/cmd/util/cmd/common"
 	"github.com/onflow/flow-go/consensus"
@@ -28,6 +30,7 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/requester"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
 	"github.com/onflow/flow-go/engine/consensus/approvals/tracker"
 	"github.com/onflow/flow-go/engine/consensus/compliance"
 	dkgeng "github.com/onflow/flow-go/engine/consensus/dkg"
@@ -85,7 +88,7 @@ func main() {
 		chunkAlpha                             uint
 		requiredApprovalsForSealVerification   uint
 		requiredApprovalsForSealConstruction   uint
-		emergencySealing                       bool
+		emergencySealingConfig                 = approvals.DefaultEmergencySealingConfig()
 		dkgControllerConfig                    dkgmodule.ControllerConfig
 		startupTimeString                      string
 		startupTime                            time.Time
@@ -109,6 +112,8 @@ func main() {
 		comp                    *compliance.Engine
 		conMetrics              module.ConsensusMetrics
 		mainMetrics             module.HotstuffMetrics
+		timeoutController       *timeout.Controller
+		emergencySealing        *approvals.EmergencySealing
 		receiptValidator        module.ReceiptValidator
 		chunkAssigner           *chmodule.ChunkAssigner
 		finalizationDistributor *pubsub.FinalizationDistributor
@@ -116,6 +121,7 @@ func main() {
 		blockTimer              protocol.BlockTimer
 		finalizedHeader         *synceng.FinalizedHeaderCache
 		dkgKeyStore             *bstorage.BeaconPrivateKeys
+		dkgState                *bstorage.DKGState
 	)
 
 	nodeBuilder := cmd.FlowNode(flow.RoleConsensus.String())
@@ -140,7 +146,9 @@ func main() {
 		flags.UintVar(&chunkAlpha, "chunk-alpha", chmodule.DefaultChunkAssignmentAlpha, "number of verifiers that should be assigned to each chunk")
 		flags.UintVar(&requiredApprovalsForSealVerification, "required-verification-seal-approvals", validation.DefaultRequiredApprovalsForSealValidation, "minimum number of approvals that are required to verify a seal")
 		flags.UintVar(&requiredApprovalsForSealConstruction, "required-construction-seal-approvals", sealing.DefaultRequiredApprovalsForSealConstruction, "minimum number of approvals that are required to construct a seal")
-		flags.BoolVar(&emergencySealing, "emergency-sealing-active", sealing.DefaultEmergencySealingActive, "(de)activation of emergency sealing")
+		flags.BoolVar(&emergencySealingConfig.Active, "emergency-sealing-active", emergencySealingConfig.Active, "(de)activation of emergency sealing, can be changed at runtime with the set-emergency-sealing admin command")
+		flags.Uint64Var(&emergencySealingConfig.UnsealedHeightThreshold, "emergency-sealing-unsealed-height-threshold", emergencySealingConfig.UnsealedHeightThreshold, "minimum number of finalized blocks on top of the block incorporating a result for it to be emergency sealed")
+		flags.UintVar(&emergencySealingConfig.RequiredReceipts, "emergency-sealing-required-receipts", emergencySealingConfig.RequiredReceipts, "minimum number of execution nodes which must have committed to a result for it to be emergency sealed")
 		flags.BoolVar(&insecureAccessAPI, "insecure-access-api", false, "required if insecure GRPC connection should be used")
 		flags.StringSliceVar(&accessNodeIDS, "access-node-ids", []string{}, fmt.Sprintf("array of access node IDs sorted in priority order where the first ID in this array will get the first connection attempt and each subsequent ID after serves as a fallback. Minimum length %d. Use '*' for all IDs in protocol state.", common.DefaultAccessNodeIDSMinimum))
 		flags.DurationVar(&dkgControllerConfig.BaseStartDelay, "dkg-controller-base-start-delay", dkgmodule.DefaultBaseStartDelay, "used to define the range for jitter prior to DKG start (eg. 500µs) - the base value is scaled quadratically with the # of DKG participants")
@@ -157,6 +165,20 @@ func main() {
 			startupTime = t
 			nodeBuilder.Logger.Info().Time("startup_time", startupTime).Msg("got startup_time")
 		}
+
+		// the timeout controller is created up front, so that the admin commands can tune it at runtime
+		timeoutConfig, err := timeout.NewConfig(
+			hotstuffTimeout,
+			hotstuffMinTimeout,
+			hotstuffTimeoutVoteAggregationFraction,
+			hotstuffTimeoutIncreaseFactor,
+			hotstuffTimeoutDecreaseFactor,
+			blockRateDelay,
+		)
+		if err != nil {
+			return fmt.Errorf("invalid hotstuff timeout configuration: %w", err)
+		}
+		timeoutController = timeout.NewController(timeoutConfig)
 		return nil
 	})
 
@@ -165,14 +187,14 @@ func main() {
 	}
 
 	nodeBuilder.
-		Module("consensus node metrics", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
-			conMetrics = metrics.NewConsensusCollector(node.Tracer, node.MetricsRegisterer)
-			return nil
-		}).
 		Module("dkg key storage", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			dkgKeyStore, err = bstorage.NewBeaconPrivateKeys(node.Metrics.Cache, node.SecretsDB)
 			return err
 		}).
+		Module("dkg state storage", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
+			dkgState, err = bstorage.NewDKGState(node.SecretsDB)
+			return err
+		}).
 		Module("mutable follower state", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			// For now, we only support state implementations from package badger.
 			// If we ever support different implementations, the following can be replaced by a type-aware factory
@@ -347,9 +369,24 @@ func main() {
 			pendingReceipts = stdmap.NewPendingReceipts(node.Storage.Headers, pendingReceiptsLimit)
 			return nil
 		}).
-		Module("hotstuff main metrics", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
+		PostInit(func(builder cmd.NodeBuilder, node *cmd.NodeConfig) {
+			// the hotstuff metrics are created before the admin commands, which report timeout configuration updates
 			mainMetrics = metrics.NewHotstuffCollector(node.RootChainID)
-			return nil
+			// the same holds for the consensus metrics and emergency sealing, which can be (de)activated at runtime
+			conMetrics = metrics.NewConsensusCollector(node.Tracer, node.MetricsRegisterer)
+			emergencySealing = approvals.NewEmergencySealing(emergencySealingConfig, node.Storage.Receipts, conMetrics)
+		}).
+		AdminCommand("get-hotstuff-timeout-config", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return consensusCommands.NewGetTimeoutConfigCommand(timeoutController)
+		}).
+		AdminCommand("set-hotstuff-timeout-config", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return consensusCommands.NewSetTimeoutConfigCommand(config.Logger, timeoutController, mainMetrics)
+		}).
+		AdminCommand("get-emergency-sealing", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return consensusCommands.NewGetEmergencySealingCommand(emergencySealing)
+		}).
+		AdminCommand("set-emergency-sealing", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return consensusCommands.NewSetEmergencySealingCommand(config.Logger, emergencySealing)
 		}).
 		Module("sync core", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			syncCore, err = synchronization.New(node.Logger, synchronization.DefaultConfig())
@@ -397,7 +434,6 @@ func main() {
 			sealingTracker := tracker.NewSealingTracker(node.Logger, node.Storage.Headers, node.Storage.Receipts, seals)
 
 			config := sealing.DefaultConfig()
-			config.EmergencySealingActive = emergencySealing
 			config.RequiredApprovalsForSealConstruction = requiredApprovalsForSealConstruction
 
 			e, err := sealing.NewEngine(
@@ -418,6 +454,7 @@ func main() {
 				chunkAssigner,
 				resultApprovalSigVerifier,
 				seals,
+				emergencySealing,
 				config,
 			)
 
@@ -638,12 +675,7 @@ func main() {
 			}
 
 			opts := []consensus.Option{
-				consensus.WithInitialTimeout(hotstuffTimeout),
-				consensus.WithMinTimeout(hotstuffMinTimeout),
-				consensus.WithVoteAggregationTimeoutFraction(hotstuffTimeoutVoteAggregationFraction),
-				consensus.WithTimeoutIncreaseFactor(hotstuffTimeoutIncreaseFactor),
-				consensus.WithTimeoutDecreaseFactor(hotstuffTimeoutDecreaseFactor),
-				consensus.WithBlockRateDelay(blockRateDelay),
+				consensus.WithTimeoutController(timeoutController),
 			}
 
 			if !startupTime.IsZero() {
@@ -744,12 +776,14 @@ func main() {
 				node.Me,
 				node.State,
 				dkgKeyStore,
+				dkgState,
 				dkgmodule.NewControllerFactory(
 					node.Logger,
 					node.Me,
 					dkgContractClients,
 					dkgBrokerTunnel,
 					dkgControllerConfig,
+					dkgState,
 				),
 				viewsObserver,
 			)
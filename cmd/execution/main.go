@@ -15,6 +15,8 @@ import (
 
 	"github.com/onflow/flow-core-contracts/lib/go/templates"
 
+	"github.com/onflow/flow-go/admin/commands"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
@@ -70,6 +72,7 @@ func main() {
 		txResults                     *storage.TransactionResults
 		results                       *storage.ExecutionResults
 		myReceipts                    *storage.MyExecutionReceipts
+		chunkDataPacks                *storage.ChunkDataPacks
 		providerEngine                *exeprovider.Engine
 		checkerEng                    *checker.Engine
 		syncCore                      *chainsync.Core
@@ -103,15 +106,18 @@ func main() {
 		pauseExecution                bool
 		checkStakedAtBlock            func(blockID flow.Identifier) (bool, error)
 		diskWAL                       *wal.DiskWAL
-		scriptLogThreshold            time.Duration
+		scriptMemoryLimit             uint64
 		chdpQueryTimeout              uint
 		chdpDeliveryTimeout           uint
 		enableBlockDataUpload         bool
 		gcpBucketName                 string
 		s3BucketName                  string
 		blockDataUploaders            []uploader.Uploader
+		pruner                        *storage.Pruner
 		blockDataUploaderMaxRetry     uint64 = 5
 		blockdataUploaderRetryTimeout        = 1 * time.Second
+		pruningConfig                        = storage.DefaultPrunerConfig()
+		scriptConfig                         = computation.DefaultScriptExecutionConfig()
 	)
 
 	nodeBuilder := cmd.FlowNode(flow.RoleExecution.String())
@@ -130,7 +136,12 @@ func main() {
 			flags.UintVar(&cadenceExecutionCache, "cadence-execution-cache", computation.DefaultProgramsCacheSize, "cache size for Cadence execution")
 			flags.UintVar(&chdpCacheSize, "chdp-cache", storage.DefaultCacheSize, "cache size for Chunk Data Packs")
 			flags.DurationVar(&requestInterval, "request-interval", 60*time.Second, "the interval between requests for the requester engine")
-			flags.DurationVar(&scriptLogThreshold, "script-log-threshold", computation.DefaultScriptLogThreshold, "threshold for logging script execution")
+			flags.DurationVar(&scriptConfig.LogThreshold, "script-log-threshold", scriptConfig.LogThreshold, "threshold for logging script execution")
+			flags.DurationVar(&scriptConfig.Timeout, "script-execution-timeout", scriptConfig.Timeout, "maximum duration of the execution of a single script")
+			flags.UintVar(&scriptConfig.MaxConcurrency, "script-max-concurrency", scriptConfig.MaxConcurrency, "maximum number of scripts executed concurrently")
+			flags.UintVar(&scriptConfig.MaxConcurrencyDuringBlockExecution, "script-max-concurrency-during-block-execution", scriptConfig.MaxConcurrencyDuringBlockExecution, "maximum number of scripts executed concurrently while blocks are being executed")
+			flags.UintVar(&scriptConfig.QueueSize, "script-queue-size", scriptConfig.QueueSize, "maximum number of scripts waiting to be executed, further scripts are rejected")
+			flags.Uint64Var(&scriptMemoryLimit, "script-memory-limit", fvm.DefaultScriptMemoryLimit, "maximum number of bytes of memory a script may use")
 			flags.StringVar(&preferredExeNodeIDStr, "preferred-exe-node-id", "", "node ID for preferred execution node used for state sync")
 			flags.UintVar(&transactionResultsCacheSize, "transaction-results-cache-size", 10000, "number of transaction results to be cached")
 			flags.BoolVar(&syncByBlocks, "sync-by-blocks", true, "deprecated, sync by blocks instead of execution state deltas")
@@ -143,6 +154,10 @@ func main() {
 			flags.BoolVar(&enableBlockDataUpload, "enable-blockdata-upload", false, "enable uploading block data to Cloud Bucket")
 			flags.StringVar(&gcpBucketName, "gcp-bucket-name", "", "GCP Bucket name for block data uploader")
 			flags.StringVar(&s3BucketName, "s3-bucket-name", "", "S3 Bucket name for block data uploader")
+			flags.Uint64Var(&pruningConfig.RetentionHeights, "pruning-retention-heights", pruningConfig.RetentionHeights, "number of sealed heights for which historical data is retained (0 disables pruning)")
+			flags.DurationVar(&pruningConfig.RetentionPeriod, "pruning-retention-period", pruningConfig.RetentionPeriod, "additionally retain historical data of blocks younger than the period")
+			flags.UintVar(&pruningConfig.BatchSize, "pruning-batch-size", pruningConfig.BatchSize, "maximum number of heights pruned within one database transaction")
+			flags.DurationVar(&pruningConfig.Interval, "pruning-interval", pruningConfig.Interval, "interval between periodic pruning runs (0 to only prune via the admin command)")
 		}).
 		ValidateFlags(func() error {
 			if enableBlockDataUpload {
@@ -150,6 +165,9 @@ func main() {
 					return fmt.Errorf("invalid flag. gcp-bucket-name or s3-bucket-name required when blockdata-uploader is enabled")
 				}
 			}
+			if pruningConfig.BatchSize == 0 {
+				return fmt.Errorf("invalid flag. pruning-batch-size must be positive")
+			}
 			return nil
 		})
 
@@ -158,6 +176,42 @@ func main() {
 	}
 
 	nodeBuilder.
+		PostInit(func(builder cmd.NodeBuilder, node *cmd.NodeConfig) {
+			// the pruner is created before the admin commands, which trigger pruning on demand
+			pruningConfig.Events = true
+			pruningConfig.TransactionResults = true
+			pruningConfig.ChunkDataPacks = true
+			pruningConfig.OwnExecutionReceipt = true
+			// data of blocks which have not been executed yet must be retained
+			executedHeight := func() (uint64, error) {
+				if executionState == nil {
+					return 0, fmt.Errorf("execution state not initialized yet")
+				}
+				height, _, err := executionState.GetHighestExecutedBlockID(context.Background())
+				return height, err
+			}
+			pruner, err = storage.NewPruner(node.Logger, node.DB, node.Metrics.Pruner, pruningConfig, storage.WithHeightLimit(executedHeight))
+			if err != nil {
+				node.Logger.Fatal().Err(err).Msg("could not create storage pruner")
+			}
+
+			// the execution storage is created before the admin commands, which read results and chunks from it
+			results = storage.NewExecutionResults(node.Metrics.Cache, node.DB)
+			myReceipts = storage.NewMyExecutionReceipts(node.Metrics.Cache, node.DB, node.Storage.Receipts.(*storage.ExecutionReceipts))
+			chunkDataPacks = storage.NewChunkDataPacks(node.Metrics.Cache, node.DB, node.Storage.Collections, chdpCacheSize)
+		}).
+		AdminCommand("prune-storage", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewPruneCommand(pruner)
+		}).
+		AdminCommand("read-chunk-data-pack", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewReadChunkDataPackCommand(chunkDataPacks)
+		}).
+		AdminCommand("read-result-chunks", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewReadResultChunksCommand(config.State, results, chunkDataPacks)
+		}).
+		AdminCommand("compare-receipts", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewCompareReceiptsCommand(config.State, config.Storage.Receipts, myReceipts)
+		}).
 		Module("mutable follower state", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			// For now, we only support state implementations from package badger.
 			// If we ever support different implementations, the following can be replaced by a type-aware factory
@@ -183,11 +237,6 @@ func main() {
 			syncCore, err = chainsync.New(node.Logger, chainsync.DefaultConfig())
 			return err
 		}).
-		Module("execution receipts storage", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
-			results = storage.NewExecutionResults(node.Metrics.Cache, node.DB)
-			myReceipts = storage.NewMyExecutionReceipts(node.Metrics.Cache, node.DB, node.Storage.Receipts.(*storage.ExecutionReceipts))
-			return nil
-		}).
 		Module("pending block cache", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			pendingBlocks = buffer.NewPendingBlocks() // for following main chain consensus
 			return nil
@@ -330,7 +379,8 @@ func main() {
 			rt := fvm.NewInterpreterRuntime()
 
 			vm := fvm.NewVirtualMachine(rt)
-			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
+			// the script memory limit only applies to scripts, which are executed locally
+			vmCtx := fvm.NewContext(node.Logger, append(node.FvmOptions, fvm.WithScriptMemoryLimit(scriptMemoryLimit))...)
 
 			committer := committer.NewLedgerViewCommitter(ledgerStorage, node.Tracer)
 			manager, err := computation.New(
@@ -343,7 +393,7 @@ func main() {
 				vmCtx,
 				cadenceExecutionCache,
 				committer,
-				scriptLogThreshold,
+				scriptConfig,
 				blockDataUploaders,
 			)
 			if err != nil {
@@ -351,7 +401,6 @@ func main() {
 			}
 			computationManager = manager
 
-			chunkDataPacks := storage.NewChunkDataPacks(node.Metrics.Cache, node.DB, node.Storage.Collections, chdpCacheSize)
 			stateCommitments := storage.NewCommits(node.Metrics.Cache, node.DB)
 
 			// Needed for gRPC server, make sure to assign to main scoped vars
@@ -441,6 +490,9 @@ func main() {
 
 			return providerEngine, nil
 		}).
+		Component("storage pruner", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			return pruner, nil
+		}).
 		Component("checker engine", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			checkerEng = checker.New(
 				node.Logger,
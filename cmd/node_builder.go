@@ -17,8 +17,10 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/id"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/p2p"
+	"github.com/onflow/flow-go/network/recorder"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/events"
 	bstorage "github.com/onflow/flow-go/storage/badger"
@@ -124,12 +126,21 @@ type BaseConfig struct {
 	profilerDuration                time.Duration
 	tracerEnabled                   bool
 	tracerSensitivity               uint
+	tracerEntitySensitivity         map[string]int
+	tracerExporter                  string
+	tracerOTLPEndpoint              string
+	tracerFile                      string
 	metricsEnabled                  bool
+	storageOperationMetrics         bool
+	storageSlowOperationThreshold   time.Duration
 	guaranteesCacheSize             uint
 	receiptsCacheSize               uint
 	db                              *badger.DB
 	PreferredUnicastProtocols       []string
 	NetworkReceivedMessageCacheSize int
+	networkRecordingDir             string
+	networkRecordingMaxFileSize     int64
+	networkRecordingMaxFiles        int
 }
 
 // NodeConfig contains all the derived parameters such the NodeID, private keys etc. and initialized instances of
@@ -196,9 +207,18 @@ func DefaultBaseConfig() *BaseConfig {
 		profilerDuration:                10 * time.Second,
 		tracerEnabled:                   false,
 		tracerSensitivity:               4,
+		tracerEntitySensitivity:         nil,
+		tracerExporter:                  trace.ExporterJaeger,
+		tracerOTLPEndpoint:              "localhost:4317",
+		tracerFile:                      "traces.jsonl",
 		metricsEnabled:                  true,
+		storageOperationMetrics:         false,
+		storageSlowOperationThreshold:   time.Second,
 		receiptsCacheSize:               bstorage.DefaultCacheSize,
 		guaranteesCacheSize:             bstorage.DefaultCacheSize,
 		NetworkReceivedMessageCacheSize: p2p.DefaultCacheSize,
+		networkRecordingDir:             NotSet,
+		networkRecordingMaxFileSize:     recorder.DefaultMaxFileSize,
+		networkRecordingMaxFiles:        recorder.DefaultMaxFiles,
 	}
 }
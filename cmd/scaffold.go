@@ -43,6 +43,7 @@ import (
 	cborcodec "github.com/onflow/flow-go/network/codec/cbor"
 	"github.com/onflow/flow-go/network/p2p"
 	"github.com/onflow/flow-go/network/p2p/unicast"
+	"github.com/onflow/flow-go/network/recorder"
 	"github.com/onflow/flow-go/network/topology"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/events"
@@ -64,6 +65,7 @@ type Metrics struct {
 	Cache          module.CacheMetrics
 	Mempool        module.MempoolMetrics
 	CleanCollector module.CleanerMetrics
+	Pruner         module.PrunerMetrics
 }
 
 type Storage = storage.All
@@ -105,6 +107,7 @@ type FlowNodeBuilder struct {
 	extraFlagCheck           func() error
 	adminCommandBootstrapper *admin.CommandRunnerBootstrapper
 	adminCommands            map[string]func(config *NodeConfig) commands.AdminCommand
+	messageRecorder          *recorder.FileRecorder
 }
 
 func (fnb *FlowNodeBuilder) BaseFlags() {
@@ -121,15 +124,27 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 	fnb.flags.DurationVar(&fnb.BaseConfig.UnicastMessageTimeout, "unicast-timeout", defaultConfig.UnicastMessageTimeout, "how long a unicast transmission can take to complete")
 	fnb.flags.UintVarP(&fnb.BaseConfig.metricsPort, "metricport", "m", defaultConfig.metricsPort, "port for /metrics endpoint")
 	fnb.flags.BoolVar(&fnb.BaseConfig.profilerEnabled, "profiler-enabled", defaultConfig.profilerEnabled, "whether to enable the auto-profiler")
-	fnb.flags.StringVar(&fnb.BaseConfig.profilerDir, "profiler-dir", defaultConfig.profilerDir, "directory to create auto-profiler profiles")
+	fnb.flags.StringVar(&fnb.BaseConfig.profilerDir, "profiler-dir", defaultConfig.profilerDir, "directory to create auto-profiler and admin command profiles")
 	fnb.flags.DurationVar(&fnb.BaseConfig.profilerInterval, "profiler-interval", defaultConfig.profilerInterval,
 		"the interval between auto-profiler runs")
 	fnb.flags.DurationVar(&fnb.BaseConfig.profilerDuration, "profiler-duration", defaultConfig.profilerDuration,
 		"the duration to run the auto-profile for")
+	fnb.flags.BoolVar(&fnb.BaseConfig.storageOperationMetrics, "storage-operation-metrics", defaultConfig.storageOperationMetrics,
+		"whether to report the latency and size of low-level storage operations by key prefix, and the latency of commits")
+	fnb.flags.DurationVar(&fnb.BaseConfig.storageSlowOperationThreshold, "storage-slow-operation-threshold", defaultConfig.storageSlowOperationThreshold,
+		"low-level storage operations taking longer are logged (0 to disable)")
 	fnb.flags.BoolVar(&fnb.BaseConfig.tracerEnabled, "tracer-enabled", defaultConfig.tracerEnabled,
 		"whether to enable tracer")
 	fnb.flags.UintVar(&fnb.BaseConfig.tracerSensitivity, "tracer-sensitivity", defaultConfig.tracerSensitivity,
 		"adjusts the level of sampling when tracing is enabled. 0 means capture everything, higher value results in less samples")
+	fnb.flags.StringToIntVar(&fnb.BaseConfig.tracerEntitySensitivity, "tracer-entity-sensitivity", defaultConfig.tracerEntitySensitivity,
+		"overrides the tracer sensitivity per entity type, e.g. Block=0,Transaction=8 (not supported by the jaeger exporter)")
+	fnb.flags.StringVar(&fnb.BaseConfig.tracerExporter, "tracer-exporter", defaultConfig.tracerExporter,
+		"the exporter of the tracer: jaeger (configured through JAEGER_* environment variables), otlp or file")
+	fnb.flags.StringVar(&fnb.BaseConfig.tracerOTLPEndpoint, "tracer-otlp-endpoint", defaultConfig.tracerOTLPEndpoint,
+		"the address of the OTLP collector (gRPC, without TLS) when using the otlp exporter")
+	fnb.flags.StringVar(&fnb.BaseConfig.tracerFile, "tracer-file", defaultConfig.tracerFile,
+		"the file the spans are appended to as JSON lines when using the file exporter")
 
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminAddr, "admin-addr", defaultConfig.AdminAddr, "address to bind on for admin HTTP server")
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminCert, "admin-cert", defaultConfig.AdminCert, "admin cert file (for TLS)")
@@ -140,11 +155,34 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 	fnb.flags.StringSliceVar(&fnb.BaseConfig.PreferredUnicastProtocols, "preferred-unicast-protocols", nil, "preferred unicast protocols in ascending order of preference")
 	fnb.flags.IntVar(&fnb.BaseConfig.NetworkReceivedMessageCacheSize, "networking-receive-cache-size", p2p.DefaultCacheSize,
 		"incoming message cache size at networking layer")
+	fnb.flags.StringVar(&fnb.BaseConfig.networkRecordingDir, "network-recording-dir", defaultConfig.networkRecordingDir,
+		"directory to record authenticated incoming network messages into, recording is disabled if not set")
+	fnb.flags.Int64Var(&fnb.BaseConfig.networkRecordingMaxFileSize, "network-recording-max-file-size", defaultConfig.networkRecordingMaxFileSize,
+		"size in bytes after which the network message recording is rotated to a new file")
+	fnb.flags.IntVar(&fnb.BaseConfig.networkRecordingMaxFiles, "network-recording-max-files", defaultConfig.networkRecordingMaxFiles,
+		"number of network message recording files to keep")
 	fnb.flags.UintVar(&fnb.BaseConfig.guaranteesCacheSize, "guarantees-cache-size", bstorage.DefaultCacheSize, "collection guarantees cache size")
 	fnb.flags.UintVar(&fnb.BaseConfig.receiptsCacheSize, "receipts-cache-size", bstorage.DefaultCacheSize, "receipts cache size")
 }
 
 func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
+	// the recorder is registered as a component before the network, so it is only closed after the network stopped
+	if fnb.BaseConfig.networkRecordingDir != NotSet {
+		fnb.Component("network message recorder", func(builder NodeBuilder, node *NodeConfig) (module.ReadyDoneAware, error) {
+			messageRecorder, err := recorder.NewFileRecorder(
+				fnb.Logger,
+				fnb.BaseConfig.networkRecordingDir,
+				recorder.WithMaxFileSize(fnb.BaseConfig.networkRecordingMaxFileSize),
+				recorder.WithMaxFiles(fnb.BaseConfig.networkRecordingMaxFiles),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not create network message recorder: %w", err)
+			}
+			fnb.messageRecorder = messageRecorder
+			return messageRecorder, nil
+		})
+	}
+
 	fnb.Component("network", func(builder NodeBuilder, node *NodeConfig) (module.ReadyDoneAware, error) {
 
 		codec := cborcodec.NewCodec()
@@ -217,6 +255,9 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 			p2p.WithPeerManager(peerManagerFactory),
 			p2p.WithConnectionGating(true),
 			p2p.WithPreferredUnicastProtocols(unicast.ToProtocolNames(fnb.PreferredUnicastProtocols)))
+		if fnb.messageRecorder != nil {
+			mwOpts = append(mwOpts, p2p.WithMessageRecorder(fnb.messageRecorder))
+		}
 
 		fnb.Middleware = p2p.NewMiddleware(
 			fnb.Logger,
@@ -250,6 +291,7 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 			topologyCache,
 			subscriptionManager,
 			fnb.Metrics.Network,
+			fnb.Tracer,
 			fnb.IdentityProvider,
 		)
 		if err != nil {
@@ -388,17 +430,43 @@ func (fnb *FlowNodeBuilder) initLogger() {
 	fnb.Logger = log
 }
 
+// newTracer creates the tracer for the configured exporter.
+func (fnb *FlowNodeBuilder) newTracer(serviceName string) (module.Tracer, error) {
+	if fnb.tracerExporter == trace.ExporterJaeger {
+		return trace.NewTracer(fnb.Logger,
+			serviceName,
+			fnb.RootChainID.String(),
+			fnb.tracerSensitivity)
+	}
+
+	entitySensitivity := make(map[string]uint, len(fnb.tracerEntitySensitivity))
+	for entityType, sensitivity := range fnb.tracerEntitySensitivity {
+		if sensitivity < 0 {
+			return nil, fmt.Errorf("invalid tracer sensitivity for %s: %d", entityType, sensitivity)
+		}
+		entitySensitivity[entityType] = uint(sensitivity)
+	}
+
+	return trace.NewOtelTracer(fnb.Logger,
+		serviceName,
+		fnb.RootChainID.String(),
+		trace.OtelConfig{
+			Exporter:          fnb.tracerExporter,
+			OTLPEndpoint:      fnb.tracerOTLPEndpoint,
+			FilePath:          fnb.tracerFile,
+			Sensitivity:       fnb.tracerSensitivity,
+			EntitySensitivity: entitySensitivity,
+		})
+}
+
 func (fnb *FlowNodeBuilder) initMetrics() {
 
 	fnb.Tracer = trace.NewNoopTracer()
 	if fnb.BaseConfig.tracerEnabled {
 		serviceName := fnb.BaseConfig.NodeRole + "-" + fnb.BaseConfig.nodeIDHex[:8]
-		tracer, err := trace.NewTracer(fnb.Logger,
-			serviceName,
-			fnb.RootChainID.String(),
-			fnb.tracerSensitivity)
+		tracer, err := fnb.newTracer(serviceName)
 		fnb.MustNot(err).Msg("could not initialize tracer")
-		fnb.Logger.Info().Msg("Tracer Started")
+		fnb.Logger.Info().Str("exporter", fnb.tracerExporter).Msg("Tracer Started")
 		fnb.Tracer = tracer
 	}
 
@@ -409,6 +477,7 @@ func (fnb *FlowNodeBuilder) initMetrics() {
 		Cache:          metrics.NewNoopCollector(),
 		Mempool:        metrics.NewNoopCollector(),
 		CleanCollector: metrics.NewNoopCollector(),
+		Pruner:         metrics.NewNoopCollector(),
 	}
 	if fnb.BaseConfig.metricsEnabled {
 		fnb.MetricsRegisterer = prometheus.DefaultRegisterer
@@ -424,6 +493,7 @@ func (fnb *FlowNodeBuilder) initMetrics() {
 			Cache:          metrics.NewNoopCollector(),
 			CleanCollector: metrics.NewCleanerCollector(),
 			Mempool:        mempools,
+			Pruner:         metrics.NewPrunerCollector(),
 		}
 
 		// registers mempools as a Component so that its Ready method is invoked upon startup
@@ -431,6 +501,12 @@ func (fnb *FlowNodeBuilder) initMetrics() {
 			return mempools, nil
 		})
 	}
+
+	// the low-level storage operations are instrumented for all databases of the node
+	operationMetrics := fnb.BaseConfig.metricsEnabled && fnb.BaseConfig.storageOperationMetrics
+	if operationMetrics || fnb.BaseConfig.storageSlowOperationThreshold > 0 {
+		operation.Instrument(fnb.Logger, operationMetrics, fnb.BaseConfig.storageSlowOperationThreshold)
+	}
 }
 
 func (fnb *FlowNodeBuilder) initProfiler() {
@@ -728,6 +804,7 @@ func (fnb *FlowNodeBuilder) initFvmOptions() {
 		fvm.WithChain(fnb.RootChainID.Chain()),
 		fvm.WithBlocks(blockFinder),
 		fvm.WithAccountStorageLimit(true),
+		fvm.WithWeightedComputationMetering(true),
 	}
 	if fnb.RootChainID == flow.Testnet || fnb.RootChainID == flow.Canary || fnb.RootChainID == flow.Mainnet {
 		vmOpts = append(vmOpts,
@@ -951,6 +1028,10 @@ func (fnb *FlowNodeBuilder) Initialize() error {
 func (fnb *FlowNodeBuilder) RegisterDefaultAdminCommands() {
 	fnb.AdminCommand("set-log-level", func(config *NodeConfig) commands.AdminCommand {
 		return &common.SetLogLevelCommand{}
+	}).AdminCommand("profile", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewProfileCommand(config.profilerDir)
+	}).AdminCommand("set-profiler-rates", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewSetProfilerRatesCommand()
 	}).AdminCommand("read-blocks", func(config *NodeConfig) commands.AdminCommand {
 		return storageCommands.NewReadBlocksCommand(config.State, config.Storage.Blocks)
 	}).AdminCommand("read-results", func(config *NodeConfig) commands.AdminCommand {
@@ -0,0 +1,275 @@
+package account_storage
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onflow/atree"
+	cadenceRuntime "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/interpreter"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountStorage describes the storage of an account, register by register
+type AccountStorage struct {
+	Address flow.Address `json:"address"`
+	// StorageUsed is the storage used recorded in the storage_used register of the account
+	StorageUsed uint64 `json:"storageUsed"`
+	// RegistersSize is the total size of all registers of the account,
+	// which should be equal to StorageUsed
+	RegistersSize uint64                  `json:"registersSize"`
+	Frozen        bool                    `json:"frozen"`
+	Keys          []flow.AccountPublicKey `json:"keys"`
+	Contracts     []ContractStorage       `json:"contracts"`
+	// Paths are the Cadence values stored in the account, including the values of contracts
+	Paths []PathStorage `json:"paths"`
+	// AccountRegisters are the registers the FVM stores account information in, e.g. keys and contract code
+	AccountRegisters []RegisterStorage `json:"accountRegisters"`
+	// UnreferencedSlabs are the slabs which are not reachable from any of the paths
+	UnreferencedSlabs []RegisterStorage `json:"unreferencedSlabs"`
+}
+
+// ContractStorage describes the code of a contract deployed to an account
+type ContractStorage struct {
+	Name     string `json:"name"`
+	CodeSize uint64 `json:"codeSize"`
+}
+
+// PathStorage describes a Cadence value stored in an account
+type PathStorage struct {
+	Domain     string `json:"domain"`
+	Identifier string `json:"identifier"`
+	Type       string `json:"type,omitempty"`
+	Value      string `json:"value,omitempty"`
+	// Size is the size of the register of the path plus the size of all slabs reachable from it
+	Size uint64 `json:"size"`
+	// Slabs is the number of slabs reachable from the path
+	Slabs int    `json:"slabs"`
+	Error string `json:"error,omitempty"`
+}
+
+// RegisterStorage describes a register of an account
+type RegisterStorage struct {
+	Key  string `json:"key"`
+	Size uint64 `json:"size"`
+}
+
+// AccountPayloads returns the payloads of the registers owned by the given address
+func AccountPayloads(address flow.Address, payloads []ledger.Payload) ([]ledger.Payload, error) {
+	owner := string(address.Bytes())
+
+	var accountPayloads []ledger.Payload
+	for _, payload := range payloads {
+		id, err := migrations.KeyToRegisterID(payload.Key)
+		if err != nil {
+			return nil, err
+		}
+		if id.Owner == owner {
+			accountPayloads = append(accountPayloads, payload)
+		}
+	}
+	return accountPayloads, nil
+}
+
+// ReadAccountStorage decodes every register of the given account. Payloads of other accounts are ignored.
+// Values which cannot be decoded are reported with an error instead of failing the whole account.
+func ReadAccountStorage(address flow.Address, payloads []ledger.Payload) (*AccountStorage, error) {
+	payloads, err := AccountPayloads(address, payloads)
+	if err != nil {
+		return nil, err
+	}
+
+	sth := state.NewStateHolder(state.NewState(migrations.NewView(payloads)))
+	accounts := state.NewAccounts(sth)
+	storage := cadenceRuntime.NewStorage(
+		&migrations.AccountsAtreeLedger{Accounts: accounts},
+		func(f func(), _ func(metrics cadenceRuntime.Metrics, duration time.Duration)) {
+			f()
+		},
+	)
+
+	account := &AccountStorage{Address: address}
+
+	account.StorageUsed, err = accounts.GetStorageUsed(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get storage used: %w", err)
+	}
+
+	account.Frozen, err = accounts.GetAccountFrozen(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get frozen state: %w", err)
+	}
+
+	account.Keys, err = accounts.GetPublicKeys(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get public keys: %w", err)
+	}
+
+	contractNames, err := accounts.GetContractNames(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get contract names: %w", err)
+	}
+	for _, name := range contractNames {
+		code, err := accounts.GetContract(name, address)
+		if err != nil {
+			return nil, fmt.Errorf("could not get contract %s: %w", name, err)
+		}
+		account.Contracts = append(account.Contracts, ContractStorage{Name: name, CodeSize: uint64(len(code))})
+	}
+
+	// sizes of all slab registers, slabs are removed once they are reached from a path
+	slabSizes := make(map[atree.StorageIndex]uint64)
+	var pathPayloads []ledger.Payload
+
+	for _, payload := range payloads {
+		id, err := migrations.KeyToRegisterID(payload.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		size := uint64(state.RegisterSize(address, id.Controller != "", id.Key, payload.Value))
+		account.RegistersSize += size
+
+		switch {
+		case state.IsFVMStateKey(id.Owner, id.Controller, id.Key) || id.Key == state.KeyStorageIndex:
+			account.AccountRegisters = append(account.AccountRegisters, RegisterStorage{Key: id.Key, Size: size})
+		case atree.LedgerKeyIsSlabKey(id.Key):
+			var index atree.StorageIndex
+			copy(index[:], id.Key[len(atree.LedgerBaseStorageSlabPrefix):])
+			slabSizes[index] = size
+		default:
+			pathPayloads = append(pathPayloads, payload)
+		}
+	}
+
+	for _, payload := range pathPayloads {
+		id, err := migrations.KeyToRegisterID(payload.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		path := readPath(storage, id.Key, payload.Value, slabSizes)
+		path.Size += uint64(state.RegisterSize(address, id.Controller != "", id.Key, payload.Value))
+		account.Paths = append(account.Paths, path)
+	}
+
+	for index, size := range slabSizes {
+		account.UnreferencedSlabs = append(account.UnreferencedSlabs, RegisterStorage{Key: slabKeyString(index), Size: size})
+	}
+
+	sort.Slice(account.Paths, func(i, j int) bool {
+		if account.Paths[i].Domain != account.Paths[j].Domain {
+			return account.Paths[i].Domain < account.Paths[j].Domain
+		}
+		return account.Paths[i].Identifier < account.Paths[j].Identifier
+	})
+	sort.Slice(account.AccountRegisters, func(i, j int) bool {
+		return account.AccountRegisters[i].Key < account.AccountRegisters[j].Key
+	})
+	sort.Slice(account.UnreferencedSlabs, func(i, j int) bool {
+		return account.UnreferencedSlabs[i].Key < account.UnreferencedSlabs[j].Key
+	})
+
+	return account, nil
+}
+
+// readPath decodes the value stored in the register with the given key and the slabs reachable from it.
+// The sizes of reached slabs are added to the size of the path and removed from slabSizes.
+func readPath(storage *cadenceRuntime.Storage, key string, value []byte, slabSizes map[atree.StorageIndex]uint64) (path PathStorage) {
+	path.Domain = key
+	if i := strings.IndexByte(key, '\x1F'); i >= 0 {
+		path.Domain = key[:i]
+		path.Identifier = key[i+1:]
+	}
+
+	// decoding values of unexpected types can panic in Cadence
+	defer func() {
+		if r := recover(); r != nil {
+			path.Error = fmt.Sprintf("could not decode value: %v", r)
+		}
+	}()
+
+	decoder := interpreter.CBORDecMode.NewByteStreamDecoder(value)
+	storable, err := interpreter.DecodeStorable(decoder, atree.StorageIDUndefined)
+	if err != nil {
+		path.Error = fmt.Sprintf("could not decode storable: %v", err)
+		return path
+	}
+
+	err = visitSlabs(storage, storable, func(id atree.StorageID) {
+		path.Slabs++
+		path.Size += slabSizes[id.Index]
+		delete(slabSizes, id.Index)
+	})
+	if err != nil {
+		path.Error = err.Error()
+		return path
+	}
+
+	storedValue, err := storable.StoredValue(storage)
+	if err != nil {
+		path.Error = fmt.Sprintf("could not load value: %v", err)
+		return path
+	}
+
+	cadenceValue, err := interpreter.ConvertStoredValue(storedValue)
+	if err != nil {
+		path.Error = fmt.Sprintf("could not convert value: %v", err)
+		return path
+	}
+
+	if staticType := cadenceValue.StaticType(); staticType != nil {
+		path.Type = staticType.String()
+	}
+	path.Value = cadenceValue.String()
+
+	return path
+}
+
+// visitSlabs calls visit for every slab reachable from the given storable, once per slab
+func visitSlabs(storage *cadenceRuntime.Storage, storable atree.Storable, visit func(atree.StorageID)) error {
+	visited := make(map[atree.StorageID]struct{})
+
+	var walk func(storable atree.Storable) error
+	walk = func(storable atree.Storable) error {
+		if id, ok := storable.(atree.StorageIDStorable); ok {
+			storageID := atree.StorageID(id)
+			if _, ok := visited[storageID]; ok {
+				return nil
+			}
+			visited[storageID] = struct{}{}
+
+			slab, found, err := storage.Retrieve(storageID)
+			if err != nil {
+				return fmt.Errorf("could not retrieve slab %s: %w", slabKeyString(storageID.Index), err)
+			}
+			if !found {
+				return fmt.Errorf("slab %s not found", slabKeyString(storageID.Index))
+			}
+			visit(storageID)
+			storable = slab
+		}
+
+		for _, child := range storable.ChildStorables() {
+			err := walk(child)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return walk(storable)
+}
+
+// slabKeyString formats the register key of the slab with the given index with the index hex-encoded
+func slabKeyString(index atree.StorageIndex) string {
+	return atree.LedgerBaseStorageSlabPrefix + hex.EncodeToString(index[:])
+}
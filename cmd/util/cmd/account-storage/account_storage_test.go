@@ -0,0 +1,70 @@
+package account_storage
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/fvm/utils"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadAccountStorage(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+	ctx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(chain))
+	view := utils.NewSimpleView()
+
+	err := vm.Run(ctx, fvm.Bootstrap(unittest.ServiceAccountPublicKey, fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply)), view, programs.NewEmptyPrograms())
+	require.NoError(t, err)
+
+	payloads := make([]ledger.Payload, 0, len(view.Ledger.Registers))
+	for _, register := range view.Ledger.Registers {
+		if len(register.Value) == 0 {
+			continue
+		}
+		payloads = append(payloads, ledger.Payload{Key: state.RegisterIDToKey(register.Key), Value: register.Value})
+	}
+
+	account, err := ReadAccountStorage(chain.ServiceAddress(), payloads)
+	require.NoError(t, err)
+
+	assert.Equal(t, chain.ServiceAddress(), account.Address)
+	assert.Equal(t, account.StorageUsed, account.RegistersSize)
+	assert.Len(t, account.Keys, 1)
+	assert.Empty(t, account.UnreferencedSlabs)
+
+	contracts := make([]string, len(account.Contracts))
+	for i, contract := range account.Contracts {
+		contracts[i] = contract.Name
+		assert.NotZero(t, contract.CodeSize)
+	}
+	assert.Contains(t, contracts, "FlowServiceAccount")
+
+	paths := make(map[string]PathStorage)
+	for _, path := range account.Paths {
+		assert.Empty(t, path.Error, path.Identifier)
+		assert.NotZero(t, path.Size, path.Identifier)
+		paths[path.Domain+"/"+path.Identifier] = path
+	}
+	require.Contains(t, paths, "storage/flowTokenVault")
+	assert.Contains(t, paths["storage/flowTokenVault"].Type, "FlowToken.Vault")
+	require.Contains(t, paths, "contract/FlowServiceAccount")
+
+	t.Run("payloads of other accounts are ignored", func(t *testing.T) {
+		other, err := ReadAccountStorage(fvm.FungibleTokenAddress(chain), payloads)
+		require.NoError(t, err)
+
+		assert.Equal(t, []ContractStorage{{Name: "FungibleToken", CodeSize: other.Contracts[0].CodeSize}}, other.Contracts)
+		for _, path := range other.Paths {
+			assert.NotEqual(t, "contract", path.Domain)
+		}
+	})
+}
@@ -0,0 +1,246 @@
+package account_storage
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+var (
+	flagCheckpoint        string
+	flagExecutionStateDir string
+	flagStateCommitment   string
+	flagAddress           string
+	flagOutput            string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "account-storage",
+	Short: "Prints the storage of an account in detail",
+	Long: `Reads all registers of an account from a checkpoint, or from the execution state dir at a state
+commitment, and decodes them: storage used, keys, contracts and the Cadence values stored in the
+account, resolving the slabs of each value. Prints a tree of the storage paths with their sizes,
+the size of a path includes the size of all slabs reachable from it.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpoint, "checkpoint", "",
+		"checkpoint file to read, --state-commitment is required if it contains more than one trie")
+
+	Cmd.Flags().StringVar(&flagExecutionStateDir, "execution-state-dir", "",
+		"Execution Node state dir (where WAL logs are written), requires --state-commitment")
+
+	Cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"State commitment (64 chars, hex-encoded)")
+
+	Cmd.Flags().StringVar(&flagAddress, "address", "",
+		"address of the account (hex-encoded)")
+	_ = Cmd.MarkFlagRequired("address")
+
+	Cmd.Flags().StringVar(&flagOutput, "output", "",
+		"file to write the account storage to as JSON, including the values")
+}
+
+func run(*cobra.Command, []string) {
+	address := flow.HexToAddress(flagAddress)
+
+	t, err := loadTrie()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load execution state")
+	}
+
+	payloads, err := trieAccountPayloads(t, address)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read payloads")
+	}
+	log.Info().Int("registers", len(payloads)).Msg("read account registers")
+
+	account, err := ReadAccountStorage(address, payloads)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read account storage")
+	}
+
+	printAccountStorage(account)
+
+	if flagOutput != "" {
+		data, err := json.MarshalIndent(account, "", "  ")
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not encode account storage")
+		}
+		err = os.WriteFile(flagOutput, data, 0644)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not write account storage")
+		}
+		log.Info().Str("file", flagOutput).Msg("account storage written")
+	}
+}
+
+// loadTrie loads the trie of the state commitment from the checkpoint or the execution state dir
+func loadTrie() (*trie.MTrie, error) {
+	if (flagCheckpoint == "") == (flagExecutionStateDir == "") {
+		return nil, fmt.Errorf("exactly one of --checkpoint and --execution-state-dir is required")
+	}
+
+	var rootHash *ledger.RootHash
+	if flagStateCommitment != "" {
+		stateCommitmentBytes, err := hex.DecodeString(flagStateCommitment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid state commitment: %w", err)
+		}
+		stateCommitment, err := flow.ToStateCommitment(stateCommitmentBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid state commitment: %w", err)
+		}
+		hash := ledger.RootHash(stateCommitment)
+		rootHash = &hash
+	}
+
+	if flagCheckpoint != "" {
+		flattenedForest, err := wal.LoadCheckpoint(flagCheckpoint)
+		if err != nil {
+			return nil, fmt.Errorf("could not load checkpoint: %w", err)
+		}
+
+		tries, err := flattener.RebuildTries(flattenedForest)
+		if err != nil {
+			return nil, fmt.Errorf("could not rebuild tries: %w", err)
+		}
+
+		if rootHash == nil {
+			if len(tries) != 1 {
+				return nil, fmt.Errorf("checkpoint contains %d tries, --state-commitment is required", len(tries))
+			}
+			return tries[0], nil
+		}
+
+		for _, t := range tries {
+			if t.RootHash() == *rootHash {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("checkpoint does not contain state commitment %x", *rootHash)
+	}
+
+	if rootHash == nil {
+		return nil, fmt.Errorf("--state-commitment is required with --execution-state-dir")
+	}
+
+	w, err := wal.NewDiskWAL(
+		zerolog.Nop(),
+		nil,
+		metrics.NewNoopCollector(),
+		flagExecutionStateDir,
+		complete.DefaultCacheSize,
+		pathfinder.PathByteSize,
+		wal.SegmentSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create WAL: %w", err)
+	}
+	defer func() {
+		<-w.Done()
+	}()
+
+	forest, err := mtrie.NewForest(complete.DefaultCacheSize, metrics.NewNoopCollector(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create forest: %w", err)
+	}
+
+	err = w.ReplayOnForest(forest)
+	if err != nil {
+		return nil, fmt.Errorf("could not replay execution state: %w", err)
+	}
+
+	return forest.GetTrie(*rootHash)
+}
+
+// trieAccountPayloads returns the payloads of the trie owned by the given address,
+// without copying the payloads of other accounts
+func trieAccountPayloads(t *trie.MTrie, address flow.Address) ([]ledger.Payload, error) {
+	owner := string(address.Bytes())
+
+	var payloads []ledger.Payload
+	itr := flattener.NewNodeIterator(t)
+	for itr.Next() {
+		n := itr.Value()
+		if !n.IsLeaf() || n.Payload() == nil {
+			continue
+		}
+
+		id, err := migrations.KeyToRegisterID(n.Payload().Key)
+		if err != nil {
+			return nil, err
+		}
+		if id.Owner == owner {
+			payloads = append(payloads, *n.Payload().DeepCopy())
+		}
+	}
+	return payloads, nil
+}
+
+func printAccountStorage(account *AccountStorage) {
+	fmt.Printf("Account %s\n", account.Address.HexWithPrefix())
+	fmt.Printf("  storage used: %d bytes (size of registers: %d bytes)\n", account.StorageUsed, account.RegistersSize)
+	fmt.Printf("  frozen: %v\n", account.Frozen)
+
+	fmt.Printf("  keys (%d):\n", len(account.Keys))
+	for _, key := range account.Keys {
+		fmt.Printf("    %d: %s %s weight %d, sequence number %d, revoked %v\n",
+			key.Index, key.SignAlgo, key.HashAlgo, key.Weight, key.SeqNumber, key.Revoked)
+	}
+
+	fmt.Printf("  contracts (%d):\n", len(account.Contracts))
+	for _, contract := range account.Contracts {
+		fmt.Printf("    %s: %d bytes of code\n", contract.Name, contract.CodeSize)
+	}
+
+	fmt.Println("  paths:")
+	for i := 0; i < len(account.Paths); {
+		// paths are sorted by domain
+		domain := account.Paths[i].Domain
+		j := i
+		var size uint64
+		for ; j < len(account.Paths) && account.Paths[j].Domain == domain; j++ {
+			size += account.Paths[j].Size
+		}
+
+		fmt.Printf("    %s (%d values, %d bytes)\n", domain, j-i, size)
+		for _, path := range account.Paths[i:j] {
+			if path.Error != "" {
+				fmt.Printf("      %s: %d bytes, %d slabs, error: %s\n", path.Identifier, path.Size, path.Slabs, path.Error)
+				continue
+			}
+			fmt.Printf("      %s: %s, %d bytes, %d slabs\n", path.Identifier, path.Type, path.Size, path.Slabs)
+		}
+		i = j
+	}
+
+	fmt.Printf("  account registers (%d):\n", len(account.AccountRegisters))
+	for _, register := range account.AccountRegisters {
+		fmt.Printf("    %s: %d bytes\n", register.Key, register.Size)
+	}
+
+	if len(account.UnreferencedSlabs) > 0 {
+		fmt.Printf("  unreferenced slabs (%d):\n", len(account.UnreferencedSlabs))
+		for _, slab := range account.UnreferencedSlabs {
+			fmt.Printf("    %s: %d bytes\n", slab.Key, slab.Size)
+		}
+	}
+}
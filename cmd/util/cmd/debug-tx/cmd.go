@@ -0,0 +1,250 @@
+package debug_tx
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/debug"
+)
+
+var (
+	flagExecutionAddress string
+	flagAccessAddress    string
+	flagChain            string
+	flagTransactionID    string
+	flagTransactionFile  string
+	flagTransactionOut   string
+	flagBlockID          string
+	flagCacheFile        string
+	flagFees             bool
+	flagCheckSignatures  bool
+)
+
+var Cmd = &cobra.Command{
+	Use:   "debug-tx",
+	Short: "Replays a transaction against the registers of a remote execution node",
+	Long: `Replays a transaction against the execution state of a block, reading the registers lazily from
+the gRPC API of an execution node, and prints the events, the computation used and the registers
+read and written by the transaction.
+
+The transaction is either fetched by ID from an access node or read from a file containing a
+JSON-encoded transaction body, as written by --transaction-out. Use the ID of the block preceding
+the block of the transaction to reproduce its original execution.
+
+Registers are cached in --cache-file, so repeated runs against the same block only fetch the
+registers not read before. The cache file records the block it was created for, and using it
+for a different block fails.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagExecutionAddress, "execution-address", "",
+		"address of the gRPC API of the execution node to read registers from")
+	_ = Cmd.MarkFlagRequired("execution-address")
+
+	Cmd.Flags().StringVar(&flagAccessAddress, "access-address", "",
+		"address of the gRPC API of the access node to fetch the transaction from, required with --transaction-id")
+
+	Cmd.Flags().StringVar(&flagChain, "chain", "", "Chain name")
+	_ = Cmd.MarkFlagRequired("chain")
+
+	Cmd.Flags().StringVar(&flagTransactionID, "transaction-id", "",
+		"ID of the transaction to replay (hex-encoded, 64 characters)")
+
+	Cmd.Flags().StringVar(&flagTransactionFile, "transaction-file", "",
+		"file containing the JSON-encoded transaction body to replay")
+
+	Cmd.Flags().StringVar(&flagTransactionOut, "transaction-out", "",
+		"file to write the JSON-encoded transaction body to, e.g. to modify it before replaying it again")
+
+	Cmd.Flags().StringVar(&flagBlockID, "block-id", "",
+		"ID of the block to replay the transaction at (hex-encoded, 64 characters)")
+	_ = Cmd.MarkFlagRequired("block-id")
+
+	Cmd.Flags().StringVar(&flagCacheFile, "cache-file", "",
+		"file to cache registers in, registers are not cached if empty")
+
+	Cmd.Flags().BoolVar(&flagFees, "fees", false,
+		"deduct transaction fees")
+
+	Cmd.Flags().BoolVar(&flagCheckSignatures, "check-signatures", false,
+		"verify the signatures of the transaction")
+}
+
+func getChain(chainName string) (chain flow.Chain, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid chain: %s", r)
+		}
+	}()
+	chain = flow.ChainID(chainName).Chain()
+	return
+}
+
+func run(*cobra.Command, []string) {
+	chain, err := getChain(flagChain)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("invalid chain name")
+	}
+
+	blockID, err := flow.HexStringToIdentifier(flagBlockID)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("malformed block ID: %v", flagBlockID)
+	}
+
+	txBody, err := readTransaction(chain)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read transaction")
+	}
+	txID := txBody.ID()
+	log.Info().Hex("transaction_id", txID[:]).Msg("read transaction")
+
+	if flagTransactionOut != "" {
+		err = writeTransaction(flagTransactionOut, txBody)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not write transaction")
+		}
+	}
+
+	logger := log.Logger.Level(zerolog.WarnLevel)
+	processors := []fvm.TransactionProcessor{
+		fvm.NewTransactionAccountFrozenChecker(),
+	}
+	if flagCheckSignatures {
+		processors = append(processors, fvm.NewTransactionSignatureVerifier(fvm.AccountKeyWeightThreshold))
+	}
+	processors = append(processors,
+		fvm.NewTransactionSequenceNumberChecker(),
+		fvm.NewTransactionAccountFrozenEnabler(),
+		fvm.NewTransactionInvoker(logger),
+	)
+
+	debugger := debug.NewRemoteDebugger(flagExecutionAddress, chain, logger,
+		fvm.WithTransactionProcessors(processors...),
+		fvm.WithTransactionFeesEnabled(flagFees),
+		fvm.WithAccountStorageLimit(true),
+		fvm.WithWeightedComputationMetering(true),
+		fvm.WithCadenceLogging(true),
+	)
+
+	replay, err := debugger.ReplayTransactionAtBlockID(txBody, blockID, flagCacheFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not replay transaction")
+	}
+
+	printReplay(replay)
+}
+
+// readTransaction reads the transaction from the transaction file or fetches it from the access node
+func readTransaction(chain flow.Chain) (*flow.TransactionBody, error) {
+	if (flagTransactionID == "") == (flagTransactionFile == "") {
+		return nil, fmt.Errorf("exactly one of --transaction-id and --transaction-file is required")
+	}
+
+	if flagTransactionFile != "" {
+		data, err := os.ReadFile(flagTransactionFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read transaction file: %w", err)
+		}
+
+		var txBody flow.TransactionBody
+		err = json.Unmarshal(data, &txBody)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode transaction file: %w", err)
+		}
+		return &txBody, nil
+	}
+
+	if flagAccessAddress == "" {
+		return nil, fmt.Errorf("--access-address is required to fetch a transaction by ID")
+	}
+
+	txID, err := flow.HexStringToIdentifier(flagTransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed transaction ID: %w", err)
+	}
+
+	conn, err := grpc.Dial(flagAccessAddress, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to access node: %w", err)
+	}
+	defer conn.Close()
+
+	resp, err := accessproto.NewAccessAPIClient(conn).GetTransaction(context.Background(), &accessproto.GetTransactionRequest{Id: txID[:]})
+	if err != nil {
+		return nil, fmt.Errorf("could not get transaction: %w", err)
+	}
+
+	txBody, err := convert.MessageToTransaction(resp.GetTransaction(), chain)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert transaction: %w", err)
+	}
+	return &txBody, nil
+}
+
+func writeTransaction(path string, txBody *flow.TransactionBody) error {
+	data, err := json.MarshalIndent(txBody, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode transaction: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func printReplay(replay *debug.TransactionReplay) {
+	tx := replay.Procedure
+
+	if tx.Err != nil {
+		fmt.Printf("Error: %v\n", tx.Err)
+	} else {
+		fmt.Println("Error: none")
+	}
+
+	fmt.Printf("\nLogs (%d):\n", len(tx.Logs))
+	for _, l := range tx.Logs {
+		fmt.Printf("  %s\n", l)
+	}
+
+	fmt.Printf("\nEvents (%d):\n", len(tx.Events))
+	for _, event := range tx.Events {
+		fmt.Printf("  %d %s %s\n", event.EventIndex, event.Type, event.Payload)
+	}
+
+	fmt.Printf("\nComputation used: %d\n", tx.ComputationUsed)
+	kinds := make([]string, 0, len(tx.ComputationUsedByKind))
+	for kind := range tx.ComputationUsedByKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Printf("  %s: %d\n", kind, tx.ComputationUsedByKind[kind])
+	}
+	fmt.Printf("Memory used: %d\n", tx.MemoryUsed)
+
+	fmt.Printf("\nRegisters read (%d):\n", len(replay.RegistersRead))
+	for _, id := range replay.RegistersRead {
+		fmt.Printf("  %s\n", registerString(id))
+	}
+
+	fmt.Printf("\nRegisters written (%d):\n", len(replay.RegisterUpdates))
+	for _, entry := range replay.RegisterUpdates {
+		fmt.Printf("  %s (%d bytes)\n", registerString(entry.Key), len(entry.Value))
+	}
+}
+
+// registerString formats the register ID with the owner and controller hex-encoded
+func registerString(id flow.RegisterID) string {
+	return fmt.Sprintf("%s/%s/%q", hex.EncodeToString([]byte(id.Owner)), hex.EncodeToString([]byte(id.Controller)), id.Key)
+}
@@ -16,10 +16,10 @@ import (
 )
 
 type blockSummary struct {
-	BlockHeight    uint64   `json:"block_height"`
-	BlockID        string   `json:"block_id"`
-	ParentBlockID  string   `json:"parent_block_id"`
-	ParentVoterIDs []string `json:"parent_voter_ids"`
+	BlockHeight        uint64 `json:"block_height"`
+	BlockID            string `json:"block_id"`
+	ParentBlockID      string `json:"parent_block_id"`
+	ParentVoterIndices string `json:"parent_voter_indices"`
 	// ParentVoterSigData []string  `json:"parent_voter_sig"`
 	ProposerID string `json:"proposer_id"`
 	// ProposerSigData    string  `json:"proposer_sig"`
@@ -87,22 +87,17 @@ func ExportBlocks(blockID flow.Identifier, dbPath string, outputPath string) (fl
 			sealsStates = append(sealsStates, hex.EncodeToString(s.FinalState[:]))
 		}
 
-		pvIDs := make([]string, 0)
-		for _, i := range header.ParentVoterIDs {
-			pvIDs = append(pvIDs, hex.EncodeToString(i[:]))
-		}
-
 		b := blockSummary{
-			BlockID:           hex.EncodeToString(activeBlockID[:]),
-			BlockHeight:       header.Height,
-			ParentBlockID:     hex.EncodeToString(header.ParentID[:]),
-			ParentVoterIDs:    pvIDs,
-			ProposerID:        hex.EncodeToString(header.ProposerID[:]),
-			Timestamp:         header.Timestamp,
-			CollectionIDs:     cols,
-			SealedBlocks:      seals,
-			SealedResults:     sealsResults,
-			SealedFinalStates: sealsStates,
+			BlockID:            hex.EncodeToString(activeBlockID[:]),
+			BlockHeight:        header.Height,
+			ParentBlockID:      hex.EncodeToString(header.ParentID[:]),
+			ParentVoterIndices: hex.EncodeToString(header.ParentVoterIndices),
+			ProposerID:         hex.EncodeToString(header.ProposerID[:]),
+			Timestamp:          header.Timestamp,
+			CollectionIDs:      cols,
+			SealedBlocks:       seals,
+			SealedResults:      sealsResults,
+			SealedFinalStates:  sealsStates,
 		}
 
 		jsonData, err := json.Marshal(b)
@@ -2,10 +2,13 @@ package extract
 
 import (
 	"fmt"
+	"path"
+	goRuntime "runtime"
 
 	"github.com/rs/zerolog"
 
 	mgr "github.com/onflow/flow-go/cmd/util/ledger/migrations"
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations/runner"
 	"github.com/onflow/flow-go/cmd/util/ledger/reporters"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
@@ -17,6 +20,10 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
+// migrationProgressFilename is the name of the file in the output dir the progress of the migration is recorded in,
+// so an interrupted extraction resumes the migration
+const migrationProgressFilename = "migration_progress"
+
 func getStateCommitment(commits storage.Commits, blockHash flow.Identifier) (flow.StateCommitment, error) {
 	return commits.ByBlockID(blockHash)
 }
@@ -61,14 +68,22 @@ func extractExecutionState(
 	var rs []ledger.Reporter
 
 	if migrate {
-		storageUsedUpdateMigration := mgr.StorageUsedUpdateMigration{
-			Log:       log,
-			OutputDir: outputDir,
+		accountMigrationRunner := &runner.AccountMigrationRunner{
+			Log: log,
+			RWF: reporters.NewReportFileWriterFactory(outputDir, log),
+			Migrations: []mgr.AccountMigration{
+				mgr.StorageUsedAccountMigration{},
+				mgr.PruneAccountMigration{},
+			},
+			Invariants: []mgr.AccountInvariant{
+				mgr.StorageUsedInvariant{},
+			},
+			NWorker:      goRuntime.NumCPU(),
+			ProgressFile: path.Join(outputDir, migrationProgressFilename),
 		}
 
 		migrations = []ledger.Migration{
-			storageUsedUpdateMigration.Migrate,
-			mgr.PruneMigration,
+			accountMigrationRunner.Migrate,
 		}
 
 	}
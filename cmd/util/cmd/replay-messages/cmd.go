@@ -0,0 +1,131 @@
+package replay_messages
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/local"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/recorder"
+	"github.com/onflow/flow-go/network/recorder/replay"
+)
+
+var (
+	flagRecording string
+	flagNodeID    string
+	flagChannels  []string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "replay-messages",
+	Short: "Replays a network message recording in order and prints the decoded messages",
+	Long: `Replays a network message recording created with --network-recording-dir into an engine
+attached to a stub network. Messages are decoded and delivered one at a time, in the order the
+recording node received them. This command attaches an engine printing each delivered message;
+to reproduce a bug in a specific engine, register it on the network of a replay.Replayer instead.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagRecording, "recording", "",
+		"recording directory or a single recording file")
+	_ = Cmd.MarkFlagRequired("recording")
+
+	Cmd.Flags().StringVar(&flagNodeID, "node-id", "",
+		"node ID of the recording node (hex-encoded, 64 characters), defaults to the zero ID")
+
+	Cmd.Flags().StringSliceVar(&flagChannels, "channels", nil,
+		"only replay messages received on these channels, defaults to all channels")
+}
+
+func run(*cobra.Command, []string) {
+	nodeID := flow.ZeroID
+	if flagNodeID != "" {
+		var err error
+		nodeID, err = flow.HexStringToIdentifier(flagNodeID)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("malformed node ID: %v", flagNodeID)
+		}
+	}
+
+	records, err := recorder.ReadRecords(flagRecording)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read recording")
+	}
+	log.Info().Int("records", len(records)).Msg("read recording")
+
+	me, err := local.NewNoKey(&flow.Identity{NodeID: nodeID})
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create local")
+	}
+
+	replayer := replay.NewReplayer(cbor.NewCodec(), nil, me)
+
+	channels := network.ChannelList{}
+	for _, channel := range flagChannels {
+		channels = append(channels, network.Channel(channel))
+	}
+	if len(channels) == 0 {
+		for _, record := range records {
+			if !channels.Contains(record.Channel) {
+				channels = append(channels, record.Channel)
+			}
+		}
+	}
+
+	printer := &printingEngine{}
+	for _, channel := range channels {
+		_, err := replayer.Network().Register(channel, printer)
+		if err != nil {
+			log.Fatal().Err(err).Str("channel", channel.String()).Msg("could not register printing engine")
+		}
+	}
+
+	delivered, err := replayer.Replay(records)
+	if err != nil {
+		log.Fatal().Err(err).Uint("delivered", delivered).Msg("could not replay recording")
+	}
+
+	log.Info().Uint("delivered", delivered).Msg("replay complete")
+}
+
+// printingEngine prints every message delivered to it, in delivery order.
+type printingEngine struct {
+	count uint
+}
+
+func (e *printingEngine) Ready() <-chan struct{} {
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+func (e *printingEngine) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+func (e *printingEngine) SubmitLocal(event interface{}) {
+	_ = e.ProcessLocal(event)
+}
+
+func (e *printingEngine) Submit(channel network.Channel, originID flow.Identifier, event interface{}) {
+	_ = e.Process(channel, originID, event)
+}
+
+func (e *printingEngine) ProcessLocal(event interface{}) error {
+	return e.Process("", flow.ZeroID, event)
+}
+
+func (e *printingEngine) Process(channel network.Channel, originID flow.Identifier, event interface{}) error {
+	fmt.Printf("#%d channel: %s, origin: %v, type: %T\n", e.count, channel, originID, event)
+	common.PrettyPrint(event)
+	e.count++
+	return nil
+}
@@ -9,13 +9,16 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	account_storage "github.com/onflow/flow-go/cmd/util/cmd/account-storage"
 	checkpoint_list_tries "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-list-tries"
+	debug_tx "github.com/onflow/flow-go/cmd/util/cmd/debug-tx"
 	epochs "github.com/onflow/flow-go/cmd/util/cmd/epochs/cmd"
 	export "github.com/onflow/flow-go/cmd/util/cmd/exec-data-json-export"
 	extract "github.com/onflow/flow-go/cmd/util/cmd/execution-state-extract"
 	ledger_json_exporter "github.com/onflow/flow-go/cmd/util/cmd/export-json-execution-state"
 	read_badger "github.com/onflow/flow-go/cmd/util/cmd/read-badger/cmd"
 	read_protocol_state "github.com/onflow/flow-go/cmd/util/cmd/read-protocol-state/cmd"
+	replay_messages "github.com/onflow/flow-go/cmd/util/cmd/replay-messages"
 	truncate_database "github.com/onflow/flow-go/cmd/util/cmd/truncate-database"
 )
 
@@ -58,6 +61,9 @@ func addCommands() {
 	rootCmd.AddCommand(read_protocol_state.RootCmd)
 	rootCmd.AddCommand(ledger_json_exporter.Cmd)
 	rootCmd.AddCommand(epochs.RootCmd)
+	rootCmd.AddCommand(replay_messages.Cmd)
+	rootCmd.AddCommand(debug_tx.Cmd)
+	rootCmd.AddCommand(account_storage.Cmd)
 }
 
 func initConfig() {
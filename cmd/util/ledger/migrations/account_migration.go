@@ -0,0 +1,157 @@
+package migrations
+
+import (
+	"fmt"
+
+	fvm "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountMigration migrates the payloads of a single account, so accounts can be migrated in parallel.
+// Payloads which are not owned by an account, e.g. the UUID generator state, are migrated as one group
+// with an empty owner.
+type AccountMigration interface {
+	// Name names the migration, it is used as the name of the report of the migration
+	Name() string
+	// MigrateAccount returns the migrated payloads of the account with the given owner,
+	// and a data point for the report of the migration, or nil if there is nothing to report
+	MigrateAccount(owner string, payloads []ledger.Payload) ([]ledger.Payload, interface{}, error)
+}
+
+// AccountInvariant is a property the payloads of every account must have
+type AccountInvariant interface {
+	Name() string
+	// Check returns an error if the payloads of the account with the given owner violate the invariant
+	Check(owner string, payloads []ledger.Payload) error
+}
+
+// PruneAccountMigration removes all the payloads of an account with empty value, see PruneMigration
+type PruneAccountMigration struct{}
+
+var _ AccountMigration = PruneAccountMigration{}
+
+type pruneRecord struct {
+	Address string `json:"address"`
+	Pruned  int    `json:"pruned"`
+}
+
+func (PruneAccountMigration) Name() string {
+	return "prune_migration"
+}
+
+func (PruneAccountMigration) MigrateAccount(owner string, payloads []ledger.Payload) ([]ledger.Payload, interface{}, error) {
+	migrated, err := PruneMigration(payloads)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(migrated) == len(payloads) {
+		return migrated, nil, nil
+	}
+	return migrated, pruneRecord{
+		Address: flow.BytesToAddress([]byte(owner)).Hex(),
+		Pruned:  len(payloads) - len(migrated),
+	}, nil
+}
+
+// StorageUsedAccountMigration sets the storage_used register of an account
+// to the total size of the registers of the account, see StorageUsedUpdateMigration
+type StorageUsedAccountMigration struct{}
+
+var _ AccountMigration = StorageUsedAccountMigration{}
+
+type storageUsedRecord struct {
+	Address string `json:"address"`
+	OldUsed uint64 `json:"oldUsed"`
+	NewUsed uint64 `json:"newUsed"`
+}
+
+func (StorageUsedAccountMigration) Name() string {
+	return "storage_used_update_migration"
+}
+
+func (StorageUsedAccountMigration) MigrateAccount(owner string, payloads []ledger.Payload) ([]ledger.Payload, interface{}, error) {
+	if len(owner) != flow.AddressLength {
+		// not an account
+		return payloads, nil, nil
+	}
+
+	index, used, err := accountStorageUsed(owner, payloads)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldUsed, _, err := utils.ReadUint64(payloads[index].Value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode storage used of %s: %w", flow.BytesToAddress([]byte(owner)).Hex(), err)
+	}
+	if oldUsed == used {
+		return payloads, nil, nil
+	}
+
+	migrated := make([]ledger.Payload, len(payloads))
+	copy(migrated, payloads)
+	migrated[index].Value = utils.Uint64ToBinary(used)
+
+	return migrated, storageUsedRecord{
+		Address: flow.BytesToAddress([]byte(owner)).Hex(),
+		OldUsed: oldUsed,
+		NewUsed: used,
+	}, nil
+}
+
+// StorageUsedInvariant checks the storage_used register of an account
+// is equal to the total size of the registers of the account
+type StorageUsedInvariant struct{}
+
+var _ AccountInvariant = StorageUsedInvariant{}
+
+func (StorageUsedInvariant) Name() string {
+	return "storage_used"
+}
+
+func (StorageUsedInvariant) Check(owner string, payloads []ledger.Payload) error {
+	if len(owner) != flow.AddressLength {
+		// not an account
+		return nil
+	}
+
+	index, used, err := accountStorageUsed(owner, payloads)
+	if err != nil {
+		return err
+	}
+
+	stored, _, err := utils.ReadUint64(payloads[index].Value)
+	if err != nil {
+		return fmt.Errorf("cannot decode storage used: %w", err)
+	}
+	if stored != used {
+		return fmt.Errorf("storage used is %d, but registers use %d", stored, used)
+	}
+	return nil
+}
+
+// accountStorageUsed returns the index of the storage_used payload of the account,
+// and the total size of the registers of the account
+func accountStorageUsed(owner string, payloads []ledger.Payload) (int, uint64, error) {
+	index := -1
+	var used uint64
+	for i, p := range payloads {
+		id, err := KeyToRegisterID(p.Key)
+		if err != nil {
+			return 0, 0, err
+		}
+		if id.Owner != owner {
+			return 0, 0, fmt.Errorf("payload %s is not owned by the account", p.Key.String())
+		}
+		if id.Key == fvm.KeyStorageUsed && id.Controller == "" {
+			index = i
+		}
+		used += uint64(registerSize(id, p))
+	}
+	if index < 0 {
+		return 0, 0, fmt.Errorf("account %s has no storage used payload", flow.BytesToAddress([]byte(owner)).Hex())
+	}
+	return index, used, nil
+}
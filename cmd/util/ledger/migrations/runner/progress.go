@@ -0,0 +1,218 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete"
+)
+
+// flushInterval is the number of accounts after which recorded progress is flushed to the file
+const flushInterval = 1000
+
+// progressHeader identifies the migration the progress is recorded for
+type progressHeader struct {
+	Migrations []string `json:"migrations"`
+	Payloads   uint64   `json:"payloads"`
+	// Input is the digest of the migrated payloads, see payloadsDigest
+	Input string `json:"input"`
+}
+
+// progressFile records the accounts which have been migrated.
+//
+// The file starts with the JSON encoded header, followed by one record per migrated account.
+// Every entry is prefixed with its length. A record consists of the owner and the digest of the
+// migrated payloads of the account. Records which were not completely written, e.g. because of
+// a crash, are discarded.
+type progressFile struct {
+	file     *os.File
+	writer   *bufio.Writer
+	unsynced int
+}
+
+// openProgressFile opens the progress file at the given path, or creates it if it doesn't exist,
+// and returns the digests of the migrated payloads of the accounts recorded in it, keyed by owner.
+// It returns an error if the file records the progress of a different migration, or of the
+// migration of different payloads.
+func openProgressFile(path string, header progressHeader) (*progressFile, map[string]hash.Hash, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrated, size, err := readProgress(file, header)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+
+	// discard a partially written record and append after the last complete one
+	err = file.Truncate(size)
+	if err == nil {
+		_, err = file.Seek(size, io.SeekStart)
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("could not truncate progress file: %w", err)
+	}
+
+	p := &progressFile{
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}
+
+	if size == 0 {
+		encodedHeader, err := json.Marshal(header)
+		if err != nil {
+			_ = file.Close()
+			return nil, nil, err
+		}
+		err = p.write(encodedHeader)
+		if err != nil {
+			_ = file.Close()
+			return nil, nil, err
+		}
+	}
+
+	return p, migrated, nil
+}
+
+// readProgress reads the header and the records of the progress file, and returns the recorded digests
+// and the size of the complete entries in the file
+func readProgress(file *os.File, header progressHeader) (map[string]hash.Hash, int64, error) {
+	migrated := make(map[string]hash.Hash)
+	reader := &countingReader{reader: bufio.NewReader(file)}
+
+	encodedHeader, err := utils.ReadLongDataFromReader(reader)
+	if err != nil {
+		// no complete header, the migration has not recorded any progress yet
+		return migrated, 0, nil
+	}
+
+	var recordedHeader progressHeader
+	err = json.Unmarshal(encodedHeader, &recordedHeader)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not decode header: %w", err)
+	}
+	if !reflect.DeepEqual(recordedHeader, header) {
+		return nil, 0, fmt.Errorf("progress file records migrations %v of %d payloads (digest %s), but migrating %v of %d payloads (digest %s), delete the file to start over",
+			recordedHeader.Migrations, recordedHeader.Payloads, recordedHeader.Input, header.Migrations, header.Payloads, header.Input)
+	}
+
+	for {
+		size := reader.read
+		record, err := utils.ReadLongDataFromReader(reader)
+		if err != nil {
+			// end of the file, or a partially written record
+			return migrated, size, nil
+		}
+
+		owner, digest, err := decodeRecord(record)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not decode record: %w", err)
+		}
+		migrated[owner] = digest
+	}
+}
+
+// Record records the digest of the migrated payloads of the account with the given owner
+func (p *progressFile) Record(owner string, digest hash.Hash) error {
+	err := p.write(encodeRecord(owner, digest))
+	if err != nil {
+		return err
+	}
+
+	p.unsynced++
+	if p.unsynced >= flushInterval {
+		return p.flush()
+	}
+	return nil
+}
+
+func (p *progressFile) write(data []byte) error {
+	_, err := p.writer.Write(utils.AppendLongData(nil, data))
+	if err != nil {
+		return fmt.Errorf("could not write progress: %w", err)
+	}
+	return nil
+}
+
+func (p *progressFile) flush() error {
+	p.unsynced = 0
+	err := p.writer.Flush()
+	if err != nil {
+		return fmt.Errorf("could not flush progress: %w", err)
+	}
+	err = p.file.Sync()
+	if err != nil {
+		return fmt.Errorf("could not sync progress: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the recorded progress and closes the file
+func (p *progressFile) Close() error {
+	err := p.flush()
+	closeErr := p.file.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func encodeRecord(owner string, digest hash.Hash) []byte {
+	buffer := utils.AppendShortData(nil, []byte(owner))
+	return append(buffer, digest[:]...)
+}
+
+func decodeRecord(record []byte) (string, hash.Hash, error) {
+	owner, rest, err := utils.ReadShortData(record)
+	if err != nil {
+		return "", hash.DummyHash, err
+	}
+
+	digest, err := hash.ToHash(rest[len(owner):])
+	if err != nil {
+		return "", hash.DummyHash, err
+	}
+
+	return string(owner), digest, nil
+}
+
+// payloadsDigest returns a digest of the given payloads, independent of their order. It combines
+// the hashes of the ledger leaves of the payloads, so payloads with an empty value are equivalent
+// to payloads with a nil value.
+func payloadsDigest(payloads []ledger.Payload) (hash.Hash, error) {
+	var digest hash.Hash
+	for _, p := range payloads {
+		path, err := pathfinder.KeyToPath(p.Key, complete.DefaultPathFinderVersion)
+		if err != nil {
+			return hash.DummyHash, err
+		}
+		leaf := hash.HashLeaf(hash.Hash(path), p.Value)
+		for i := range digest {
+			digest[i] ^= leaf[i]
+		}
+	}
+	return digest, nil
+}
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	reader io.Reader
+	read   int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.read += int64(n)
+	return n, err
+}
@@ -0,0 +1,294 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations"
+	"github.com/onflow/flow-go/cmd/util/ledger/reporters"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountMigrationRunner applies account migrations to the payloads of every account in parallel.
+//
+// The payloads of at most NWorker accounts are migrated at the same time. Before the migrations,
+// violated invariants are reported, after the migrations every invariant must hold, otherwise the
+// migration fails. If ProgressFile is set, every migrated account is recorded in it, along with a
+// digest of its migrated payloads, so an interrupted migration of the same payloads can be resumed.
+// The migrated payloads are not stored: the recorded accounts are migrated again, which re-emits
+// their migration reports, and the migration fails if their payloads do not match the digest. The
+// invariants are only checked for the accounts not migrated yet.
+type AccountMigrationRunner struct {
+	Log        zerolog.Logger
+	RWF        reporters.ReportWriterFactory
+	Migrations []migrations.AccountMigration
+	Invariants []migrations.AccountInvariant
+	// NWorker is the number of accounts migrated in parallel
+	NWorker int
+	// ProgressFile is the file the progress of the migration is recorded in, progress is not recorded if empty.
+	// The file has to be deleted to run the migration from the start.
+	ProgressFile string
+}
+
+// accountPayloads are the payloads of an account
+type accountPayloads struct {
+	owner    string
+	payloads []ledger.Payload
+}
+
+type accountResult struct {
+	accountPayloads
+	digest  hash.Hash
+	resumed bool
+	err     error
+}
+
+// invariantRecord reports an invariant violated by an account
+type invariantRecord struct {
+	Address   string `json:"address"`
+	Invariant string `json:"invariant"`
+	Stage     string `json:"stage"`
+	Error     string `json:"error"`
+}
+
+// Migrate migrates the given payloads, it can be used as a ledger.Migration.
+// The order of the given payloads is changed, and the order of the returned payloads is undefined.
+func (r *AccountMigrationRunner) Migrate(payloads []ledger.Payload) ([]ledger.Payload, error) {
+	accounts, err := groupByOwner(payloads)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(r.Migrations))
+	for i, m := range r.Migrations {
+		names[i] = m.Name()
+	}
+
+	var progress *progressFile
+	migrated := make(map[string]hash.Hash)
+	if r.ProgressFile != "" {
+		input, err := payloadsDigest(payloads)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute digest of payloads: %w", err)
+		}
+		header := progressHeader{
+			Migrations: names,
+			Payloads:   uint64(len(payloads)),
+			Input:      input.String(),
+		}
+		progress, migrated, err = openProgressFile(r.ProgressFile, header)
+		if err != nil {
+			return nil, fmt.Errorf("could not open progress file: %w", err)
+		}
+		defer progress.Close()
+	}
+
+	r.Log.Info().
+		Int("accounts", len(accounts)).
+		Int("resumed_accounts", len(migrated)).
+		Strs("migrations", names).
+		Msg("migrating accounts")
+
+	migrationReports := make([]reporters.ReportWriter, len(r.Migrations))
+	for i, name := range names {
+		migrationReports[i] = r.RWF.ReportWriter(name)
+		defer migrationReports[i].Close()
+	}
+	invariantsReport := r.RWF.ReportWriter("migration_invariants")
+	defer invariantsReport.Close()
+
+	nWorker := r.NWorker
+	if nWorker < 1 {
+		nWorker = 1
+	}
+
+	jobs := make(chan accountPayloads, nWorker)
+	results := make(chan accountResult, nWorker)
+	done := make(chan struct{})
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < nWorker; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for account := range jobs {
+				// the migrated map is not modified while the workers are running
+				_, resumed := migrated[account.owner]
+				result := accountResult{
+					accountPayloads: accountPayloads{owner: account.owner},
+					resumed:         resumed,
+				}
+				result.payloads, result.err = r.migrateAccount(account, !resumed, migrationReports, invariantsReport)
+				if result.err == nil && progress != nil {
+					result.digest, result.err = payloadsDigest(result.payloads)
+				}
+				results <- result
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, account := range accounts {
+			select {
+			case jobs <- account:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	output := make([]ledger.Payload, 0, len(payloads))
+
+	var migrationErr error
+	count := 0
+	for result := range results {
+		if migrationErr != nil {
+			// drain the results of the accounts in flight
+			continue
+		}
+
+		err := result.err
+		if err == nil && result.resumed && result.digest != migrated[result.owner] {
+			err = fmt.Errorf("migrated payloads do not match the recorded progress, the migrations are not deterministic")
+		}
+		if err == nil && progress != nil && !result.resumed {
+			err = progress.Record(result.owner, result.digest)
+		}
+		if err != nil {
+			migrationErr = fmt.Errorf("could not migrate account %s: %w", flow.BytesToAddress([]byte(result.owner)).Hex(), err)
+			close(done)
+			continue
+		}
+
+		output = append(output, result.payloads...)
+		count++
+		if count%100_000 == 0 {
+			r.Log.Info().Int("migrated_accounts", count).Int("accounts", len(accounts)).Msg("migrating accounts")
+		}
+	}
+	if migrationErr != nil {
+		return nil, migrationErr
+	}
+
+	r.Log.Info().Int("accounts", len(accounts)).Int("payloads", len(output)).Msg("migrated accounts")
+
+	return output, nil
+}
+
+// migrateAccount applies all migrations to the payloads of the account. If checkInvariants is set,
+// the invariants are checked before and after the migrations.
+func (r *AccountMigrationRunner) migrateAccount(
+	account accountPayloads,
+	checkInvariants bool,
+	migrationReports []reporters.ReportWriter,
+	invariantsReport reporters.ReportWriter,
+) ([]ledger.Payload, error) {
+
+	address := flow.BytesToAddress([]byte(account.owner)).Hex()
+
+	invariants := r.Invariants
+	if !checkInvariants {
+		invariants = nil
+	}
+
+	// violations before the migration are only reported, migrations might fix them
+	for _, invariant := range invariants {
+		err := invariant.Check(account.owner, account.payloads)
+		if err != nil {
+			invariantsReport.Write(invariantRecord{
+				Address:   address,
+				Invariant: invariant.Name(),
+				Stage:     "before",
+				Error:     err.Error(),
+			})
+		}
+	}
+
+	payloads := account.payloads
+	for i, migration := range r.Migrations {
+		var record interface{}
+		var err error
+		payloads, record, err = migration.MigrateAccount(account.owner, payloads)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s failed: %w", migration.Name(), err)
+		}
+		if record != nil {
+			migrationReports[i].Write(record)
+		}
+	}
+
+	for _, invariant := range invariants {
+		err := invariant.Check(account.owner, payloads)
+		if err != nil {
+			invariantsReport.Write(invariantRecord{
+				Address:   address,
+				Invariant: invariant.Name(),
+				Stage:     "after",
+				Error:     err.Error(),
+			})
+			return nil, fmt.Errorf("invariant %s violated after migration: %w", invariant.Name(), err)
+		}
+	}
+
+	return payloads, nil
+}
+
+// groupByOwner sorts the payloads by owner in place and returns the payloads of each owner,
+// sharing the backing array of the given payloads
+func groupByOwner(payloads []ledger.Payload) ([]accountPayloads, error) {
+	owners := make([][]byte, len(payloads))
+	for i, p := range payloads {
+		// validates the key has the format of a register ID, its first part is the owner
+		_, err := migrations.KeyToRegisterID(p.Key)
+		if err != nil {
+			return nil, err
+		}
+		owners[i] = p.Key.KeyParts[0].Value
+	}
+
+	sort.Sort(byOwner{payloads: payloads, owners: owners})
+
+	var accounts []accountPayloads
+	for start := 0; start < len(payloads); {
+		end := start + 1
+		for end < len(payloads) && bytes.Equal(owners[start], owners[end]) {
+			end++
+		}
+		accounts = append(accounts, accountPayloads{
+			owner:    string(owners[start]),
+			payloads: payloads[start:end:end],
+		})
+		start = end
+	}
+	return accounts, nil
+}
+
+type byOwner struct {
+	payloads []ledger.Payload
+	owners   [][]byte
+}
+
+func (b byOwner) Len() int {
+	return len(b.payloads)
+}
+
+func (b byOwner) Less(i, j int) bool {
+	return bytes.Compare(b.owners[i], b.owners[j]) < 0
+}
+
+func (b byOwner) Swap(i, j int) {
+	b.payloads[i], b.payloads[j] = b.payloads[j], b.payloads[i]
+	b.owners[i], b.owners[j] = b.owners[j], b.owners[i]
+}
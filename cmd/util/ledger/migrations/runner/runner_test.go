@@ -0,0 +1,225 @@
+package runner
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations"
+	"github.com/onflow/flow-go/cmd/util/ledger/reporters"
+	"github.com/onflow/flow-go/engine/execution/state"
+	fvmState "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// testReportWriterFactory keeps the written data points in memory
+type testReportWriterFactory struct {
+	mu      sync.Mutex
+	reports map[string][]interface{}
+}
+
+func newTestReportWriterFactory() *testReportWriterFactory {
+	return &testReportWriterFactory{reports: make(map[string][]interface{})}
+}
+
+func (f *testReportWriterFactory) ReportWriter(dataNamespace string) reporters.ReportWriter {
+	return &testReportWriter{factory: f, namespace: dataNamespace}
+}
+
+func (f *testReportWriterFactory) report(namespace string) []interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reports[namespace]
+}
+
+type testReportWriter struct {
+	factory   *testReportWriterFactory
+	namespace string
+}
+
+func (w *testReportWriter) Write(dataPoint interface{}) {
+	w.factory.mu.Lock()
+	defer w.factory.mu.Unlock()
+	w.factory.reports[w.namespace] = append(w.factory.reports[w.namespace], dataPoint)
+}
+
+func (w *testReportWriter) Close() {}
+
+// countingMigration counts and reports the migrated accounts, and fails for the account with the given owner.
+// If tamper is set, the last payload of every account is dropped.
+type countingMigration struct {
+	mu       sync.Mutex
+	migrated int
+	failFor  string
+	tamper   bool
+}
+
+func (m *countingMigration) Name() string {
+	return "counting_migration"
+}
+
+func (m *countingMigration) MigrateAccount(owner string, payloads []ledger.Payload) ([]ledger.Payload, interface{}, error) {
+	if m.failFor != "" && owner == m.failFor {
+		return nil, nil, fmt.Errorf("failing migration")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.migrated++
+	if m.tamper {
+		payloads = payloads[:len(payloads)-1]
+	}
+	return payloads, owner, nil
+}
+
+func payload(address flow.Address, key string, value []byte) ledger.Payload {
+	return ledger.Payload{
+		Key:   state.RegisterIDToKey(flow.NewRegisterID(string(address.Bytes()), "", key)),
+		Value: value,
+	}
+}
+
+// testPayloads returns the payloads of the given number of accounts with a wrong storage used,
+// one empty payload per account, and a payload not owned by any account
+func testPayloads(accounts int) []ledger.Payload {
+	payloads := []ledger.Payload{
+		{
+			Key:   state.RegisterIDToKey(flow.NewRegisterID("", "", "uuid")),
+			Value: []byte{1},
+		},
+	}
+	for i := 1; i <= accounts; i++ {
+		address := flow.HexToAddress(fmt.Sprintf("%016x", i))
+		payloads = append(payloads,
+			payload(address, fvmState.KeyExists, []byte{1}),
+			payload(address, fvmState.KeyStorageUsed, utils.Uint64ToBinary(1)),
+			payload(address, "deleted", nil),
+		)
+	}
+	return payloads
+}
+
+func storageUsed(t *testing.T, payloads []ledger.Payload) map[string]uint64 {
+	used := make(map[string]uint64)
+	for _, p := range payloads {
+		id, err := migrations.KeyToRegisterID(p.Key)
+		require.NoError(t, err)
+		if id.Key == fvmState.KeyStorageUsed {
+			used[id.Owner], _, err = utils.ReadUint64(p.Value)
+			require.NoError(t, err)
+		}
+	}
+	return used
+}
+
+// registers returns the registers of the payloads, comparable independent of the encoding of empty values
+func registers(payloads []ledger.Payload) []string {
+	registers := make([]string, len(payloads))
+	for i, p := range payloads {
+		registers[i] = fmt.Sprintf("%s=%x", p.Key.String(), []byte(p.Value))
+	}
+	return registers
+}
+
+func TestAccountMigrationRunner(t *testing.T) {
+	t.Run("migrates all accounts", func(t *testing.T) {
+		rwf := newTestReportWriterFactory()
+		runner := &AccountMigrationRunner{
+			Log: zerolog.Nop(),
+			RWF: rwf,
+			Migrations: []migrations.AccountMigration{
+				migrations.StorageUsedAccountMigration{},
+				migrations.PruneAccountMigration{},
+			},
+			Invariants: []migrations.AccountInvariant{
+				migrations.StorageUsedInvariant{},
+			},
+			NWorker: 4,
+		}
+
+		migrated, err := runner.Migrate(testPayloads(10))
+		require.NoError(t, err)
+
+		// the empty payloads are pruned
+		assert.Len(t, migrated, 1+10*2)
+
+		used := storageUsed(t, migrated)
+		assert.Len(t, used, 10)
+		for _, u := range used {
+			assert.Equal(t, uint64(55), u)
+		}
+
+		assert.Len(t, rwf.report("storage_used_update_migration"), 10)
+		assert.Len(t, rwf.report("prune_migration"), 10)
+		// the wrong storage used is reported before, but not after the migration
+		assert.Len(t, rwf.report("migration_invariants"), 10)
+	})
+
+	t.Run("invariants violated after the migration", func(t *testing.T) {
+		runner := &AccountMigrationRunner{
+			Log:        zerolog.Nop(),
+			RWF:        newTestReportWriterFactory(),
+			Migrations: []migrations.AccountMigration{migrations.PruneAccountMigration{}},
+			Invariants: []migrations.AccountInvariant{migrations.StorageUsedInvariant{}},
+			NWorker:    4,
+		}
+
+		_, err := runner.Migrate(testPayloads(10))
+		require.Error(t, err)
+	})
+
+	t.Run("resumes an interrupted migration", func(t *testing.T) {
+		progressFile := filepath.Join(t.TempDir(), "progress")
+		failFor := string(flow.HexToAddress("05").Bytes())
+
+		// the accounts are migrated in order of their addresses, the migration fails on the fifth account
+		failing := &countingMigration{failFor: failFor}
+		runner := &AccountMigrationRunner{
+			Log:          zerolog.Nop(),
+			RWF:          newTestReportWriterFactory(),
+			Migrations:   []migrations.AccountMigration{failing},
+			NWorker:      1,
+			ProgressFile: progressFile,
+		}
+		_, err := runner.Migrate(testPayloads(10))
+		require.Error(t, err)
+		// the payloads not owned by an account and accounts 0x1 to 0x4 are recorded,
+		// accounts after the failing one might have been migrated, but aren't recorded
+		require.GreaterOrEqual(t, failing.migrated, 5)
+
+		// the migration of different payloads can't resume from the progress
+		changed := testPayloads(10)
+		changed[1].Value = []byte{2}
+		runner.Migrations = []migrations.AccountMigration{&countingMigration{}}
+		_, err = runner.Migrate(changed)
+		require.Error(t, err)
+
+		// the recorded accounts are migrated again, so their reports are emitted again
+		rwf := newTestReportWriterFactory()
+		resumed := &countingMigration{}
+		runner.RWF = rwf
+		runner.Migrations = []migrations.AccountMigration{resumed}
+		migrated, err := runner.Migrate(testPayloads(10))
+		require.NoError(t, err)
+		assert.Equal(t, 11, resumed.migrated)
+		assert.Len(t, rwf.report("counting_migration"), 11)
+		assert.ElementsMatch(t, registers(testPayloads(10)), registers(migrated))
+
+		// a different migration can't resume from the progress
+		runner.Migrations = []migrations.AccountMigration{migrations.PruneAccountMigration{}}
+		_, err = runner.Migrate(testPayloads(10))
+		require.Error(t, err)
+
+		// the migration fails if the migrated payloads of recorded accounts change
+		runner.Migrations = []migrations.AccountMigration{&countingMigration{tamper: true}}
+		_, err = runner.Migrate(testPayloads(10))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not deterministic")
+	})
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/onflow/flow-go/admin/commands"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
@@ -28,6 +30,7 @@ import (
 	"github.com/onflow/flow-go/module/buffer"
 	"github.com/onflow/flow-go/module/chunks"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/jobqueue"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
@@ -36,6 +39,7 @@ import (
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
+	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/badger"
 )
 
@@ -56,6 +60,10 @@ func main() {
 		blockWorkers uint64 // number of blocks processed in parallel.
 		chunkWorkers uint64 // number of chunks processed in parallel.
 
+		jobRetryConfig  jobqueue.RetryConfig // how the block and chunk consumers retry failed jobs.
+		failedChunkJobs *storage.FailedJobs  // chunk jobs that failed even after retrying
+		failedBlockJobs *storage.FailedJobs  // block jobs that failed even after retrying
+
 		chunkStatuses        *stdmap.ChunkStatuses     // used in fetcher engine
 		chunkRequests        *stdmap.ChunkRequests     // used in requester engine
 		processedChunkIndex  *storage.ConsumerProgress // used in chunk consumer
@@ -89,6 +97,9 @@ func main() {
 		flags.Uint64Var(&requestTargets, "request-targets", vereq.DefaultRequestTargets, "maximum number of execution nodes a chunk data pack request is dispatched to")
 		flags.Uint64Var(&blockWorkers, "block-workers", blockconsumer.DefaultBlockWorkers, "maximum number of blocks being processed in parallel")
 		flags.Uint64Var(&chunkWorkers, "chunk-workers", chunkconsumer.DefaultChunkWorkers, "maximum number of execution nodes a chunk data pack request is dispatched to")
+		flags.UintVar(&jobRetryConfig.MaxAttempts, "job-max-attempts", jobqueue.DefaultMaxAttempts, "maximum number of attempts to process a block or chunk before recording it as failed")
+		flags.DurationVar(&jobRetryConfig.Backoff, "job-retry-backoff", jobqueue.DefaultBackoff, "time a failed block or chunk waits before its first retry, doubled for every further retry")
+		flags.DurationVar(&jobRetryConfig.MaxBackoff, "job-retry-max-backoff", jobqueue.DefaultMaxBackoff, "maximum time a failed block or chunk waits before it is retried")
 
 	})
 
@@ -97,6 +108,23 @@ func main() {
 	}
 
 	nodeBuilder.
+		PostInit(func(builder cmd.NodeBuilder, node *cmd.NodeConfig) {
+			// the failed jobs are created before the admin commands, which list and re-queue them
+			failedChunkJobs = storage.NewFailedJobs(node.DB, module.ConsumeProgressVerificationChunkIndex)
+			failedBlockJobs = storage.NewFailedJobs(node.DB, module.ConsumeProgressVerificationBlockHeight)
+		}).
+		AdminCommand("list-failed-jobs", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewListFailedJobsCommand(map[string]realstorage.FailedJobs{
+				"chunk": failedChunkJobs,
+				"block": failedBlockJobs,
+			})
+		}).
+		AdminCommand("requeue-failed-job", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return storageCommands.NewRequeueFailedJobCommand(map[string]realstorage.FailedJobs{
+				"chunk": failedChunkJobs,
+				"block": failedBlockJobs,
+			})
+		}).
 		Module("mutable follower state", func(builder cmd.NodeBuilder, node *cmd.NodeConfig) error {
 			// For now, we only support state implementations from package badger.
 			// If we ever support different implementations, the following can be replaced by a type-aware factory
@@ -222,7 +250,8 @@ func main() {
 				processedChunkIndex,
 				chunkQueue,
 				fetcherEngine,
-				chunkWorkers)
+				chunkWorkers,
+				jobqueue.WithRetries(jobRetryConfig, failedChunkJobs))
 
 			err = node.Metrics.Mempool.Register(metrics.ResourceChunkConsumer, chunkConsumer.Size)
 			if err != nil {
@@ -260,7 +289,8 @@ func main() {
 				node.Storage.Blocks,
 				node.State,
 				assignerEngine,
-				blockWorkers)
+				blockWorkers,
+				jobqueue.WithRetries(jobRetryConfig, failedBlockJobs))
 
 			if err != nil {
 				return nil, fmt.Errorf("could not initialize block consumer: %w", err)
@@ -2,16 +2,19 @@ package consensus
 
 import (
 	"time"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
 )
 
 type ParticipantConfig struct {
-	StartupTime                time.Time     // the time when consensus participant enters first view
-	TimeoutInitial             time.Duration // the initial timeout for the pacemaker
-	TimeoutMinimum             time.Duration // the minimum timeout for the pacemaker
-	TimeoutAggregationFraction float64       // the percentage part of the timeout period reserved for vote aggregation
-	TimeoutIncreaseFactor      float64       // the factor at which the timeout grows when timeouts occur
-	TimeoutDecreaseFactor      float64       // the factor at which the timeout grows when timeouts occur
-	BlockRateDelay             time.Duration // a delay to broadcast block proposal in order to control the block production rate
+	StartupTime                time.Time           // the time when consensus participant enters first view
+	TimeoutInitial             time.Duration       // the initial timeout for the pacemaker
+	TimeoutMinimum             time.Duration       // the minimum timeout for the pacemaker
+	TimeoutAggregationFraction float64             // the percentage part of the timeout period reserved for vote aggregation
+	TimeoutIncreaseFactor      float64             // the factor at which the timeout grows when timeouts occur
+	TimeoutDecreaseFactor      float64             // the factor at which the timeout grows when timeouts occur
+	BlockRateDelay             time.Duration       // a delay to broadcast block proposal in order to control the block production rate
+	TimeoutController          *timeout.Controller // pre-built timeout controller, which takes precedence over the timeout parameters above
 }
 
 type Option func(*ParticipantConfig)
@@ -57,3 +60,11 @@ func WithBlockRateDelay(delay time.Duration) Option {
 		cfg.BlockRateDelay = delay
 	}
 }
+
+// WithTimeoutController sets the timeout controller of the pacemaker, which allows the owner to
+// tune the timeouts at runtime. The other timeout options are ignored if it is set.
+func WithTimeoutController(controller *timeout.Controller) Option {
+	return func(cfg *ParticipantConfig) {
+		cfg.TimeoutController = controller
+	}
+}
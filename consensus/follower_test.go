@@ -15,6 +15,7 @@ import (
 	mockhotstuff "github.com/onflow/flow-go/consensus/hotstuff/mocks"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
 	mockmodule "github.com/onflow/flow-go/module/mock"
 	mockstorage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -77,6 +78,7 @@ func (s *HotStuffFollowerSuite) SetupTest() {
 	for _, identity := range identities {
 		s.committee.On("Identity", mock.Anything, identity.NodeID).Return(identity, nil)
 	}
+	s.committee.On("CanonicalCommittee", mock.Anything).Return(identities.Sort(order.Canonical), nil)
 	s.committee.On("LeaderForView", mock.Anything).Return(
 		func(view uint64) flow.Identifier { return identities[int(view)%len(identities)].NodeID },
 		nil,
@@ -106,9 +108,9 @@ func (s *HotStuffFollowerSuite) SetupTest() {
 		View:      52078,
 	}
 	s.rootQC = &flow.QuorumCertificate{
-		View:      s.rootHeader.View,
-		BlockID:   s.rootHeader.ID(),
-		SignerIDs: identities.NodeIDs()[:3],
+		View:          s.rootHeader.View,
+		BlockID:       s.rootHeader.ID(),
+		SignerIndices: unittest.SignerIndicesByIdentifiers(identities.NodeIDs(), identities.NodeIDs()[:3]),
 	}
 
 	// we start with the latest finalized block being the root block
@@ -335,6 +337,6 @@ func (mc *MockConsensus) extendBlock(blockView uint64, parent *flow.Header) *flo
 	nextBlock := unittest.BlockHeaderWithParentFixture(parent)
 	nextBlock.View = blockView
 	nextBlock.ProposerID = mc.identities[int(blockView)%len(mc.identities)].NodeID
-	nextBlock.ParentVoterIDs = mc.identities.NodeIDs()
+	nextBlock.ParentVoterIndices = unittest.SignerIndicesByIdentifiers(mc.identities.NodeIDs(), mc.identities.NodeIDs())
 	return &nextBlock
 }
@@ -26,6 +26,12 @@ type Committee interface {
 	// The list of all legitimate HotStuff participants for the specified block can be obtained by using `filter.Any`
 	Identities(blockID flow.Identifier, selector flow.IdentityFilter) (flow.IdentityList, error)
 
+	// CanonicalCommittee returns the canonically ordered committee of the epoch containing the
+	// specified block. The signer indices of QCs for the block are encoded relative to this committee.
+	// In contrast to Identities, the committee is fixed for the entire epoch and is NOT restricted to
+	// legitimate participants: it may contain nodes which have been ejected or lost their stake since.
+	CanonicalCommittee(blockID flow.Identifier) (flow.IdentityList, error)
+
 	// Identity returns the full Identity for specified HotStuff participant.
 	// The node must be a legitimate HotStuff participant with NON-ZERO STAKE at the specified block.
 	// ERROR conditions:
@@ -8,6 +8,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
@@ -29,6 +30,8 @@ type Cluster struct {
 	clusterMemberFilter flow.IdentityFilter
 	// initial set of cluster members, WITHOUT updated weight
 	initialClusterMembers flow.IdentityList
+	// initial set of cluster members in canonical order, signer indices are encoded relative to it
+	canonicalCommittee flow.IdentityList
 }
 
 func NewClusterCommittee(
@@ -51,6 +54,7 @@ func NewClusterCommittee(
 		selection:             selection,
 		clusterMemberFilter:   cluster.Members().Selector(),
 		initialClusterMembers: cluster.Members(),
+		canonicalCommittee:    signature.ClusterCommittee(cluster),
 	}
 	return com, nil
 }
@@ -79,6 +83,12 @@ func (c *Cluster) Identities(blockID flow.Identifier, selector flow.IdentityFilt
 	return identities, err
 }
 
+// CanonicalCommittee returns the initial cluster members in canonical order, as
+// clusters are epoch-scoped.
+func (c *Cluster) CanonicalCommittee(_ flow.Identifier) (flow.IdentityList, error) {
+	return c.canonicalCommittee, nil
+}
+
 func (c *Cluster) Identity(blockID flow.Identifier, nodeID flow.Identifier) (*flow.Identity, error) {
 
 	// first retrieve the cluster block payload
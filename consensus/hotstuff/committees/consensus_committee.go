@@ -10,7 +10,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
-	"github.com/onflow/flow-go/model/indices"
+	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/state/protocol"
 )
 
@@ -19,18 +19,20 @@ var errSelectionNotComputed = fmt.Errorf("leader selection for epoch not yet com
 // Consensus represents the main committee for consensus nodes. The consensus
 // committee persists across epochs.
 type Consensus struct {
-	mu      sync.RWMutex
-	state   protocol.State                     // the protocol state
-	me      flow.Identifier                    // the node ID of this node
-	leaders map[uint64]*leader.LeaderSelection // pre-computed leader selection for each epoch
+	mu        sync.RWMutex
+	state     protocol.State                     // the protocol state
+	me        flow.Identifier                    // the node ID of this node
+	leaders   map[uint64]*leader.LeaderSelection // pre-computed leader selection for each epoch
+	fallbacks map[uint64]*leader.LeaderSelection // pre-computed leader selection for the extension of each epoch in fallback mode
 }
 
 func NewConsensusCommittee(state protocol.State, me flow.Identifier) (*Consensus, error) {
 
 	com := &Consensus{
-		state:   state,
-		me:      me,
-		leaders: make(map[uint64]*leader.LeaderSelection),
+		state:     state,
+		me:        me,
+		leaders:   make(map[uint64]*leader.LeaderSelection),
+		fallbacks: make(map[uint64]*leader.LeaderSelection),
 	}
 
 	final := state.Final()
@@ -74,6 +76,10 @@ func (c *Consensus) Identities(blockID flow.Identifier, selector flow.IdentityFi
 	return il, err
 }
 
+func (c *Consensus) CanonicalCommittee(blockID flow.Identifier) (flow.IdentityList, error) {
+	return signature.EpochConsensusCommittee(c.state.AtBlockID(blockID).Epochs().Current())
+}
+
 func (c *Consensus) Identity(blockID flow.Identifier, nodeID flow.Identifier) (*flow.Identity, error) {
 	identity, err := c.state.AtBlockID(blockID).Identity(nodeID)
 	if protocol.IsIdentityNotFound(err) {
@@ -114,82 +120,112 @@ func (c *Consensus) LeaderForView(view uint64) (flow.Identifier, error) {
 	//
 	//   oldestEpoch.firstView <= V <= newestEpoch.finalView
 	//
-	// Thus, the requested view is either before oldestEpoch.firstView or after
-	// newestEpoch.finalView.
+	// unless an epoch has been extended in epoch emergency fallback mode [EECC].
+	// The leaders for the views of an extension are never served from the
+	// pre-computed selections above, because the extension ends as soon as a
+	// recovery epoch is committed, which we check against the protocol state.
 	//
-	// CASE 1: V < oldestEpoch.firstView
-	// If the view is before the first view we've computed the leader for, this
-	// represents an invalid query because we only guarantee the protocol state
-	// will contain epoch information for the current, previous, and next epoch -
-	// such a query must be for a view within an epoch at least TWO epochs before
-	// the current epoch when we started up. This is considered an invalid query.
+	// CASE 1: V > current.finalView
+	// If the view is after the final view of the current epoch (w.r.t. the finalized
+	// head), we assume the view is within the next epoch. This assumption is
+	// equivalent to assuming that we build at least one block in every epoch, which
+	// is anyway a requirement for valid epochs. If the next epoch has not been
+	// committed, or starts after the view, the view is in the extension of the
+	// current epoch.
 	//
-	// CASE 2: V > newestEpoch.finalView
-	// If the view is after the last view we've computed the leader for, we
-	// assume the view is within the next epoch (w.r.t. the finalized head).
-	// This assumption is equivalent to assuming that we build at least one
-	// block in every epoch, which is anyway a requirement for valid epochs.
+	// CASE 2: V < current.firstView
+	// If the view is before the first view of the current epoch, it is either in
+	// the extension of the previous epoch, or represents an invalid query because
+	// we only guarantee the protocol state will contain epoch information for the
+	// current, previous, and next epoch.
 	//
 	epochs := c.state.Final().Epochs()
-	next := epochs.Next()
-
-	// TMP: EMERGENCY EPOCH CHAIN CONTINUATION [EECC]
-	//
-	// If we reach this code-path, it means we are about to propose or vote
-	// for the first block in the next epoch. If that epoch has not been
-	// committed or set up, rather than stopping consensus, this intervention
-	// will create a new fallback leader selection for the next epoch containing
-	// 6 months worth of views, so that consensus will have leaders specified
-	// for the duration of the current spork, without any epoch transitions.
-	//
-	_, err = next.DKG() // either of the following errors indicates that we have transitioned into EECC
-	if errors.Is(err, protocol.ErrEpochNotCommitted) || errors.Is(err, protocol.ErrNextEpochNotSetup) {
-		current := epochs.Current()
+	current := epochs.Current()
+	firstView, err := current.FirstView()
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("could not get current epoch first view: %w", err)
+	}
+	finalView, err := current.FinalView()
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("could not get current epoch final view: %w", err)
+	}
 
-		currentCounter, err := current.Counter()
-		if err != nil {
-			return flow.ZeroID, fmt.Errorf("could not get next epoch currentCounter: %w", err)
+	// CASE 1
+	if view > finalView {
+		next := epochs.Next()
+		_, err = next.DKG() // either of the following errors indicates that the next epoch is not committed
+		if errors.Is(err, protocol.ErrEpochNotCommitted) || errors.Is(err, protocol.ErrNextEpochNotSetup) {
+			return c.fallbackLeaderForView(current, view)
 		}
-		identities, err := current.InitialIdentities()
 		if err != nil {
-			return flow.ZeroID, fmt.Errorf("could not get epoch initial identities: %w", err)
+			return flow.ZeroID, fmt.Errorf("unexpected error retrieving DKG data for next epoch: %w", err)
 		}
-		// CAUTION: this is re-using the same leader selection seed from the now-ending epoch
-		seed, err := current.Seed(indices.ProtocolConsensusLeaderSelection...)
+
+		// HAPPY PATH logic
+		selection, err := c.prepareLeaderSelection(next)
 		if err != nil {
-			return flow.ZeroID, fmt.Errorf("could not get epoch seed: %w", err)
+			return flow.ZeroID, fmt.Errorf("could not compute leader selection for next epoch: %w", err)
 		}
-		currentFinalView, err := current.FinalView()
-		if err != nil {
-			return flow.ZeroID, fmt.Errorf("could not get epoch first view: %w", err)
+		// a recovery epoch starts after the extension of the current epoch
+		if view < selection.FirstView() {
+			return c.fallbackLeaderForView(current, view)
 		}
+		return selection.LeaderForView(view)
+	}
 
-		// we will inject a fallback leader selection in place of the next epoch
-		counter := currentCounter + 1
-		// the fallback leader selection begins after the final view of the current epoch
-		firstView := currentFinalView + 1
-		selection, err := leader.ComputeLeaderSelectionFromSeed(
-			firstView,
-			seed,
-			int(firstView+leader.EstimatedSixMonthOfViews), // the fallback epoch lasts until the next spork
-			identities.Filter(filter.IsVotingConsensusCommitteeMember),
-		)
+	// CASE 2
+	if view < firstView {
+		previous := epochs.Previous()
+		previousFinalView, err := previous.FinalView()
+		if errors.Is(err, protocol.ErrNoPreviousEpoch) {
+			return c.invalidView(current, view)
+		}
 		if err != nil {
-			return flow.ZeroID, fmt.Errorf("could not compute epoch fallback leader selection: %w", err)
+			return flow.ZeroID, fmt.Errorf("could not get previous epoch final view: %w", err)
 		}
-		c.mu.Lock()
-		c.leaders[counter] = selection
-		c.mu.Unlock()
-		return selection.LeaderForView(view)
+		if view > previousFinalView {
+			return c.fallbackLeaderForView(previous, view)
+		}
+		return c.invalidView(previous, view)
+	}
+
+	// the view is within the current epoch
+	selection, err := c.prepareLeaderSelection(current)
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("could not compute leader selection for current epoch: %w", err)
 	}
+	return selection.LeaderForView(view)
+}
+
+// invalidView returns the leader.InvalidViewError for a view which is too far in
+// the past, using the leader selection of the given (oldest known) epoch.
+func (c *Consensus) invalidView(epoch protocol.Epoch, view uint64) (flow.Identifier, error) {
+	selection, err := c.prepareLeaderSelection(epoch)
 	if err != nil {
-		return flow.ZeroID, fmt.Errorf("unexpected error in EECC logic while retrieving DKG data: %w", err)
+		return flow.ZeroID, fmt.Errorf("could not compute leader selection: %w", err)
 	}
+	return selection.LeaderForView(view)
+}
 
-	// HAPPY PATH logic
-	selection, err := c.prepareLeaderSelection(next)
+// fallbackLeaderForView returns the leader for a view in the extension of the
+// given epoch in epoch emergency fallback mode. The leader selection for the
+// extension is computed on first use.
+func (c *Consensus) fallbackLeaderForView(epoch protocol.Epoch, view uint64) (flow.Identifier, error) {
+	counter, err := epoch.Counter()
 	if err != nil {
-		return flow.ZeroID, fmt.Errorf("could not compute leader selection for next epoch: %w", err)
+		return flow.ZeroID, fmt.Errorf("could not get epoch counter: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	selection, exists := c.fallbacks[counter]
+	if !exists {
+		selection, err = leader.SelectionForConsensusFallback(epoch)
+		if err != nil {
+			return flow.ZeroID, fmt.Errorf("could not compute epoch fallback leader selection: %w", err)
+		}
+		c.fallbacks[counter] = selection
 	}
 
 	return selection.LeaderForView(view)
@@ -276,6 +312,11 @@ func (c *Consensus) prepareLeaderSelection(epoch protocol.Epoch) (*leader.Leader
 			delete(c.leaders, counter)
 		}
 	}
+	for counter := range c.fallbacks {
+		if counter+3 <= max {
+			delete(c.fallbacks, counter)
+		}
+	}
 
 	return selection, nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/consensus/hotstuff/committees/leader"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/indices"
@@ -203,6 +204,76 @@ func TestConsensus_LeaderForView(t *testing.T) {
 	})
 }
 
+// test that LeaderForView keeps returning leaders past the final view of the
+// current epoch in epoch emergency fallback mode, and switches to the recovery
+// epoch once it has been committed and started.
+func TestConsensus_LeaderForView_EpochFallback(t *testing.T) {
+
+	identities := unittest.IdentityListFixture(10)
+	me := identities[0].NodeID
+
+	// the counter for the current epoch
+	epochCounter := uint64(2)
+
+	state := new(protocolmock.State)
+	snapshot := new(protocolmock.Snapshot)
+
+	prevEpoch := newMockEpoch(epochCounter-1, identities, 1, 100, unittest.SeedFixture(32))
+	currEpoch := newMockEpoch(epochCounter, identities, 101, 200, unittest.SeedFixture(32))
+
+	state.On("Final").Return(snapshot)
+	epochs := mocks.NewEpochQuery(t, epochCounter, prevEpoch, currEpoch)
+	snapshot.On("Epochs").Return(epochs)
+
+	committee, err := NewConsensusCommittee(state, me)
+	require.NoError(t, err)
+
+	// the next epoch is not committed: the current epoch is extended
+	fallbackLeader, err := committee.LeaderForView(250)
+	require.NoError(t, err)
+	_, exists := identities.ByNodeID(fallbackLeader)
+	assert.True(t, exists)
+
+	// the fallback leader selection is deterministic
+	restarted, err := NewConsensusCommittee(state, me)
+	require.NoError(t, err)
+	leaderID, err := restarted.LeaderForView(250)
+	require.NoError(t, err)
+	assert.Equal(t, fallbackLeader, leaderID)
+
+	// a recovery epoch is committed, which starts after the extension
+	recoveryEpoch := newMockEpoch(epochCounter+1, identities, 301, 400, unittest.SeedFixture(32))
+	epochs.Add(recoveryEpoch)
+
+	t.Run("extension of current epoch", func(t *testing.T) {
+		leaderID, err := committee.LeaderForView(250)
+		require.NoError(t, err)
+		assert.Equal(t, fallbackLeader, leaderID)
+	})
+
+	t.Run("recovery epoch", func(t *testing.T) {
+		expected, err := leader.SelectionForConsensus(recoveryEpoch)
+		require.NoError(t, err)
+		for view := uint64(301); view <= 400; view++ {
+			leaderID, err := committee.LeaderForView(view)
+			require.NoError(t, err)
+			expectedID, err := expected.LeaderForView(view)
+			require.NoError(t, err)
+			assert.Equal(t, expectedID, leaderID)
+		}
+	})
+
+	t.Run("extension of previous epoch after transition", func(t *testing.T) {
+		epochs.Transition()
+		restarted, err := NewConsensusCommittee(state, me)
+		require.NoError(t, err)
+
+		leaderID, err := restarted.LeaderForView(250)
+		require.NoError(t, err)
+		assert.Equal(t, fallbackLeader, leaderID)
+	})
+}
+
 func TestRemoveOldEpochs(t *testing.T) {
 
 	identities := unittest.IdentityListFixture(10)
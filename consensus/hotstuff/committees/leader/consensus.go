@@ -39,3 +39,32 @@ func SelectionForConsensus(epoch protocol.Epoch) (*LeaderSelection, error) {
 	)
 	return leaders, err
 }
+
+// SelectionForConsensusFallback pre-computes and returns leaders for the consensus
+// committee for the extension of the given epoch in epoch emergency fallback mode.
+// The extension starts right after the final view of the epoch and re-uses the
+// committee and leader selection seed of the epoch. It spans enough views to last
+// until the next spork, unless a recovery epoch is committed earlier.
+func SelectionForConsensusFallback(epoch protocol.Epoch) (*LeaderSelection, error) {
+
+	identities, err := epoch.InitialIdentities()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch initial identities: %w", err)
+	}
+	// CAUTION: this is re-using the same leader selection seed from the extended epoch
+	seed, err := epoch.Seed(indices.ProtocolConsensusLeaderSelection...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch seed: %w", err)
+	}
+	finalView, err := epoch.FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch final view: %w", err)
+	}
+	leaders, err := ComputeLeaderSelectionFromSeed(
+		finalView+1,
+		seed,
+		EstimatedSixMonthOfViews,
+		identities.Filter(filter.IsVotingConsensusCommitteeMember),
+	)
+	return leaders, err
+}
@@ -33,6 +33,13 @@ func (w CommitteeMetricsWrapper) Identities(blockID flow.Identifier, selector fl
 	return identities, err
 }
 
+func (w CommitteeMetricsWrapper) CanonicalCommittee(blockID flow.Identifier) (flow.IdentityList, error) {
+	processStart := time.Now()
+	identities, err := w.committee.CanonicalCommittee(blockID)
+	w.metrics.CommitteeProcessingDuration(time.Since(processStart))
+	return identities, err
+}
+
 func (w CommitteeMetricsWrapper) Identity(blockID flow.Identifier, participantID flow.Identifier) (*flow.Identity, error) {
 	processStart := time.Now()
 	identity, err := w.committee.Identity(blockID, participantID)
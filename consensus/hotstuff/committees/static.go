@@ -6,6 +6,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
 )
 
 // NewStaticCommittee returns a new committee with a static participant set.
@@ -33,6 +34,10 @@ func (s Static) Identities(_ flow.Identifier, selector flow.IdentityFilter) (flo
 	return s.participants.Filter(selector), nil
 }
 
+func (s Static) CanonicalCommittee(_ flow.Identifier) (flow.IdentityList, error) {
+	return s.participants.Sort(order.Canonical), nil
+}
+
 func (s Static) Identity(_ flow.Identifier, participantID flow.Identifier) (*flow.Identity, error) {
 	identity, ok := s.participants.ByNodeID(participantID)
 	if !ok {
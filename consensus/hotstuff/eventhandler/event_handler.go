@@ -10,6 +10,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/utils/logging"
 )
 
@@ -531,7 +532,7 @@ func (e *EventHandler) processQC(qc *flow.QuorumCertificate) error {
 	log := e.log.With().
 		Uint64("block_view", qc.View).
 		Hex("block_id", qc.BlockID[:]).
-		Int("signers", len(qc.SignerIDs)).
+		Int("signers", signerindices.Count(qc.SignerIndices)).
 		Logger()
 
 	err := e.forks.AddQC(qc)
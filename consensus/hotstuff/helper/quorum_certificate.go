@@ -11,10 +11,10 @@ import (
 
 func MakeQC(t *testing.T, options ...func(*flow.QuorumCertificate)) *flow.QuorumCertificate {
 	qc := flow.QuorumCertificate{
-		View:      rand.Uint64(),
-		BlockID:   unittest.IdentifierFixture(),
-		SignerIDs: unittest.IdentityListFixture(7).NodeIDs(),
-		SigData:   unittest.SignatureFixture(),
+		View:          rand.Uint64(),
+		BlockID:       unittest.IdentifierFixture(),
+		SignerIndices: unittest.SignerIndicesFixture(7),
+		SigData:       unittest.SignatureFixture(),
 	}
 	for _, option := range options {
 		option(&qc)
@@ -29,9 +29,9 @@ func WithQCBlock(block *model.Block) func(*flow.QuorumCertificate) {
 	}
 }
 
-func WithQCSigners(signerIDs []flow.Identifier) func(*flow.QuorumCertificate) {
+func WithQCSigners(signerIndices []byte) func(*flow.QuorumCertificate) {
 	return func(qc *flow.QuorumCertificate) {
-		qc.SignerIDs = signerIDs
+		qc.SignerIndices = signerIndices
 	}
 }
 
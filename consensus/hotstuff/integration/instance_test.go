@@ -25,6 +25,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/voteaggregator"
 	"github.com/onflow/flow-go/consensus/hotstuff/voter"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
 	module "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -135,6 +136,7 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		},
 		nil,
 	)
+	in.committee.On("CanonicalCommittee", mock.Anything).Return(in.participants.Sort(order.Canonical), nil)
 	for _, participant := range in.participants {
 		in.committee.On("Identity", mock.Anything, participant.NodeID).Return(participant, nil)
 	}
@@ -206,10 +208,10 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 				voterIDs = append(voterIDs, vote.SignerID)
 			}
 			qc := &flow.QuorumCertificate{
-				View:      votes[0].View,
-				BlockID:   votes[0].BlockID,
-				SignerIDs: voterIDs,
-				SigData:   nil,
+				View:          votes[0].View,
+				BlockID:       votes[0].BlockID,
+				SignerIndices: unittest.SignerIndicesByIdentifiers(in.participants.NodeIDs(), voterIDs),
+				SigData:       nil,
 			}
 			return qc
 		},
@@ -297,9 +299,9 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 	// initialize the finalizer
 	rootBlock := model.BlockFromFlow(cfg.Root, 0)
 	rootQC := &flow.QuorumCertificate{
-		View:      rootBlock.View,
-		BlockID:   rootBlock.BlockID,
-		SignerIDs: in.participants.NodeIDs(),
+		View:          rootBlock.View,
+		BlockID:       rootBlock.BlockID,
+		SignerIndices: unittest.SignerIndicesByIdentifiers(in.participants.NodeIDs(), in.participants.NodeIDs()),
 	}
 	rootBlockQC := &forks.BlockQC{Block: rootBlock, QC: rootQC}
 	forkalizer, err := finalizer.New(rootBlockQC, in.finalizer, notifier)
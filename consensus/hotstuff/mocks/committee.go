@@ -14,6 +14,29 @@ type Committee struct {
 	mock.Mock
 }
 
+// CanonicalCommittee provides a mock function with given fields: blockID
+func (_m *Committee) CanonicalCommittee(blockID flow.Identifier) (flow.IdentityList, error) {
+	ret := _m.Called(blockID)
+
+	var r0 flow.IdentityList
+	if rf, ok := ret.Get(0).(func(flow.Identifier) flow.IdentityList); ok {
+		r0 = rf(blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(flow.IdentityList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
+		r1 = rf(blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DKG provides a mock function with given fields: blockID
 func (_m *Committee) DKG(blockID flow.Identifier) (hotstuff.DKG, error) {
 	ret := _m.Called(blockID)
@@ -79,24 +79,7 @@ func NewConfig(
 	timeoutDecrease float64,
 	blockRateDelay time.Duration,
 ) (Config, error) {
-	if startReplicaTimeout < minReplicaTimeout {
-		msg := fmt.Sprintf(
-			"startReplicaTimeout (%dms) cannot be smaller than minReplicaTimeout (%dms)",
-			startReplicaTimeout.Milliseconds(), minReplicaTimeout.Milliseconds())
-		return Config{}, model.ConfigurationError{Msg: msg}
-	}
-	if minReplicaTimeout < 0 {
-		return Config{}, model.ConfigurationError{Msg: "minReplicaTimeout must non-negative"}
-	}
-	if voteAggregationTimeoutFraction <= 0 || 1 < voteAggregationTimeoutFraction {
-		return Config{}, model.ConfigurationError{Msg: "VoteAggregationTimeoutFraction must be in range (0,1]"}
-	}
-	if timeoutIncrease <= 1 {
-		return Config{}, model.ConfigurationError{Msg: "TimeoutIncrease must be strictly bigger than 1"}
-	}
-	if timeoutDecrease <= 0 || 1 <= timeoutDecrease {
-		return Config{}, model.ConfigurationError{Msg: "timeoutDecrease must be in range (0,1)"}
-	}
+	// sub-millisecond durations are truncated below, hence we check the sign up front
 	if blockRateDelay < 0 {
 		return Config{}, model.ConfigurationError{Msg: "blockRateDelay must be must be non-negative"}
 	}
@@ -109,9 +92,40 @@ func NewConfig(
 		TimeoutDecrease:                timeoutDecrease,
 		BlockRateDelayMS:               float64(blockRateDelay.Milliseconds()),
 	}
+	err := tc.Validate()
+	if err != nil {
+		return Config{}, err
+	}
 	return tc, nil
 }
 
+// Validate checks that the configuration parameters are consistent. It returns a
+// model.ConfigurationError if they are not.
+func (c Config) Validate() error {
+	if c.ReplicaTimeout < c.MinReplicaTimeout {
+		msg := fmt.Sprintf(
+			"replicaTimeout (%.0fms) cannot be smaller than minReplicaTimeout (%.0fms)",
+			c.ReplicaTimeout, c.MinReplicaTimeout)
+		return model.ConfigurationError{Msg: msg}
+	}
+	if c.MinReplicaTimeout < 0 {
+		return model.ConfigurationError{Msg: "minReplicaTimeout must non-negative"}
+	}
+	if c.VoteAggregationTimeoutFraction <= 0 || 1 < c.VoteAggregationTimeoutFraction {
+		return model.ConfigurationError{Msg: "VoteAggregationTimeoutFraction must be in range (0,1]"}
+	}
+	if c.TimeoutIncrease <= 1 {
+		return model.ConfigurationError{Msg: "TimeoutIncrease must be strictly bigger than 1"}
+	}
+	if c.TimeoutDecrease <= 0 || 1 <= c.TimeoutDecrease {
+		return model.ConfigurationError{Msg: "timeoutDecrease must be in range (0,1)"}
+	}
+	if c.BlockRateDelayMS < 0 {
+		return model.ConfigurationError{Msg: "blockRateDelay must be must be non-negative"}
+	}
+	return nil
+}
+
 // StandardVoteAggregationTimeoutFraction calculates a standard value for the VoteAggregationTimeoutFraction in case a block delay is used.
 // The motivation for the standard value is as follows:
 //  * the next primary receives the block it ideally would extend at some time t
@@ -2,6 +2,7 @@ package timeout
 
 import (
 	"math"
+	"sync"
 	"time"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
@@ -11,7 +12,10 @@ import (
 // - on timeout: increase timeout by multiplicative factor `timeoutIncrease` (user-specified)
 //   this results in exponential growing timeout duration on multiple subsequent timeouts
 // - on progress: decrease timeout by subtrahend `timeoutDecrease`
+// The configuration can be read and updated concurrently with the pacemaker, through
+// Config and SetConfig.
 type Controller struct {
+	mu             sync.RWMutex // protects cfg
 	cfg            Config
 	timer          *time.Timer
 	timerInfo      *model.TimerInfo
@@ -87,27 +91,66 @@ func (t *Controller) computeTimeoutDuration(mode model.TimeoutMode) time.Duratio
 
 // ReplicaTimeout returns the duration of the current view before we time out
 func (t *Controller) ReplicaTimeout() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return time.Duration(t.cfg.ReplicaTimeout * 1e6)
 }
 
 // VoteCollectionTimeout returns the duration of Vote aggregation _after_ receiving a block
 // during which the primary tries to aggregate votes for the view where it is leader
 func (t *Controller) VoteCollectionTimeout() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	// time.Duration expects an int64 as input which specifies the duration in units of nanoseconds (1E-9)
 	return time.Duration(t.cfg.ReplicaTimeout * 1e6 * t.cfg.VoteAggregationTimeoutFraction)
 }
 
 // OnTimeout indicates to the Controller that the timeout was reached
 func (t *Controller) OnTimeout() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.cfg.ReplicaTimeout = math.Min(t.cfg.ReplicaTimeout*t.cfg.TimeoutIncrease, timeoutCap)
 }
 
 // OnProgressBeforeTimeout indicates to the Controller that progress was made _before_ the timeout was reached
 func (t *Controller) OnProgressBeforeTimeout() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.cfg.ReplicaTimeout = math.Max(t.cfg.ReplicaTimeout*t.cfg.TimeoutDecrease, t.cfg.MinReplicaTimeout)
 }
 
 // BlockRateDelay is a delay to broadcast the proposal in order to control block production rate
 func (t *Controller) BlockRateDelay() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return time.Duration(t.cfg.BlockRateDelayMS * float64(time.Millisecond))
 }
+
+// Config returns the current configuration of the controller, including the current
+// replica timeout.
+func (t *Controller) Config() Config {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.cfg
+}
+
+// UpdateConfig atomically applies the given update to the configuration of the controller at
+// runtime, and returns the resulting configuration. The replica timeout is raised to the new
+// minimum if necessary, and changes take effect when the next timer is started. It returns a
+// model.ConfigurationError if the updated configuration is invalid, in which case the
+// configuration is left unchanged.
+func (t *Controller) UpdateConfig(update func(cfg *Config)) (Config, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cfg := t.cfg
+	update(&cfg)
+	cfg.ReplicaTimeout = math.Min(math.Max(cfg.ReplicaTimeout, cfg.MinReplicaTimeout), timeoutCap)
+	err := cfg.Validate()
+	if err != nil {
+		return t.cfg, err
+	}
+
+	t.cfg = cfg
+	return cfg, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
 )
 
 const (
@@ -167,3 +169,33 @@ func Test_BlockRateDelay(t *testing.T) {
 	tc := NewController(c)
 	assert.Equal(t, time.Second, tc.BlockRateDelay())
 }
+
+// Test_UpdateConfig tests that the configuration can be updated at runtime, and that invalid
+// updates are rejected without changing the configuration
+func Test_UpdateConfig(t *testing.T) {
+	tc, err := NewConfig(
+		time.Duration(minRepTimeout*4*1e6),
+		time.Duration(minRepTimeout*1e6),
+		voteTimeoutFraction,
+		multiplicativeIncrease,
+		multiplicativeDecrease,
+		0)
+	require.NoError(t, err)
+	tos := NewController(tc)
+
+	cfg, err := tos.UpdateConfig(func(cfg *Config) {
+		cfg.BlockRateDelayMS = 500
+		cfg.MinReplicaTimeout = minRepTimeout * 8
+	})
+	require.NoError(t, err)
+	require.Equal(t, 500*time.Millisecond, tos.BlockRateDelay())
+	// the replica timeout is raised to the new minimum
+	require.Equal(t, minRepTimeout*8, cfg.ReplicaTimeout)
+	require.Equal(t, cfg, tos.Config())
+
+	_, err = tos.UpdateConfig(func(cfg *Config) {
+		cfg.TimeoutIncrease = 0.5
+	})
+	require.ErrorAs(t, err, &model.ConfigurationError{})
+	require.Equal(t, cfg, tos.Config())
+}
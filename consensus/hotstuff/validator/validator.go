@@ -8,6 +8,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/module/signature"
 )
 
@@ -43,15 +44,28 @@ func (v *Validator) ValidateQC(qc *flow.QuorumCertificate, block *model.Block) e
 		return newInvalidBlockError(block, fmt.Errorf("qc's View %d doesn't match referenced block's View %d", qc.View, block.View))
 	}
 
+	// decode the qc's signers relative to the canonical committee of the epoch containing the block
+	committee, err := v.committee.CanonicalCommittee(block.BlockID)
+	if err != nil {
+		return fmt.Errorf("could not get canonical committee for block %s: %w", block.BlockID, err)
+	}
+	signerIDs, err := signerindices.DecodeToIdentifiers(committee.NodeIDs(), qc.SignerIndices)
+	if errors.Is(err, signerindices.ErrInvalid) {
+		return newInvalidBlockError(block, fmt.Errorf("qc has invalid signer indices: %w", err))
+	}
+	if err != nil {
+		return fmt.Errorf("could not decode signer indices of qc for block %s: %w", block.BlockID, err)
+	}
+
 	// Retrieve full Identities of all legitimate consensus participants and the Identities of the qc's signers
 	// IdentityList returned by hotstuff.Committee contains only legitimate consensus participants for the specified block (must have positive stake)
 	allParticipants, err := v.committee.Identities(block.BlockID, filter.Any)
 	if err != nil {
 		return fmt.Errorf("could not get consensus participants for block %s: %w", block.BlockID, err)
 	}
-	signers := allParticipants.Filter(filter.HasNodeID(qc.SignerIDs...)) // resulting IdentityList contains no duplicates
-	if len(signers) != len(qc.SignerIDs) {
-		return newInvalidBlockError(block, fmt.Errorf("some qc signers are duplicated or invalid consensus participants at block %x: %w", block.BlockID, model.ErrInvalidSigner))
+	signers := allParticipants.Filter(filter.HasNodeID(signerIDs...)) // decoded signers contain no duplicates
+	if len(signers) != len(signerIDs) {
+		return newInvalidBlockError(block, fmt.Errorf("some qc signers are invalid consensus participants at block %x: %w", block.BlockID, model.ErrInvalidSigner))
 	}
 
 	// determine whether signers reach minimally required stake threshold for consensus
@@ -15,7 +15,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
-	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
 	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -56,9 +56,9 @@ func (ps *ProposalSuite) SetupTest() {
 		helper.WithBlockView(ps.finalized+1),
 		helper.WithBlockProposer(ps.leader.NodeID),
 		helper.WithParentBlock(ps.parent),
-		helper.WithParentSigners(ps.participants.NodeIDs()),
+		helper.WithParentSigners(unittest.SignerIndicesByIdentifiers(ps.participants.NodeIDs(), ps.participants.NodeIDs())),
 	)
-	ps.voters = ps.participants.Filter(filter.HasNodeID(ps.block.QC.SignerIDs...))
+	ps.voters = ps.participants
 	ps.proposal = &model.Proposal{Block: ps.block}
 	ps.vote = ps.proposal.ProposerVote()
 	ps.voter = ps.leader
@@ -66,6 +66,7 @@ func (ps *ProposalSuite) SetupTest() {
 	// set up the mocked hotstuff Committee state
 	ps.committee = &mocks.Committee{}
 	ps.committee.On("LeaderForView", ps.block.View).Return(ps.leader.NodeID, nil)
+	ps.committee.On("CanonicalCommittee", mock.Anything).Return(ps.participants.Sort(order.Canonical), nil)
 	ps.committee.On("Identities", mock.Anything, mock.Anything).Return(
 		func(blockID flow.Identifier, selector flow.IdentityFilter) flow.IdentityList {
 			return ps.participants.Filter(selector)
@@ -351,10 +352,11 @@ func (qs *QCSuite) SetupTest() {
 
 	// create a block that has the signers in its QC
 	qs.block = helper.MakeBlock(qs.T())
-	qs.qc = helper.MakeQC(qs.T(), helper.WithQCBlock(qs.block), helper.WithQCSigners(qs.signers.NodeIDs()))
+	qs.qc = helper.MakeQC(qs.T(), helper.WithQCBlock(qs.block), helper.WithQCSigners(qs.signerIndices(qs.signers)))
 
 	// return the correct participants and identities from view state
 	qs.committee = &mocks.Committee{}
+	qs.committee.On("CanonicalCommittee", mock.Anything).Return(qs.participants.Sort(order.Canonical), nil)
 	qs.committee.On("Identities", mock.Anything, mock.Anything).Return(
 		func(blockID flow.Identifier, selector flow.IdentityFilter) flow.IdentityList {
 			return qs.participants.Filter(selector)
@@ -370,6 +372,11 @@ func (qs *QCSuite) SetupTest() {
 	qs.validator = New(qs.committee, nil, qs.verifier)
 }
 
+// signerIndices encodes the signers relative to the canonically ordered participants
+func (qs *QCSuite) signerIndices(signers flow.IdentityList) []byte {
+	return unittest.SignerIndicesByIdentifiers(qs.participants.NodeIDs(), signers.NodeIDs())
+}
+
 func (qs *QCSuite) TestQCOK() {
 
 	// check the default happy case passes
@@ -385,11 +392,32 @@ func (qs *QCSuite) TestQCInvalidSignersError() {
 	assert.True(qs.T(), model.IsInvalidBlockError(err), "if some signers are invalid consensus participants, an ErrorInvalidBlock error should be raised")
 }
 
+// TestQCInvalidSignerIndices tests that a qc fails validation if:
+// the signer indices are not encoded relative to the canonical committee
+func (qs *QCSuite) TestQCInvalidSignerIndices() {
+	other := unittest.IdentityListFixture(10)
+	qs.qc.SignerIndices = unittest.SignerIndicesByIdentifiers(other.NodeIDs(), other[:7].NodeIDs())
+	err := qs.validator.ValidateQC(qs.qc, qs.block)
+	assert.True(qs.T(), model.IsInvalidBlockError(err), "if the signer indices are invalid, an ErrorInvalidBlock error should be raised")
+}
+
+// TestQCRetrievingCanonicalCommitteeError tests that validation errors if:
+// there is an error retrieving the canonical committee the signer indices are encoded against
+func (qs *QCSuite) TestQCRetrievingCanonicalCommitteeError() {
+	*qs.committee = mocks.Committee{}
+	qs.committee.On("CanonicalCommittee", mock.Anything).Return(nil, errors.New("FATAL internal error"))
+
+	err := qs.validator.ValidateQC(qs.qc, qs.block)
+	assert.Error(qs.T(), err, "unspecific error when retrieving the canonical committee should be escalated to surrounding logic")
+	assert.False(qs.T(), model.IsInvalidBlockError(err), "unspecific internal errors should not result in ErrorInvalidBlock error")
+}
+
 // TestQCRetrievingParticipantsError tests that validation errors if:
 // there is an error retrieving identities of consensus participants
 func (qs *QCSuite) TestQCRetrievingParticipantsError() {
 	// change the hotstuff.Committee to fail on retrieving participants
 	*qs.committee = mocks.Committee{}
+	qs.committee.On("CanonicalCommittee", mock.Anything).Return(qs.participants.Sort(order.Canonical), nil)
 	qs.committee.On("Identities", mock.Anything, mock.Anything).Return(qs.participants, errors.New("FATAL internal error"))
 
 	// verifier should escalate unspecific internal error to surrounding logic, but NOT as ErrorInvalidBlock
@@ -403,7 +431,7 @@ func (qs *QCSuite) TestQCRetrievingParticipantsError() {
 func (qs *QCSuite) TestQCInsufficientStake() {
 	// signers only have stake 6 out of 10 total (NOT have a supermajority)
 	qs.signers = qs.participants[:6]
-	qs.qc = helper.MakeQC(qs.T(), helper.WithQCBlock(qs.block), helper.WithQCSigners(qs.signers.NodeIDs()))
+	qs.qc = helper.MakeQC(qs.T(), helper.WithQCBlock(qs.block), helper.WithQCSigners(qs.signerIndices(qs.signers)))
 
 	// the QC should not be validated anymore
 	err := qs.validator.ValidateQC(qs.qc, qs.block)
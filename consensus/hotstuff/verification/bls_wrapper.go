@@ -1,5 +1,3 @@
-// +build relic
-
 package verification
 
 import "github.com/onflow/flow-go/crypto"
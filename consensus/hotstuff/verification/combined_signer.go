@@ -7,6 +7,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/signature"
 )
@@ -169,12 +170,22 @@ func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate,
 		return nil, fmt.Errorf("could not join signatures: %w", err)
 	}
 
+	// encode the signers relative to the canonical committee
+	committee, err := c.committee.CanonicalCommittee(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get canonical committee: %w", err)
+	}
+	signerIndices, err := signerindices.Encode(committee.NodeIDs(), signerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode signer indices: %w", err)
+	}
+
 	// create the QC
 	qc := &flow.QuorumCertificate{
-		View:      votes[0].View,
-		BlockID:   votes[0].BlockID,
-		SignerIDs: signerIDs,
-		SigData:   combinedMultiSig,
+		View:          votes[0].View,
+		BlockID:       votes[0].BlockID,
+		SignerIndices: signerIndices,
+		SigData:       combinedMultiSig,
 	}
 
 	return qc, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/local"
 	module_mock "github.com/onflow/flow-go/module/mock"
@@ -96,6 +97,12 @@ func MakeHotstuffCommitteeState(t *testing.T, identities flow.IdentityList, beac
 	for _, identity := range identities {
 		committee.On("Identity", mock.Anything, identity.NodeID).Return(identity, nil)
 	}
+	committee.On("CanonicalCommittee", mock.Anything).Return(
+		func(blockID flow.Identifier) flow.IdentityList {
+			return identities.Sort(order.Canonical)
+		},
+		nil,
+	)
 
 	// generate the staking keys
 	var stakingKeys []crypto.PrivateKey
@@ -7,6 +7,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/module"
 )
 
@@ -23,7 +24,7 @@ type SingleSignerVerifier struct {
 func NewSingleSignerVerifier(committee hotstuff.Committee, signer module.AggregatingSigner, signerID flow.Identifier) *SingleSignerVerifier {
 	sc := &SingleSignerVerifier{
 		SingleVerifier: NewSingleVerifier(committee, signer),
-		SingleSigner:   NewSingleSigner(signer, signerID),
+		SingleSigner:   NewSingleSigner(committee, signer, signerID),
 	}
 	return sc
 }
@@ -31,14 +32,18 @@ func NewSingleSignerVerifier(committee hotstuff.Committee, signer module.Aggrega
 // SingleSigner is a signer capable of adding single signatures that can be
 // aggregated to data structures.
 type SingleSigner struct {
-	signer   module.AggregatingSigner
-	signerID flow.Identifier
+	committee hotstuff.Committee
+	signer    module.AggregatingSigner
+	signerID  flow.Identifier
 }
 
-func NewSingleSigner(signer module.AggregatingSigner, signerID flow.Identifier) *SingleSigner {
+// NewSingleSigner creates a new single signer. The committee is used to encode the
+// signers of the QCs created by the signer and may be nil if no QCs are created.
+func NewSingleSigner(committee hotstuff.Committee, signer module.AggregatingSigner, signerID flow.Identifier) *SingleSigner {
 	return &SingleSigner{
-		signer:   signer,
-		signerID: signerID,
+		committee: committee,
+		signer:    signer,
+		signerID:  signerID,
 	}
 }
 
@@ -112,12 +117,22 @@ func (s *SingleSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate, e
 		return nil, fmt.Errorf("could not aggregate signatures: %w", err)
 	}
 
+	// encode the voters relative to the canonical committee
+	committee, err := s.committee.CanonicalCommittee(votes[0].BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get canonical committee: %w", err)
+	}
+	signerIndices, err := signerindices.Encode(committee.NodeIDs(), voterIDs)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode signer indices: %w", err)
+	}
+
 	// create the QC
 	qc := &flow.QuorumCertificate{
-		View:      votes[0].View,
-		BlockID:   votes[0].BlockID,
-		SignerIDs: voterIDs,
-		SigData:   aggSig,
+		View:          votes[0].View,
+		BlockID:       votes[0].BlockID,
+		SignerIndices: signerIndices,
+		SigData:       aggSig,
 	}
 
 	return qc, nil
@@ -1,5 +1,3 @@
-// +build relic
-
 package voteaggregator
 
 import (
@@ -13,6 +11,8 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/model/indices"
 	"github.com/onflow/flow-go/state/protocol"
 
@@ -109,13 +109,15 @@ func (as *AggregatorSuite) SetupTest() {
 			if len(votes) < 1 {
 				return nil
 			}
-			qc := &flow.QuorumCertificate{
-				View:    votes[0].View,
-				BlockID: votes[0].BlockID,
-				SigData: []byte{},
-			}
+			signerIDs := make([]flow.Identifier, 0, len(votes))
 			for _, v := range votes {
-				qc.SignerIDs = append(qc.SignerIDs, v.SignerID)
+				signerIDs = append(signerIDs, v.SignerID)
+			}
+			qc := &flow.QuorumCertificate{
+				View:          votes[0].View,
+				BlockID:       votes[0].BlockID,
+				SignerIndices: unittest.SignerIndicesByIdentifiers(as.participants.NodeIDs(), signerIDs),
+				SigData:       []byte{},
 			}
 			return qc
 		},
@@ -186,7 +188,7 @@ func (as *AggregatorSuite) TestReceiveBlockBeforeInsufficientVotes() {
 func (as *AggregatorSuite) TestReceiveBlockBeforeSufficientVotes() {
 	testView := uint64(5)
 	bp := newMockBlock(as, testView, as.participants[len(as.participants)-1].NodeID)
-	expectedVoters := newExpectedQcContributors()
+	expectedVoters := newExpectedQcContributors(as.participants)
 
 	proposerVote := bp.ProposerVote()
 	expectedVoters.AddVote(proposerVote)
@@ -218,7 +220,7 @@ func (as *AggregatorSuite) TestReceiveBlockBeforeSufficientVotes() {
 func (as *AggregatorSuite) TestReceiveVoteAfterQCBuilt() {
 	testView := uint64(5)
 	bp := newMockBlock(as, testView, as.participants[len(as.participants)-1].NodeID)
-	expectedVoters := newExpectedQcContributors()
+	expectedVoters := newExpectedQcContributors(as.participants)
 
 	proposerVote := bp.ProposerVote()
 	expectedVoters.AddVote(proposerVote)
@@ -300,7 +302,7 @@ func (as *AggregatorSuite) TestReceiveInsufficientVotesBeforeBlock() {
 func (as *AggregatorSuite) TestReceiveSufficientVotesBeforeBlock() {
 	testView := uint64(5)
 	bp := newMockBlock(as, testView, as.participants[len(as.participants)-1].NodeID)
-	expectedVoters := newExpectedQcContributors()
+	expectedVoters := newExpectedQcContributors(as.participants)
 
 	proposerVote := bp.ProposerVote()
 	expectedVoters.AddVote(proposerVote)
@@ -337,7 +339,7 @@ func (as *AggregatorSuite) TestReceiveSufficientVotesBeforeProposal() {
 
 	// the proposal is from the last node
 	bp := newMockBlock(as, testView, as.participants[len(as.participants)-1].NodeID)
-	expectedVoters := newExpectedQcContributors()
+	expectedVoters := newExpectedQcContributors(as.participants)
 
 	// create 3 pending votes from the first 3 nodes, which are different from the last node
 	for i := 0; i < 3; i++ {
@@ -487,7 +489,7 @@ func (as *AggregatorSuite) TestInvalidVotesOnly() {
 func (as *AggregatorSuite) TestVoteMixtureBeforeBlock() {
 	testView := uint64(5)
 	bp := newMockBlock(as, testView, as.participants[len(as.participants)-1].NodeID)
-	expectedVoters := newExpectedQcContributors()
+	expectedVoters := newExpectedQcContributors(as.participants)
 
 	// testing invalid pending votes
 	for i := 0; i < 5; i++ {
@@ -623,7 +625,7 @@ func (as *AggregatorSuite) TestEquivocation_DoubleProposal() {
 func (as *AggregatorSuite) TestVoteOrderAfterBlock() {
 	testView := uint64(5)
 	bp := newMockBlock(as, testView, as.participants[len(as.participants)-1].NodeID)
-	expectedVoters := newExpectedQcContributors()
+	expectedVoters := newExpectedQcContributors(as.participants)
 
 	// the first 4 votes should make it into the QC
 	for i := 0; i < 4; i++ {
@@ -910,7 +912,7 @@ func (as *AggregatorSuite) TestSufficientRBSig() {
 
 	testView := uint64(5)
 	bp := newMockBlock(as, testView, as.participants[0].NodeID)
-	expectedVoters := newExpectedQcContributors()
+	expectedVoters := newExpectedQcContributors(as.participants)
 
 	proposerVote := bp.ProposerVote()
 	expectedVoters.AddVote(proposerVote)
@@ -976,11 +978,13 @@ func (as *AggregatorSuite) qcForBlock(proposal *model.Proposal, expectedQcContri
 }
 
 type expectedQcContributors struct {
+	committee  flow.IdentifierList
 	blockVotes map[flow.Identifier](map[flow.Identifier]struct{})
 }
 
-func newExpectedQcContributors() *expectedQcContributors {
+func newExpectedQcContributors(participants flow.IdentityList) *expectedQcContributors {
 	return &expectedQcContributors{
+		committee:  participants.Sort(order.Canonical).NodeIDs(),
 		blockVotes: make(map[flow.Identifier](map[flow.Identifier]struct{})),
 	}
 }
@@ -1000,11 +1004,16 @@ func (c *expectedQcContributors) HasExpectedVoters(qc *flow.QuorumCertificate) b
 		return false
 	}
 
+	signerIDs, err := signerindices.DecodeToIdentifiers(c.committee, qc.SignerIndices)
+	if err != nil {
+		return false
+	}
+
 	// check set equivalence
-	if len(voters) != len(qc.SignerIDs) {
+	if len(voters) != len(signerIDs) {
 		return false
 	}
-	for _, signer := range qc.SignerIDs {
+	for _, signer := range signerIDs {
 		_, ok := voters[signer]
 		if !ok {
 			return false
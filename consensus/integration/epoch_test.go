@@ -13,6 +13,7 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/flow/mapfunc"
 	"github.com/onflow/flow-go/model/flow/order"
+	clusterstate "github.com/onflow/flow-go/state/cluster"
 	"github.com/onflow/flow-go/state/protocol/inmem"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -173,16 +174,35 @@ func withNextEpoch(snapshot *inmem.Snapshot, nextEpochIdentities flow.IdentityLi
 
 	currEpoch := &encodableSnapshot.Epochs.Current                // take pointer so assignments apply
 	currEpoch.FinalView = currEpoch.FirstView + curEpochViews - 1 // first epoch lasts curEpochViews
+
+	// the root QCs of the clusters must be signed by members of the next epoch's clusters
+	nextCounter := currEpoch.Counter + 1
+	nextClustering := unittest.ClusterList(1, nextEpochIdentities)
+	nextClusters := make([]inmem.EncodableCluster, 0, len(nextClustering))
+	for i, members := range nextClustering {
+		rootBlock := clusterstate.CanonicalRootBlock(nextCounter, members)
+		nextClusters = append(nextClusters, inmem.EncodableCluster{
+			Index:     uint(i),
+			Counter:   nextCounter,
+			Members:   members,
+			RootBlock: rootBlock,
+			RootQC: unittest.QuorumCertificateFixture(
+				unittest.QCWithBlockID(rootBlock.ID()),
+				unittest.QCWithSignerIndices(unittest.SignerIndicesByIdentifiers(members.NodeIDs(), members.NodeIDs())),
+			),
+		})
+	}
+
 	encodableSnapshot.Epochs.Next = &inmem.EncodableEpoch{
-		Counter:           currEpoch.Counter + 1,
+		Counter:           nextCounter,
 		FirstView:         currEpoch.FinalView + 1,
 		FinalView:         currEpoch.FinalView + 1 + 10000,
 		RandomSource:      unittest.SeedFixture(flow.EpochSetupRandomSourceLength),
 		InitialIdentities: nextEpochIdentities,
 		// must include info corresponding to EpochCommit event, since we are
 		// starting in committed phase
-		Clustering: unittest.ClusterList(1, nextEpochIdentities),
-		Clusters:   currEpoch.Clusters,
+		Clustering: nextClustering,
+		Clusters:   nextClusters,
 		DKG: &inmem.EncodableDKG{
 			GroupKey: encodable.RandomBeaconPubKey{
 				PublicKey: unittest.KeyFixture(crypto.BLSBLS12381).PublicKey(),
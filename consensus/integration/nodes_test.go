@@ -91,10 +91,10 @@ func createRootSnapshot(t *testing.T, n int) *inmem.Snapshot {
 
 	root, result, seal := unittest.BootstrapFixture(participants)
 	rootQC := &flow.QuorumCertificate{
-		View:      root.Header.View,
-		BlockID:   root.ID(),
-		SignerIDs: consensus.NodeIDs(), // all participants sign root block
-		SigData:   unittest.CombinedSignatureFixture(2),
+		View:          root.Header.View,
+		BlockID:       root.ID(),
+		SignerIndices: unittest.SignerIndicesByIdentifiers(consensus.NodeIDs(), consensus.NodeIDs()), // all participants sign root block
+		SigData:       unittest.CombinedSignatureFixture(2),
 	}
 
 	rootSnapshot, err := inmem.SnapshotFromBootstrapState(root, result, seal, rootQC)
@@ -192,8 +192,6 @@ func createNode(
 		guarantees, consensusMempools.NewIncorporatedResultSeals(seals, receiptsDB), receipts, tracer)
 	require.NoError(t, err)
 
-	signer := &Signer{identity.ID()}
-
 	// initialize the pending blocks cache
 	cache := buffer.NewPendingBlocks()
 
@@ -207,6 +205,8 @@ func createNode(
 	committee, err := committees.NewConsensusCommittee(state, localID)
 	require.NoError(t, err)
 
+	signer := &Signer{localID: identity.ID(), committee: committee}
+
 	// initialize the block finalizer
 	final := finalizer.NewFinalizer(db, headersDB, fullState, trace.NewNoopTracer())
 
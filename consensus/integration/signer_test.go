@@ -1,12 +1,17 @@
 package integration_test
 
 import (
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 )
 
 type Signer struct {
-	localID flow.Identifier
+	localID   flow.Identifier
+	committee hotstuff.Committee
 }
 
 func (*Signer) CreateProposal(block *model.Block) (*model.Proposal, error) {
@@ -25,16 +30,24 @@ func (s *Signer) CreateVote(block *model.Block) (*model.Vote, error) {
 	}
 	return vote, nil
 }
-func (*Signer) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate, error) {
+func (s *Signer) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate, error) {
 	voterIDs := make([]flow.Identifier, 0, len(votes))
 	for _, vote := range votes {
 		voterIDs = append(voterIDs, vote.SignerID)
 	}
+	canonical, err := s.committee.CanonicalCommittee(votes[0].BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get canonical committee: %w", err)
+	}
+	signerIndices, err := signerindices.Encode(canonical.NodeIDs(), voterIDs)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode signer indices: %w", err)
+	}
 	qc := &flow.QuorumCertificate{
-		View:      votes[0].View,
-		BlockID:   votes[0].BlockID,
-		SignerIDs: voterIDs,
-		SigData:   nil,
+		View:          votes[0].View,
+		BlockID:       votes[0].BlockID,
+		SignerIndices: signerIndices,
+		SigData:       nil,
 	}
 	return qc, nil
 }
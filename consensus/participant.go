@@ -93,21 +93,26 @@ func NewParticipant(
 		return nil, fmt.Errorf("could not recover hotstuff state: %w", err)
 	}
 
-	// initialize the timeout config
-	timeoutConfig, err := timeout.NewConfig(
-		cfg.TimeoutInitial,
-		cfg.TimeoutMinimum,
-		cfg.TimeoutAggregationFraction,
-		cfg.TimeoutIncreaseFactor,
-		cfg.TimeoutDecreaseFactor,
-		cfg.BlockRateDelay,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("could not initialize timeout config: %w", err)
+	// initialize the timeout controller, unless it was provided
+	controller := cfg.TimeoutController
+	if controller == nil {
+		timeoutConfig, err := timeout.NewConfig(
+			cfg.TimeoutInitial,
+			cfg.TimeoutMinimum,
+			cfg.TimeoutAggregationFraction,
+			cfg.TimeoutIncreaseFactor,
+			cfg.TimeoutDecreaseFactor,
+			cfg.BlockRateDelay,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize timeout config: %w", err)
+		}
+		controller = timeout.NewController(timeoutConfig)
 	}
+	metrics.SetMinTimeout(time.Duration(controller.Config().MinReplicaTimeout) * time.Millisecond)
+	metrics.SetBlockRateDelay(controller.BlockRateDelay())
 
 	// initialize the pacemaker
-	controller := timeout.NewController(timeoutConfig)
 	pacemaker, err := pacemaker.New(started+1, controller, notifier)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize flow pacemaker: %w", err)
@@ -1,10 +1,10 @@
-// +build relic
-
 package crypto
 
 // BLS signature scheme implementation using BLS12-381 curve
 // ([zcash]https://electriccoin.co/blog/new-snark-curve/)
-// Pairing, ellipic curve and modular arithmetic is using Relic library.
+// Pairing, ellipic curve and modular arithmetic is using Relic library when the package
+// is built with the `relic` tag, and a pure Go implementation (gnark-crypto) otherwise.
+// Both backends use the same encodings and are interchangeable.
 // This implementation does not include any security against side-channel attacks.
 
 // existing features:
@@ -27,11 +27,6 @@ package crypto
 //  - membership checks G2 using Bowe's method (https://eprint.iacr.org/2019/814.pdf)
 //  - implement a G1/G2 swap (signatures on G2 and public keys on G1)
 
-// #cgo CFLAGS: -g -Wall -std=c99 -I./ -I./relic/build/include
-// #cgo LDFLAGS: -Lrelic/build/lib -l relic_s
-// #include "bls_include.h"
-import "C"
-
 import (
 	"errors"
 	"fmt"
@@ -41,7 +36,7 @@ import (
 
 // blsBLS12381Algo, embeds SignAlgo
 type blsBLS12381Algo struct {
-	// points to Relic context of BLS12-381 with all the parameters
+	// points to the backend context of BLS12-381 with all the parameters
 	context ctx
 	// the signing algo and parameters
 	algo SigningAlgorithm
@@ -103,10 +98,7 @@ func (sk *PrKeyBLSBLS12381) Sign(data []byte, kmac hash.Hasher) (Signature, erro
 	blsInstance.reInit()
 
 	s := make([]byte, SignatureLenBLSBLS12381)
-	C.bls_sign((*C.uchar)(&s[0]),
-		(*C.bn_st)(&sk.scalar),
-		(*C.uchar)(&h[0]),
-		(C.int)(len(h)))
+	blsSign(s, &sk.scalar, h)
 	return s, nil
 }
 
@@ -143,19 +135,7 @@ func (pk *PubKeyBLSBLS12381) Verify(s Signature, data []byte, kmac hash.Hasher)
 	// intialize BLS context
 	blsInstance.reInit()
 
-	verif := C.bls_verify((*C.ep2_st)(&pk.point),
-		(*C.uchar)(&s[0]),
-		(*C.uchar)(&h[0]),
-		(C.int)(len(h)))
-
-	switch verif {
-	case invalid:
-		return false, nil
-	case valid:
-		return true, nil
-	default:
-		return false, fmt.Errorf("signature verification failed")
-	}
+	return blsVerify(&pk.point, s, h)
 }
 
 // generatePrivateKey generates a private key for BLS on BLS12-381 curve.
@@ -188,7 +168,7 @@ const invalidBLSSignatureHeader = byte(0xE0)
 // makes the verification fail early. The verification would return (false, nil).
 func BLSInvalidSignature() Signature {
 	signature := make([]byte, SignatureLenBLSBLS12381)
-	signature[0] = invalidBLSSignatureHeader // invalid header as per C.ep_read_bin_compact
+	signature[0] = invalidBLSSignatureHeader // invalid header as per readPointG1
 	return signature
 }
 
@@ -201,8 +181,9 @@ func (a *blsBLS12381Algo) decodePrivateKey(privateKeyBytes []byte) (PrivateKey,
 	}
 	sk := newPrKeyBLSBLS12381(nil)
 
-	readScalar(&sk.scalar, privateKeyBytes)
-	if C.check_membership_Zr((*C.bn_st)(&sk.scalar)) == valid {
+	// check the scalar is in the range 0 < x < r
+	err := readScalarZr(&sk.scalar, privateKeyBytes)
+	if err == nil && !sk.scalar.isZero() {
 		return sk, nil
 	}
 
@@ -251,7 +232,7 @@ func newPrKeyBLSBLS12381(x *scalar) *PrKeyBLSBLS12381 {
 	var sk PrKeyBLSBLS12381
 	if x == nil {
 		// initialize the scalar
-		C.bn_new_wrapper((*C.bn_st)(&sk.scalar))
+		initScalar(&sk.scalar)
 	} else {
 		// set the scalar
 		sk.scalar = *x
@@ -372,28 +353,33 @@ func (pk *PubKeyBLSBLS12381) String() string {
 	return fmt.Sprintf("%#x", pk.Encode())
 }
 
-// Get Macro definitions from the C layer as Cgo does not export macros
-var signatureLengthBLSBLS12381 = int(C.get_signature_len())
-var pubKeyLengthBLSBLS12381 = int(C.get_pk_len())
-var prKeyLengthBLSBLS12381 = int(C.get_sk_len())
+// initBLS initializes the context of BLS on BLS12-381
+func initBLS() {
+	blsInstance = &blsBLS12381Algo{
+		algo: BLSBLS12381,
+	}
+	if err := blsInstance.init(); err != nil {
+		panic(fmt.Sprintf("initialization of BLS failed: %s", err.Error()))
+	}
+}
 
 // init sets the context of BLS12-381 curve
 func (a *blsBLS12381Algo) init() error {
-	// initializes relic context and sets the B12_381 parameters
+	// initializes the backend context and sets the B12_381 parameters
 	if err := a.context.initContext(); err != nil {
 		return err
 	}
 
-	// compare the Go and C layer constants as a sanity check
+	// compare the package and backend constants as a sanity check
 	if signatureLengthBLSBLS12381 != SignatureLenBLSBLS12381 ||
 		pubKeyLengthBLSBLS12381 != PubKeyLenBLSBLS12381 ||
 		prKeyLengthBLSBLS12381 != PrKeyLenBLSBLS12381 {
-		return errors.New("BLS-12381 length settings in Go and C are not consistent, check hardcoded lengths and compressions")
+		return errors.New("BLS-12381 length settings in Go and the backend are not consistent, check hardcoded lengths and compressions")
 	}
 	return nil
 }
 
-// set the context of BLS 12-381 curve in the lower C and Relic layers assuming the context
+// set the context of BLS 12-381 curve in the backend layers assuming the context
 // was previously initialized with a call to init().
 //
 // If the implementation evolves to support multiple contexts,
@@ -414,45 +400,40 @@ func (a *blsBLS12381Algo) reInit() {
 // multiple verification calls using the same public key.
 func (pk *pointG2) checkValidPublicKeyPoint() bool {
 	// check point is non-infinity
-	verif := C.ep2_is_infty((*C.ep2_st)(pk))
-	if verif != valid {
+	if pk.isInfinity() {
 		return false
 	}
 
 	// membership check in G2
-	verif = C.check_membership_G2((*C.ep2_st)(pk))
-	return verif == valid
+	return checkMembershipG2(pk)
 }
 
-// This is only a TEST/DEBUG/BENCH function.
-// It returns the hash to G1 point from a slice of 128 bytes
-func hashToG1(data []byte) *pointG1 {
-	l := len(data)
-	var h pointG1
-	C.map_to_G1((*C.ep_st)(&h), (*C.uchar)(&data[0]), (C.int)(l))
-	return &h
+// This is only a TEST function.
+// It hashes `data` to a G1 point using the tag `dst` and returns the G1 point serialization.
+// The function uses xmd with SHA256 in the hash-to-field.
+func hashToG1Bytes(data, dst []byte) []byte {
+	hash := expandMsgXMDSHA256(data, dst)
+
+	// map the hash to G1
+	point := hashToG1(hash)
+
+	// serialize the point
+	pointBytes := make([]byte, signatureLengthBLSBLS12381)
+	writePointG1(pointBytes, point)
+	return pointBytes
 }
 
 // This is only a TEST function.
 // signWithXMDSHA256 signs a message using XMD_SHA256 as a hash to field.
 //
-// The function is in this file because cgo can't be used in go test files.
 // TODO: implement a hasher for XMD SHA256 and use the `Sign` function.
 func (sk *PrKeyBLSBLS12381) signWithXMDSHA256(data []byte) Signature {
 
 	dst := []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_")
-	hash := make([]byte, opSwUInputLenBLSBLS12381)
-	// XMD using SHA256
-	C.xmd_sha256((*C.uchar)(&hash[0]),
-		(C.int)(opSwUInputLenBLSBLS12381),
-		(*C.uchar)(&data[0]), (C.int)(len(data)),
-		(*C.uchar)(&dst[0]), (C.int)(len(dst)))
+	hash := expandMsgXMDSHA256(data, dst)
 
 	// sign the hash
 	s := make([]byte, SignatureLenBLSBLS12381)
-	C.bls_sign((*C.uchar)(&s[0]),
-		(*C.bn_st)(&sk.scalar),
-		(*C.uchar)(&hash[0]),
-		(C.int)(len(hash)))
+	blsSign(s, &sk.scalar, hash)
 	return s
 }
@@ -42,9 +42,9 @@ func (ct *ctx) initContext() error {
 	return nil
 }
 
-// seeds the internal relic random function.
+// seedPRG seeds the internal relic random function.
 // relic context must be initialized before seeding.
-func seedRelic(seed []byte) error {
+func seedPRG(seed []byte) error {
 	if len(seed) < (securityBits / 8) {
 		return invalidInputsErrorf(
 			"seed length needs to be larger than %d",
@@ -93,6 +93,16 @@ func (p *pointG2) equals(other *pointG2) bool {
 	return C.ep2_cmp((*C.ep2_st)(p), (*C.ep2_st)(other)) == valid
 }
 
+// initScalar initializes a scalar to zero
+func initScalar(x *scalar) {
+	C.bn_new_wrapper((*C.bn_st)(x))
+}
+
+// isZero returns true if the scalar is zero
+func (x *scalar) isZero() bool {
+	return C.bn_is_zero((*C.bn_st)(x)) == 1
+}
+
 // returns a random number in Zr
 func randZr(x *scalar) {
 	C.bn_randZr((*C.bn_st)(x))
@@ -117,7 +127,7 @@ func mapToZr(x *scalar, src []byte) error {
 	return nil
 }
 
-// writeScalar writes a G2 point in a slice of bytes
+// writeScalar writes a scalar in a slice of bytes
 func writeScalar(dest []byte, x *scalar) {
 	C.bn_write_bin((*C.uchar)(&dest[0]),
 		(C.int)(prKeyLengthBLSBLS12381),
@@ -133,6 +143,26 @@ func readScalar(x *scalar, src []byte) {
 	)
 }
 
+// readScalarZr reads a scalar from a slice of bytes and checks it is
+// a valid Zr element (less than r). The slice length must be PrKeyLenBLSBLS12381.
+func readScalarZr(x *scalar, src []byte) error {
+	if C.bn_read_Zr_bin((*C.bn_st)(x),
+		(*C.uchar)(&src[0]),
+		(C.int)(len(src)),
+	) != valid {
+		return invalidInputsErrorf("input is not a Zr element")
+	}
+	return nil
+}
+
+// sumScalarVector sets res to the sum of the scalars x in Zr
+// (the vector should not be empty)
+func sumScalarVector(res *scalar, x []scalar) {
+	initScalar(res)
+	C.bn_sum_vector((*C.bn_st)(res), (*C.bn_st)(&x[0]),
+		(C.int)(len(x)))
+}
+
 // writePointG2 writes a G2 point in a slice of bytes
 // The slice should be of size PubKeyLenBLSBLS12381 and the serialization will
 // follow the Zcash format specified in draft-irtf-cfrg-pairing-friendly-curves
@@ -185,6 +215,30 @@ func readPointG1(a *pointG1, src []byte) error {
 	}
 }
 
+// isInfinity returns true if the G2 point is the infinity point
+func (p *pointG2) isInfinity() bool {
+	return C.ep2_is_infty((*C.ep2_st)(p)) == 1
+}
+
+// setInfinity sets the G2 point to infinity
+func (p *pointG2) setInfinity() {
+	C.ep2_set_infty((*C.ep2_st)(p))
+}
+
+// sumPointG2Vector sets res to the sum of the G2 points y
+// (the vector should not be empty)
+func sumPointG2Vector(res *pointG2, y []pointG2) {
+	C.ep2_sum_vector((*C.ep2_st)(res), (*C.ep2_st)(&y[0]),
+		(C.int)(len(y)))
+}
+
+// subtractPointG2Vector sets res to the G2 point p minus the sum of the points y
+// (the vector should not be empty)
+func subtractPointG2Vector(res *pointG2, p *pointG2, y []pointG2) {
+	C.ep2_subtract_vector((*C.ep2_st)(res), (*C.ep2_st)(p),
+		(*C.ep2_st)(&y[0]), (C.int)(len(y)))
+}
+
 // This is only a TEST function.
 // It wraps calls to subgroup checks since cgo can't be used
 // in go test files.
@@ -210,22 +264,22 @@ func benchG1Test() {
 }
 
 // This is only a TEST function.
-// It hashes `data` to a G1 point using the tag `dst` and returns the G1 point serialization.
-// The function uses xmd with SHA256 in the hash-to-field.
-func hashToG1Bytes(data, dst []byte) []byte {
+// It expands `data` into opSwUInputLenBLSBLS12381 bytes using xmd with SHA256
+// and the tag `dst`.
+func expandMsgXMDSHA256(data, dst []byte) []byte {
 	hash := make([]byte, opSwUInputLenBLSBLS12381)
-	// XMD using SHA256
 	C.xmd_sha256((*C.uchar)(&hash[0]),
 		(C.int)(opSwUInputLenBLSBLS12381),
 		(*C.uchar)(&data[0]), (C.int)(len(data)),
 		(*C.uchar)(&dst[0]), (C.int)(len(dst)))
+	return hash
+}
 
-	// map the hash to G1
-	var point pointG1
-	C.map_to_G1((*C.ep_st)(&point), (*C.uchar)(&hash[0]), (C.int)(len(hash)))
-
-	// serialize the point
-	pointBytes := make([]byte, signatureLengthBLSBLS12381)
-	writePointG1(pointBytes, &point)
-	return pointBytes
+// This is only a TEST/DEBUG/BENCH function.
+// It returns the hash to G1 point from a slice of 128 bytes
+func hashToG1(data []byte) *pointG1 {
+	l := len(data)
+	var h pointG1
+	C.map_to_G1((*C.ep_st)(&h), (*C.uchar)(&data[0]), (C.int)(l))
+	return &h
 }
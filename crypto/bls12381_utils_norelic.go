@@ -0,0 +1,451 @@
+// +build !relic
+
+package crypto
+
+// this file contains utility functions for the curve BLS 12-381
+// these tools are shared by the BLS signature scheme, the BLS based threshold signature
+// and the BLS distributed key generation protcols.
+// This is the pure Go backend (gnark-crypto), used when the package is built without
+// the `relic` tag. Encodings and results are identical to the Relic backend.
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+// Go wrappers to gnark-crypto types
+// points are stored in affine coordinates, the infinity point being (0,0)
+type pointG1 bls12381.G1Affine
+type pointG2 bls12381.G2Affine
+type scalar fr.Element
+
+// context required for the BLS set-up
+// (the Go backend does not require any precomputed data)
+type ctx struct{}
+
+// the backend PRG is global, as the Relic PRG is.
+// It is used to generate random scalars (BLS keys, threshold and DKG polynomials).
+var prg struct {
+	sync.Mutex
+	xof sha3.ShakeHash
+}
+
+// initContext seeds the backend PRG with a fresh random seed
+func (ct *ctx) initContext() error {
+	seed := make([]byte, securityBits/8)
+	if _, err := rand.Read(seed); err != nil {
+		return err
+	}
+	return seedPRG(seed)
+}
+
+// seedPRG seeds the backend random function.
+func seedPRG(seed []byte) error {
+	if len(seed) < (securityBits / 8) {
+		return invalidInputsErrorf(
+			"seed length needs to be larger than %d",
+			securityBits/8)
+	}
+	if len(seed) > maxRelicPrgSeed {
+		return invalidInputsErrorf(
+			"seed length needs to be less than %x",
+			maxRelicPrgSeed)
+	}
+	prg.Lock()
+	defer prg.Unlock()
+	prg.xof = sha3.NewShake256()
+	_, _ = prg.xof.Write(seed)
+	return nil
+}
+
+// readPRG fills dest with bytes from the backend PRG
+func readPRG(dest []byte) {
+	prg.Lock()
+	defer prg.Unlock()
+	_, _ = prg.xof.Read(dest)
+}
+
+// setContext is a no-op for the Go backend as the context is not shared
+// with any other library.
+func (ct *ctx) setContext() {}
+
+// r is the order of G1 and G2
+var frOrder = fr.Modulus()
+
+// bigInt returns the regular (non Montgomery) value of a scalar
+func (x *scalar) bigInt() *big.Int {
+	var res big.Int
+	(*fr.Element)(x).ToBigIntRegular(&res)
+	return &res
+}
+
+// Exponentiation in G1 (scalar point multiplication)
+func (p *pointG1) scalarMultG1(res *pointG1, expo *scalar) {
+	(*bls12381.G1Affine)(res).ScalarMultiplication((*bls12381.G1Affine)(p), expo.bigInt())
+}
+
+// This function is for TEST only
+// Exponentiation of g1 in G1
+func genScalarMultG1(res *pointG1, expo *scalar) {
+	_, _, g1, _ := bls12381.Generators()
+	(*bls12381.G1Affine)(res).ScalarMultiplication(&g1, expo.bigInt())
+}
+
+// Exponentiation of g2 in G2
+func genScalarMultG2(res *pointG2, expo *scalar) {
+	_, _, _, g2 := bls12381.Generators()
+	(*bls12381.G2Affine)(res).ScalarMultiplication(&g2, expo.bigInt())
+}
+
+// comparison in Zr where r is the group order of G1/G2
+// (both scalars should be reduced mod r)
+func (x *scalar) equals(other *scalar) bool {
+	return (*fr.Element)(x).Equal((*fr.Element)(other))
+}
+
+// comparison in G2
+func (p *pointG2) equals(other *pointG2) bool {
+	return (*bls12381.G2Affine)(p).Equal((*bls12381.G2Affine)(other))
+}
+
+// initScalar initializes a scalar to zero
+func initScalar(x *scalar) {
+	(*fr.Element)(x).SetZero()
+}
+
+// isZero returns true if the scalar is zero
+func (x *scalar) isZero() bool {
+	return (*fr.Element)(x).IsZero()
+}
+
+// randZrBytes is the number of random bytes reduced modulo r
+// to get a statistically uniform element in Zr
+const randZrBytes = (fr.Bits + securityBits + 7) / 8
+
+// returns a random number in Zr
+func randZr(x *scalar) {
+	buf := make([]byte, randZrBytes)
+	readPRG(buf)
+	(*fr.Element)(x).SetBigInt(new(big.Int).SetBytes(buf))
+}
+
+// returns a random non-zero number in Zr
+func randZrStar(x *scalar) {
+	buf := make([]byte, randZrBytes)
+	readPRG(buf)
+	_ = mapToZr(x, buf)
+}
+
+// mapToZr reads a scalar from a slice of bytes and maps it to Zr
+// the resulting scalar is in the range 0 < k < r
+func mapToZr(x *scalar, src []byte) error {
+	if len(src) > maxScalarSize {
+		return invalidInputsErrorf(
+			"input slice length must be less than %d",
+			maxScalarSize)
+	}
+	// k = (src mod (r-1)) + 1
+	rMinusOne := new(big.Int).Sub(frOrder, big.NewInt(1))
+	k := new(big.Int).SetBytes(src)
+	k.Mod(k, rMinusOne).Add(k, big.NewInt(1))
+	(*fr.Element)(x).SetBigInt(k)
+	return nil
+}
+
+// writeScalar writes a scalar in a slice of bytes
+func writeScalar(dest []byte, x *scalar) {
+	b := (*fr.Element)(x).Bytes()
+	copy(dest, b[:])
+}
+
+// readScalar reads a scalar from a slice of bytes
+// (the scalar is reduced modulo r)
+func readScalar(x *scalar, src []byte) {
+	(*fr.Element)(x).SetBigInt(new(big.Int).SetBytes(src))
+}
+
+// readScalarZr reads a scalar from a slice of bytes and checks it is
+// a valid Zr element (less than r). The slice length must be PrKeyLenBLSBLS12381.
+func readScalarZr(x *scalar, src []byte) error {
+	k := new(big.Int).SetBytes(src)
+	if len(src) != PrKeyLenBLSBLS12381 || k.Cmp(frOrder) >= 0 {
+		return invalidInputsErrorf("input is not a Zr element")
+	}
+	(*fr.Element)(x).SetBigInt(k)
+	return nil
+}
+
+// sumScalarVector sets res to the sum of the scalars x in Zr
+// (the vector should not be empty)
+func sumScalarVector(res *scalar, x []scalar) {
+	initScalar(res)
+	for i := range x {
+		(*fr.Element)(res).Add((*fr.Element)(res), (*fr.Element)(&x[i]))
+	}
+}
+
+// writePointG2 writes a G2 point in a slice of bytes
+// The slice should be of size PubKeyLenBLSBLS12381 and the serialization will
+// follow the Zcash format specified in draft-irtf-cfrg-pairing-friendly-curves
+func writePointG2(dest []byte, a *pointG2) {
+	b := (*bls12381.G2Affine)(a).Bytes()
+	copy(dest, b[:])
+}
+
+// writePointG1 writes a G1 point in a slice of bytes
+// The slice should be of size SignatureLenBLSBLS12381 and the serialization will
+// follow the Zcash format specified in draft-irtf-cfrg-pairing-friendly-curves
+func writePointG1(dest []byte, a *pointG1) {
+	b := (*bls12381.G1Affine)(a).Bytes()
+	copy(dest, b[:])
+}
+
+// Zcash serialization header bits
+const (
+	serializationCompressedBit = 0x80
+	serializationInfinityBit   = 0x40
+	serializationHeaderMask    = 0xE0
+	// bits that must be zero in the first byte of the infinity encoding
+	serializationInfinityMask = 0x3F
+)
+
+// checkZcashEncoding checks the header and the field elements of a compressed
+// point encoding, before the point is decompressed.
+// It mirrors the checks of the Relic backend:
+//  - the compression bit must be set.
+//  - the infinity encoding must be zero apart from the header (the last byte is not checked).
+//  - each serialized coordinate must be less than p.
+func checkZcashEncoding(src []byte) bool {
+	if src[0]&serializationCompressedBit == 0 {
+		return false
+	}
+	if src[0]&serializationInfinityBit != 0 {
+		if src[0]&serializationInfinityMask != 0 {
+			return false
+		}
+		for i := 1; i < len(src)-1; i++ {
+			if src[i] != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	p := fp.Modulus()
+	x := make([]byte, len(src))
+	copy(x, src)
+	x[0] &= ^byte(serializationHeaderMask)
+	for i := 0; i < len(x); i += fieldSize {
+		if new(big.Int).SetBytes(x[i:i+fieldSize]).Cmp(p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isInfinityEncoding returns true if the encoding has the infinity bit set
+// (the encoding is assumed to be checked by checkZcashEncoding)
+func isInfinityEncoding(src []byte) bool {
+	return src[0]&serializationInfinityBit != 0
+}
+
+// readPointG2 reads a G2 point from a slice of bytes
+// The slice is expected to be of size PubKeyLenBLSBLS12381 and the deserialization will
+// follow the Zcash format specified in draft-irtf-cfrg-pairing-friendly-curves
+func readPointG2(a *pointG2, src []byte) error {
+	if len(src) != PubKeyLenBLSBLS12381 || !checkZcashEncoding(src) {
+		return invalidInputsErrorf("input is not a G2 point")
+	}
+	if isInfinityEncoding(src) {
+		a.setInfinity()
+		return nil
+	}
+	// the membership check in G2 is not part of the deserialization
+	dec := bls12381.NewDecoder(bytes.NewReader(src), bls12381.NoSubgroupChecks())
+	if err := dec.Decode((*bls12381.G2Affine)(a)); err != nil {
+		return invalidInputsErrorf("input is not a G2 point")
+	}
+	return nil
+}
+
+// readPointG1 reads a G1 point from a slice of bytes
+// The slice should be of size SignatureLenBLSBLS12381 and the deserialization will
+// follow the Zcash format specified in draft-irtf-cfrg-pairing-friendly-curves
+func readPointG1(a *pointG1, src []byte) error {
+	if len(src) != SignatureLenBLSBLS12381 || !checkZcashEncoding(src) {
+		return invalidInputsErrorf("input is not a G1 point")
+	}
+	if isInfinityEncoding(src) {
+		*a = pointG1{}
+		return nil
+	}
+	// the membership check in G1 is not part of the deserialization
+	dec := bls12381.NewDecoder(bytes.NewReader(src), bls12381.NoSubgroupChecks())
+	if err := dec.Decode((*bls12381.G1Affine)(a)); err != nil {
+		return invalidInputsErrorf("input is not a G1 point")
+	}
+	return nil
+}
+
+// isInfinity returns true if the G2 point is the infinity point
+func (p *pointG2) isInfinity() bool {
+	return (*bls12381.G2Affine)(p).IsInfinity()
+}
+
+// setInfinity sets the G2 point to infinity
+func (p *pointG2) setInfinity() {
+	*p = pointG2{}
+}
+
+// sumPointG2Vector sets res to the sum of the G2 points y
+// (the vector should not be empty)
+func sumPointG2Vector(res *pointG2, y []pointG2) {
+	var sum, tmp bls12381.G2Jac
+	sum.FromAffine((*bls12381.G2Affine)(&y[0]))
+	for i := 1; i < len(y); i++ {
+		tmp.FromAffine((*bls12381.G2Affine)(&y[i]))
+		sum.AddAssign(&tmp)
+	}
+	(*bls12381.G2Affine)(res).FromJacobian(&sum)
+}
+
+// subtractPointG2Vector sets res to the G2 point p minus the sum of the points y
+// (the vector should not be empty)
+func subtractPointG2Vector(res *pointG2, p *pointG2, y []pointG2) {
+	var sum pointG2
+	sumPointG2Vector(&sum, y)
+	(*bls12381.G2Affine)(res).Sub((*bls12381.G2Affine)(p), (*bls12381.G2Affine)(&sum))
+}
+
+// checkMembershipG1 returns true if the point is on the curve E1
+// and is in the subgroup G1.
+func checkMembershipG1(p *pointG1) bool {
+	pt := (*bls12381.G1Affine)(p)
+	return pt.IsOnCurve() && pt.IsInSubGroup()
+}
+
+// checkMembershipG2 returns true if the point is on the curve E2
+// and is in the subgroup G2.
+func checkMembershipG2(p *pointG2) bool {
+	pt := (*bls12381.G2Affine)(p)
+	return pt.IsOnCurve() && pt.IsInSubGroup()
+}
+
+// checkMembershipG1Simple returns true if the point is on the curve E1
+// and is in the subgroup G1, using a scalar multiplication by the group order.
+func checkMembershipG1Simple(p *pointG1) bool {
+	pt := (*bls12381.G1Affine)(p)
+	if !pt.IsOnCurve() {
+		return false
+	}
+	// the scalar multiplication is computed with a plain double-and-add since
+	// the GLV-based multiplication of gnark-crypto is only correct for points in G1
+	var base, res bls12381.G1Jac
+	base.FromAffine(pt)
+	for i := frOrder.BitLen() - 1; i >= 0; i-- {
+		res.DoubleAssign()
+		if frOrder.Bit(i) == 1 {
+			res.AddAssign(&base)
+		}
+	}
+	return res.Z.IsZero()
+}
+
+// randPointG1Test returns a random point in G1 if inG1 is true,
+// and a random point in E1\G1 otherwise.
+func randPointG1Test(inG1 bool) *pointG1 {
+	buf := make([]byte, opSwUInputLenBLSBLS12381)
+	readPRG(buf)
+	if inG1 {
+		return hashToG1(buf)
+	}
+	// a point on E1 without clearing the cofactor is not in G1 with overwhelming probability
+	var u fp.Element
+	u.SetBigInt(new(big.Int).SetBytes(buf[:opSwUInputLenBLSBLS12381/2]))
+	p := sswuMapG1(&u)
+	return (*pointG1)(&p)
+}
+
+// This is only a TEST function.
+// It wraps calls to subgroup checks for the tests to be shared
+// with the Relic backend.
+// if inG1 is true, the function tests the membership of a point in G1,
+// otherwise, a point in E1\G1 membership is tested.
+// method is the index of the membership check method (0 for the simple
+// scalar multiplication check, 1 for the endomorphism-based check)
+func checkG1Test(inG1 int, method int) bool {
+	p := randPointG1Test(inG1 == 1)
+	if method == 0 {
+		return checkMembershipG1Simple(p)
+	}
+	return checkMembershipG1(p)
+}
+
+// This is only a TEST function.
+// It wraps a call to a subgroup check in G1 for the tests to be shared
+// with the Relic backend.
+func checkInG1Test(pt *pointG1) bool {
+	return checkMembershipG1(pt)
+}
+
+// This is only a TEST function.
+// It wraps calls to subgroup checks for the tests to be shared
+// with the Relic backend.
+func benchG1Test() {
+	_ = checkMembershipG1(randPointG1Test(true))
+}
+
+// This is only a TEST function.
+// It expands `data` into opSwUInputLenBLSBLS12381 bytes using xmd with SHA256
+// and the tag `dst`.
+func expandMsgXMDSHA256(data, dst []byte) []byte {
+	// expand_message_xmd as per draft-irtf-cfrg-hash-to-curve section 5.4.1
+	const bInBytes = sha256.Size
+	const rInBytes = sha256.BlockSize
+	ell := (opSwUInputLenBLSBLS12381 + bInBytes - 1) / bInBytes
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	h := sha256.New()
+	h.Write(make([]byte, rInBytes))
+	h.Write(data)
+	h.Write([]byte{byte(opSwUInputLenBLSBLS12381 >> 8), byte(opSwUInputLenBLSBLS12381), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	hash := make([]byte, 0, ell*bInBytes)
+	hash = append(hash, bi...)
+	xor := make([]byte, bInBytes)
+	for i := 2; i <= ell; i++ {
+		for j := range b0 {
+			xor[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(xor)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		hash = append(hash, bi...)
+	}
+	return hash[:opSwUInputLenBLSBLS12381]
+}
+
+// This is only a TEST/DEBUG/BENCH function.
+// It returns the hash to G1 point from a slice of 128 bytes
+func hashToG1(data []byte) *pointG1 {
+	var h pointG1
+	mapToG1(&h, data)
+	return &h
+}
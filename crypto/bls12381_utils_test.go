@@ -1,5 +1,3 @@
-// +build relic
-
 package crypto
 
 import (
@@ -24,7 +22,7 @@ func TestDeterministicKeyGen(t *testing.T) {
 	assert.True(t, sk1.Equals(sk2), "private keys should be equal")
 }
 
-// test the deterministicity of the relic PRG (used by the DKG polynomials)
+// test the deterministicity of the backend PRG (used by the DKG polynomials)
 func TestPRGseeding(t *testing.T) {
 	blsInstance.reInit()
 	// 2 scalars generated with the same seed should be equal
@@ -33,12 +31,12 @@ func TestPRGseeding(t *testing.T) {
 	require.Equal(t, n, KeyGenSeedMinLenBLSBLS12381)
 	require.NoError(t, err)
 	// 1st scalar (wrapped in a private key)
-	err = seedRelic(seed)
+	err = seedPRG(seed)
 	require.Nil(t, err)
 	var sk1 PrKeyBLSBLS12381
 	randZr(&sk1.scalar)
 	// 2nd scalar (wrapped in a private key)
-	err = seedRelic(seed)
+	err = seedPRG(seed)
 	require.Nil(t, err)
 	var sk2 PrKeyBLSBLS12381
 	randZr(&sk2.scalar)
@@ -51,7 +49,7 @@ func BenchmarkScalarMult(b *testing.B) {
 	blsInstance.reInit()
 	seed := make([]byte, securityBits/8)
 	rand.Read(seed)
-	seedRelic(seed)
+	seedPRG(seed)
 	var expo scalar
 	randZr(&expo)
 
@@ -125,10 +123,10 @@ func BenchmarkHashToG1(b *testing.B) {
 // The test compares Bowe's check result to multiplying by the group order
 func TestSubgroupCheckG1(t *testing.T) {
 	blsInstance.reInit()
-	// seed Relic PRG
+	// seed the backend PRG
 	seed := make([]byte, securityBits/8)
 	rand.Read(seed)
-	seedRelic(seed)
+	seedPRG(seed)
 
 	// tests for simple membership check
 	t.Run("simple check", func(t *testing.T) {
@@ -1,4 +1,4 @@
-// +build relic, blst
+// +build blst
 
 package crypto
 
@@ -112,7 +112,7 @@ func testEncodeDecodePublicKeyCrossBLST(t *rapid.T) {
 	// check decoding results are consistent
 	pkFlow, err := DecodePublicKey(BLSBLS12381, pkBytes)
 	var pkBLST blst.P2Affine
-	res := pkBLST.Deserialize(pkBytes)
+	res := pkBLST.Uncompress(pkBytes)
 	pkValidBLST := pkBLST.KeyValidate()
 
 	flowPass := err == nil
@@ -1,5 +1,3 @@
-// +build relic
-
 package crypto
 
 import (
@@ -10,7 +8,7 @@ import (
 
 // BLS multi-signature using BLS12-381 curve
 // ([zcash]https://github.com/zkcrypto/pairing/blob/master/src/bls12_381/README.md#bls12-381)
-// Pairing, ellipic curve and modular arithmetic is using Relic library.
+// Pairing, ellipic curve and modular arithmetic is using the backend in bls.go.
 // This implementation does not include any security against side-channel attacks.
 
 // existing features:
@@ -25,11 +23,6 @@ import (
 //  - batch verification of multiple signatures of a single message under multiple
 //  public keys: use a binary tree of aggregations to find the invalid signatures.
 
-// #cgo CFLAGS: -g -Wall -std=c99 -I./ -I./relic/build/include
-// #cgo LDFLAGS: -Lrelic/build/lib -l relic_s
-// #include "bls_include.h"
-import "C"
-
 // prefix for all application tags (any non PoP tag)
 const applicationTagPrefix = "APP"
 
@@ -81,32 +74,20 @@ func AggregateBLSSignatures(sigs []Signature) (Signature, error) {
 		return nil, invalidInputsErrorf("signature list should not be empty")
 	}
 
-	// flatten the shares (required by the C layer)
-	flatSigs := make([]byte, 0, signatureLengthBLSBLS12381*len(sigs))
 	for i, sig := range sigs {
 		if len(sig) != signatureLengthBLSBLS12381 {
 			return nil, invalidInputsErrorf(
 				"signature at index %d has an invalid length, %d is expected, got %d",
 				i, signatureLengthBLSBLS12381, len(sig))
 		}
-		flatSigs = append(flatSigs, sig...)
 	}
 	aggregatedSig := make([]byte, signatureLengthBLSBLS12381)
 
-	// add the points in the C layer
-	result := C.ep_sum_vector_byte(
-		(*C.uchar)(&aggregatedSig[0]),
-		(*C.uchar)(&flatSigs[0]),
-		(C.int)(len(sigs)))
-
-	switch result {
-	case valid:
-		return aggregatedSig, nil
-	case invalid:
-		return nil, invalidInputsErrorf("decoding at least one BLS signatures failed")
-	default:
-		return nil, fmt.Errorf("aggregating signatures failed")
+	// add the points in the backend
+	if err := sumSignatureVector(aggregatedSig, sigs); err != nil {
+		return nil, err
 	}
+	return aggregatedSig, nil
 }
 
 // AggregateBLSPrivateKeys aggregate multiple BLS private keys into one.
@@ -133,9 +114,7 @@ func AggregateBLSPrivateKeys(keys []PrivateKey) (PrivateKey, error) {
 	}
 
 	var sum scalar
-	C.bn_new_wrapper((*C.bn_st)(&sum))
-	C.bn_sum_vector((*C.bn_st)(&sum), (*C.bn_st)(&scalars[0]),
-		(C.int)(len(scalars)))
+	sumScalarVector(&sum, scalars)
 	return newPrKeyBLSBLS12381(&sum), nil
 }
 
@@ -163,8 +142,7 @@ func AggregateBLSPublicKeys(keys []PublicKey) (PublicKey, error) {
 	}
 
 	var sum pointG2
-	C.ep2_sum_vector((*C.ep2_st)(&sum), (*C.ep2_st)(&points[0]),
-		(C.int)(len(points)))
+	sumPointG2Vector(&sum, points)
 	return newPubKeyBLSBLS12381(&sum), nil
 }
 
@@ -174,7 +152,7 @@ func NeutralBLSPublicKey() PublicKey {
 
 	neutralPk := *newPubKeyBLSBLS12381(nil)
 	// set the point to infinity
-	C.ep2_set_infty((*C.ep2_st)(&neutralPk.point))
+	neutralPk.point.setInfinity()
 	return &neutralPk
 }
 
@@ -210,8 +188,7 @@ func RemoveBLSPublicKeys(aggKey PublicKey, keysToRemove []PublicKey) (PublicKey,
 	}
 
 	var resultKey pointG2
-	C.ep2_subtract_vector((*C.ep2_st)(&resultKey), (*C.ep2_st)(&aggPKBLS.point),
-		(*C.ep2_st)(&pointsToSubtract[0]), (C.int)(len(pointsToSubtract)))
+	subtractPointG2Vector(&resultKey, &aggPKBLS.point, pointsToSubtract)
 
 	return newPubKeyBLSBLS12381(&resultKey), nil
 }
@@ -301,7 +278,7 @@ func VerifyBLSSignatureManyMessages(pks []PublicKey, s Signature,
 	// the verification equation.
 	mapPerHash := make(map[string][]pointG2)
 	mapPerPk := make(map[pointG2][][]byte)
-	// Note: mapPerPk is using a cgo structure as map keys which may lead to 2 equal public keys
+	// Note: mapPerPk is using a backend structure as map keys which may lead to 2 equal public keys
 	// being considered distinct. This does not make the verification equation wrong but leads to
 	// computing extra pairings. This case is considered unlikely to happen since a caller is likely
 	// to use the same struct for a same public key.
@@ -322,63 +299,15 @@ func VerifyBLSSignatureManyMessages(pks []PublicKey, s Signature,
 		mapPerPk[pkBLS.point] = append(mapPerPk[pkBLS.point], hashes[i])
 	}
 
-	var verif (C.int)
 	//compare the 2 maps for the shortest length
 	if len(mapPerHash) < len(mapPerPk) {
 		// aggregate keys per distinct hashes
 		// using the linearity of the pairing on the G2 variables.
-		flatDistinctHashes := make([]byte, 0)
-		lenHashes := make([]uint32, 0)
-		pkPerHash := make([]uint32, 0, len(mapPerHash))
-		allPks := make([]pointG2, 0)
-		for hash, pksVal := range mapPerHash {
-			flatDistinctHashes = append(flatDistinctHashes, []byte(hash)...)
-			lenHashes = append(lenHashes, uint32(len([]byte(hash))))
-			pkPerHash = append(pkPerHash, uint32(len(pksVal)))
-			allPks = append(allPks, pksVal...)
-		}
-		verif = C.bls_verifyPerDistinctMessage(
-			(*C.uchar)(&s[0]),
-			(C.int)(len(mapPerHash)),
-			(*C.uchar)(&flatDistinctHashes[0]),
-			(*C.uint32_t)(&lenHashes[0]),
-			(*C.uint32_t)(&pkPerHash[0]),
-			(*C.ep2_st)(&allPks[0]),
-		)
-
-	} else {
-		// aggregate hashes per distinct key
-		// using the linearity of the pairing on the G1 variables.
-		distinctPks := make([]pointG2, 0, len(mapPerPk))
-		hashPerPk := make([]uint32, 0, len(mapPerPk))
-		flatHashes := make([]byte, 0)
-		lenHashes := make([]uint32, 0)
-		for pk, hashesVal := range mapPerPk {
-			distinctPks = append(distinctPks, pk)
-			hashPerPk = append(hashPerPk, uint32(len(hashesVal)))
-			for _, h := range hashesVal {
-				flatHashes = append(flatHashes, h...)
-				lenHashes = append(lenHashes, uint32(len(h)))
-			}
-		}
-
-		verif = C.bls_verifyPerDistinctKey(
-			(*C.uchar)(&s[0]),
-			(C.int)(len(mapPerPk)),
-			(*C.ep2_st)(&distinctPks[0]),
-			(*C.uint32_t)(&hashPerPk[0]),
-			(*C.uchar)(&flatHashes[0]),
-			(*C.uint32_t)(&lenHashes[0]))
-	}
-
-	switch verif {
-	case invalid:
-		return false, nil
-	case valid:
-		return true, nil
-	default:
-		return false, fmt.Errorf("signature verification failed")
+		return blsVerifyPerDistinctMessage(s, mapPerHash)
 	}
+	// aggregate hashes per distinct key
+	// using the linearity of the pairing on the G1 variables.
+	return blsVerifyPerDistinctKey(s, mapPerPk)
 }
 
 // BatchVerifyBLSSignaturesOneMessage is a batch verification of multiple
@@ -434,40 +363,25 @@ func BatchVerifyBLSSignaturesOneMessage(pks []PublicKey, sigs []Signature,
 		pkPoints = append(pkPoints, pkBLS.point)
 	}
 
-	// flatten the shares (required by the C layer)
-	flatSigs := make([]byte, 0, signatureLengthBLSBLS12381*len(sigs))
 	// an invalid signature with an incorrect header but correct length
-	invalidSig := make([]byte, signatureLengthBLSBLS12381)
-	invalidSig[0] = invalidBLSSignatureHeader // incorrect header
+	invalidSig := BLSInvalidSignature()
+	checkedSigs := make([]Signature, 0, len(sigs))
 	for _, sig := range sigs {
 		if len(sig) == signatureLengthBLSBLS12381 {
-			flatSigs = append(flatSigs, sig...)
+			checkedSigs = append(checkedSigs, sig)
 		} else {
-			// if the signature length is invalid, replace it by an invalid array
-			// that fails the deserialization in C.ep_read_bin_compact
-			flatSigs = append(flatSigs, invalidSig...)
+			// if the signature length is invalid, replace it by an invalid signature
+			// that fails the deserialization in readPointG1
+			checkedSigs = append(checkedSigs, invalidSig)
 		}
 	}
 
 	// hash the input to 128 bytes
 	h := kmac.ComputeHash(message)
-	verifInt := make([]byte, len(verifBool))
-
-	C.bls_batchVerify(
-		(C.int)(len(verifInt)),
-		(*C.uchar)(&verifInt[0]),
-		(*C.ep2_st)(&pkPoints[0]),
-		(*C.uchar)(&flatSigs[0]),
-		(*C.uchar)(&h[0]),
-		(C.int)(len(h)),
-	)
-
-	for i, v := range verifInt {
-		if (C.int)(v) != valid && (C.int)(v) != invalid {
-			return verifBool, fmt.Errorf("batch verification failed")
-		}
-		verifBool[i] = ((C.int)(v) == valid)
-	}
 
+	err := blsBatchVerify(verifBool, pkPoints, checkedSigs, h)
+	if err != nil {
+		return verifBool, err
+	}
 	return verifBool, nil
 }
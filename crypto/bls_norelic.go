@@ -0,0 +1,307 @@
+// +build !relic
+
+package crypto
+
+// this file contains the Go backend of the BLS signature scheme,
+// the BLS multi-signature and the BLS-based SPoCK.
+// The pairing and the curve arithmetic are implemented by gnark-crypto.
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// lengths are constants in the Go backend
+var signatureLengthBLSBLS12381 = SignatureLenBLSBLS12381
+var pubKeyLengthBLSBLS12381 = PubKeyLenBLSBLS12381
+var prKeyLengthBLSBLS12381 = PrKeyLenBLSBLS12381
+
+// negated generator of G2
+var g2Neg = func() bls12381.G2Affine {
+	_, _, _, g2 := bls12381.Generators()
+	g2.Neg(&g2)
+	return g2
+}()
+
+// blsSign computes the BLS signature of the hash h under the private scalar sk
+// and writes it in s.
+// The slice s should be of size SignatureLenBLSBLS12381.
+func blsSign(s []byte, sk *scalar, h []byte) {
+	var hPoint, sPoint pointG1
+	mapToG1(&hPoint, h)
+	hPoint.scalarMultG1(&sPoint, sk)
+	writePointG1(s, &sPoint)
+}
+
+// readSignatureG1 deserializes a signature and checks its membership in G1.
+// It returns false if the signature is not a valid G1 point.
+func readSignatureG1(s *pointG1, sig []byte) bool {
+	if err := readPointG1(s, sig); err != nil {
+		return false
+	}
+	return checkMembershipG1(s)
+}
+
+// pairingCheck returns true if the product of the pairings e(p[i], q[i]) is equal to 1
+func pairingCheck(p []bls12381.G1Affine, q []bls12381.G2Affine) (bool, error) {
+	res, err := bls12381.PairingCheck(p, q)
+	if err != nil {
+		return false, fmt.Errorf("pairing computation failed: %w", err)
+	}
+	return res, nil
+}
+
+// blsVerifyPoint verifies the signature point s of the hash h against the public key pk.
+// The signature and public key are assumed to be in G1 and G2 respectively.
+// This function only checks the pairing equality e(s, g2) = e(H(h), pk).
+func blsVerifyPoint(pk *pointG2, s *pointG1, h []byte) (bool, error) {
+	var hPoint pointG1
+	mapToG1(&hPoint, h)
+	return pairingCheck(
+		[]bls12381.G1Affine{bls12381.G1Affine(*s), bls12381.G1Affine(hPoint)},
+		[]bls12381.G2Affine{g2Neg, bls12381.G2Affine(*pk)},
+	)
+}
+
+// blsVerify verifies the signature s of the hash h against the public key pk.
+// The signature membership check in G1 is included.
+func blsVerify(pk *pointG2, s []byte, h []byte) (bool, error) {
+	var sPoint pointG1
+	if !readSignatureG1(&sPoint, s) {
+		return false, nil
+	}
+	verif, err := blsVerifyPoint(pk, &sPoint, h)
+	if err != nil {
+		return false, fmt.Errorf("signature verification failed: %w", err)
+	}
+	return verif, nil
+}
+
+// blsVerifyPerDistinctMessage verifies an aggregated signature s using
+// one pairing per distinct message hash. Each hash is mapped to the public keys
+// that signed it.
+func blsVerifyPerDistinctMessage(s []byte, mapPerHash map[string][]pointG2) (bool, error) {
+	var sPoint pointG1
+	if !readSignatureG1(&sPoint, s) {
+		return false, nil
+	}
+
+	elemsG1 := make([]bls12381.G1Affine, 0, len(mapPerHash)+1)
+	elemsG2 := make([]bls12381.G2Affine, 0, len(mapPerHash)+1)
+	elemsG1 = append(elemsG1, bls12381.G1Affine(sPoint))
+	elemsG2 = append(elemsG2, g2Neg)
+	for hash, pks := range mapPerHash {
+		// map the hash to G1 and aggregate the public keys
+		var hPoint pointG1
+		mapToG1(&hPoint, []byte(hash))
+		var aggPk pointG2
+		sumPointG2Vector(&aggPk, pks)
+		elemsG1 = append(elemsG1, bls12381.G1Affine(hPoint))
+		elemsG2 = append(elemsG2, bls12381.G2Affine(aggPk))
+	}
+
+	verif, err := pairingCheck(elemsG1, elemsG2)
+	if err != nil {
+		return false, fmt.Errorf("signature verification failed: %w", err)
+	}
+	return verif, nil
+}
+
+// blsVerifyPerDistinctKey verifies an aggregated signature s using
+// one pairing per distinct public key. Each key is mapped to the message hashes
+// it signed.
+func blsVerifyPerDistinctKey(s []byte, mapPerPk map[pointG2][][]byte) (bool, error) {
+	var sPoint pointG1
+	if !readSignatureG1(&sPoint, s) {
+		return false, nil
+	}
+
+	elemsG1 := make([]bls12381.G1Affine, 0, len(mapPerPk)+1)
+	elemsG2 := make([]bls12381.G2Affine, 0, len(mapPerPk)+1)
+	elemsG1 = append(elemsG1, bls12381.G1Affine(sPoint))
+	elemsG2 = append(elemsG2, g2Neg)
+	for pk, hashes := range mapPerPk {
+		// map the hashes to G1 and aggregate them
+		var aggH, tmp bls12381.G1Jac
+		for i, h := range hashes {
+			var hPoint pointG1
+			mapToG1(&hPoint, h)
+			if i == 0 {
+				aggH.FromAffine((*bls12381.G1Affine)(&hPoint))
+				continue
+			}
+			tmp.FromAffine((*bls12381.G1Affine)(&hPoint))
+			aggH.AddAssign(&tmp)
+		}
+		var aggHAffine bls12381.G1Affine
+		aggHAffine.FromJacobian(&aggH)
+		elemsG1 = append(elemsG1, aggHAffine)
+		elemsG2 = append(elemsG2, bls12381.G2Affine(pk))
+	}
+
+	verif, err := pairingCheck(elemsG1, elemsG2)
+	if err != nil {
+		return false, fmt.Errorf("signature verification failed: %w", err)
+	}
+	return verif, nil
+}
+
+// batchNode is a node of the binary tree used by the batch verification.
+// Each node contains a signature and a public key, the signature (resp. the public key)
+// being the aggregated signature of the two children's signature (resp. public keys).
+// The leaves contain the initial signatures and public keys.
+type batchNode struct {
+	sig         pointG1
+	pk          pointG2
+	left, right *batchNode
+}
+
+// buildBatchTree builds a binary tree of aggregations of signatures and public keys recursively.
+func buildBatchTree(pks []pointG2, sigs []pointG1) *batchNode {
+	// check if a leaf is reached
+	if len(sigs) == 1 {
+		return &batchNode{sig: sigs[0], pk: pks[0]}
+	}
+	// a leaf is not reached yet
+	rightLen := len(sigs) / 2
+	leftLen := len(sigs) - rightLen
+	t := &batchNode{
+		left:  buildBatchTree(pks[:leftLen], sigs[:leftLen]),
+		right: buildBatchTree(pks[leftLen:], sigs[leftLen:]),
+	}
+	// sum the children
+	(*bls12381.G1Affine)(&t.sig).Add((*bls12381.G1Affine)(&t.left.sig), (*bls12381.G1Affine)(&t.right.sig))
+	(*bls12381.G2Affine)(&t.pk).Add((*bls12381.G2Affine)(&t.left.pk), (*bls12381.G2Affine)(&t.right.pk))
+	return t
+}
+
+// batch verification results
+const (
+	batchUndefined = iota
+	batchValid
+	batchInvalid
+)
+
+// batchVerifyTree verifies the binary tree and fills the results using recursive verifications.
+func batchVerifyTree(root *batchNode, results []int, h []byte) error {
+	// verify the aggregated signature against the aggregated public key.
+	verif, err := blsVerifyPoint(&root.pk, &root.sig, h)
+	if err != nil {
+		return err
+	}
+	// if the result is valid, all the subtree signatures are valid.
+	if verif {
+		for i := range results {
+			if results[i] == batchUndefined { // do not overwrite invalid results
+				results[i] = batchValid
+			}
+		}
+		return nil
+	}
+	// check if root is a leaf
+	if root.left == nil {
+		results[0] = batchInvalid
+		return nil
+	}
+	// otherwise, at least one of the subtree signatures is invalid.
+	// use the binary tree structure to find the invalid signatures.
+	rightLen := len(results) / 2
+	leftLen := len(results) - rightLen
+	if err := batchVerifyTree(root.left, results[:leftLen], h); err != nil {
+		return err
+	}
+	return batchVerifyTree(root.right, results[leftLen:], h)
+}
+
+// blsBatchVerify verifies each signature sigs[i] of the hash h against the public key pks[i]
+// and writes the results in verifBool.
+// All signatures are assumed to have the correct length.
+//
+// - membership checks of all signatures is verified upfront.
+// - random coefficients are used for signatures and public keys at the same index.
+// - the verification is optimized by verifying an aggregated signature against an aggregated
+// public key, and using a recursive verification to find invalid signatures.
+func blsBatchVerify(verifBool []bool, pks []pointG2, sigs []Signature, h []byte) error {
+	results := make([]int, len(sigs))
+	sigPoints := make([]pointG1, len(sigs))
+	pkPoints := make([]pointG2, len(sigs))
+	// random non-zero coefficient of a least 128 bits
+	bound := new(big.Int).Lsh(big.NewInt(1), securityBits)
+	for i, sig := range sigs {
+		// convert the signature points:
+		// - invalid points are stored as infinity points with an invalid result, so that
+		// the tree aggregations remain valid.
+		// - valid points are multiplied by a random scalar (same for public keys at same index)
+		// to make sure a signature at index (i) is verified against the public key at the same index.
+		if !readSignatureG1(&sigPoints[i], sig) {
+			sigPoints[i] = pointG1{}
+			pkPoints[i] = pks[i]
+			results[i] = batchInvalid
+			continue
+		}
+		r, err := rand.Int(rand.Reader, bound)
+		if err != nil {
+			return fmt.Errorf("batch verification failed: %w", err)
+		}
+		r.Add(r, big.NewInt(1))
+		(*bls12381.G1Affine)(&sigPoints[i]).ScalarMultiplication((*bls12381.G1Affine)(&sigPoints[i]), r)
+		(*bls12381.G2Affine)(&pkPoints[i]).ScalarMultiplication((*bls12381.G2Affine)(&pks[i]), r)
+	}
+
+	// build a binary tree of aggregations
+	root := buildBatchTree(pkPoints, sigPoints)
+	// verify the binary tree and fill the results using batch verification
+	if err := batchVerifyTree(root, results, h); err != nil {
+		return fmt.Errorf("batch verification failed: %w", err)
+	}
+	for i, res := range results {
+		verifBool[i] = (res == batchValid)
+	}
+	return nil
+}
+
+// sumSignatureVector decodes the signatures sigs, adds them in G1 and writes the
+// sum in dest. No subgroup membership check is performed on the signatures.
+// All signatures are assumed to have the correct length.
+func sumSignatureVector(dest []byte, sigs []Signature) error {
+	var sum, tmp bls12381.G1Jac
+	for i, sig := range sigs {
+		var p pointG1
+		if err := readPointG1(&p, sig); err != nil {
+			return invalidInputsErrorf("decoding at least one BLS signatures failed")
+		}
+		if i == 0 {
+			sum.FromAffine((*bls12381.G1Affine)(&p))
+			continue
+		}
+		tmp.FromAffine((*bls12381.G1Affine)(&p))
+		sum.AddAssign(&tmp)
+	}
+	var res pointG1
+	(*bls12381.G1Affine)(&res).FromJacobian(&sum)
+	writePointG1(dest, &res)
+	return nil
+}
+
+// blsSpockVerify verifies the two SPoCK proofs are consistent against the two public keys.
+// The proofs membership checks in G1 are included.
+func blsSpockVerify(pk1 *pointG2, proof1 []byte, pk2 *pointG2, proof2 []byte) (bool, error) {
+	var s1, s2 pointG1
+	if !readSignatureG1(&s1, proof1) || !readSignatureG1(&s2, proof2) {
+		return false, nil
+	}
+	// e(s1, pk2) = e(s2, pk1)
+	var pk2Neg bls12381.G2Affine
+	pk2Neg.Neg((*bls12381.G2Affine)(pk2))
+	verif, err := pairingCheck(
+		[]bls12381.G1Affine{bls12381.G1Affine(s1), bls12381.G1Affine(s2)},
+		[]bls12381.G2Affine{pk2Neg, bls12381.G2Affine(*pk1)},
+	)
+	if err != nil {
+		return false, fmt.Errorf("SPoCK verification failed: %w", err)
+	}
+	return verif, nil
+}
@@ -0,0 +1,197 @@
+// +build relic
+
+package crypto
+
+// this file contains the Relic backend of the BLS signature scheme,
+// the BLS multi-signature and the BLS-based SPoCK.
+// The core functions are implemented in the C layer (bls_core.c).
+
+// #cgo CFLAGS: -g -Wall -std=c99 -I./ -I./relic/build/include
+// #cgo LDFLAGS: -Lrelic/build/lib -l relic_s
+// #include "bls_include.h"
+import "C"
+
+import (
+	"fmt"
+)
+
+// Get Macro definitions from the C layer as Cgo does not export macros
+var signatureLengthBLSBLS12381 = int(C.get_signature_len())
+var pubKeyLengthBLSBLS12381 = int(C.get_pk_len())
+var prKeyLengthBLSBLS12381 = int(C.get_sk_len())
+
+// checkMembershipG2 returns true if the point is on the curve E2
+// and is in the subgroup G2.
+func checkMembershipG2(p *pointG2) bool {
+	return C.check_membership_G2((*C.ep2_st)(p)) == valid
+}
+
+// blsSign computes the BLS signature of the hash h under the private scalar sk
+// and writes it in s.
+// The slice s should be of size SignatureLenBLSBLS12381.
+func blsSign(s []byte, sk *scalar, h []byte) {
+	C.bls_sign((*C.uchar)(&s[0]),
+		(*C.bn_st)(sk),
+		(*C.uchar)(&h[0]),
+		(C.int)(len(h)))
+}
+
+// blsVerify verifies the signature s of the hash h against the public key pk.
+// The signature membership check in G1 is included.
+func blsVerify(pk *pointG2, s []byte, h []byte) (bool, error) {
+	verif := C.bls_verify((*C.ep2_st)(pk),
+		(*C.uchar)(&s[0]),
+		(*C.uchar)(&h[0]),
+		(C.int)(len(h)))
+
+	switch verif {
+	case invalid:
+		return false, nil
+	case valid:
+		return true, nil
+	default:
+		return false, fmt.Errorf("signature verification failed")
+	}
+}
+
+// blsVerifyPerDistinctMessage verifies an aggregated signature s using
+// one pairing per distinct message hash. Each hash is mapped to the public keys
+// that signed it.
+func blsVerifyPerDistinctMessage(s []byte, mapPerHash map[string][]pointG2) (bool, error) {
+	// flatten the maps (required by the C layer)
+	flatDistinctHashes := make([]byte, 0)
+	lenHashes := make([]uint32, 0)
+	pkPerHash := make([]uint32, 0, len(mapPerHash))
+	allPks := make([]pointG2, 0)
+	for hash, pksVal := range mapPerHash {
+		flatDistinctHashes = append(flatDistinctHashes, []byte(hash)...)
+		lenHashes = append(lenHashes, uint32(len([]byte(hash))))
+		pkPerHash = append(pkPerHash, uint32(len(pksVal)))
+		allPks = append(allPks, pksVal...)
+	}
+	verif := C.bls_verifyPerDistinctMessage(
+		(*C.uchar)(&s[0]),
+		(C.int)(len(mapPerHash)),
+		(*C.uchar)(&flatDistinctHashes[0]),
+		(*C.uint32_t)(&lenHashes[0]),
+		(*C.uint32_t)(&pkPerHash[0]),
+		(*C.ep2_st)(&allPks[0]),
+	)
+
+	switch verif {
+	case invalid:
+		return false, nil
+	case valid:
+		return true, nil
+	default:
+		return false, fmt.Errorf("signature verification failed")
+	}
+}
+
+// blsVerifyPerDistinctKey verifies an aggregated signature s using
+// one pairing per distinct public key. Each key is mapped to the message hashes
+// it signed.
+func blsVerifyPerDistinctKey(s []byte, mapPerPk map[pointG2][][]byte) (bool, error) {
+	// flatten the maps (required by the C layer)
+	distinctPks := make([]pointG2, 0, len(mapPerPk))
+	hashPerPk := make([]uint32, 0, len(mapPerPk))
+	flatHashes := make([]byte, 0)
+	lenHashes := make([]uint32, 0)
+	for pk, hashesVal := range mapPerPk {
+		distinctPks = append(distinctPks, pk)
+		hashPerPk = append(hashPerPk, uint32(len(hashesVal)))
+		for _, h := range hashesVal {
+			flatHashes = append(flatHashes, h...)
+			lenHashes = append(lenHashes, uint32(len(h)))
+		}
+	}
+	verif := C.bls_verifyPerDistinctKey(
+		(*C.uchar)(&s[0]),
+		(C.int)(len(mapPerPk)),
+		(*C.ep2_st)(&distinctPks[0]),
+		(*C.uint32_t)(&hashPerPk[0]),
+		(*C.uchar)(&flatHashes[0]),
+		(*C.uint32_t)(&lenHashes[0]))
+
+	switch verif {
+	case invalid:
+		return false, nil
+	case valid:
+		return true, nil
+	default:
+		return false, fmt.Errorf("signature verification failed")
+	}
+}
+
+// blsBatchVerify verifies each signature sigs[i] of the hash h against the public key pks[i]
+// and writes the results in verifBool.
+// All signatures are assumed to have the correct length.
+func blsBatchVerify(verifBool []bool, pks []pointG2, sigs []Signature, h []byte) error {
+	// flatten the signatures (required by the C layer)
+	flatSigs := make([]byte, 0, signatureLengthBLSBLS12381*len(sigs))
+	for _, sig := range sigs {
+		flatSigs = append(flatSigs, sig...)
+	}
+	verifInt := make([]byte, len(verifBool))
+
+	C.bls_batchVerify(
+		(C.int)(len(verifInt)),
+		(*C.uchar)(&verifInt[0]),
+		(*C.ep2_st)(&pks[0]),
+		(*C.uchar)(&flatSigs[0]),
+		(*C.uchar)(&h[0]),
+		(C.int)(len(h)),
+	)
+
+	for i, v := range verifInt {
+		if (C.int)(v) != valid && (C.int)(v) != invalid {
+			return fmt.Errorf("batch verification failed")
+		}
+		verifBool[i] = ((C.int)(v) == valid)
+	}
+	return nil
+}
+
+// sumSignatureVector decodes the signatures sigs, adds them in G1 and writes the
+// sum in dest. No subgroup membership check is performed on the signatures.
+// All signatures are assumed to have the correct length.
+func sumSignatureVector(dest []byte, sigs []Signature) error {
+	// flatten the signatures (required by the C layer)
+	flatSigs := make([]byte, 0, signatureLengthBLSBLS12381*len(sigs))
+	for _, sig := range sigs {
+		flatSigs = append(flatSigs, sig...)
+	}
+
+	// add the points in the C layer
+	result := C.ep_sum_vector_byte(
+		(*C.uchar)(&dest[0]),
+		(*C.uchar)(&flatSigs[0]),
+		(C.int)(len(sigs)))
+
+	switch result {
+	case valid:
+		return nil
+	case invalid:
+		return invalidInputsErrorf("decoding at least one BLS signatures failed")
+	default:
+		return fmt.Errorf("aggregating signatures failed")
+	}
+}
+
+// blsSpockVerify verifies the two SPoCK proofs are consistent against the two public keys.
+// The proofs membership checks in G1 are included.
+func blsSpockVerify(pk1 *pointG2, proof1 []byte, pk2 *pointG2, proof2 []byte) (bool, error) {
+	verif := C.bls_spock_verify((*C.ep2_st)(pk1),
+		(*C.uchar)(&proof1[0]),
+		(*C.ep2_st)(pk2),
+		(*C.uchar)(&proof2[0]))
+
+	switch verif {
+	case invalid:
+		return false, nil
+	case valid:
+		return true, nil
+	default:
+		return false, fmt.Errorf("SPoCK verification failed")
+	}
+}
@@ -1,11 +1,5 @@
-// +build relic
-
 package crypto
 
-// #cgo CFLAGS: -g -Wall -std=c99
-// #include "dkg_include.h"
-import "C"
-
 import (
 	"errors"
 	"fmt"
@@ -73,7 +67,7 @@ func (s *feldmanVSSstate) init() {
 	s.y = nil
 	s.xReceived = false
 	s.vAReceived = false
-	C.bn_new_wrapper((*C.bn_st)(&s.x))
+	initScalar(&s.x)
 }
 
 // Start starts running the protocol in the current node
@@ -226,7 +220,7 @@ func (s *feldmanVSSstate) ForceDisqualify(node int) error {
 
 // generates all private and public data by the leader
 func (s *feldmanVSSstate) generateShares(seed []byte) error {
-	err := seedRelic(seed)
+	err := seedPRG(seed)
 	if err != nil {
 		return fmt.Errorf("generating shares failed: %w", err)
 	}
@@ -238,7 +232,7 @@ func (s *feldmanVSSstate) generateShares(seed []byte) error {
 	randZrStar(&s.a[0]) // non zero a[0]
 	genScalarMultG2(&s.vA[0], &s.a[0])
 	for i := 1; i < s.threshold+1; i++ {
-		C.bn_new_wrapper((*C.bn_st)(&s.a[i]))
+		initScalar(&s.a[i])
 		randZr(&s.a[i])
 		genScalarMultG2(&s.vA[i], &s.a[i])
 	}
@@ -249,10 +243,7 @@ func (s *feldmanVSSstate) generateShares(seed []byte) error {
 		if i-1 == s.currentIndex {
 			xdata := make([]byte, shareSize)
 			zrPolynomialImage(xdata, s.a, i, &s.y[i-1])
-			C.bn_read_bin((*C.bn_st)(&s.x),
-				(*C.uchar)(&xdata[0]),
-				PrKeyLenBLSBLS12381,
-			)
+			readScalar(&s.x, xdata)
 			continue
 		}
 		// the-other-node shares
@@ -294,10 +285,7 @@ func (s *feldmanVSSstate) receiveShare(origin index, data []byte) {
 	}
 
 	// read the node private share
-	if C.bn_read_Zr_bin((*C.bn_st)(&s.x),
-		(*C.uchar)(&data[0]),
-		PrKeyLenBLSBLS12381,
-	) != valid {
+	if err := readScalarZr(&s.x, data); err != nil {
 		s.processor.FlagMisbehavior(int(origin),
 			fmt.Sprintf("invalid share value %x", data))
 		return
@@ -344,54 +332,14 @@ func (s *feldmanVSSstate) receiveVerifVector(origin index, data []byte) {
 	}
 }
 
-// zrPolynomialImage computes P(x) = a_0 + a_1*x + .. + a_n*x^n (mod r) in Z/Zr
-// r being the order of G1
-// P(x) is written in dest, while g2^P(x) is written in y
-// x being a small integer
-func zrPolynomialImage(dest []byte, a []scalar, x index, y *pointG2) {
-	C.Zr_polynomialImage_export((*C.uchar)(&dest[0]),
-		(*C.ep2_st)(y),
-		(*C.bn_st)(&a[0]), (C.int)(len(a)),
-		(C.uint8_t)(x),
-	)
-}
-
-// writeVerifVector exports a vector A into an array of bytes
-// assuming the array length matches the vector length
-func writeVerifVector(dest []byte, A []pointG2) {
-	C.ep2_vector_write_bin((*C.uchar)(&dest[0]),
-		(*C.ep2_st)(&A[0]),
-		(C.int)(len(A)),
-	)
-}
-
-// readVerifVector imports A vector from an array of bytes,
-// assuming the slice length matches the vector length
-func readVerifVector(A []pointG2, src []byte) error {
-	switch C.ep2_vector_read_bin((*C.ep2_st)(&A[0]),
-		(*C.uchar)(&src[0]),
-		(C.int)(len(A))) {
-	case valid:
-		return nil
-	case invalid:
-		return invalidInputsErrorf("the verifcation vector does not serialize G2 points")
-	default:
-		return errors.New("reading the verifcation vector failed")
-	}
-}
-
 func (s *feldmanVSSstate) verifyShare() bool {
 	// check y[current] == x.G2
-	return C.verifyshare((*C.bn_st)(&s.x),
-		(*C.ep2_st)(&s.y[s.currentIndex])) == 1
+	return verifyShare(&s.x, &s.y[s.currentIndex])
 }
 
 // computePublicKeys extracts the nodes public keys from the verification vector
 // y[i] = Q(i+1) for all nodes i, with:
 //  Q(x) = A_0 + A_1*x + ... +  A_n*x^n  in G2
 func (s *feldmanVSSstate) computePublicKeys() {
-	C.G2_polynomialImages(
-		(*C.ep2_st)(&s.y[0]), (C.int)(len(s.y)),
-		(*C.ep2_st)(&s.vA[0]), (C.int)(len(s.vA)),
-	)
+	g2PolynomialImages(s.y, s.vA)
 }
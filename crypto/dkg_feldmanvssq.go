@@ -1,11 +1,5 @@
-// +build relic
-
 package crypto
 
-// #cgo CFLAGS: -g -Wall -std=c99
-// #include "dkg_include.h"
-import "C"
-
 import (
 	"errors"
 	"fmt"
@@ -339,10 +333,7 @@ func (s *feldmanVSSQualState) receiveShare(origin index, data []byte) {
 		return
 	}
 	// read the node private share
-	if C.bn_read_Zr_bin((*C.bn_st)(&s.x),
-		(*C.uchar)(&data[0]),
-		PrKeyLenBLSBLS12381,
-	) != valid {
+	if err := readScalarZr(&s.x, data); err != nil {
 		s.processor.FlagMisbehavior(int(origin),
 			fmt.Sprintf("invalid share value %x", data))
 		return
@@ -439,8 +430,7 @@ func (s *feldmanVSSQualState) receiveVerifVector(origin index, data []byte) {
 // - true if the complaint answer is not correct
 func (s *feldmanVSSQualState) checkComplaint(complainer index, c *complaint) bool {
 	// check y[complainer] == share.G2
-	return C.verifyshare((*C.bn_st)(&c.answer),
-		(*C.ep2_st)(&s.y[complainer])) == 0
+	return !verifyShare(&c.answer, &s.y[complainer])
 }
 
 // data = |complainee|
@@ -561,11 +551,8 @@ func (s *feldmanVSSQualState) receiveComplaintAnswer(origin index, data []byte)
 		}
 
 		// read the complainer private share
-		C.bn_new_wrapper((*C.bn_st)(&s.complaints[complainer].answer))
-		if C.bn_read_Zr_bin((*C.bn_st)(&s.complaints[complainer].answer),
-			(*C.uchar)(&data[1]),
-			PrKeyLenBLSBLS12381,
-		) != valid {
+		initScalar(&s.complaints[complainer].answer)
+		if err := readScalarZr(&s.complaints[complainer].answer, data[1:]); err != nil {
 			s.disqualified = true
 			s.processor.Disqualify(int(s.leaderIndex),
 				fmt.Sprintf("invalid complaint answer value %x", data))
@@ -585,11 +572,8 @@ func (s *feldmanVSSQualState) receiveComplaintAnswer(origin index, data []byte)
 	// flag check is a sanity check
 	if c.received {
 		// read the complainer private share
-		C.bn_new_wrapper((*C.bn_st)(&c.answer))
-		if C.bn_read_Zr_bin((*C.bn_st)(&c.answer),
-			(*C.uchar)(&data[1]),
-			PrKeyLenBLSBLS12381,
-		) != valid {
+		initScalar(&c.answer)
+		if err := readScalarZr(&c.answer, data[1:]); err != nil {
 			s.disqualified = true
 			s.processor.Disqualify(int(s.leaderIndex),
 				fmt.Sprintf("invalid complaint answer value %x", data))
@@ -1,12 +1,5 @@
-// +build relic
-
 package crypto
 
-// #cgo CFLAGS: -g -Wall -std=c99 -I./ -I./relic/include -I./relic/include/low
-// #cgo LDFLAGS: -Lrelic/build/lib -l relic_s
-// #include "dkg_include.h"
-import "C"
-
 import (
 	"errors"
 	"fmt"
@@ -251,18 +244,14 @@ func (s *JointFeldmanState) sumUpQualifiedKeys(qualified int) (*scalar, *pointG2
 
 	// sum up x
 	var jointx scalar
-	C.bn_new_wrapper((*C.bn_st)(&jointx))
-	C.bn_sum_vector((*C.bn_st)(&jointx), (*C.bn_st)(&qualifiedx[0]),
-		(C.int)(qualified))
+	sumScalarVector(&jointx, qualifiedx)
 	// sum up Y
 	var jointPublicKey pointG2
-	C.ep2_sum_vector((*C.ep2_st)(&jointPublicKey),
-		(*C.ep2_st)(&qualifiedPubKey[0]), (C.int)(qualified))
+	sumPointG2Vector(&jointPublicKey, qualifiedPubKey)
 	// sum up []y
 	jointy := make([]pointG2, s.size)
 	for i := 0; i < s.size; i++ {
-		C.ep2_sum_vector((*C.ep2_st)(&jointy[i]),
-			(*C.ep2_st)(&qualifiedy[i][0]), (C.int)(qualified))
+		sumPointG2Vector(&jointy[i], qualifiedy[i])
 	}
 	return &jointx, &jointPublicKey, jointy
 }
@@ -0,0 +1,84 @@
+// +build !relic
+
+package crypto
+
+import (
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// zrPolynomialImage computes P(x) = a_0 + a_1*x + .. + a_n*x^n (mod r) in Z/Zr
+// r being the order of G1
+// P(x) is written in dest, while g2^P(x) is written in y
+// x being a small integer
+func zrPolynomialImage(dest []byte, a []scalar, x index, y *pointG2) {
+	var image, xElement fr.Element
+	xElement.SetUint64(uint64(x))
+	// Horner's method
+	for i := len(a) - 1; i >= 0; i-- {
+		image.Mul(&image, &xElement).Add(&image, (*fr.Element)(&a[i]))
+	}
+	// export the result
+	writeScalar(dest, (*scalar)(&image))
+	// compute y = P(x).g2
+	if y != nil {
+		genScalarMultG2(y, (*scalar)(&image))
+	}
+}
+
+// writeVerifVector exports a vector A into an array of bytes
+// assuming the array length matches the vector length
+func writeVerifVector(dest []byte, A []pointG2) {
+	for i := range A {
+		writePointG2(dest[i*PubKeyLenBLSBLS12381:(i+1)*PubKeyLenBLSBLS12381], &A[i])
+	}
+}
+
+// readVerifVector imports A vector from an array of bytes,
+// assuming the slice length matches the vector length
+func readVerifVector(A []pointG2, src []byte) error {
+	for i := range A {
+		if err := readPointG2(&A[i], src[i*PubKeyLenBLSBLS12381:(i+1)*PubKeyLenBLSBLS12381]); err != nil {
+			return invalidInputsErrorf("the verifcation vector does not serialize G2 points")
+		}
+	}
+	return nil
+}
+
+// verifyShare returns true if g2^x = y, where g2 is the generator of G2
+func verifyShare(x *scalar, y *pointG2) bool {
+	var res pointG2
+	genScalarMultG2(&res, x)
+	return res.equals(y)
+}
+
+// g2PolynomialImages computes the images y[i] = Q(i+1) for all i, with:
+//  Q(x) = A_0 + A_1*x + ... +  A_n*x^n  in G2
+func g2PolynomialImages(y []pointG2, A []pointG2) {
+	for i := range y {
+		// Horner's method
+		x := index(i + 1)
+		var acc, tmp bls12381.G2Jac
+		acc.FromAffine((*bls12381.G2Affine)(&A[len(A)-1]))
+		for j := len(A) - 2; j >= 0; j-- {
+			smallScalarMultG2(&acc, x)
+			tmp.FromAffine((*bls12381.G2Affine)(&A[j]))
+			acc.AddAssign(&tmp)
+		}
+		(*bls12381.G2Affine)(&y[i]).FromJacobian(&acc)
+	}
+}
+
+// smallScalarMultG2 sets p to x.p using a double-and-add, which is faster than a
+// generic scalar multiplication when x is a small integer.
+func smallScalarMultG2(p *bls12381.G2Jac, x index) {
+	var res bls12381.G2Jac
+	base := *p
+	for i := 7; i >= 0; i-- {
+		res.DoubleAssign()
+		if (x>>uint(i))&1 == 1 {
+			res.AddAssign(&base)
+		}
+	}
+	*p = res
+}
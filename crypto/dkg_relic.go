@@ -0,0 +1,62 @@
+// +build relic
+
+package crypto
+
+// #cgo CFLAGS: -g -Wall -std=c99
+// #include "dkg_include.h"
+import "C"
+
+import (
+	"errors"
+)
+
+// zrPolynomialImage computes P(x) = a_0 + a_1*x + .. + a_n*x^n (mod r) in Z/Zr
+// r being the order of G1
+// P(x) is written in dest, while g2^P(x) is written in y
+// x being a small integer
+func zrPolynomialImage(dest []byte, a []scalar, x index, y *pointG2) {
+	C.Zr_polynomialImage_export((*C.uchar)(&dest[0]),
+		(*C.ep2_st)(y),
+		(*C.bn_st)(&a[0]), (C.int)(len(a)),
+		(C.uint8_t)(x),
+	)
+}
+
+// writeVerifVector exports a vector A into an array of bytes
+// assuming the array length matches the vector length
+func writeVerifVector(dest []byte, A []pointG2) {
+	C.ep2_vector_write_bin((*C.uchar)(&dest[0]),
+		(*C.ep2_st)(&A[0]),
+		(C.int)(len(A)),
+	)
+}
+
+// readVerifVector imports A vector from an array of bytes,
+// assuming the slice length matches the vector length
+func readVerifVector(A []pointG2, src []byte) error {
+	switch C.ep2_vector_read_bin((*C.ep2_st)(&A[0]),
+		(*C.uchar)(&src[0]),
+		(C.int)(len(A))) {
+	case valid:
+		return nil
+	case invalid:
+		return invalidInputsErrorf("the verifcation vector does not serialize G2 points")
+	default:
+		return errors.New("reading the verifcation vector failed")
+	}
+}
+
+// verifyShare returns true if g2^x = y, where g2 is the generator of G2
+func verifyShare(x *scalar, y *pointG2) bool {
+	return C.verifyshare((*C.bn_st)(x),
+		(*C.ep2_st)(y)) == 1
+}
+
+// g2PolynomialImages computes the images y[i] = Q(i+1) for all i, with:
+//  Q(x) = A_0 + A_1*x + ... +  A_n*x^n  in G2
+func g2PolynomialImages(y []pointG2, A []pointG2) {
+	C.G2_polynomialImages(
+		(*C.ep2_st)(&y[0]), (C.int)(len(y)),
+		(*C.ep2_st)(&A[0]), (C.int)(len(A)),
+	)
+}
@@ -4,12 +4,12 @@ go 1.16
 
 require (
 	github.com/btcsuite/btcd v0.20.1-beta
+	github.com/consensys/gnark-crypto v0.5.3
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/sirupsen/logrus v1.4.2
 	github.com/stretchr/testify v1.4.0
 	github.com/supranational/blst v0.3.4
-	golang.org/x/crypto v0.0.0-20200117160349-530e935923ad
-	golang.org/x/sys v0.0.0-20200107162124-548cf772de50 // indirect
+	golang.org/x/crypto v0.0.0-20210322153248-0c34fe9e7dc2
 	gonum.org/v1/gonum v0.6.1
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
 	gopkg.in/yaml.v2 v2.2.4 // indirect
@@ -26,6 +26,20 @@ type signer interface {
 	decodePublicKeyCompressed([]byte) (PublicKey, error)
 }
 
+// newSigner chooses and initializes a signature scheme
+func newSigner(algo SigningAlgorithm) (signer, error) {
+	if algo == BLSBLS12381 {
+		return blsInstance, nil
+	}
+	return newNonRelicSigner(algo)
+}
+
+// Initialize the BLS context on BLS 12-381 and the other algos
+func init() {
+	initBLS()
+	initNonRelic()
+}
+
 // newNonRelicSigner returns a signer that does not depend on Relic library.
 func newNonRelicSigner(algo SigningAlgorithm) (signer, error) {
 	switch algo {
@@ -1,17 +1,9 @@
-// +build relic
-
 package crypto
 
 // SPoCK design based on the BLS signature scheme.
 // BLS is using BLS12-381 curve and the same settings in bls.go.
 
-// #cgo CFLAGS: -g -Wall -std=c99 -I./ -I./relic/build/include
-// #cgo LDFLAGS: -Lrelic/build/lib -l relic_s
-// #include "bls_include.h"
-import "C"
 import (
-	"fmt"
-
 	"github.com/onflow/flow-go/crypto/hash"
 )
 
@@ -65,17 +57,5 @@ func SPOCKVerify(pk1 PublicKey, proof1 Signature, pk2 PublicKey, proof2 Signatur
 	}
 
 	// verify the spock proof using the secret data
-	verif := C.bls_spock_verify((*C.ep2_st)(&blsPk1.point),
-		(*C.uchar)(&proof1[0]),
-		(*C.ep2_st)(&blsPk2.point),
-		(*C.uchar)(&proof2[0]))
-
-	switch verif {
-	case invalid:
-		return false, nil
-	case valid:
-		return true, nil
-	default:
-		return false, fmt.Errorf("SPoCK verification failed")
-	}
+	return blsSpockVerify(&blsPk1.point, proof1, &blsPk2.point, proof2)
 }
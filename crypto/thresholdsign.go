@@ -1,11 +1,5 @@
-// +build relic
-
 package crypto
 
-// #cgo CFLAGS: -g -Wall -std=c99
-// #include "thresholdsign_include.h"
-import "C"
-
 import (
 	"errors"
 	"fmt"
@@ -311,15 +305,10 @@ func (s *thresholdSigner) reconstructThresholdSignature() (Signature, error) {
 	}
 	thresholdSignature := make([]byte, signatureLengthBLSBLS12381)
 	// Lagrange Interpolate at point 0
-	result := C.G1_lagrangeInterpolateAtZero(
-		(*C.uchar)(&thresholdSignature[0]),
-		(*C.uchar)(&s.shares[0]),
-		(*C.uint8_t)(&s.signers[0]), (C.int)(len(s.signers)))
-	if result != valid {
-		if result == invalid { // sanity check, but shouldn't happen
-			return nil, invalidInputsErrorf("a signature share is not valid")
-		}
-		return nil, errors.New("reading signatures failed")
+	err := lagrangeInterpolateAtZero(thresholdSignature, s.shares, s.signers)
+	if err != nil {
+		// an invalid share is a sanity check, but shouldn't happen
+		return nil, err
 	}
 
 	// Verify the computed signature
@@ -379,7 +368,7 @@ func ReconstructThresholdSignature(size int, threshold int,
 	// map to check signers are distinct
 	m := make(map[index]bool)
 
-	// flatten the shares (required by the C layer)
+	// flatten the shares (required by the backend layer)
 	flatShares := make([]byte, 0, signatureLengthBLSBLS12381*(threshold+1))
 	indexSigners := make([]index, 0, threshold+1)
 	for i, share := range shares {
@@ -400,11 +389,7 @@ func ReconstructThresholdSignature(size int, threshold int,
 
 	thresholdSignature := make([]byte, signatureLengthBLSBLS12381)
 	// Lagrange Interpolate at point 0
-	if C.G1_lagrangeInterpolateAtZero(
-		(*C.uchar)(&thresholdSignature[0]),
-		(*C.uchar)(&flatShares[0]),
-		(*C.uint8_t)(&indexSigners[0]), (C.int)(threshold+1),
-	) != valid {
+	if lagrangeInterpolateAtZero(thresholdSignature, flatShares, indexSigners[:threshold+1]) != nil {
 		return nil, errors.New("reading signatures failed")
 	}
 	return thresholdSignature, nil
@@ -449,9 +434,9 @@ func ThresholdSignKeyGen(size int, threshold int, seed []byte) ([]PrivateKey,
 	y := make([]pointG2, size)
 	var X0 pointG2
 
-	// seed relic
-	if err := seedRelic(seed); err != nil {
-		return nil, nil, nil, fmt.Errorf("seeding relic failed: %w", err)
+	// seed the backend PRG
+	if err := seedPRG(seed); err != nil {
+		return nil, nil, nil, fmt.Errorf("seeding the PRG failed: %w", err)
 	}
 	// Generate a polynomial P in Zr[X] of degree t
 	a := make([]scalar, threshold+1)
@@ -460,13 +445,10 @@ func ThresholdSignKeyGen(size int, threshold int, seed []byte) ([]PrivateKey,
 		randZr(&a[i])
 	}
 	// compute the shares
+	xdata := make([]byte, PrKeyLenBLSBLS12381)
 	for i := index(1); int(i) <= size; i++ {
-		C.Zr_polynomialImage(
-			(*C.bn_st)(&x[i-1]),
-			(*C.ep2_st)(&y[i-1]),
-			(*C.bn_st)(&a[0]), (C.int)(len(a)),
-			(C.uint8_t)(i),
-		)
+		zrPolynomialImage(xdata, a, i, &y[i-1])
+		readScalar(&x[i-1], xdata)
 	}
 	// group public key
 	genScalarMultG2(&X0, &a[0])
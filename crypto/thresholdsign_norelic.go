@@ -0,0 +1,58 @@
+// +build !relic
+
+package crypto
+
+import (
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// lagrangeCoefficientAtZero computes the Lagrange coefficient L_i(0) in Zr
+// with regards to the points [signers(1)+1..signers(t+1)+1]:
+//  L_i(0) = Π_{j≠i} (signers(j)+1) / (signers(j)-signers(i))
+func lagrangeCoefficientAtZero(res *fr.Element, i index, signers []index) {
+	var numerator, denominator, tmp, si fr.Element
+	si.SetUint64(uint64(i))
+	numerator.SetOne()
+	denominator.SetOne()
+	for _, j := range signers {
+		if j == i {
+			continue
+		}
+		tmp.SetUint64(uint64(j) + 1)
+		numerator.Mul(&numerator, &tmp)
+		tmp.SetUint64(uint64(j))
+		tmp.Sub(&tmp, &si)
+		denominator.Mul(&denominator, &tmp)
+	}
+	res.Inverse(&denominator).Mul(res, &numerator)
+}
+
+// lagrangeInterpolateAtZero computes the Lagrange interpolation at zero of the
+// signature shares and writes the resulting signature in dest.
+// The shares are flattened in a slice of bytes, and the share at index (i) is the
+// image of the point signers[i]+1. Only the first len(signers) shares are used.
+func lagrangeInterpolateAtZero(dest []byte, shares []byte, signers []index) error {
+	var acc, tmp bls12381.G1Jac
+	var coefficient fr.Element
+	for i, signer := range signers {
+		var share pointG1
+		if err := readPointG1(&share, shares[i*signatureLengthBLSBLS12381:(i+1)*signatureLengthBLSBLS12381]); err != nil {
+			return invalidInputsErrorf("a signature share is not valid")
+		}
+		lagrangeCoefficientAtZero(&coefficient, signer, signers)
+		var mult pointG1
+		share.scalarMultG1(&mult, (*scalar)(&coefficient))
+		tmp.FromAffine((*bls12381.G1Affine)(&mult))
+		if i == 0 {
+			acc.Set(&tmp)
+			continue
+		}
+		acc.AddAssign(&tmp)
+	}
+	// export the result
+	var res pointG1
+	(*bls12381.G1Affine)(&res).FromJacobian(&acc)
+	writePointG1(dest, &res)
+	return nil
+}
@@ -0,0 +1,30 @@
+// +build relic
+
+package crypto
+
+// #cgo CFLAGS: -g -Wall -std=c99
+// #include "thresholdsign_include.h"
+import "C"
+
+import (
+	"errors"
+)
+
+// lagrangeInterpolateAtZero computes the Lagrange interpolation at zero of the
+// signature shares and writes the resulting signature in dest.
+// The shares are flattened in a slice of bytes, and the share at index (i) is the
+// image of the point signers[i]+1. Only the first len(signers) shares are used.
+func lagrangeInterpolateAtZero(dest []byte, shares []byte, signers []index) error {
+	result := C.G1_lagrangeInterpolateAtZero(
+		(*C.uchar)(&dest[0]),
+		(*C.uchar)(&shares[0]),
+		(*C.uint8_t)(&signers[0]), (C.int)(len(signers)))
+	switch result {
+	case valid:
+		return nil
+	case invalid:
+		return invalidInputsErrorf("a signature share is not valid")
+	default:
+		return errors.New("reading signatures failed")
+	}
+}
@@ -119,6 +119,8 @@ func (suite *Suite) RunTest(
 			backend.DefaultMaxHeightRange,
 			nil,
 			nil,
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -295,6 +297,8 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 			backend.DefaultMaxHeightRange,
 			nil,
 			nil,
+			0,
+			metrics,
 			suite.log,
 		)
 
@@ -559,13 +563,15 @@ func (suite *Suite) TestGetSealedTransaction() {
 			backend.DefaultMaxHeightRange,
 			nil,
 			enNodeIDs.Strings(),
+			0,
+			metrics,
 			suite.log,
 		)
 
 		handler := access.NewHandler(backend, suite.chainID.Chain())
 
-		rpcEng := rpc.New(suite.log, suite.state, rpc.Config{}, nil, nil, blocks, headers, collections, transactions,
-			receipts, results, suite.chainID, metrics, 0, 0, false, false, nil, nil)
+		rpcEng := rpc.New(suite.log, suite.state, rpc.Config{}, nil, nil, nil, blocks, headers, collections, transactions,
+			receipts, results, suite.chainID, metrics, metrics, 0, 0, false, false, nil, nil)
 
 		// create the ingest engine
 		ingestEng, err := ingestion.New(suite.log, suite.net, suite.state, suite.me, suite.request, blocks, headers, collections,
@@ -643,6 +649,8 @@ func (suite *Suite) TestExecuteScript() {
 			backend.DefaultMaxHeightRange,
 			nil,
 			flow.IdentifierList(identities.NodeIDs()).Strings(),
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -766,10 +774,19 @@ func (suite *Suite) TestExecuteScript() {
 
 func (suite *Suite) createChain() (flow.Block, flow.Collection) {
 	collection := unittest.CollectionFixture(10)
+	cluster := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleCollection))
 	guarantee := &flow.CollectionGuarantee{
-		CollectionID: collection.ID(),
-		Signature:    crypto.Signature([]byte("signature A")),
+		CollectionID:  collection.ID(),
+		SignerIndices: unittest.SignerIndicesByIdentifiers(cluster.NodeIDs(), cluster.NodeIDs()),
+		Signature:     crypto.Signature([]byte("signature A")),
 	}
+
+	// the guarantors are decoded relative to the clustering of the reference block's epoch
+	epoch := new(protocol.Epoch)
+	epoch.On("Clustering").Return(flow.ClusterList{cluster}, nil)
+	suite.epochQuery.On("Current").Return(epoch)
+	suite.state.On("AtBlockID", guarantee.ReferenceBlockID).Return(suite.snapshot)
+
 	block := unittest.BlockFixture()
 	block.Payload.Guarantees = []*flow.CollectionGuarantee{guarantee}
 	block.Header.PayloadHash = block.Payload.Hash()
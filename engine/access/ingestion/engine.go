@@ -17,6 +17,7 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -230,7 +231,10 @@ func (e *Engine) processFinalizedBlock(blockID flow.Identifier) error {
 	}
 
 	// queue requesting each of the collections from the collection node
-	e.requestCollections(block.Payload.Guarantees)
+	err = e.requestCollections(block.Payload.Guarantees)
+	if err != nil {
+		return fmt.Errorf("could not request collections: %w", err)
+	}
 
 	return nil
 }
@@ -438,7 +442,10 @@ func (e *Engine) requestMissingCollections(ctx context.Context) error {
 		}
 
 		// request the missing collections
-		e.requestCollections(missingColls)
+		err = e.requestCollections(missingColls)
+		if err != nil {
+			return fmt.Errorf("failed to request missing collections at height %d during collection catchup: %w", i, err)
+		}
 
 		// add them to the missing collection id map to track later
 		for _, cg := range missingColls {
@@ -583,7 +590,11 @@ func (e *Engine) updateLastFullBlockReceivedIndex() {
 			Int("threshold", defaultMissingCollsForBlkThreshold).
 			Uint64("last_full_blk_height", latestFullHeight).
 			Msg("re-requesting missing collections")
-		e.requestCollections(allMissingColls)
+		err = e.requestCollections(allMissingColls)
+		if err != nil {
+			logError(err)
+			return
+		}
 	}
 
 	e.log.Debug().Uint64("last_full_blk_height", latestFullHeight).Msg("updated LastFullBlockReceived index")
@@ -624,8 +635,13 @@ func (e *Engine) lookupCollection(collId flow.Identifier) (bool, error) {
 }
 
 // requestCollections registers collection requests with the requester engine
-func (e *Engine) requestCollections(missingColls []*flow.CollectionGuarantee) {
+func (e *Engine) requestCollections(missingColls []*flow.CollectionGuarantee) error {
 	for _, cg := range missingColls {
-		e.request.EntityByID(cg.ID(), filter.HasNodeID(cg.SignerIDs...))
+		guarantors, err := signature.GuarantorsAtBlock(e.state, cg)
+		if err != nil {
+			return fmt.Errorf("could not decode guarantors of collection %x: %w", cg.ID(), err)
+		}
+		e.request.EntityByID(cg.ID(), filter.HasNodeID(guarantors.NodeIDs()...))
 	}
+	return nil
 }
@@ -38,6 +38,9 @@ type Suite struct {
 		params   *protocol.Params
 	}
 
+	// collector cluster guaranteeing all collections
+	collectors flow.IdentityList
+
 	me           *module.Local
 	request      *module.Requester
 	provider     *mocknetwork.Engine
@@ -68,6 +71,15 @@ func (suite *Suite) SetupTest() {
 	suite.proto.state.On("Final").Return(suite.proto.snapshot, nil)
 	suite.proto.state.On("Params").Return(suite.proto.params)
 
+	// guarantors are decoded relative to the clustering of the reference block's epoch
+	suite.collectors = unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleCollection))
+	epoch := new(protocol.Epoch)
+	epoch.On("Clustering").Return(flow.ClusterList{suite.collectors}, nil)
+	epochs := new(protocol.EpochQuery)
+	epochs.On("Current").Return(epoch)
+	suite.proto.snapshot.On("Epochs").Return(epochs)
+	suite.proto.state.On("AtBlockID", mock.Anything).Return(suite.proto.snapshot)
+
 	suite.me = new(module.Local)
 	suite.me.On("NodeID").Return(obsIdentity.NodeID)
 
@@ -92,8 +104,8 @@ func (suite *Suite) SetupTest() {
 	blocksToMarkExecuted, err := stdmap.NewTimes(100)
 	require.NoError(suite.T(), err)
 
-	rpcEng := rpc.New(log, suite.proto.state, rpc.Config{}, nil, nil, suite.blocks, suite.headers, suite.collections,
-		suite.transactions, suite.receipts, suite.results, flow.Testnet, metrics.NewNoopCollector(), 0, 0, false, false, nil, nil)
+	rpcEng := rpc.New(log, suite.proto.state, rpc.Config{}, nil, nil, nil, suite.blocks, suite.headers, suite.collections,
+		suite.transactions, suite.receipts, suite.results, flow.Testnet, metrics.NewNoopCollector(), metrics.NewNoopCollector(), 0, 0, false, false, nil, nil)
 
 	eng, err := New(log, net, suite.proto.state, suite.me, suite.request, suite.blocks, suite.headers, suite.collections,
 		suite.transactions, suite.results, suite.receipts, metrics.NewNoopCollector(), collectionsToMarkFinalized, collectionsToMarkExecuted,
@@ -109,7 +121,7 @@ func (suite *Suite) TestOnFinalizedBlock() {
 
 	block := unittest.BlockFixture()
 	block.SetPayload(unittest.PayloadFixture(
-		unittest.WithGuarantees(unittest.CollectionGuaranteesFixture(4)...),
+		unittest.WithGuarantees(unittest.CollectionGuaranteesFixture(4, suite.withGuarantors)...),
 	))
 	hotstuffBlock := hotmodel.Block{
 		BlockID: block.ID(),
@@ -301,7 +313,7 @@ func (suite *Suite) TestRequestMissingCollections() {
 	for i := 0; i < blkCnt; i++ {
 		block := unittest.BlockFixture()
 		block.SetPayload(unittest.PayloadFixture(
-			unittest.WithGuarantees(unittest.CollectionGuaranteesFixture(4)...),
+			unittest.WithGuarantees(unittest.CollectionGuaranteesFixture(4, suite.withGuarantors)...),
 		))
 		// some blocks may not be present hence add a gap
 		height := startHeight + uint64(i)
@@ -422,7 +434,7 @@ func (suite *Suite) TestUpdateLastFullBlockReceivedIndex() {
 		for j := 0; j < collPerBlk; j++ {
 			coll := unittest.CollectionFixture(2).Light()
 			collMap[coll.ID()] = &coll
-			cg := unittest.CollectionGuaranteeFixture(func(cg *flow.CollectionGuarantee) {
+			cg := unittest.CollectionGuaranteeFixture(suite.withGuarantors, func(cg *flow.CollectionGuarantee) {
 				cg.CollectionID = coll.ID()
 			})
 			guarantees[j] = cg
@@ -596,3 +608,8 @@ func (suite *Suite) TestUpdateLastFullBlockReceivedIndex() {
 		suite.blocks.AssertExpectations(suite.T()) // not new call to UpdateLastFullBlockHeight should be made
 	})
 }
+
+// withGuarantors sets the guarantors of the collection guarantee to the collector cluster.
+func (suite *Suite) withGuarantors(guarantee *flow.CollectionGuarantee) {
+	guarantee.SignerIndices = unittest.SignerIndicesByIdentifiers(suite.collectors.NodeIDs(), suite.collectors.NodeIDs())
+}
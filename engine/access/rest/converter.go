@@ -8,6 +8,8 @@ import (
 
 	"github.com/onflow/flow-go/engine/access/rest/generated"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/state/protocol"
 )
 
 // Converter provides functionality to convert from request models generated using
@@ -207,16 +209,22 @@ func transactionResponse(tx *flow.TransactionBody) *generated.Transaction {
 	}
 }
 
-func blockResponse(flowBlock *flow.Block) *generated.Block {
-	return &generated.Block{
-		Header:  blockHeaderResponse(flowBlock.Header),
-		Payload: blockPayloadResponse(flowBlock.Payload),
+// blockResponse converts the block with the given ID. The ID is not derived from the block, as the blocks of
+// previous sporks served by their access nodes only have some of the fields of the original blocks.
+func blockResponse(blockID flow.Identifier, flowBlock *flow.Block, state protocol.State) (*generated.Block, error) {
+	payload, err := blockPayloadResponse(flowBlock.Payload, state)
+	if err != nil {
+		return nil, err
 	}
+	return &generated.Block{
+		Header:  blockHeaderResponse(blockID, flowBlock.Header),
+		Payload: payload,
+	}, nil
 }
 
-func blockHeaderResponse(flowHeader *flow.Header) *generated.BlockHeader {
+func blockHeaderResponse(blockID flow.Identifier, flowHeader *flow.Header) *generated.BlockHeader {
 	return &generated.BlockHeader{
-		Id:                   flowHeader.ID().String(),
+		Id:                   blockID.String(),
 		ParentId:             flowHeader.ParentID.String(),
 		Height:               int32(flowHeader.Height),
 		Timestamp:            flowHeader.Timestamp,
@@ -224,31 +232,49 @@ func blockHeaderResponse(flowHeader *flow.Header) *generated.BlockHeader {
 	}
 }
 
-func blockPayloadResponse(flowPayload *flow.Payload) *generated.BlockPayload {
+func blockPayloadResponse(flowPayload *flow.Payload, state protocol.State) (*generated.BlockPayload, error) {
+	collectionGuarantees, err := collectionGuaranteesResponse(flowPayload.Guarantees, state)
+	if err != nil {
+		return nil, err
+	}
 	return &generated.BlockPayload{
-		CollectionGuarantees: collectionGuaranteesResponse(flowPayload.Guarantees),
+		CollectionGuarantees: collectionGuarantees,
 		BlockSeals:           blockSealsResponse(flowPayload.Seals),
-	}
+	}, nil
 }
 
-func collectionGuaranteesResponse(flowCollGuarantee []*flow.CollectionGuarantee) []generated.CollectionGuarantee {
+func collectionGuaranteesResponse(flowCollGuarantee []*flow.CollectionGuarantee, state protocol.State) ([]generated.CollectionGuarantee, error) {
 	collectionGuarantees := make([]generated.CollectionGuarantee, len(flowCollGuarantee))
 	for i, flowCollGuarantee := range flowCollGuarantee {
-		collectionGuarantees[i] = collectionGuaranteeResponse(flowCollGuarantee)
+		collectionGuarantee, err := collectionGuaranteeResponse(flowCollGuarantee, state)
+		if err != nil {
+			return nil, err
+		}
+		collectionGuarantees[i] = collectionGuarantee
 	}
-	return collectionGuarantees
+	return collectionGuarantees, nil
 }
 
-func collectionGuaranteeResponse(flowCollGuarantee *flow.CollectionGuarantee) generated.CollectionGuarantee {
-	signerIDs := make([]string, len(flowCollGuarantee.SignerIDs))
-	for i, signerID := range flowCollGuarantee.SignerIDs {
-		signerIDs[i] = signerID.String()
+// collectionGuaranteeResponse converts the guarantee, the signer IDs are decoded from the signer
+// indices of the guarantee relative to the cluster of the guarantee's reference block. Guarantees
+// of blocks of previous sporks have no signer indices, their signer IDs are left empty.
+func collectionGuaranteeResponse(flowCollGuarantee *flow.CollectionGuarantee, state protocol.State) (generated.CollectionGuarantee, error) {
+	var signerIDs []string
+	if len(flowCollGuarantee.SignerIndices) > 0 {
+		guarantors, err := signature.GuarantorsAtBlock(state, flowCollGuarantee)
+		if err != nil {
+			return generated.CollectionGuarantee{}, fmt.Errorf("could not decode guarantors of collection %v: %w", flowCollGuarantee.CollectionID, err)
+		}
+		signerIDs = make([]string, len(guarantors))
+		for i, guarantor := range guarantors {
+			signerIDs[i] = guarantor.NodeID.String()
+		}
 	}
 	return generated.CollectionGuarantee{
 		CollectionId: flowCollGuarantee.CollectionID.String(),
 		SignerIds:    signerIDs,
 		Signature:    base64.StdEncoding.EncodeToString(flowCollGuarantee.Signature.Bytes()),
-	}
+	}, nil
 }
 
 func blockSealsResponse(flowSeals []*flow.Seal) []generated.BlockSeal {
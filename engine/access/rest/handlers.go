@@ -15,6 +15,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/access/rest/generated"
+	"github.com/onflow/flow-go/state/protocol"
 )
 
 const BlockIDCntLimit = 50
@@ -24,12 +25,14 @@ var MaxAllowedBlockIDsCnt = BlockIDCntLimit
 // Handlers provide collection of handlers used by the API server
 type Handlers struct {
 	backend access.API
+	state   protocol.State // used to decode the signers of collection guarantees
 	logger  zerolog.Logger
 }
 
-func NewHandlers(backend access.API, logger zerolog.Logger) *Handlers {
+func NewHandlers(backend access.API, state protocol.State, logger zerolog.Logger) *Handlers {
 	return &Handlers{
 		backend: backend,
+		state:   state,
 		logger:  logger,
 	}
 }
@@ -76,7 +79,12 @@ func (h *Handlers) BlocksIdGet(w http.ResponseWriter, r *http.Request) {
 			h.errorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to look up block with ID %s", id), errorLogger)
 			return
 		}
-		blocks[i] = blockResponse(flowBlock)
+		blocks[i], err = blockResponse(flowID, flowBlock, h.state)
+		if err != nil {
+			errorLogger.Error().Err(err).Str("block_id", id).Msg("failed to convert block")
+			h.errorResponse(w, http.StatusInternalServerError, fmt.Sprintf("failed to convert block with ID %s", id), errorLogger)
+			return
+		}
 	}
 
 	h.jsonResponse(w, blocks, errorLogger)
@@ -21,6 +21,7 @@ import (
 	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
 	"github.com/onflow/flow-go/module/metrics"
 	module "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/network"
@@ -36,6 +37,7 @@ type RestAPITestSuite struct {
 	state      *protocol.State
 	snapshot   *protocol.Snapshot
 	epochQuery *protocol.EpochQuery
+	cluster    flow.IdentityList // the only cluster of the current epoch, signing all guarantees
 	log        zerolog.Logger
 	net        *network.Network
 	request    *module.Requester
@@ -64,6 +66,13 @@ func (suite *RestAPITestSuite) SetupTest() {
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
 	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
 	suite.snapshot.On("Epochs").Return(suite.epochQuery).Maybe()
+
+	// the signers of guarantees are decoded relative to the clustering of the epoch of their reference block
+	suite.cluster = unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleCollection))
+	epoch := new(protocol.Epoch)
+	epoch.On("Clustering").Return(flow.ClusterList{suite.cluster}, nil).Maybe()
+	suite.epochQuery.On("Current").Return(epoch).Maybe()
+	suite.state.On("AtBlockID", mock.Anything).Return(suite.snapshot).Maybe()
 	suite.blocks = new(storagemock.Blocks)
 	suite.headers = new(storagemock.Headers)
 	suite.transactions = new(storagemock.Transactions)
@@ -94,8 +103,8 @@ func (suite *RestAPITestSuite) SetupTest() {
 		RESTListenAddr:         anyPort,
 	}
 
-	suite.rpcEng = rpc.New(suite.log, suite.state, config, suite.collClient, nil, suite.blocks, suite.headers, suite.collections, suite.transactions,
-		nil, nil, suite.chainID, suite.metrics, 0, 0, false, false, nil, nil)
+	suite.rpcEng = rpc.New(suite.log, suite.state, config, suite.collClient, nil, nil, suite.blocks, suite.headers, suite.collections, suite.transactions,
+		nil, nil, suite.chainID, suite.metrics, suite.metrics, 0, 0, false, false, nil, nil)
 	unittest.AssertClosesBefore(suite.T(), suite.rpcEng.Ready(), 2*time.Second)
 
 	// wait for the server to startup
@@ -112,10 +121,7 @@ func (suite *RestAPITestSuite) TestRestAPICall() {
 
 	suite.Run("GetBlockByID for a single ID - happy path", func() {
 
-		collections := unittest.CollectionListFixture(1)
-		block := unittest.BlockWithGuaranteesFixture(
-			unittest.CollectionGuaranteesWithCollectionIDFixture(collections),
-		)
+		block := suite.blockFixture()
 		suite.blocks.On("ByID", block.ID()).Return(block, nil).Once()
 
 		client := suite.restAPIClient()
@@ -127,6 +133,13 @@ func (suite *RestAPITestSuite) TestRestAPICall() {
 		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
 		assert.Len(suite.T(), blocks, 1)
 		assert.Equal(suite.T(), block.ID().String(), blocks[0].Header.Id)
+
+		signerIDs := make([]string, 0, len(suite.cluster))
+		for _, nodeID := range suite.cluster.Sort(order.Canonical).NodeIDs() {
+			signerIDs = append(signerIDs, nodeID.String())
+		}
+		require.Len(suite.T(), blocks[0].Payload.CollectionGuarantees, 1)
+		assert.Equal(suite.T(), signerIDs, blocks[0].Payload.CollectionGuarantees[0].SignerIds)
 	})
 
 	suite.Run("GetBlockByID for multiple IDs - happy path", func() {
@@ -138,14 +151,10 @@ func (suite *RestAPITestSuite) TestRestAPICall() {
 		blockIDs := make([]string, rest.MaxAllowedBlockIDsCnt)
 		blocks := make([]*flow.Block, rest.MaxAllowedBlockIDsCnt)
 		for i := range blockIDs {
-			id := unittest.IdentifierFixture()
-			blockIDs[i] = id.String()
-			collections := unittest.CollectionListFixture(1)
-			block := unittest.BlockWithGuaranteesFixture(
-				unittest.CollectionGuaranteesWithCollectionIDFixture(collections),
-			)
+			block := suite.blockFixture()
 			blocks[i] = block
-			suite.blocks.On("ByID", id).Return(block, nil).Once()
+			blockIDs[i] = block.ID().String()
+			suite.blocks.On("ByID", block.ID()).Return(block, nil).Once()
 		}
 
 		// the swagger generated Go client code has bug where it generates a space delimited list of ids instead of a
@@ -219,10 +228,7 @@ func (suite *RestAPITestSuite) TestRestAPICall() {
 				suite.blocks.On("ByID", id).Return(nil, storage.ErrNotFound).Once()
 				continue
 			}
-			collections := unittest.CollectionListFixture(1)
-			block := unittest.BlockWithGuaranteesFixture(
-				unittest.CollectionGuaranteesWithCollectionIDFixture(collections),
-			)
+			block := suite.blockFixture()
 			suite.blocks.On("ByID", id).Return(block, nil).Once()
 		}
 		blockIDSlice := []string{strings.Join(blockIDs, ",")}
@@ -232,6 +238,16 @@ func (suite *RestAPITestSuite) TestRestAPICall() {
 
 }
 
+// blockFixture returns a block with a guarantee signed by the cluster of the current epoch
+func (suite *RestAPITestSuite) blockFixture() *flow.Block {
+	collections := unittest.CollectionListFixture(1)
+	guarantees := unittest.CollectionGuaranteesWithCollectionIDFixture(collections)
+	for _, guarantee := range guarantees {
+		guarantee.SignerIndices = unittest.SignerIndicesByIdentifiers(suite.cluster.NodeIDs(), suite.cluster.NodeIDs())
+	}
+	return unittest.BlockWithGuaranteesFixture(guarantees)
+}
+
 func (suite *RestAPITestSuite) TearDownTest() {
 	// close the server
 	if suite.rpcEng != nil {
@@ -80,6 +80,8 @@ func New(
 	maxHeightRange uint,
 	preferredExecutionNodeIDs []string,
 	fixedExecutionNodeIDs []string,
+	requiredExecutionAgreement uint,
+	accessMetrics module.AccessMetrics,
 	log zerolog.Logger,
 ) *Backend {
 	retry := newRetry()
@@ -87,6 +89,8 @@ func New(
 		retry.Activate()
 	}
 
+	agreement := newExecutionAgreement(requiredExecutionAgreement, accessMetrics, log)
+
 	b := &Backend{
 		state: state,
 		// create the sub-backends
@@ -96,6 +100,7 @@ func New(
 			connFactory:       connFactory,
 			state:             state,
 			log:               log,
+			agreement:         agreement,
 		},
 		backendTransactions: backendTransactions{
 			staticCollectionRPC:  collectionRPC,
@@ -119,6 +124,7 @@ func New(
 			connFactory:       connFactory,
 			log:               log,
 			maxHeightRange:    maxHeightRange,
+			agreement:         agreement,
 		},
 		backendBlockHeaders: backendBlockHeaders{
 			headers: headers,
@@ -134,6 +140,7 @@ func New(
 			executionReceipts: executionReceipts,
 			connFactory:       connFactory,
 			log:               log,
+			agreement:         agreement,
 		},
 		backendExecutionResults: backendExecutionResults{
 			executionResults: executionResults,
@@ -250,15 +257,51 @@ func convertStorageError(err error) error {
 }
 
 // executionNodesForBlockID returns upto maxExecutionNodesCnt number of randomly chosen execution node identities
-// which have executed the given block ID.
+// which have executed the given block ID, leaving out the nodes the connection factory considers unhealthy.
 // If no such execution node is found, an InsufficientExecutionReceipts error is returned.
 func executionNodesForBlockID(
 	ctx context.Context,
 	blockID flow.Identifier,
 	executionReceipts storage.ExecutionReceipts,
 	state protocol.State,
+	connFactory ConnectionFactory,
 	log zerolog.Logger) (flow.IdentityList, error) {
 
+	executorIDs, err := executorsForBlockID(ctx, blockID, executionReceipts, state, log)
+	if err != nil {
+		return flow.IdentityList{}, err
+	}
+
+	// choose from the preferred or fixed execution nodes
+	subsetENs, err := chooseExecutionNodes(state, executorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retreive execution IDs for block ID %v: %w", blockID, err)
+	}
+
+	// leave out the execution nodes which have been taken out of rotation
+	subsetENs = healthyNodes(subsetENs, connFactory)
+
+	// randomly choose upto maxExecutionNodesCnt identities
+	executionIdentitiesRandom := subsetENs.Sample(maxExecutionNodesCnt)
+
+	if len(executionIdentitiesRandom) == 0 {
+		return flow.IdentityList{},
+			fmt.Errorf("no matching execution node could for block ID %v", blockID)
+	}
+
+	return executionIdentitiesRandom, nil
+}
+
+// executorsForBlockID returns the IDs of the execution nodes which committed to the execution result most
+// receipts for the given block ID agree on. For the root block, all execution nodes are returned.
+// If fewer than minExecutionNodesCnt such execution nodes are found, an InsufficientExecutionReceipts error is returned.
+func executorsForBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	executionReceipts storage.ExecutionReceipts,
+	state protocol.State,
+	log zerolog.Logger) (flow.IdentifierList, error) {
+
 	var executorIDs flow.IdentifierList
 	var err error
 	attempt := 0
@@ -282,7 +325,7 @@ func executionNodesForBlockID(
 
 			executorIDs, err = findAllExecutionNodes(blockID, executionReceipts, log)
 			if err != nil {
-				return flow.IdentityList{}, err
+				return nil, err
 			}
 
 			if len(executorIDs) >= minExecutionNodesCnt {
@@ -300,7 +343,7 @@ func executionNodesForBlockID(
 
 			select {
 			case <-ctx.Done():
-				return flow.IdentityList{}, err
+				return nil, err
 			case <-time.After(100 * time.Millisecond << time.Duration(attempt)):
 				//retry after an exponential backoff
 			}
@@ -309,25 +352,11 @@ func executionNodesForBlockID(
 		receiptCnt := len(executorIDs)
 		// if less than minExecutionNodesCnt execution receipts have been received so far, then throw an error
 		if receiptCnt < minExecutionNodesCnt {
-			return flow.IdentityList{}, InsufficientExecutionReceipts{blockID: blockID, receiptCount: receiptCnt}
+			return nil, InsufficientExecutionReceipts{blockID: blockID, receiptCount: receiptCnt}
 		}
 	}
 
-	// choose from the preferred or fixed execution nodes
-	subsetENs, err := chooseExecutionNodes(state, executorIDs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retreive execution IDs for block ID %v: %w", blockID, err)
-	}
-
-	// randomly choose upto maxExecutionNodesCnt identities
-	executionIdentitiesRandom := subsetENs.Sample(maxExecutionNodesCnt)
-
-	if len(executionIdentitiesRandom) == 0 {
-		return flow.IdentityList{},
-			fmt.Errorf("no matching execution node could for block ID %v", blockID)
-	}
-
-	return executionIdentitiesRandom, nil
+	return executorIDs, nil
 }
 
 // findAllExecutionNodes find all the execution nodes ids from the execution receipts that have been received for the
@@ -386,6 +415,23 @@ func findAllExecutionNodes(
 	return executorIDs, nil
 }
 
+// healthyNodes returns the nodes which the connection factory considers healthy. If none of the nodes
+// are healthy, all nodes are returned, so that the requests fail with the reason of the nodes being unhealthy.
+func healthyNodes(nodes flow.IdentityList, connFactory ConnectionFactory) flow.IdentityList {
+	checker, ok := connFactory.(NodeHealthChecker)
+	if !ok {
+		return nodes
+	}
+
+	healthy := nodes.Filter(func(node *flow.Identity) bool {
+		return checker.IsHealthy(node.Address)
+	})
+	if len(healthy) == 0 {
+		return nodes
+	}
+	return healthy
+}
+
 // chooseExecutionNodes finds the subset of execution nodes defined in the identity table by first
 // choosing the preferred execution nodes which have executed the transaction. If no such preferred
 // execution nodes are found, then the fixed execution nodes defined in the identity table are returned
@@ -2,8 +2,10 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/go-multierror"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
@@ -22,6 +24,7 @@ type backendAccounts struct {
 	executionReceipts storage.ExecutionReceipts
 	connFactory       ConnectionFactory
 	log               zerolog.Logger
+	agreement         executionAgreement
 }
 
 func (b *backendAccounts) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
@@ -81,13 +84,13 @@ func (b *backendAccounts) getAccountAtBlockID(
 		BlockId: blockID[:],
 	}
 
-	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
-	if err != nil {
-		return nil, getAccountError(err)
-	}
-
 	var exeRes *execproto.GetAccountAtBlockIDResponse
-	exeRes, err = b.getAccountFromAnyExeNode(ctx, execNodes, exeReq)
+	var err error
+	if b.agreement.enabled() {
+		exeRes, err = b.getAccountWithAgreement(ctx, blockID, exeReq)
+	} else {
+		exeRes, err = b.getAccountFromAnyExeNodeForBlockID(ctx, blockID, exeReq)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +111,37 @@ func getAccountError(err error) error {
 	return status.Errorf(codes.Internal, "failed to get account from the execution node: %v", err)
 }
 
+func (b *backendAccounts) getAccountFromAnyExeNodeForBlockID(ctx context.Context, blockID flow.Identifier, req execproto.GetAccountAtBlockIDRequest) (*execproto.GetAccountAtBlockIDResponse, error) {
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.connFactory, b.log)
+	if err != nil {
+		return nil, getAccountError(err)
+	}
+
+	return b.getAccountFromAnyExeNode(ctx, execNodes, req)
+}
+
+// getAccountWithAgreement gets the account from the required number of execution nodes which committed to the
+// same execution result for the block, and returns it only if all of them returned the same account
+func (b *backendAccounts) getAccountWithAgreement(ctx context.Context, blockID flow.Identifier, req execproto.GetAccountAtBlockIDRequest) (*execproto.GetAccountAtBlockIDResponse, error) {
+	execNodes, err := b.agreement.executionNodes(ctx, blockID, b.executionReceipts, b.state, b.connFactory)
+	if err != nil {
+		return nil, getAccountError(err)
+	}
+
+	resp, err := b.agreement.query(blockID, execNodes, func(execNode *flow.Identity) (proto.Message, error) {
+		return b.tryGetAccount(ctx, execNode, req)
+	})
+	var insufficient InsufficientExecutionAgreement
+	if errors.As(err, &insufficient) {
+		return nil, accountLookupError(insufficient.Errors(), err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get account from the execution nodes: %v", err)
+	}
+
+	return resp.(*execproto.GetAccountAtBlockIDResponse), nil
+}
+
 func (b *backendAccounts) getAccountFromAnyExeNode(ctx context.Context, execNodes flow.IdentityList, req execproto.GetAccountAtBlockIDRequest) (*execproto.GetAccountAtBlockIDResponse, error) {
 	var errors *multierror.Error // captures all error except
 	for _, execNode := range execNodes {
@@ -136,18 +170,22 @@ func (b *backendAccounts) getAccountFromAnyExeNode(ctx context.Context, execNode
 		errors = multierror.Append(errors, err)
 	}
 	// if we made it till here means there was at least one error
-	errToReturn := errors.ErrorOrNil()
+	return nil, accountLookupError(errors.Errors, errors.ErrorOrNil())
+}
 
+// accountLookupError returns a codes.NotFound error if all the errors returned by the execution nodes were
+// codes.NotFound, and a codes.Internal error otherwise
+func accountLookupError(errs []error, errToReturn error) error {
 	// if there were an any errors other than codes.NotFound, return those
-	for _, err := range errors.Errors {
+	for _, err := range errs {
 		errStatus, _ := status.FromError(err)
 		if errStatus.Code() != codes.NotFound {
-			return nil, status.Errorf(codes.Internal, "failed to get account from the execution node: %v", errToReturn)
+			return status.Errorf(codes.Internal, "failed to get account from the execution node: %v", errToReturn)
 		}
 	}
 
 	// if all errors were codes.NotFound, then return a codes.NotFound error wrapping all those error
-	return nil, status.Errorf(codes.NotFound, "failed to get account from the execution node: %v", errToReturn)
+	return status.Errorf(codes.NotFound, "failed to get account from the execution node: %v", errToReturn)
 }
 
 func (b *backendAccounts) tryGetAccount(ctx context.Context, execNode *flow.Identity, req execproto.GetAccountAtBlockIDRequest) (*execproto.GetAccountAtBlockIDResponse, error) {
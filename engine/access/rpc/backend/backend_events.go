@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/go-multierror"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
@@ -25,6 +26,7 @@ type backendEvents struct {
 	connFactory       ConnectionFactory
 	log               zerolog.Logger
 	maxHeightRange    uint
+	agreement         executionAgreement
 }
 
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start block height and
@@ -126,7 +128,11 @@ func (b *backendEvents) getBlockEventsFromExecutionNode(
 	// choose the last block ID to find the list of execution nodes
 	lastBlockID := blockIDs[len(blockIDs)-1]
 
-	execNodes, err := executionNodesForBlockID(ctx, lastBlockID, b.executionReceipts, b.state, b.log)
+	if b.agreement.enabled() {
+		return b.getBlockEventsWithAgreement(ctx, lastBlockID, blockHeaders, req)
+	}
+
+	execNodes, err := executionNodesForBlockID(ctx, lastBlockID, b.executionReceipts, b.state, b.connFactory, b.log)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to retrieve events from execution node: %v", err)
 	}
@@ -151,6 +157,35 @@ func (b *backendEvents) getBlockEventsFromExecutionNode(
 	return results, nil
 }
 
+// getBlockEventsWithAgreement retrieves the events from the required number of execution nodes which committed to the
+// same execution result for the last requested block, and returns them only if all of them returned the same events
+func (b *backendEvents) getBlockEventsWithAgreement(
+	ctx context.Context,
+	lastBlockID flow.Identifier,
+	blockHeaders []*flow.Header,
+	req execproto.GetEventsForBlockIDsRequest,
+) ([]flow.BlockEvents, error) {
+
+	execNodes, err := b.agreement.executionNodes(ctx, lastBlockID, b.executionReceipts, b.state, b.connFactory)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to retrieve events from execution node: %v", err)
+	}
+
+	resp, err := b.agreement.query(lastBlockID, execNodes, func(execNode *flow.Identity) (proto.Message, error) {
+		return b.tryGetEvents(ctx, execNode, req)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to retrieve events from execution nodes %s: %v", execNodes, err)
+	}
+
+	results, err := verifyAndConvertToAccessEvents(resp.(*execproto.GetEventsForBlockIDsResponse).GetResults(), blockHeaders)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify retrieved events from execution node: %v", err)
+	}
+
+	return results, nil
+}
+
 // verifyAndConvertToAccessEvents converts execution node api result to access node api result, and verifies that the results contains
 // results from each block that was requested
 func verifyAndConvertToAccessEvents(execEvents []*execproto.GetEventsForBlockIDsResponse_Result, requestedBlockHeaders []*flow.Header) ([]flow.BlockEvents, error) {
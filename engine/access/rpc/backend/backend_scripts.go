@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/go-multierror"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
@@ -20,6 +21,7 @@ type backendScripts struct {
 	state             protocol.State
 	connFactory       ConnectionFactory
 	log               zerolog.Logger
+	agreement         executionAgreement
 }
 
 func (b *backendScripts) ExecuteScriptAtLatestBlock(
@@ -85,8 +87,12 @@ func (b *backendScripts) executeScriptOnExecutionNode(
 		Arguments: arguments,
 	}
 
+	if b.agreement.enabled() {
+		return b.executeScriptWithAgreement(ctx, blockID, execReq)
+	}
+
 	// find few execution nodes which have executed the block earlier and provided an execution receipt for it
-	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.connFactory, b.log)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to execute the script on the execution node: %v", err)
 	}
@@ -109,6 +115,33 @@ func (b *backendScripts) executeScriptOnExecutionNode(
 	return nil, errors.ErrorOrNil()
 }
 
+// executeScriptWithAgreement executes the script on the required number of execution nodes which committed to the
+// same execution result for the block, and returns the result only if all of them returned the same value
+func (b *backendScripts) executeScriptWithAgreement(
+	ctx context.Context,
+	blockID flow.Identifier,
+	execReq execproto.ExecuteScriptAtBlockIDRequest,
+) ([]byte, error) {
+
+	execNodes, err := b.agreement.executionNodes(ctx, blockID, b.executionReceipts, b.state, b.connFactory)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to execute the script on the execution node: %v", err)
+	}
+
+	resp, err := b.agreement.query(blockID, execNodes, func(execNode *flow.Identity) (proto.Message, error) {
+		value, err := b.tryExecuteScript(ctx, execNode, execReq)
+		if err != nil {
+			return nil, err
+		}
+		return &execproto.ExecuteScriptAtBlockIDResponse{Value: value}, nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to execute the script on the execution nodes: %v", err)
+	}
+
+	return resp.(*execproto.ExecuteScriptAtBlockIDResponse).GetValue(), nil
+}
+
 func (b *backendScripts) tryExecuteScript(ctx context.Context, execNode *flow.Identity, req execproto.ExecuteScriptAtBlockIDRequest) ([]byte, error) {
 	execRPCClient, closer, err := b.connFactory.GetExecutionAPIClient(execNode.Address)
 	if err != nil {
@@ -94,6 +94,8 @@ func (suite *Suite) TestPing() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -118,6 +120,8 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -150,6 +154,8 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot() {
 		100,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -181,6 +187,8 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -219,6 +227,8 @@ func (suite *Suite) TestGetTransaction() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -254,6 +264,8 @@ func (suite *Suite) TestGetCollection() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -335,6 +347,8 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		DefaultMaxHeightRange,
 		nil,
 		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -453,6 +467,8 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -610,6 +626,8 @@ func (suite *Suite) TestTransactionPendingToFinalizedStatusTransition() {
 		100,
 		nil,
 		flow.IdentifierList(enIDs.NodeIDs()).Strings(),
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -666,6 +684,8 @@ func (suite *Suite) TestTransactionResultUnknown() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -707,6 +727,8 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -831,6 +853,8 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			DefaultMaxHeightRange,
 			nil,
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -858,6 +882,8 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			DefaultMaxHeightRange,
 			nil,
 			validENIDs.Strings(),
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -913,6 +939,8 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			DefaultMaxHeightRange,
 			nil,
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -939,6 +967,8 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			DefaultMaxHeightRange,
 			nil,
 			validENIDs.Strings(),
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -1082,6 +1112,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			DefaultMaxHeightRange,
 			nil,
 			nil,
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -1117,6 +1149,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			DefaultMaxHeightRange,
 			nil,
 			fixedENIdentifiersStr,
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -1151,6 +1185,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			DefaultMaxHeightRange,
 			nil,
 			fixedENIdentifiersStr,
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -1184,6 +1220,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			1, // set maximum range to 1
 			nil,
 			fixedENIdentifiersStr,
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -1217,6 +1255,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			DefaultMaxHeightRange,
 			nil,
 			fixedENIdentifiersStr,
+			0,
+			metrics.NewNoopCollector(),
 			suite.log,
 		)
 
@@ -1289,6 +1329,8 @@ func (suite *Suite) TestGetAccount() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -1365,6 +1407,8 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -1395,6 +1439,8 @@ func (suite *Suite) TestGetNetworkParameters() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		0,
+		metrics.NewNoopCollector(),
 		suite.log,
 	)
 
@@ -1464,7 +1510,7 @@ func (suite *Suite) TestExecutionNodesForBlockID() {
 		if fixedENs != nil {
 			fixedENIdentifiers = fixedENs.NodeIDs()
 		}
-		actualList, err := executionNodesForBlockID(context.Background(), block.ID(), suite.receipts, suite.state, suite.log)
+		actualList, err := executionNodesForBlockID(context.Background(), block.ID(), suite.receipts, suite.state, nil, suite.log)
 		require.NoError(suite.T(), err)
 		if expectedENs == nil {
 			expectedENs = flow.IdentityList{}
@@ -126,8 +126,8 @@ func (b *backendTransactions) chooseCollectionNodes(tx *flow.TransactionBody, sa
 		return nil, fmt.Errorf("could not get local cluster by txID: %x", tx.ID())
 	}
 
-	// select a random subset of collection nodes from the cluster to be tried in order
-	targetNodes := txCluster.Sample(sampleSize)
+	// select a random subset of the healthy collection nodes from the cluster to be tried in order
+	targetNodes := healthyNodes(txCluster, b.connFactory).Sample(sampleSize)
 
 	// collect the addresses of all the chosen collection nodes
 	var targetAddrs = make([]string, len(targetNodes))
@@ -143,7 +143,6 @@ func (b *backendTransactions) sendTransactionToCollector(ctx context.Context,
 	tx *flow.TransactionBody,
 	collectionNodeAddr string) error {
 
-	// TODO: Use a connection pool to cache connections
 	collectionRPC, conn, err := b.connFactory.GetAccessAPIClient(collectionNodeAddr)
 	if err != nil {
 		return fmt.Errorf("failed to connect to collection node at %s: %w", collectionNodeAddr, err)
@@ -441,7 +440,7 @@ func (b *backendTransactions) getTransactionResultFromExecutionNode(
 		TransactionId: transactionID,
 	}
 
-	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.connFactory, b.log)
 	if err != nil {
 		// if no execution receipt were found, return a NotFound GRPC error
 		if errors.As(err, &InsufficientExecutionReceipts{}) {
@@ -0,0 +1,130 @@
+package backend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/module"
+)
+
+// CircuitBreakerConfig configures when upstream nodes are taken out of rotation.
+type CircuitBreakerConfig struct {
+	MaxFailures uint          // number of consecutive failed requests after which a node is taken out of rotation, 0 disables the circuit breaker
+	Backoff     time.Duration // time a node is kept out of rotation, doubled every time it fails again right after
+	MaxBackoff  time.Duration // upper bound of the time a node is kept out of rotation
+}
+
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		MaxFailures: 5,
+		Backoff:     10 * time.Second,
+		MaxBackoff:  5 * time.Minute,
+	}
+}
+
+// CircuitBreaker keeps track of the failing requests to each upstream node, and takes a node
+// out of rotation for a backoff period once it failed too many requests in a row.
+// Once the backoff has passed, requests to the node are allowed again: a successful request
+// closes the breaker, while a failed request takes the node out of rotation again, for twice
+// as long as before.
+type CircuitBreaker struct {
+	mu      sync.Mutex
+	log     zerolog.Logger
+	metrics module.AccessMetrics
+	config  CircuitBreakerConfig
+	nodes   map[string]*nodeBreaker // breaker state by node address
+}
+
+type nodeBreaker struct {
+	failures  uint          // number of consecutive failed requests
+	backoff   time.Duration // how long the node was last taken out of rotation
+	openUntil time.Time     // the node is out of rotation until this time
+}
+
+func NewCircuitBreaker(log zerolog.Logger, metrics module.AccessMetrics, config CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		log:     log.With().Str("component", "circuit_breaker").Logger(),
+		metrics: metrics,
+		config:  config,
+		nodes:   make(map[string]*nodeBreaker),
+	}
+}
+
+// IsOpen returns true if the node at the given address is currently out of rotation.
+func (cb *CircuitBreaker) IsOpen(address string) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	node, ok := cb.nodes[address]
+	if !ok {
+		return false
+	}
+	return time.Now().Before(node.openUntil)
+}
+
+// OnSuccess records a successful request to the node at the given address.
+func (cb *CircuitBreaker) OnSuccess(address string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	// the node is healthy again, forget about its earlier failures
+	delete(cb.nodes, address)
+}
+
+// OnFailure records a failed request to the node at the given address, and takes the node
+// out of rotation if it failed too many requests in a row.
+func (cb *CircuitBreaker) OnFailure(address string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	node, ok := cb.nodes[address]
+	if !ok {
+		node = &nodeBreaker{}
+		cb.nodes[address] = node
+	}
+
+	now := time.Now()
+	if now.Before(node.openUntil) {
+		// requests that were already in flight when the breaker tripped
+		return
+	}
+
+	node.failures++
+	if node.failures < cb.config.MaxFailures {
+		return
+	}
+
+	// after the backoff the node is given another chance, if it fails again it is kept out of
+	// rotation for twice as long
+	if node.backoff == 0 {
+		node.backoff = cb.config.Backoff
+	} else {
+		node.backoff *= 2
+	}
+	if node.backoff > cb.config.MaxBackoff {
+		node.backoff = cb.config.MaxBackoff
+	}
+	node.openUntil = now.Add(node.backoff)
+	node.failures = cb.config.MaxFailures - 1
+
+	cb.metrics.CircuitBreakerTripped()
+	cb.log.Warn().
+		Str("address", address).
+		Dur("backoff", node.backoff).
+		Msg("upstream node taken out of rotation after failing requests")
+}
+
+// isNodeFailure returns true if the error returned by a request indicates the upstream node
+// is unavailable, as opposed to the request being invalid or the data not being found.
+func isNodeFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,84 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestCircuitBreaker tests that a node is taken out of rotation after failing requests in a row, and
+// is given another chance after the backoff
+func TestCircuitBreaker(t *testing.T) {
+	accessMetrics := new(mock.AccessMetrics)
+	accessMetrics.On("CircuitBreakerTripped").Return()
+
+	backoff := 100 * time.Millisecond
+	cb := NewCircuitBreaker(unittest.Logger(), accessMetrics, CircuitBreakerConfig{
+		MaxFailures: 3,
+		Backoff:     backoff,
+		MaxBackoff:  time.Minute,
+	})
+	address := "en:3569"
+
+	// a success in between resets the count of failures
+	cb.OnFailure(address)
+	cb.OnFailure(address)
+	cb.OnSuccess(address)
+	cb.OnFailure(address)
+	cb.OnFailure(address)
+	assert.False(t, cb.IsOpen(address))
+
+	cb.OnFailure(address)
+	assert.True(t, cb.IsOpen(address))
+	assert.False(t, cb.IsOpen("other:3569"))
+	accessMetrics.AssertNumberOfCalls(t, "CircuitBreakerTripped", 1)
+
+	// requests in flight while the node is out of rotation don't extend the backoff
+	cb.OnFailure(address)
+	accessMetrics.AssertNumberOfCalls(t, "CircuitBreakerTripped", 1)
+
+	// after the backoff the node is back in rotation, but a single failure takes it out again
+	require.Eventually(t, func() bool { return !cb.IsOpen(address) }, time.Second, 10*time.Millisecond)
+	cb.OnFailure(address)
+	assert.True(t, cb.IsOpen(address))
+	accessMetrics.AssertNumberOfCalls(t, "CircuitBreakerTripped", 2)
+	assert.Equal(t, 2*backoff, cb.nodes[address].backoff)
+
+	// a successful request closes the breaker
+	require.Eventually(t, func() bool { return !cb.IsOpen(address) }, time.Second, 10*time.Millisecond)
+	cb.OnSuccess(address)
+	cb.OnFailure(address)
+	assert.False(t, cb.IsOpen(address))
+}
+
+// TestHealthyNodes tests that the nodes out of rotation are left out when choosing upstream nodes
+func TestHealthyNodes(t *testing.T) {
+	nodes := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+
+	cb := NewCircuitBreaker(unittest.Logger(), metrics.NewNoopCollector(), CircuitBreakerConfig{
+		MaxFailures: 1,
+		Backoff:     time.Minute,
+		MaxBackoff:  time.Minute,
+	})
+	connFactory := &ConnectionFactoryImpl{CircuitBreaker: cb}
+
+	assert.Equal(t, nodes, healthyNodes(nodes, connFactory))
+
+	cb.OnFailure(nodes[1].Address)
+	assert.Equal(t, flow.IdentityList{nodes[0], nodes[2]}, healthyNodes(nodes, connFactory))
+
+	// if all nodes are out of rotation, they are all returned
+	cb.OnFailure(nodes[0].Address)
+	cb.OnFailure(nodes[2].Address)
+	assert.Equal(t, nodes, healthyNodes(nodes, connFactory))
+
+	// connection factories which don't track the health of nodes return all nodes
+	assert.Equal(t, nodes, healthyNodes(nodes, nil))
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 
+	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/utils/grpcutils"
 )
 
@@ -23,6 +25,13 @@ type ConnectionFactory interface {
 	GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error)
 }
 
+// NodeHealthChecker is implemented by connection factories which keep track of the health of the
+// upstream nodes, so that the nodes which are failing requests are not chosen for new requests.
+type NodeHealthChecker interface {
+	// IsHealthy returns false if the node at the given address is currently out of rotation.
+	IsHealthy(address string) bool
+}
+
 type ProxyConnectionFactory struct {
 	ConnectionFactory
 	targetAddress string
@@ -41,57 +50,124 @@ type ConnectionFactoryImpl struct {
 	ExecutionGRPCPort         uint
 	CollectionNodeGRPCTimeout time.Duration
 	ExecutionNodeGRPCTimeout  time.Duration
+	KeepaliveTime             time.Duration        // time after which an idle connection is pinged, 0 disables keepalive pings
+	ConnectionPool            *ConnectionPool      // caches the connections, if nil a connection is dialed for every request
+	CircuitBreaker            *CircuitBreaker      // takes failing nodes out of rotation, if nil all nodes are always used
+	AccessMetrics             module.AccessMetrics // if nil, no metrics are reported
 }
 
+var _ NodeHealthChecker = (*ConnectionFactoryImpl)(nil)
+
 // createConnection creates new gRPC connections to remote node
-func (cf *ConnectionFactoryImpl) createConnection(address string, timeout time.Duration) (*grpc.ClientConn, error) {
+func (cf *ConnectionFactoryImpl) createConnection(nodeAddress string, address string, timeout time.Duration) (*grpc.ClientConn, error) {
 
 	if timeout == 0 {
 		timeout = defaultClientTimeout
 	}
 
-	conn, err := grpc.Dial(
-		address,
+	opts := []grpc.DialOption{
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
 		grpc.WithInsecure(),
-		WithClientUnaryInterceptor(timeout))
+		grpc.WithChainUnaryInterceptor(
+			cf.circuitBreakerInterceptor(nodeAddress),
+			clientTimeoutInterceptor(timeout),
+		),
+	}
+	if cf.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cf.KeepaliveTime,
+			Timeout: timeout,
+		}))
+	}
+
+	conn, err := grpc.Dial(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to address %s: %w", address, err)
 	}
+	if cf.AccessMetrics != nil {
+		cf.AccessMetrics.ConnectionDialed()
+	}
 	return conn, nil
 }
 
-func (cf *ConnectionFactoryImpl) GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error) {
+// retrieveConnection returns a connection to the node at the given address, from the connection pool if there is one.
+func (cf *ConnectionFactoryImpl) retrieveConnection(nodeAddress string, grpcPort uint, timeout time.Duration) (*grpc.ClientConn, io.Closer, error) {
 
-	grpcAddress, err := getGRPCAddress(address, cf.CollectionGRPCPort)
+	if cf.CircuitBreaker != nil && cf.CircuitBreaker.IsOpen(nodeAddress) {
+		return nil, nil, fmt.Errorf("node at address %s is out of rotation after failing requests", nodeAddress)
+	}
+
+	grpcAddress, err := getGRPCAddress(nodeAddress, grpcPort)
 	if err != nil {
 		return nil, nil, err
 	}
-	conn, err := cf.createConnection(grpcAddress, cf.CollectionNodeGRPCTimeout)
+
+	dial := func() (*grpc.ClientConn, error) {
+		return cf.createConnection(nodeAddress, grpcAddress, timeout)
+	}
+
+	if cf.ConnectionPool != nil {
+		return cf.ConnectionPool.Get(grpcAddress, dial)
+	}
+
+	conn, err := dial()
 	if err != nil {
 		return nil, nil, err
 	}
-	accessAPIClient := access.NewAccessAPIClient(conn)
-	closer := io.Closer(conn)
-	return accessAPIClient, closer, nil
+	return conn, io.Closer(conn), nil
 }
 
-func (cf *ConnectionFactoryImpl) GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error) {
+func (cf *ConnectionFactoryImpl) GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error) {
 
-	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	conn, closer, err := cf.retrieveConnection(address, cf.CollectionGRPCPort, cf.CollectionNodeGRPCTimeout)
 	if err != nil {
 		return nil, nil, err
 	}
+	accessAPIClient := access.NewAccessAPIClient(conn)
+	return accessAPIClient, closer, nil
+}
 
-	conn, err := cf.createConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
+func (cf *ConnectionFactoryImpl) GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error) {
+
+	conn, closer, err := cf.retrieveConnection(address, cf.ExecutionGRPCPort, cf.ExecutionNodeGRPCTimeout)
 	if err != nil {
 		return nil, nil, err
 	}
 	executionAPIClient := execution.NewExecutionAPIClient(conn)
-	closer := io.Closer(conn)
 	return executionAPIClient, closer, nil
 }
 
+// IsHealthy returns false if the node at the given address has been taken out of rotation by the circuit breaker.
+func (cf *ConnectionFactoryImpl) IsHealthy(address string) bool {
+	return cf.CircuitBreaker == nil || !cf.CircuitBreaker.IsOpen(address)
+}
+
+// circuitBreakerInterceptor reports the outcome of the requests to the node at the given address to the circuit breaker.
+func (cf *ConnectionFactoryImpl) circuitBreakerInterceptor(nodeAddress string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req interface{},
+		reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		if cf.CircuitBreaker != nil {
+			if isNodeFailure(err) {
+				cf.CircuitBreaker.OnFailure(nodeAddress)
+			} else {
+				cf.CircuitBreaker.OnSuccess(nodeAddress)
+			}
+		}
+
+		return err
+	}
+}
+
 // getExecutionNodeAddress translates flow.Identity address to the GRPC address of the node by switching the port to the
 // GRPC port from the libp2p port
 func getGRPCAddress(address string, grpcPort uint) (string, error) {
@@ -107,8 +183,13 @@ func getGRPCAddress(address string, grpcPort uint) (string, error) {
 }
 
 func WithClientUnaryInterceptor(timeout time.Duration) grpc.DialOption {
+	return grpc.WithUnaryInterceptor(clientTimeoutInterceptor(timeout))
+}
 
-	clientTimeoutInterceptor := func(
+// clientTimeoutInterceptor bounds each request by the given timeout.
+func clientTimeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+
+	return func(
 		ctx context.Context,
 		method string,
 		req interface{},
@@ -128,6 +209,4 @@ func WithClientUnaryInterceptor(timeout time.Duration) grpc.DialOption {
 
 		return err
 	}
-
-	return grpc.WithUnaryInterceptor(clientTimeoutInterceptor)
 }
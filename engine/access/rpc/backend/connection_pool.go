@@ -0,0 +1,141 @@
+package backend
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/onflow/flow-go/module"
+)
+
+// ConnectionPoolConfig configures the caching of connections to upstream nodes.
+type ConnectionPoolConfig struct {
+	MaxIdleTime   time.Duration // time after which an unused connection is closed, 0 disables the pool
+	KeepaliveTime time.Duration // time after which a connection without activity is pinged to check it is still alive
+}
+
+func DefaultConnectionPoolConfig() ConnectionPoolConfig {
+	return ConnectionPoolConfig{
+		MaxIdleTime: 5 * time.Minute,
+		// pinging more often than every 5 minutes is rejected by the default keepalive enforcement of gRPC servers
+		KeepaliveTime: 5 * time.Minute,
+	}
+}
+
+// ConnectionPool caches the gRPC connections to upstream nodes by address, so that they are
+// reused across requests instead of being dialed for every request. Connections are closed
+// once they have not been used for the configured idle time.
+type ConnectionPool struct {
+	mu      sync.Mutex
+	log     zerolog.Logger
+	metrics module.AccessMetrics
+	config  ConnectionPoolConfig
+	conns   map[string]*pooledConnection // connections by gRPC address
+}
+
+type pooledConnection struct {
+	conn     *grpc.ClientConn
+	users    uint      // number of requests currently using the connection
+	lastUsed time.Time // when the connection was last released
+}
+
+func NewConnectionPool(log zerolog.Logger, metrics module.AccessMetrics, config ConnectionPoolConfig) *ConnectionPool {
+	return &ConnectionPool{
+		log:     log.With().Str("component", "connection_pool").Logger(),
+		metrics: metrics,
+		config:  config,
+		conns:   make(map[string]*pooledConnection),
+	}
+}
+
+// Get returns the cached connection to the given address, or dials a new one if there is no
+// usable connection cached. The returned closer must be called once the request is done, it
+// releases the connection back to the pool rather than closing it.
+func (p *ConnectionPool) Get(address string, dial func() (*grpc.ClientConn, error)) (*grpc.ClientConn, io.Closer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	p.evictIdle(now)
+
+	pooled, ok := p.conns[address]
+	if ok && pooled.conn.GetState() == connectivity.Shutdown {
+		p.remove(address, pooled)
+		ok = false
+	}
+
+	if !ok {
+		conn, err := dial()
+		if err != nil {
+			return nil, nil, err
+		}
+		pooled = &pooledConnection{
+			conn:     conn,
+			lastUsed: now,
+		}
+		p.conns[address] = pooled
+		p.metrics.ConnectionPoolSize(len(p.conns))
+	}
+
+	pooled.users++
+	return pooled.conn, &connectionRelease{pool: p, pooled: pooled}, nil
+}
+
+// Close closes all cached connections.
+func (p *ConnectionPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for address, pooled := range p.conns {
+		p.remove(address, pooled)
+	}
+}
+
+// Size returns the number of cached connections.
+func (p *ConnectionPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.conns)
+}
+
+// evictIdle closes the connections that have not been used for longer than the max idle time.
+func (p *ConnectionPool) evictIdle(now time.Time) {
+	for address, pooled := range p.conns {
+		if pooled.users == 0 && now.Sub(pooled.lastUsed) > p.config.MaxIdleTime {
+			p.remove(address, pooled)
+		}
+	}
+}
+
+func (p *ConnectionPool) remove(address string, pooled *pooledConnection) {
+	delete(p.conns, address)
+	p.metrics.ConnectionPoolSize(len(p.conns))
+
+	err := pooled.conn.Close()
+	if err != nil {
+		p.log.Debug().Err(err).Str("address", address).Msg("failed to close connection")
+	}
+}
+
+// connectionRelease releases a pooled connection once a request is done with it.
+type connectionRelease struct {
+	pool   *ConnectionPool
+	pooled *pooledConnection
+	once   sync.Once
+}
+
+func (r *connectionRelease) Close() error {
+	r.once.Do(func() {
+		r.pool.mu.Lock()
+		defer r.pool.mu.Unlock()
+
+		r.pooled.users--
+		r.pooled.lastUsed = time.Now()
+	})
+	return nil
+}
@@ -0,0 +1,125 @@
+package backend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestConnectionPool tests that connections are reused across requests, and closed once idle
+func TestConnectionPool(t *testing.T) {
+	// create an execution node
+	en := new(executionNode)
+	en.start(t)
+	defer en.stop(t)
+
+	req := &execution.PingRequest{}
+	expected := &execution.PingResponse{}
+	en.handler.On("Ping", testifymock.Anything, req).Return(expected, nil)
+
+	maxIdleTime := 100 * time.Millisecond
+	pool := NewConnectionPool(unittest.Logger(), metrics.NewNoopCollector(), ConnectionPoolConfig{
+		MaxIdleTime: maxIdleTime,
+	})
+	defer pool.Close()
+
+	// create the factory
+	connectionFactory := new(ConnectionFactoryImpl)
+	connectionFactory.ExecutionGRPCPort = en.port
+	connectionFactory.ConnectionPool = pool
+
+	address := en.listener.Addr().String()
+	client1, closer1, err := connectionFactory.GetExecutionAPIClient(address)
+	require.NoError(t, err)
+	client2, closer2, err := connectionFactory.GetExecutionAPIClient(address)
+	require.NoError(t, err)
+
+	// both clients share the same connection
+	assert.Equal(t, 1, pool.Size())
+
+	ctx := context.Background()
+	resp, err := client1.Ping(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+	resp, err = client2.Ping(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+
+	// releasing a connection doesn't close it
+	assert.NoError(t, closer1.Close())
+	assert.NoError(t, closer2.Close())
+	assert.NoError(t, closer2.Close()) // releasing twice has no effect
+	assert.Equal(t, 1, pool.Size())
+
+	// connections in use are not evicted
+	time.Sleep(2 * maxIdleTime)
+	_, closer3, err := connectionFactory.GetExecutionAPIClient(address)
+	require.NoError(t, err)
+	conn := pool.conns[address].conn
+	assert.Equal(t, 1, pool.Size())
+
+	// once idle for too long, the connection is closed and a new one is dialed
+	assert.NoError(t, closer3.Close())
+	time.Sleep(2 * maxIdleTime)
+	client4, closer4, err := connectionFactory.GetExecutionAPIClient(address)
+	require.NoError(t, err)
+	defer closer4.Close()
+	assert.Equal(t, 1, pool.Size())
+	assert.NotSame(t, conn, pool.conns[address].conn)
+
+	resp, err = client4.Ping(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+// TestCircuitBreakerTakesNodeOutOfRotation tests that an execution node which fails requests is taken out of rotation
+func TestCircuitBreakerTakesNodeOutOfRotation(t *testing.T) {
+
+	timeout := 10 * time.Millisecond
+
+	// create an execution node
+	en := new(executionNode)
+	en.start(t)
+	defer en.stop(t)
+
+	// setup the handler mock to not respond within the timeout
+	resp := &execution.PingResponse{}
+	en.handler.On("Ping", testifymock.Anything, testifymock.Anything).After(timeout+time.Second).Return(resp, nil)
+
+	// create the factory
+	connectionFactory := new(ConnectionFactoryImpl)
+	connectionFactory.ExecutionGRPCPort = en.port
+	connectionFactory.ExecutionNodeGRPCTimeout = timeout
+	connectionFactory.CircuitBreaker = NewCircuitBreaker(unittest.Logger(), metrics.NewNoopCollector(), CircuitBreakerConfig{
+		MaxFailures: 2,
+		Backoff:     time.Minute,
+		MaxBackoff:  time.Minute,
+	})
+
+	address := en.listener.Addr().String()
+	client, closer, err := connectionFactory.GetExecutionAPIClient(address)
+	require.NoError(t, err)
+	defer closer.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		assert.True(t, connectionFactory.IsHealthy(address))
+		_, err = client.Ping(ctx, &execution.PingRequest{})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	}
+
+	// the node is out of rotation
+	assert.False(t, connectionFactory.IsHealthy(address))
+	_, _, err = connectionFactory.GetExecutionAPIClient(address)
+	assert.Error(t, err)
+}
@@ -2,6 +2,9 @@ package backend
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
 
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -15,3 +18,47 @@ type InsufficientExecutionReceipts struct {
 func (e InsufficientExecutionReceipts) Error() string {
 	return fmt.Sprintf("insufficient execution receipts found (%d) for block ID: %s", e.receiptCount, e.blockID.String())
 }
+
+// InsufficientExecutionAgreement indicates that fewer execution nodes than required replied successfully to a request
+type InsufficientExecutionAgreement struct {
+	blockID  flow.Identifier
+	required uint
+	replied  uint
+	errors   *multierror.Error
+}
+
+func (e InsufficientExecutionAgreement) Error() string {
+	return fmt.Sprintf("only %d of %d required execution nodes replied for block ID %s: %v",
+		e.replied, e.required, e.blockID.String(), e.errors.ErrorOrNil())
+}
+
+// Errors returns the errors returned by the execution nodes which failed to reply
+func (e InsufficientExecutionAgreement) Errors() []error {
+	return e.errors.WrappedErrors()
+}
+
+// ExecutionResultMismatch indicates that execution nodes which committed to the same execution result
+// returned different responses for the same request
+type ExecutionResultMismatch struct {
+	blockID   flow.Identifier
+	responses []executionResponse
+}
+
+// executionResponse is a distinct response returned by one or more execution nodes
+type executionResponse struct {
+	digest    string
+	executors flow.IdentifierList
+}
+
+func (e ExecutionResultMismatch) Error() string {
+	return fmt.Sprintf("execution nodes committed to the same result for block ID %s returned different responses: %s",
+		e.blockID.String(), e.describeResponses())
+}
+
+func (e ExecutionResultMismatch) describeResponses() string {
+	descriptions := make([]string, 0, len(e.responses))
+	for _, response := range e.responses {
+		descriptions = append(descriptions, fmt.Sprintf("response %s from %v", response.digest, response.executors))
+	}
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,159 @@
+package backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// executionAgreement cross-checks the responses of execution nodes which committed to the same execution
+// result. When enabled, a request is answered only once the required number of execution nodes returned
+// byte-for-byte identical responses for it.
+type executionAgreement struct {
+	required uint
+	metrics  module.AccessMetrics
+	log      zerolog.Logger
+}
+
+func newExecutionAgreement(required uint, metrics module.AccessMetrics, log zerolog.Logger) executionAgreement {
+	return executionAgreement{
+		required: required,
+		metrics:  metrics,
+		log:      log.With().Str("component", "execution_agreement").Logger(),
+	}
+}
+
+// enabled returns true if the responses of more than one execution node are required to agree
+func (a executionAgreement) enabled() bool {
+	return a.required > 1
+}
+
+// executionNodes returns all execution nodes which committed to the execution result most receipts for
+// the given block ID agree on. The preferred or fixed execution nodes come first, followed by the remaining
+// nodes in random order. Nodes the connection factory considers unhealthy are moved to the end of the list.
+// If fewer execution nodes than required committed to the result, an InsufficientExecutionReceipts error
+// is returned.
+func (a executionAgreement) executionNodes(
+	ctx context.Context,
+	blockID flow.Identifier,
+	executionReceipts storage.ExecutionReceipts,
+	state protocol.State,
+	connFactory ConnectionFactory,
+) (flow.IdentityList, error) {
+
+	executorIDs, err := executorsForBlockID(ctx, blockID, executionReceipts, state, a.log)
+	if err != nil {
+		return nil, err
+	}
+
+	allENs, err := state.Final().Identities(filter.HasRole(flow.RoleExecution))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retreive all execution IDs: %w", err)
+	}
+	executors := allENs.Filter(filter.HasNodeID(executorIDs...))
+	if uint(len(executors)) < a.required {
+		return nil, InsufficientExecutionReceipts{blockID: blockID, receiptCount: len(executors)}
+	}
+
+	// the fixed execution nodes are returned even if they have not executed the block, only keep the executors
+	chosen, err := chooseExecutionNodes(state, executorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retreive execution IDs for block ID %v: %w", blockID, err)
+	}
+	chosen = chosen.Filter(filter.HasNodeID(executorIDs...))
+	chosen = chosen.Sample(uint(len(chosen)))
+
+	remaining := executors.Filter(filter.Not(filter.HasNodeID(chosen.NodeIDs()...)))
+	remaining = remaining.Sample(uint(len(remaining)))
+
+	ordered := append(chosen, remaining...)
+	healthy := healthyNodes(ordered, connFactory)
+	if len(healthy) == len(ordered) {
+		return ordered, nil
+	}
+	return append(healthy, ordered.Filter(filter.Not(filter.HasNodeID(healthy.NodeIDs()...)))...), nil
+}
+
+// query sends the request to the given execution nodes one after the other, until the required number of
+// them replied successfully, and returns the response if all of the replies are identical. If fewer execution
+// nodes than required replied successfully, an InsufficientExecutionAgreement error is returned. If the replies
+// differ, the mismatch is reported and an ExecutionResultMismatch error is returned.
+func (a executionAgreement) query(
+	blockID flow.Identifier,
+	execNodes flow.IdentityList,
+	request func(execNode *flow.Identity) (proto.Message, error),
+) (proto.Message, error) {
+
+	var errors *multierror.Error
+	var response proto.Message
+	replies := make(map[string]flow.IdentifierList)
+	var digests []string
+	replied := uint(0)
+
+	for _, execNode := range execNodes {
+		if replied == a.required {
+			break
+		}
+
+		resp, err := request(execNode)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+
+		encoded, err := protov2.MarshalOptions{Deterministic: true}.Marshal(proto.MessageV2(resp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode response from execution node %v: %w", execNode.NodeID, err)
+		}
+		sum := sha256.Sum256(encoded)
+		digest := hex.EncodeToString(sum[:])
+
+		if _, ok := replies[digest]; !ok {
+			digests = append(digests, digest)
+		}
+		replies[digest] = append(replies[digest], execNode.NodeID)
+		response = resp
+		replied++
+	}
+
+	if replied < a.required {
+		return nil, InsufficientExecutionAgreement{
+			blockID:  blockID,
+			required: a.required,
+			replied:  replied,
+			errors:   errors,
+		}
+	}
+
+	if len(digests) > 1 {
+		mismatch := ExecutionResultMismatch{blockID: blockID}
+		for _, digest := range digests {
+			mismatch.responses = append(mismatch.responses, executionResponse{
+				digest:    digest,
+				executors: replies[digest],
+			})
+		}
+
+		a.metrics.ExecutionResultMismatch()
+		a.log.Error().
+			Hex("block_id", blockID[:]).
+			Str("responses", mismatch.describeResponses()).
+			Msg("execution nodes committed to the same result returned different responses")
+
+		return nil, mismatch
+	}
+
+	return response, nil
+}
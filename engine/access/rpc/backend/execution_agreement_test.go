@@ -0,0 +1,244 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	entitiesproto "github.com/onflow/flow/protobuf/go/flow/entities"
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	access "github.com/onflow/flow-go/engine/access/mock"
+	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestExecuteScriptWithAgreement tests executing a script when the responses of two execution nodes are required to agree
+func (suite *Suite) TestExecuteScriptWithAgreement() {
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+	script := []byte("pub fun main() { return 1 }")
+	execReq := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId: blockID[:],
+		Script:  script,
+	}
+
+	_, ids := suite.setupReceipts(&block)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	// setup a separate execution client for each of the execution nodes
+	setup := func(value1, value2 []byte, err2 error) (*backendmock.ConnectionFactory, *modulemock.AccessMetrics) {
+		execClient1 := new(access.ExecutionAPIClient)
+		execClient1.On("ExecuteScriptAtBlockID", mock.Anything, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: value1}, nil)
+		execClient2 := new(access.ExecutionAPIClient)
+		execClient2.On("ExecuteScriptAtBlockID", mock.Anything, execReq).
+			Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: value2}, err2)
+
+		connFactory := new(backendmock.ConnectionFactory)
+		connFactory.On("GetExecutionAPIClient", ids[0].Address).Return(execClient1, &mockCloser{}, nil)
+		connFactory.On("GetExecutionAPIClient", ids[1].Address).Return(execClient2, &mockCloser{}, nil)
+
+		return connFactory, new(modulemock.AccessMetrics)
+	}
+
+	newBackend := func(connFactory ConnectionFactory, accessMetrics *modulemock.AccessMetrics) *Backend {
+		return New(
+			suite.state,
+			nil, nil, nil, nil, nil, nil,
+			suite.receipts,
+			suite.results,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			connFactory,
+			false,
+			DefaultMaxHeightRange,
+			nil,
+			nil,
+			2,
+			accessMetrics,
+			suite.log,
+		)
+	}
+
+	suite.Run("identical responses", func() {
+		connFactory, accessMetrics := setup([]byte{1}, []byte{1}, nil)
+		backend := newBackend(connFactory, accessMetrics)
+
+		value, err := backend.ExecuteScriptAtBlockID(context.Background(), blockID, script, nil)
+		suite.Require().NoError(err)
+		suite.Require().Equal([]byte{1}, value)
+
+		accessMetrics.AssertNotCalled(suite.T(), "ExecutionResultMismatch")
+	})
+
+	suite.Run("different responses", func() {
+		connFactory, accessMetrics := setup([]byte{1}, []byte{2}, nil)
+		accessMetrics.On("ExecutionResultMismatch").Once()
+		backend := newBackend(connFactory, accessMetrics)
+
+		_, err := backend.ExecuteScriptAtBlockID(context.Background(), blockID, script, nil)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+		suite.Require().Contains(err.Error(), ids[0].NodeID.String())
+		suite.Require().Contains(err.Error(), ids[1].NodeID.String())
+
+		accessMetrics.AssertExpectations(suite.T())
+	})
+
+	suite.Run("too few execution nodes reply", func() {
+		connFactory, accessMetrics := setup([]byte{1}, nil, errors.New("execution node unavailable"))
+		backend := newBackend(connFactory, accessMetrics)
+
+		_, err := backend.ExecuteScriptAtBlockID(context.Background(), blockID, script, nil)
+		suite.Require().Error(err)
+		suite.Require().Contains(err.Error(), "only 1 of 2 required execution nodes replied")
+
+		accessMetrics.AssertNotCalled(suite.T(), "ExecutionResultMismatch")
+	})
+
+	suite.Run("more execution nodes required than committed to the result", func() {
+		connFactory, accessMetrics := setup([]byte{1}, []byte{1}, nil)
+		backend := newBackend(connFactory, accessMetrics)
+		backend.backendScripts.agreement.required = 3
+
+		_, err := backend.ExecuteScriptAtBlockID(context.Background(), blockID, script, nil)
+		suite.Require().Error(err)
+		suite.Require().Contains(err.Error(), "insufficient execution receipts")
+	})
+}
+
+// TestGetAccountWithAgreement tests getting an account when the responses of two execution nodes are required to agree
+func (suite *Suite) TestGetAccountWithAgreement() {
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	block := unittest.BlockFixture()
+	header := block.Header
+	blockID := block.ID()
+	address := unittest.AddressFixture()
+	exeReq := &execproto.GetAccountAtBlockIDRequest{
+		BlockId: blockID[:],
+		Address: address.Bytes(),
+	}
+
+	_, ids := suite.setupReceipts(&block)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+	suite.headers.On("ByHeight", header.Height).Return(header, nil)
+
+	newBackend := func(resp1, resp2 *execproto.GetAccountAtBlockIDResponse, err1, err2 error) *Backend {
+		execClient1 := new(access.ExecutionAPIClient)
+		execClient1.On("GetAccountAtBlockID", mock.Anything, exeReq).Return(resp1, err1)
+		execClient2 := new(access.ExecutionAPIClient)
+		execClient2.On("GetAccountAtBlockID", mock.Anything, exeReq).Return(resp2, err2)
+
+		connFactory := new(backendmock.ConnectionFactory)
+		connFactory.On("GetExecutionAPIClient", ids[0].Address).Return(execClient1, &mockCloser{}, nil)
+		connFactory.On("GetExecutionAPIClient", ids[1].Address).Return(execClient2, &mockCloser{}, nil)
+
+		return New(
+			suite.state,
+			nil, nil, nil,
+			suite.headers,
+			nil, nil,
+			suite.receipts,
+			suite.results,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			connFactory,
+			false,
+			DefaultMaxHeightRange,
+			nil,
+			nil,
+			2,
+			metrics.NewNoopCollector(),
+			suite.log,
+		)
+	}
+
+	account := &entitiesproto.Account{
+		Address: address.Bytes(),
+		Balance: 10,
+		Contracts: map[string][]byte{
+			"A": []byte("contract A"),
+			"B": []byte("contract B"),
+			"C": []byte("contract C"),
+		},
+	}
+
+	suite.Run("identical accounts", func() {
+		backend := newBackend(
+			&execproto.GetAccountAtBlockIDResponse{Account: account},
+			&execproto.GetAccountAtBlockIDResponse{Account: account},
+			nil, nil,
+		)
+
+		result, err := backend.GetAccountAtBlockHeight(context.Background(), address, header.Height)
+		suite.Require().NoError(err)
+		suite.Require().Equal(address, result.Address)
+		suite.Require().Equal(uint64(10), result.Balance)
+	})
+
+	suite.Run("account not found", func() {
+		notFound := status.Error(codes.NotFound, "account not found")
+		backend := newBackend(nil, nil, notFound, notFound)
+
+		_, err := backend.GetAccountAtBlockHeight(context.Background(), address, header.Height)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+}
+
+// TestExecutionAgreementNodeOrder tests the preferred execution nodes are queried first and the unhealthy ones last
+func (suite *Suite) TestExecutionAgreementNodeOrder() {
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+
+	ids := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleExecution))
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	receipts := make(flow.ExecutionReceiptList, 0, len(ids))
+	for _, id := range ids {
+		receipt := unittest.ReceiptForBlockFixture(&block)
+		receipt.ExecutorID = id.NodeID
+		receipt.ExecutionResult = *result
+		receipts = append(receipts, receipt)
+	}
+	suite.receipts.On("ByBlockID", blockID).Return(receipts, nil)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	agreement := newExecutionAgreement(2, metrics.NewNoopCollector(), suite.log)
+	preferredENIdentifiers = flow.IdentifierList{ids[3].NodeID}
+	fixedENIdentifiers = nil
+	defer func() {
+		preferredENIdentifiers = nil
+	}()
+
+	connFactory := &healthCheckingConnectionFactory{
+		ConnectionFactory: new(backendmock.ConnectionFactory),
+		unhealthy:         ids[0].Address,
+	}
+
+	execNodes, err := agreement.executionNodes(context.Background(), blockID, suite.receipts, suite.state, connFactory)
+	suite.Require().NoError(err)
+	suite.Require().Len(execNodes, len(ids))
+	suite.Require().Equal(ids[3].NodeID, execNodes[0].NodeID)
+	suite.Require().Equal(ids[0].NodeID, execNodes[len(execNodes)-1].NodeID)
+}
+
+// healthCheckingConnectionFactory is a connection factory which considers a single node unhealthy
+type healthCheckingConnectionFactory struct {
+	ConnectionFactory
+	unhealthy string
+}
+
+func (f *healthCheckingConnectionFactory) IsHealthy(address string) bool {
+	return address != f.unhealthy
+}
@@ -0,0 +1,471 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru"
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// sporkByBlockIDCacheSize is the number of block IDs for which the spork serving the block is remembered
+const sporkByBlockIDCacheSize = 1000
+
+// unknownBlockIDsCacheSize is the number of block IDs remembered to be unknown to all historical sporks
+const unknownBlockIDsCacheSize = 1000
+
+// HistoricalSpork is a previous spork, served by the access node of that spork
+type HistoricalSpork struct {
+	RootHeight uint64                      // the height of the first block of the spork
+	EndHeight  uint64                      // the height of the last block of the spork
+	Address    string                      // the rpc address of the access node serving the spork
+	Client     accessproto.AccessAPIClient // the client of the access node serving the spork
+}
+
+// ParseHistoricalSporks parses a comma separated list of previous sporks, each given as the height range of the
+// spork and the rpc address of its access node, e.g. "0-99=access-001:9000,100-199=access-002:9000".
+// The height ranges of the sporks must not overlap.
+func ParseHistoricalSporks(value string) ([]HistoricalSpork, error) {
+	var sporks []HistoricalSpork
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid historical spork %q, expected <root height>-<end height>=<address>", entry)
+		}
+		heights := strings.SplitN(parts[0], "-", 2)
+		if len(heights) != 2 {
+			return nil, fmt.Errorf("invalid height range %q of historical spork %q", parts[0], entry)
+		}
+		rootHeight, err := strconv.ParseUint(strings.TrimSpace(heights[0]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid root height of historical spork %q: %w", entry, err)
+		}
+		endHeight, err := strconv.ParseUint(strings.TrimSpace(heights[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end height of historical spork %q: %w", entry, err)
+		}
+		if endHeight < rootHeight {
+			return nil, fmt.Errorf("end height of historical spork %q is below its root height", entry)
+		}
+
+		sporks = append(sporks, HistoricalSpork{
+			RootHeight: rootHeight,
+			EndHeight:  endHeight,
+			Address:    strings.TrimSpace(parts[1]),
+		})
+	}
+
+	sort.Slice(sporks, func(i, j int) bool {
+		return sporks[i].RootHeight < sporks[j].RootHeight
+	})
+	for i := 1; i < len(sporks); i++ {
+		if sporks[i].RootHeight <= sporks[i-1].EndHeight {
+			return nil, fmt.Errorf("height ranges of historical sporks at %s and %s overlap", sporks[i-1].Address, sporks[i].Address)
+		}
+	}
+
+	return sporks, nil
+}
+
+// HistoricalRouter routes the Access API requests for blocks of previous sporks to the access nodes of those sporks.
+// It wraps the Access API of the current spork, so the requests of all servers built on it are routed.
+//
+// Requests addressing a block by height are routed by the height ranges of the sporks. Requests addressing a block by
+// ID are served by the current spork if the block is known locally, and routed to the spork whose access node knows
+// the block otherwise. Event requests covering blocks of several sporks are split by spork and the responses merged.
+//
+// The access nodes of previous sporks don't return all fields of blocks and block headers, so the blocks and block
+// headers of previous sporks returned by the router only have the fields included in their responses, and their
+// IDs differ from the IDs of the original blocks. The gRPC server therefore forwards these responses as they are.
+type HistoricalRouter struct {
+	access.API
+	log             zerolog.Logger
+	headers         storage.Headers
+	sporks          []HistoricalSpork // ordered by height
+	maxHeightRange  uint
+	sporkByBlockID  *lru.Cache // block ID -> index of the spork serving the block
+	unknownBlockIDs *lru.Cache // block IDs unknown to all sporks, which don't change once they ended
+}
+
+var _ access.API = (*HistoricalRouter)(nil)
+
+// NewHistoricalRouter creates a new historical router for the given sporks, ordered by height, which serves the
+// requests for blocks of the current spork by the given API
+func NewHistoricalRouter(
+	api access.API,
+	log zerolog.Logger,
+	headers storage.Headers,
+	sporks []HistoricalSpork,
+	maxHeightRange uint,
+) *HistoricalRouter {
+	// errors only if the cache size is not positive
+	sporkByBlockID, _ := lru.New(sporkByBlockIDCacheSize)
+	unknownBlockIDs, _ := lru.New(unknownBlockIDsCacheSize)
+
+	return &HistoricalRouter{
+		API:             api,
+		log:             log.With().Str("component", "historical_router").Logger(),
+		headers:         headers,
+		sporks:          sporks,
+		maxHeightRange:  maxHeightRange,
+		sporkByBlockID:  sporkByBlockID,
+		unknownBlockIDs: unknownBlockIDs,
+	}
+}
+
+func (r *HistoricalRouter) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.Header, error) {
+	spork, ok := r.SporkForHeight(height)
+	if !ok {
+		return r.API.GetBlockHeaderByHeight(ctx, height)
+	}
+
+	resp, err := spork.Client.GetBlockHeaderByHeight(ctx, &accessproto.GetBlockHeaderByHeightRequest{Height: height})
+	if err != nil {
+		return nil, err
+	}
+	return convert.MessageToBlockHeader(resp.GetBlock()), nil
+}
+
+func (r *HistoricalRouter) GetBlockHeaderByID(ctx context.Context, id flow.Identifier) (*flow.Header, error) {
+	spork, ok := r.SporkForBlockID(ctx, id)
+	if !ok {
+		return r.API.GetBlockHeaderByID(ctx, id)
+	}
+
+	resp, err := spork.Client.GetBlockHeaderByID(ctx, &accessproto.GetBlockHeaderByIDRequest{Id: id[:]})
+	if err != nil {
+		return nil, err
+	}
+	return convert.MessageToBlockHeader(resp.GetBlock()), nil
+}
+
+func (r *HistoricalRouter) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	spork, ok := r.SporkForHeight(height)
+	if !ok {
+		return r.API.GetBlockByHeight(ctx, height)
+	}
+
+	resp, err := spork.Client.GetBlockByHeight(ctx, &accessproto.GetBlockByHeightRequest{Height: height})
+	if err != nil {
+		return nil, err
+	}
+	return convert.MessageToBlock(resp.GetBlock()), nil
+}
+
+func (r *HistoricalRouter) GetBlockByID(ctx context.Context, id flow.Identifier) (*flow.Block, error) {
+	spork, ok := r.SporkForBlockID(ctx, id)
+	if !ok {
+		return r.API.GetBlockByID(ctx, id)
+	}
+
+	resp, err := spork.Client.GetBlockByID(ctx, &accessproto.GetBlockByIDRequest{Id: id[:]})
+	if err != nil {
+		return nil, err
+	}
+	return convert.MessageToBlock(resp.GetBlock()), nil
+}
+
+func (r *HistoricalRouter) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	spork, ok := r.SporkForBlockID(ctx, blockID)
+	if !ok {
+		return r.API.GetExecutionResultForBlockID(ctx, blockID)
+	}
+
+	resp, err := spork.Client.GetExecutionResultForBlockID(ctx, &accessproto.GetExecutionResultForBlockIDRequest{BlockId: blockID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := convert.MessageToExecutionResult(resp.GetExecutionResult())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert execution result of previous spork: %v", err)
+	}
+	return result, nil
+}
+
+func (r *HistoricalRouter) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	spork, ok := r.SporkForHeight(height)
+	if !ok {
+		return r.API.GetAccountAtBlockHeight(ctx, address, height)
+	}
+
+	resp, err := spork.Client.GetAccountAtBlockHeight(ctx, &accessproto.GetAccountAtBlockHeightRequest{
+		Address:     address.Bytes(),
+		BlockHeight: height,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := convert.MessageToAccount(resp.GetAccount())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert account of previous spork: %v", err)
+	}
+	return account, nil
+}
+
+func (r *HistoricalRouter) ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error) {
+	spork, ok := r.SporkForHeight(blockHeight)
+	if !ok {
+		return r.API.ExecuteScriptAtBlockHeight(ctx, blockHeight, script, arguments)
+	}
+
+	resp, err := spork.Client.ExecuteScriptAtBlockHeight(ctx, &accessproto.ExecuteScriptAtBlockHeightRequest{
+		BlockHeight: blockHeight,
+		Script:      script,
+		Arguments:   arguments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetValue(), nil
+}
+
+func (r *HistoricalRouter) ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments [][]byte) ([]byte, error) {
+	spork, ok := r.SporkForBlockID(ctx, blockID)
+	if !ok {
+		return r.API.ExecuteScriptAtBlockID(ctx, blockID, script, arguments)
+	}
+
+	resp, err := spork.Client.ExecuteScriptAtBlockID(ctx, &accessproto.ExecuteScriptAtBlockIDRequest{
+		BlockId:   blockID[:],
+		Script:    script,
+		Arguments: arguments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetValue(), nil
+}
+
+// GetEventsForHeightRange splits the requested height range by spork, gets the events of each part from the current
+// spork or the access node of a previous spork, and merges them in order of height
+func (r *HistoricalRouter) GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64) ([]flow.BlockEvents, error) {
+	if endHeight < startHeight {
+		return r.API.GetEventsForHeightRange(ctx, eventType, startHeight, endHeight)
+	}
+
+	// if the range lies within the current spork, there is nothing to route
+	_, startsInSpork := r.SporkForHeight(startHeight)
+	if !startsInSpork && r.nextSporkIndex(startHeight) == len(r.sporks) {
+		return r.API.GetEventsForHeightRange(ctx, eventType, startHeight, endHeight)
+	}
+
+	rangeSize := endHeight - startHeight + 1 // range is inclusive on both ends
+	if rangeSize > uint64(r.maxHeightRange) {
+		return nil, status.Errorf(codes.InvalidArgument, "requested block range (%d) exceeded maximum (%d)", rangeSize, r.maxHeightRange)
+	}
+
+	var merged []flow.BlockEvents
+	for height := startHeight; height <= endHeight; {
+		var partEndHeight uint64
+		var events []flow.BlockEvents
+		var err error
+
+		if spork, ok := r.SporkForHeight(height); ok {
+			partEndHeight = min(endHeight, spork.EndHeight)
+			var resp *accessproto.EventsResponse
+			resp, err = spork.Client.GetEventsForHeightRange(ctx, &accessproto.GetEventsForHeightRangeRequest{
+				Type:        eventType,
+				StartHeight: height,
+				EndHeight:   partEndHeight,
+			})
+			if err == nil {
+				events = messagesToBlockEvents(resp.GetResults())
+			}
+		} else {
+			// the part up to the next historical spork, if any, is served by the current spork
+			partEndHeight = endHeight
+			if next := r.nextSporkIndex(height); next < len(r.sporks) {
+				partEndHeight = min(endHeight, r.sporks[next].RootHeight-1)
+			}
+			events, err = r.API.GetEventsForHeightRange(ctx, eventType, height, partEndHeight)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		merged = append(merged, events...)
+
+		if partEndHeight == endHeight {
+			break
+		}
+		height = partEndHeight + 1
+	}
+
+	return merged, nil
+}
+
+// GetEventsForBlockIDs groups the requested blocks by spork, gets the events of each group from the current spork or
+// the access node of a previous spork, and merges them in the requested order
+func (r *HistoricalRouter) GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error) {
+	const current = -1
+
+	// the spork of each requested block, in order of the first requested block of each spork
+	groups := make(map[int][]flow.Identifier)
+	var order []int
+	for _, id := range blockIDs {
+		index := current
+		if spork, ok := r.SporkForBlockID(ctx, id); ok {
+			index = r.sporkIndex(spork)
+		}
+		if _, ok := groups[index]; !ok {
+			order = append(order, index)
+		}
+		groups[index] = append(groups[index], id)
+	}
+
+	if len(order) == 0 || (len(order) == 1 && order[0] == current) {
+		return r.API.GetEventsForBlockIDs(ctx, eventType, blockIDs)
+	}
+
+	results := make(map[flow.Identifier]flow.BlockEvents, len(blockIDs))
+	for _, index := range order {
+		var events []flow.BlockEvents
+		var err error
+		if index == current {
+			events, err = r.API.GetEventsForBlockIDs(ctx, eventType, groups[index])
+		} else {
+			var resp *accessproto.EventsResponse
+			resp, err = r.sporks[index].Client.GetEventsForBlockIDs(ctx, &accessproto.GetEventsForBlockIDsRequest{
+				Type:     eventType,
+				BlockIds: convert.IdentifiersToMessages(groups[index]),
+			})
+			if err == nil {
+				events = messagesToBlockEvents(resp.GetResults())
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, blockEvents := range events {
+			results[blockEvents.BlockID] = blockEvents
+		}
+	}
+
+	merged := make([]flow.BlockEvents, 0, len(blockIDs))
+	for _, id := range blockIDs {
+		if blockEvents, ok := results[id]; ok {
+			merged = append(merged, blockEvents)
+		}
+	}
+
+	return merged, nil
+}
+
+// SporkForHeight returns the historical spork the block at the given height belongs to
+func (r *HistoricalRouter) SporkForHeight(height uint64) (HistoricalSpork, bool) {
+	i := sort.Search(len(r.sporks), func(i int) bool {
+		return r.sporks[i].EndHeight >= height
+	})
+	if i < len(r.sporks) && r.sporks[i].RootHeight <= height {
+		return r.sporks[i], true
+	}
+	return HistoricalSpork{}, false
+}
+
+// SporkForBlockID returns the historical spork the block with the given ID belongs to. Blocks known locally belong
+// to the current spork. Otherwise, the access nodes of the historical sporks are asked for the block, latest spork
+// first, and the spork is identified by the height of the block. Both the spork of a block and block IDs unknown to
+// all sporks are remembered, so the access nodes of the historical sporks are only asked once per block.
+func (r *HistoricalRouter) SporkForBlockID(ctx context.Context, blockID flow.Identifier) (HistoricalSpork, bool) {
+	if index, ok := r.sporkByBlockID.Get(blockID); ok {
+		return r.sporks[index.(int)], true
+	}
+
+	_, err := r.headers.ByBlockID(blockID)
+	if err == nil || !errors.Is(err, storage.ErrNotFound) {
+		// the block is known locally, or the local API is left to report the error
+		return HistoricalSpork{}, false
+	}
+
+	if r.unknownBlockIDs.Contains(blockID) {
+		return HistoricalSpork{}, false
+	}
+
+	unknown := true
+	for i := len(r.sporks) - 1; i >= 0; i-- {
+		resp, err := r.sporks[i].Client.GetBlockHeaderByID(ctx, &accessproto.GetBlockHeaderByIDRequest{Id: blockID[:]})
+		if err != nil {
+			if status.Code(err) != codes.NotFound {
+				// the block might be known to the spork, so it is asked again next time
+				unknown = false
+				r.log.Warn().Err(err).
+					Str("access_node", r.sporks[i].Address).
+					Hex("block_id", blockID[:]).
+					Msg("failed to look up block on historical access node")
+			}
+			continue
+		}
+
+		// the block belongs to the spork of its height, which the access node might not serve itself
+		spork, ok := r.SporkForHeight(resp.GetBlock().GetHeight())
+		if !ok {
+			r.log.Warn().
+				Str("access_node", r.sporks[i].Address).
+				Hex("block_id", blockID[:]).
+				Uint64("height", resp.GetBlock().GetHeight()).
+				Msg("historical access node returned block outside of the historical sporks")
+			continue
+		}
+		r.sporkByBlockID.Add(blockID, r.sporkIndex(spork))
+		return spork, true
+	}
+
+	if unknown {
+		r.unknownBlockIDs.Add(blockID, struct{}{})
+	}
+	return HistoricalSpork{}, false
+}
+
+// nextSporkIndex returns the index of the first historical spork starting above the given height
+func (r *HistoricalRouter) nextSporkIndex(height uint64) int {
+	return sort.Search(len(r.sporks), func(i int) bool {
+		return r.sporks[i].RootHeight > height
+	})
+}
+
+// sporkIndex returns the index of the given historical spork
+func (r *HistoricalRouter) sporkIndex(spork HistoricalSpork) int {
+	return sort.Search(len(r.sporks), func(i int) bool {
+		return r.sporks[i].RootHeight >= spork.RootHeight
+	})
+}
+
+func messagesToBlockEvents(results []*accessproto.EventsResponse_Result) []flow.BlockEvents {
+	blockEvents := make([]flow.BlockEvents, len(results))
+	for i, result := range results {
+		blockEvents[i] = flow.BlockEvents{
+			BlockID:        convert.MessageToIdentifier(result.GetBlockId()),
+			BlockHeight:    result.GetBlockHeight(),
+			BlockTimestamp: result.GetBlockTimestamp().AsTime(),
+			Events:         convert.MessagesToEvents(result.GetEvents()),
+		}
+	}
+	return blockEvents
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,319 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestParseHistoricalSporks(t *testing.T) {
+	t.Run("valid sporks", func(t *testing.T) {
+		sporks, err := ParseHistoricalSporks(" 100-199=access-002:9000, 0-99=access-001:9000,")
+		require.NoError(t, err)
+		require.Equal(t, []HistoricalSpork{
+			{RootHeight: 0, EndHeight: 99, Address: "access-001:9000"},
+			{RootHeight: 100, EndHeight: 199, Address: "access-002:9000"},
+		}, sporks)
+	})
+
+	t.Run("no sporks", func(t *testing.T) {
+		sporks, err := ParseHistoricalSporks("")
+		require.NoError(t, err)
+		require.Empty(t, sporks)
+	})
+
+	for _, value := range []string{
+		"access-001:9000",
+		"0-99=",
+		"99=access-001:9000",
+		"a-99=access-001:9000",
+		"99-0=access-001:9000",
+		"0-99=access-001:9000,99-199=access-002:9000",
+	} {
+		_, err := ParseHistoricalSporks(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// currentSporkAPI serves the requests for blocks of the current spork, and records the requests for events
+type currentSporkAPI struct {
+	access.API
+	events            map[flow.Identifier]flow.BlockEvents
+	heightRangeCalled [][2]uint64
+	blockIDsCalled    [][]flow.Identifier
+}
+
+func (a *currentSporkAPI) GetEventsForHeightRange(_ context.Context, _ string, startHeight, endHeight uint64) ([]flow.BlockEvents, error) {
+	a.heightRangeCalled = append(a.heightRangeCalled, [2]uint64{startHeight, endHeight})
+	var events []flow.BlockEvents
+	for height := startHeight; height <= endHeight; height++ {
+		for _, blockEvents := range a.events {
+			if blockEvents.BlockHeight == height {
+				events = append(events, blockEvents)
+			}
+		}
+	}
+	return events, nil
+}
+
+func (a *currentSporkAPI) GetEventsForBlockIDs(_ context.Context, _ string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error) {
+	a.blockIDsCalled = append(a.blockIDsCalled, blockIDs)
+	var events []flow.BlockEvents
+	for _, id := range blockIDs {
+		events = append(events, a.events[id])
+	}
+	return events, nil
+}
+
+func (a *currentSporkAPI) ExecuteScriptAtBlockHeight(context.Context, uint64, []byte, [][]byte) ([]byte, error) {
+	return []byte("current"), nil
+}
+
+func (a *currentSporkAPI) ExecuteScriptAtBlockID(context.Context, flow.Identifier, []byte, [][]byte) ([]byte, error) {
+	return []byte("current"), nil
+}
+
+type historicalRouterSuite struct {
+	router  *HistoricalRouter
+	current *currentSporkAPI
+	headers *storagemock.Headers
+	spork1  *accessmock.AccessAPIClient
+	spork2  *accessmock.AccessAPIClient
+}
+
+// newHistoricalRouterSuite creates a router for two historical sporks covering heights 0-99 and 100-199,
+// the current spork starts at height 200
+func newHistoricalRouterSuite() *historicalRouterSuite {
+	s := &historicalRouterSuite{
+		current: &currentSporkAPI{events: make(map[flow.Identifier]flow.BlockEvents)},
+		headers: new(storagemock.Headers),
+		spork1:  new(accessmock.AccessAPIClient),
+		spork2:  new(accessmock.AccessAPIClient),
+	}
+	s.router = NewHistoricalRouter(s.current, zerolog.Nop(), s.headers, []HistoricalSpork{
+		{RootHeight: 0, EndHeight: 99, Address: "access-001:9000", Client: s.spork1},
+		{RootHeight: 100, EndHeight: 199, Address: "access-002:9000", Client: s.spork2},
+	}, 250)
+	return s
+}
+
+func blockEvents(height uint64) flow.BlockEvents {
+	return flow.BlockEvents{
+		BlockID:     unittest.IdentifierFixture(),
+		BlockHeight: height,
+		Events:      []flow.Event{unittest.EventFixture("A", 0, 0, unittest.IdentifierFixture(), 0)},
+	}
+}
+
+func blockEventsToMessages(events []flow.BlockEvents) *accessproto.EventsResponse {
+	resp := &accessproto.EventsResponse{}
+	for _, e := range events {
+		blockID := e.BlockID
+		resp.Results = append(resp.Results, &accessproto.EventsResponse_Result{
+			BlockId:     blockID[:],
+			BlockHeight: e.BlockHeight,
+			Events:      []*entities.Event{{Type: string(e.Events[0].Type), TransactionId: e.Events[0].TransactionID[:]}},
+		})
+	}
+	return resp
+}
+
+func TestHistoricalRouterByHeight(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("script at height of a historical spork", func(t *testing.T) {
+		s := newHistoricalRouterSuite()
+		s.spork2.On("ExecuteScriptAtBlockHeight", ctx, &accessproto.ExecuteScriptAtBlockHeightRequest{BlockHeight: 150, Script: []byte("script")}).
+			Return(&accessproto.ExecuteScriptResponse{Value: []byte("historical")}, nil).Once()
+
+		value, err := s.router.ExecuteScriptAtBlockHeight(ctx, 150, []byte("script"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("historical"), value)
+		s.spork2.AssertExpectations(t)
+	})
+
+	t.Run("script at height of the current spork", func(t *testing.T) {
+		s := newHistoricalRouterSuite()
+
+		value, err := s.router.ExecuteScriptAtBlockHeight(ctx, 200, []byte("script"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("current"), value)
+		s.spork1.AssertNotCalled(t, "ExecuteScriptAtBlockHeight", mock.Anything, mock.Anything)
+		s.spork2.AssertNotCalled(t, "ExecuteScriptAtBlockHeight", mock.Anything, mock.Anything)
+	})
+
+	t.Run("block at height of a historical spork", func(t *testing.T) {
+		s := newHistoricalRouterSuite()
+		parentID := unittest.IdentifierFixture()
+		s.spork1.On("GetBlockByHeight", ctx, &accessproto.GetBlockByHeightRequest{Height: 50}).
+			Return(&accessproto.BlockResponse{Block: &entities.Block{ParentId: parentID[:], Height: 50}}, nil).Once()
+
+		block, err := s.router.GetBlockByHeight(ctx, 50)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50), block.Header.Height)
+		assert.Equal(t, parentID, block.Header.ParentID)
+		s.spork1.AssertExpectations(t)
+	})
+
+	t.Run("event range crossing spork boundaries", func(t *testing.T) {
+		s := newHistoricalRouterSuite()
+		events := []flow.BlockEvents{blockEvents(99), blockEvents(100), blockEvents(199), blockEvents(200), blockEvents(201)}
+		for _, e := range events[3:] {
+			s.current.events[e.BlockID] = e
+		}
+
+		s.spork1.On("GetEventsForHeightRange", ctx, &accessproto.GetEventsForHeightRangeRequest{Type: "A", StartHeight: 99, EndHeight: 99}).
+			Return(blockEventsToMessages(events[0:1]), nil).Once()
+		s.spork2.On("GetEventsForHeightRange", ctx, &accessproto.GetEventsForHeightRangeRequest{Type: "A", StartHeight: 100, EndHeight: 199}).
+			Return(blockEventsToMessages(events[1:3]), nil).Once()
+
+		merged, err := s.router.GetEventsForHeightRange(ctx, "A", 99, 201)
+		require.NoError(t, err)
+
+		require.Len(t, merged, len(events))
+		for i, e := range events {
+			assert.Equal(t, e.BlockID, merged[i].BlockID)
+			assert.Equal(t, e.BlockHeight, merged[i].BlockHeight)
+			assert.Equal(t, e.Events[0].Type, merged[i].Events[0].Type)
+		}
+		assert.Equal(t, [][2]uint64{{200, 201}}, s.current.heightRangeCalled)
+		s.spork1.AssertExpectations(t)
+		s.spork2.AssertExpectations(t)
+	})
+
+	t.Run("event range crossing spork boundaries exceeding the maximum range", func(t *testing.T) {
+		s := newHistoricalRouterSuite()
+
+		_, err := s.router.GetEventsForHeightRange(ctx, "A", 0, 300)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Empty(t, s.current.heightRangeCalled)
+	})
+}
+
+func TestHistoricalRouterByBlockID(t *testing.T) {
+	ctx := context.Background()
+
+	localID := unittest.IdentifierFixture()
+	historicalID := unittest.IdentifierFixture()
+	unknownID := unittest.IdentifierFixture()
+	header := unittest.BlockHeaderFixture()
+
+	setup := func() *historicalRouterSuite {
+		s := newHistoricalRouterSuite()
+		s.headers.On("ByBlockID", localID).Return(&header, nil)
+		s.headers.On("ByBlockID", mock.Anything).Return((*flow.Header)(nil), storage.ErrNotFound)
+		s.spork2.On("GetBlockHeaderByID", ctx, mock.Anything).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+		s.spork1.On("GetBlockHeaderByID", ctx, &accessproto.GetBlockHeaderByIDRequest{Id: historicalID[:]}).
+			Return(&accessproto.BlockHeaderResponse{Block: &entities.BlockHeader{Id: historicalID[:], Height: 50}}, nil)
+		s.spork1.On("GetBlockHeaderByID", ctx, &accessproto.GetBlockHeaderByIDRequest{Id: unknownID[:]}).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+		return s
+	}
+
+	t.Run("block of the current spork", func(t *testing.T) {
+		s := setup()
+
+		value, err := s.router.ExecuteScriptAtBlockID(ctx, localID, []byte("script"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("current"), value)
+		s.spork1.AssertNotCalled(t, "GetBlockHeaderByID", mock.Anything, mock.Anything)
+		s.spork2.AssertNotCalled(t, "GetBlockHeaderByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("block of a historical spork", func(t *testing.T) {
+		s := setup()
+		req := &accessproto.ExecuteScriptAtBlockIDRequest{BlockId: historicalID[:], Script: []byte("script")}
+		s.spork1.On("ExecuteScriptAtBlockID", ctx, req).
+			Return(&accessproto.ExecuteScriptResponse{Value: []byte("historical")}, nil).Twice()
+
+		for i := 0; i < 2; i++ {
+			value, err := s.router.ExecuteScriptAtBlockID(ctx, historicalID, []byte("script"), nil)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("historical"), value)
+		}
+
+		// the spork of the block is only looked up once
+		s.spork1.AssertNumberOfCalls(t, "ExecuteScriptAtBlockID", 2)
+		s.spork1.AssertNumberOfCalls(t, "GetBlockHeaderByID", 1)
+		s.spork2.AssertNumberOfCalls(t, "GetBlockHeaderByID", 1)
+
+		previousResultID := unittest.IdentifierFixture()
+		s.spork1.On("GetExecutionResultForBlockID", ctx, &accessproto.GetExecutionResultForBlockIDRequest{BlockId: historicalID[:]}).
+			Return(&accessproto.ExecutionResultForBlockIDResponse{ExecutionResult: &entities.ExecutionResult{
+				PreviousResultId: previousResultID[:],
+				BlockId:          historicalID[:],
+			}}, nil).Once()
+
+		result, err := s.router.GetExecutionResultForBlockID(ctx, historicalID)
+		require.NoError(t, err)
+		assert.Equal(t, historicalID, result.BlockID)
+		assert.Equal(t, previousResultID, result.PreviousResultID)
+		s.spork1.AssertNumberOfCalls(t, "GetExecutionResultForBlockID", 1)
+	})
+
+	t.Run("block unknown to all sporks", func(t *testing.T) {
+		s := setup()
+
+		for i := 0; i < 2; i++ {
+			value, err := s.router.ExecuteScriptAtBlockID(ctx, unknownID, []byte("script"), nil)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("current"), value)
+		}
+
+		// the unknown block is only looked up once
+		s.spork1.AssertNumberOfCalls(t, "GetBlockHeaderByID", 1)
+		s.spork2.AssertNumberOfCalls(t, "GetBlockHeaderByID", 1)
+	})
+
+	t.Run("block looked up again after a failure", func(t *testing.T) {
+		s := newHistoricalRouterSuite()
+		s.headers.On("ByBlockID", unknownID).Return((*flow.Header)(nil), storage.ErrNotFound)
+		s.spork2.On("GetBlockHeaderByID", ctx, mock.Anything).
+			Return(nil, status.Error(codes.Unavailable, "unavailable"))
+		s.spork1.On("GetBlockHeaderByID", ctx, mock.Anything).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+
+		for i := 0; i < 2; i++ {
+			_, ok := s.router.SporkForBlockID(ctx, unknownID)
+			assert.False(t, ok)
+		}
+		s.spork2.AssertNumberOfCalls(t, "GetBlockHeaderByID", 2)
+	})
+
+	t.Run("events of blocks of several sporks", func(t *testing.T) {
+		s := setup()
+		local := blockEvents(200)
+		local.BlockID = localID
+		s.current.events[localID] = local
+		historical := blockEvents(50)
+		historical.BlockID = historicalID
+
+		s.spork1.On("GetEventsForBlockIDs", ctx, &accessproto.GetEventsForBlockIDsRequest{Type: "A", BlockIds: [][]byte{historicalID[:]}}).
+			Return(blockEventsToMessages([]flow.BlockEvents{historical}), nil).Once()
+
+		merged, err := s.router.GetEventsForBlockIDs(ctx, "A", []flow.Identifier{historicalID, localID})
+		require.NoError(t, err)
+
+		require.Len(t, merged, 2)
+		assert.Equal(t, historicalID, merged[0].BlockID)
+		assert.Equal(t, local, merged[1])
+		assert.Equal(t, [][]flow.Identifier{{localID}}, s.current.blockIDsCalled)
+		s.spork1.AssertNumberOfCalls(t, "GetEventsForBlockIDs", 1)
+	})
+}
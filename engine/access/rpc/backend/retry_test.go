@@ -44,7 +44,7 @@ func (suite *Suite) TestTransactionRetry() {
 	// Setup Handler + Retry
 	backend := New(suite.state, suite.colClient, nil, suite.blocks, suite.headers,
 		suite.collections, suite.transactions, suite.receipts, suite.results, suite.chainID, metrics.NewNoopCollector(), nil,
-		false, DefaultMaxHeightRange, nil, nil, suite.log)
+		false, DefaultMaxHeightRange, nil, nil, 0, metrics.NewNoopCollector(), suite.log)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
 
@@ -108,7 +108,7 @@ func (suite *Suite) TestSuccessfulTransactionsDontRetry() {
 	// Setup Handler + Retry
 	backend := New(suite.state, suite.colClient, nil, suite.blocks, suite.headers,
 		suite.collections, suite.transactions, suite.receipts, suite.results, suite.chainID, metrics.NewNoopCollector(), connFactory,
-		false, DefaultMaxHeightRange, nil, nil, suite.log)
+		false, DefaultMaxHeightRange, nil, nil, 0, metrics.NewNoopCollector(), suite.log)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
 
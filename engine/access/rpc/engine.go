@@ -31,19 +31,23 @@ import (
 // A secure GRPC server here implies a server that presents a self-signed TLS certificate and a client that authenticates
 // the server via a pre-shared public key
 type Config struct {
-	UnsecureGRPCListenAddr    string                           // the non-secure GRPC server address as ip:port
-	SecureGRPCListenAddr      string                           // the secure GRPC server address as ip:port
-	TransportCredentials      credentials.TransportCredentials // the secure GRPC credentials
-	HTTPListenAddr            string                           // the HTTP web proxy address as ip:port
-	RESTListenAddr            string                           // the REST server address as ip:port (if empty the REST server will not be started)
-	CollectionAddr            string                           // the address of the upstream collection node
-	HistoricalAccessAddrs     string                           // the list of all access nodes from previous spork
-	MaxMsgSize                int                              // GRPC max message size
-	ExecutionClientTimeout    time.Duration                    // execution API GRPC client timeout
-	CollectionClientTimeout   time.Duration                    // collection API GRPC client timeout
-	MaxHeightRange            uint                             // max size of height range requests
-	PreferredExecutionNodeIDs []string                         // preferred list of upstream execution node IDs
-	FixedExecutionNodeIDs     []string                         // fixed list of execution node IDs to choose from if no node node ID can be chosen from the PreferredExecutionNodeIDs
+	UnsecureGRPCListenAddr     string                           // the non-secure GRPC server address as ip:port
+	SecureGRPCListenAddr       string                           // the secure GRPC server address as ip:port
+	TransportCredentials       credentials.TransportCredentials // the secure GRPC credentials
+	HTTPListenAddr             string                           // the HTTP web proxy address as ip:port
+	RESTListenAddr             string                           // the REST server address as ip:port (if empty the REST server will not be started)
+	CollectionAddr             string                           // the address of the upstream collection node
+	HistoricalAccessAddrs      string                           // the list of all access nodes from previous spork
+	HistoricalSporks           string                           // the list of previous sporks with the height range of each spork and the access node serving it
+	MaxMsgSize                 int                              // GRPC max message size
+	ExecutionClientTimeout     time.Duration                    // execution API GRPC client timeout
+	CollectionClientTimeout    time.Duration                    // collection API GRPC client timeout
+	MaxHeightRange             uint                             // max size of height range requests
+	PreferredExecutionNodeIDs  []string                         // preferred list of upstream execution node IDs
+	FixedExecutionNodeIDs      []string                         // fixed list of execution node IDs to choose from if no node node ID can be chosen from the PreferredExecutionNodeIDs
+	ConnectionPool             backend.ConnectionPoolConfig     // caching of the connections to upstream collection and execution nodes
+	CircuitBreaker             backend.CircuitBreakerConfig     // taking failing upstream collection and execution nodes out of rotation
+	RequiredExecutionAgreement uint                             // number of execution nodes committed to the same result which must return identical responses (disabled if less than 2)
 }
 
 // Engine exposes the server with a simplified version of the Access API.
@@ -61,6 +65,9 @@ type Engine struct {
 	unsecureGrpcAddress net.Addr
 	secureGrpcAddress   net.Addr
 	restAPIAddress      net.Addr
+	connectionPool      *backend.ConnectionPool // nil if connections to upstream nodes are not cached
+	state               protocol.State          // used by the REST API to decode the signers of guarantees
+	api                 access.API              // the Access API served by the gRPC and REST servers
 }
 
 // New returns a new RPC engine.
@@ -69,6 +76,7 @@ func New(log zerolog.Logger,
 	config Config,
 	collectionRPC accessproto.AccessAPIClient,
 	historicalAccessNodes []accessproto.AccessAPIClient,
+	historicalSporks []backend.HistoricalSpork,
 	blocks storage.Blocks,
 	headers storage.Headers,
 	collections storage.Collections,
@@ -77,6 +85,7 @@ func New(log zerolog.Logger,
 	executionResults storage.ExecutionResults,
 	chainID flow.ChainID,
 	transactionMetrics module.TransactionMetrics,
+	accessMetrics module.AccessMetrics,
 	collectionGRPCPort uint,
 	executionGRPCPort uint,
 	retryEnabled bool,
@@ -134,9 +143,20 @@ func New(log zerolog.Logger,
 		ExecutionGRPCPort:         executionGRPCPort,
 		CollectionNodeGRPCTimeout: config.CollectionClientTimeout,
 		ExecutionNodeGRPCTimeout:  config.ExecutionClientTimeout,
+		KeepaliveTime:             config.ConnectionPool.KeepaliveTime,
+		AccessMetrics:             accessMetrics,
 	}
 
-	backend := backend.New(
+	var connectionPool *backend.ConnectionPool
+	if config.ConnectionPool.MaxIdleTime > 0 {
+		connectionPool = backend.NewConnectionPool(log, accessMetrics, config.ConnectionPool)
+		connectionFactory.ConnectionPool = connectionPool
+	}
+	if config.CircuitBreaker.MaxFailures > 0 {
+		connectionFactory.CircuitBreaker = backend.NewCircuitBreaker(log, accessMetrics, config.CircuitBreaker)
+	}
+
+	accessBackend := backend.New(
 		state,
 		collectionRPC,
 		historicalAccessNodes,
@@ -153,27 +173,45 @@ func New(log zerolog.Logger,
 		config.MaxHeightRange,
 		config.PreferredExecutionNodeIDs,
 		config.FixedExecutionNodeIDs,
+		config.RequiredExecutionAgreement,
+		accessMetrics,
 		log,
 	)
 
+	var api access.API = accessBackend
+	var historicalRouter *backend.HistoricalRouter
+	if len(historicalSporks) > 0 {
+		// route the requests for blocks of previous sporks to the access nodes of those sporks
+		historicalRouter = backend.NewHistoricalRouter(accessBackend, log, headers, historicalSporks, config.MaxHeightRange)
+		api = historicalRouter
+	}
+
 	eng := &Engine{
 		log:                log,
 		unit:               engine.NewUnit(),
-		backend:            backend,
+		backend:            accessBackend,
+		api:                api,
+		state:              state,
 		unsecureGrpcServer: unsecureGrpcServer,
 		secureGrpcServer:   secureGrpcServer,
 		httpServer:         httpServer,
 		config:             config,
+		connectionPool:     connectionPool,
+	}
+
+	var handler accessproto.AccessAPIServer = access.NewHandler(api, chainID.Chain())
+	if historicalRouter != nil {
+		handler = newHistoricalHandler(handler, historicalRouter)
 	}
 
 	accessproto.RegisterAccessAPIServer(
 		eng.unsecureGrpcServer,
-		access.NewHandler(backend, chainID.Chain()),
+		handler,
 	)
 
 	accessproto.RegisterAccessAPIServer(
 		eng.secureGrpcServer,
-		access.NewHandler(backend, chainID.Chain()),
+		handler,
 	)
 
 	if rpcMetricsEnabled {
@@ -186,11 +224,11 @@ func New(log zerolog.Logger,
 	// Register legacy gRPC handlers for backwards compatibility, to be removed at a later date
 	legacyaccessproto.RegisterAccessAPIServer(
 		eng.unsecureGrpcServer,
-		legacyaccess.NewHandler(backend, chainID.Chain()),
+		legacyaccess.NewHandler(api, chainID.Chain()),
 	)
 	legacyaccessproto.RegisterAccessAPIServer(
 		eng.secureGrpcServer,
-		legacyaccess.NewHandler(backend, chainID.Chain()),
+		legacyaccess.NewHandler(api, chainID.Chain()),
 	)
 
 	return eng
@@ -228,6 +266,11 @@ func (e *Engine) Done() <-chan struct{} {
 					e.log.Error().Err(err).Msg("error stopping http REST server")
 				}
 			}
+		},
+		func() {
+			if e.connectionPool != nil {
+				e.connectionPool.Close()
+			}
 		})
 }
 
@@ -332,7 +375,7 @@ func (e *Engine) serveREST() {
 
 	e.log.Info().Str("rest_api_address", e.config.RESTListenAddr).Msg("starting REST server on address")
 
-	restAPIHandler := rest.NewHandlers(e.backend, e.log)
+	restAPIHandler := rest.NewHandlers(e.api, e.state, e.log)
 	e.restServer = rest.NewServer(restAPIHandler, e.config.RESTListenAddr, e.log)
 
 	l, err := net.Listen("tcp", e.config.RESTListenAddr)
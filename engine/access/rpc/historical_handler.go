@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+)
+
+// historicalHandler forwards the gRPC requests for blocks, block headers and execution results of previous sporks
+// to the access nodes of those sporks and returns their responses as they are. The historical router the handler is
+// built on forwards these requests too, but the blocks and block headers it returns only have the fields included in
+// those responses, so encoding them again would change their IDs. All other requests are routed by the router.
+type historicalHandler struct {
+	accessproto.AccessAPIServer
+	router *backend.HistoricalRouter
+}
+
+var _ accessproto.AccessAPIServer = (*historicalHandler)(nil)
+
+func newHistoricalHandler(handler accessproto.AccessAPIServer, router *backend.HistoricalRouter) *historicalHandler {
+	return &historicalHandler{
+		AccessAPIServer: handler,
+		router:          router,
+	}
+}
+
+func (h *historicalHandler) GetBlockHeaderByHeight(ctx context.Context, req *accessproto.GetBlockHeaderByHeightRequest) (*accessproto.BlockHeaderResponse, error) {
+	if spork, ok := h.router.SporkForHeight(req.GetHeight()); ok {
+		return spork.Client.GetBlockHeaderByHeight(ctx, req)
+	}
+	return h.AccessAPIServer.GetBlockHeaderByHeight(ctx, req)
+}
+
+func (h *historicalHandler) GetBlockHeaderByID(ctx context.Context, req *accessproto.GetBlockHeaderByIDRequest) (*accessproto.BlockHeaderResponse, error) {
+	if spork, ok := h.router.SporkForBlockID(ctx, convert.MessageToIdentifier(req.GetId())); ok {
+		return spork.Client.GetBlockHeaderByID(ctx, req)
+	}
+	return h.AccessAPIServer.GetBlockHeaderByID(ctx, req)
+}
+
+func (h *historicalHandler) GetBlockByHeight(ctx context.Context, req *accessproto.GetBlockByHeightRequest) (*accessproto.BlockResponse, error) {
+	if spork, ok := h.router.SporkForHeight(req.GetHeight()); ok {
+		return spork.Client.GetBlockByHeight(ctx, req)
+	}
+	return h.AccessAPIServer.GetBlockByHeight(ctx, req)
+}
+
+func (h *historicalHandler) GetBlockByID(ctx context.Context, req *accessproto.GetBlockByIDRequest) (*accessproto.BlockResponse, error) {
+	if spork, ok := h.router.SporkForBlockID(ctx, convert.MessageToIdentifier(req.GetId())); ok {
+		return spork.Client.GetBlockByID(ctx, req)
+	}
+	return h.AccessAPIServer.GetBlockByID(ctx, req)
+}
+
+func (h *historicalHandler) GetExecutionResultForBlockID(ctx context.Context, req *accessproto.GetExecutionResultForBlockIDRequest) (*accessproto.ExecutionResultForBlockIDResponse, error) {
+	if spork, ok := h.router.SporkForBlockID(ctx, convert.MessageToIdentifier(req.GetBlockId())); ok {
+		return spork.Client.GetExecutionResultForBlockID(ctx, req)
+	}
+	return h.AccessAPIServer.GetExecutionResultForBlockID(ctx, req)
+}
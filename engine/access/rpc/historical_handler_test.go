@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+)
+
+// currentSporkHandler serves the requests for blocks of the current spork
+type currentSporkHandler struct {
+	accessproto.AccessAPIServer
+	resp *accessproto.BlockResponse
+}
+
+func (h *currentSporkHandler) GetBlockByHeight(context.Context, *accessproto.GetBlockByHeightRequest) (*accessproto.BlockResponse, error) {
+	return h.resp, nil
+}
+
+func TestHistoricalHandler(t *testing.T) {
+	ctx := context.Background()
+
+	spork := new(accessmock.AccessAPIClient)
+	router := backend.NewHistoricalRouter(nil, zerolog.Nop(), new(storagemock.Headers), []backend.HistoricalSpork{
+		{RootHeight: 0, EndHeight: 99, Address: "access-001:9000", Client: spork},
+	}, 250)
+	current := &currentSporkHandler{resp: &accessproto.BlockResponse{}}
+	handler := newHistoricalHandler(current, router)
+
+	t.Run("block of a historical spork is forwarded", func(t *testing.T) {
+		req := &accessproto.GetBlockByHeightRequest{Height: 50}
+		expected := &accessproto.BlockResponse{}
+		spork.On("GetBlockByHeight", ctx, req).Return(expected, nil).Once()
+
+		resp, err := handler.GetBlockByHeight(ctx, req)
+		require.NoError(t, err)
+		assert.Same(t, expected, resp)
+		spork.AssertExpectations(t)
+	})
+
+	t.Run("block of the current spork is served locally", func(t *testing.T) {
+		resp, err := handler.GetBlockByHeight(ctx, &accessproto.GetBlockByHeightRequest{Height: 100})
+		require.NoError(t, err)
+		assert.Same(t, current.resp, resp)
+		spork.AssertNumberOfCalls(t, "GetBlockByHeight", 1)
+		spork.AssertNotCalled(t, "GetBlockByHeight", ctx, mock.MatchedBy(func(req *accessproto.GetBlockByHeightRequest) bool {
+			return req.GetHeight() == 100
+		}))
+	})
+}
@@ -185,6 +185,23 @@ func (e *Engine) EpochSetupPhaseStarted(_ uint64, _ *flow.Header) {
 	e.unit.Launch(e.onEpochSetupPhaseStarted)
 }
 
+// EpochEmergencyFallbackTriggered handles the epoch emergency fallback protocol
+// event. The current epoch is extended in fallback mode, with the same clusters,
+// so the components for the current epoch keep running past the epoch's final
+// view. They are only stopped after the transition to a recovery epoch.
+func (e *Engine) EpochEmergencyFallbackTriggered(counter uint64, first *flow.Header) {
+	e.unit.Lock()
+	_, running := e.epochs[counter]
+	e.unit.Unlock()
+
+	e.log.Warn().
+		Uint64("epoch_counter", counter).
+		Uint64("view", first.View).
+		Uint64("height", first.Height).
+		Bool("components_running", running).
+		Msg("epoch emergency fallback triggered: extending current epoch")
+}
+
 // onEpochTransition is called when we transition to a new epoch. It arranges
 // to shut down the last epoch's components and starts up the new epoch's.
 func (e *Engine) onEpochTransition(first *flow.Header) error {
@@ -0,0 +1,197 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	sdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow/protobuf/go/flow/execution"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// AccountProvider provides the keys and balance of accounts, as of the latest state known
+// to the provider. It is used to pre-validate transactions before they are added to the
+// transaction mempool.
+type AccountProvider interface {
+	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
+}
+
+// AccessAccountProvider reads accounts through the Access API of an access node.
+// Closing the provider closes the client, if it can be closed.
+type AccessAccountProvider struct {
+	client AccessClient
+}
+
+// AccessClient is the subset of the Flow SDK client used to read accounts.
+type AccessClient interface {
+	GetAccountAtLatestBlock(ctx context.Context, address sdk.Address, opts ...grpc.CallOption) (*sdk.Account, error)
+}
+
+var _ AccountProvider = (*AccessAccountProvider)(nil)
+var _ io.Closer = (*AccessAccountProvider)(nil)
+
+func NewAccessAccountProvider(client AccessClient) *AccessAccountProvider {
+	return &AccessAccountProvider{
+		client: client,
+	}
+}
+
+// GetAccount returns the account at the latest sealed block known to the access node.
+func (p *AccessAccountProvider) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	account, err := p.client.GetAccountAtLatestBlock(ctx, sdk.Address(address))
+	if err != nil {
+		return nil, fmt.Errorf("could not get account from access node: %w", err)
+	}
+
+	keys := make([]flow.AccountPublicKey, 0, len(account.Keys))
+	for _, key := range account.Keys {
+		keys = append(keys, flow.AccountPublicKey{
+			Index:     key.Index,
+			PublicKey: key.PublicKey,
+			SignAlgo:  key.SigAlgo,
+			HashAlgo:  key.HashAlgo,
+			SeqNumber: key.SequenceNumber,
+			Weight:    key.Weight,
+			Revoked:   key.Revoked,
+		})
+	}
+
+	return &flow.Account{
+		Address:   address,
+		Balance:   account.Balance,
+		Keys:      keys,
+		Contracts: account.Contracts,
+	}, nil
+}
+
+// Close closes the client, if it can be closed.
+func (p *AccessAccountProvider) Close() error {
+	closer, ok := p.client.(io.Closer)
+	if !ok {
+		return nil
+	}
+	return closer.Close()
+}
+
+// ExecutionAccountProvider reads accounts through the Execution API of an execution node,
+// at the latest sealed block of the local protocol state. Closing the provider closes the
+// connection to the execution node.
+type ExecutionAccountProvider struct {
+	conn   *grpc.ClientConn
+	client execution.ExecutionAPIClient
+	state  protocol.State
+}
+
+var _ AccountProvider = (*ExecutionAccountProvider)(nil)
+var _ io.Closer = (*ExecutionAccountProvider)(nil)
+
+func NewExecutionAccountProvider(conn *grpc.ClientConn, state protocol.State) *ExecutionAccountProvider {
+	return &ExecutionAccountProvider{
+		conn:   conn,
+		client: execution.NewExecutionAPIClient(conn),
+		state:  state,
+	}
+}
+
+// GetAccount returns the account at the latest sealed block.
+func (p *ExecutionAccountProvider) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	sealed, err := p.state.Sealed().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest sealed header: %w", err)
+	}
+	blockID := sealed.ID()
+
+	resp, err := p.client.GetAccountAtBlockID(ctx, &execution.GetAccountAtBlockIDRequest{
+		BlockId: blockID[:],
+		Address: address.Bytes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get account from execution node: %w", err)
+	}
+
+	account, err := convert.MessageToAccount(resp.GetAccount())
+	if err != nil {
+		return nil, fmt.Errorf("could not convert account: %w", err)
+	}
+
+	return account, nil
+}
+
+// Close closes the connection to the execution node.
+func (p *ExecutionAccountProvider) Close() error {
+	return p.conn.Close()
+}
+
+// CachedAccountProvider caches the accounts returned by an underlying provider for a short
+// time, so that bursts of transactions from the same accounts only need a single lookup.
+// Cached accounts may be slightly stale, which is acceptable for pre-validation, since the
+// execution node performs the authoritative checks.
+type CachedAccountProvider struct {
+	provider AccountProvider
+	ttl      time.Duration
+	cache    *lru.Cache
+}
+
+type cachedAccount struct {
+	account *flow.Account
+	expiry  time.Time
+}
+
+var _ AccountProvider = (*CachedAccountProvider)(nil)
+var _ io.Closer = (*CachedAccountProvider)(nil)
+
+func NewCachedAccountProvider(provider AccountProvider, size uint, ttl time.Duration) (*CachedAccountProvider, error) {
+	cache, err := lru.New(int(size))
+	if err != nil {
+		return nil, fmt.Errorf("could not create account cache: %w", err)
+	}
+	return &CachedAccountProvider{
+		provider: provider,
+		ttl:      ttl,
+		cache:    cache,
+	}, nil
+}
+
+// GetAccount returns the cached account if it has not expired yet, and otherwise reads it
+// from the underlying provider.
+func (p *CachedAccountProvider) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	now := time.Now()
+
+	cached, ok := p.cache.Get(address)
+	if ok {
+		entry := cached.(cachedAccount)
+		if now.Before(entry.expiry) {
+			return entry.account, nil
+		}
+	}
+
+	account, err := p.provider.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cache.Add(address, cachedAccount{account: account, expiry: now.Add(p.ttl)})
+
+	return account, nil
+}
+
+// Close closes the underlying provider, if it can be closed.
+func (p *CachedAccountProvider) Close() error {
+	return closeProvider(p.provider)
+}
+
+// closeProvider closes the given provider, if it can be closed.
+func closeProvider(provider AccountProvider) error {
+	closer, ok := provider.(io.Closer)
+	if !ok {
+		return nil
+	}
+	return closer.Close()
+}
@@ -0,0 +1,170 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountValidator pre-validates transactions against the state of the accounts involved:
+// it verifies the payload and envelope signatures against the account keys, checks that the
+// proposal key sequence number has not been used yet, and that the payer can cover fees.
+//
+// The checks run against a possibly stale view of the accounts, hence they only reject
+// transactions which can not become valid anymore. If the account state can not be read, the
+// transaction is accepted, as the execution node performs the authoritative checks.
+type AccountValidator struct {
+	log             zerolog.Logger
+	accounts        AccountProvider
+	verifier        crypto.SignatureVerifier
+	minPayerBalance uint64
+	timeout         time.Duration
+}
+
+// NewAccountValidator creates a validator reading accounts from the given provider. Payers with a
+// balance below minPayerBalance are rejected, a minPayerBalance of 0 disables the balance check.
+func NewAccountValidator(log zerolog.Logger, accounts AccountProvider, minPayerBalance uint64, timeout time.Duration) *AccountValidator {
+	return &AccountValidator{
+		log:             log.With().Str("component", "account_validator").Logger(),
+		accounts:        accounts,
+		verifier:        crypto.DefaultSignatureVerifier{},
+		minPayerBalance: minPayerBalance,
+		timeout:         timeout,
+	}
+}
+
+// Close closes the account provider, if it can be closed.
+func (v *AccountValidator) Close() error {
+	return closeProvider(v.accounts)
+}
+
+// Validate checks the transaction against the state of its accounts. It returns an invalid
+// input error if the transaction can never be valid.
+func (v *AccountValidator) Validate(tx *flow.TransactionBody) error {
+	ctx, cancel := context.WithTimeout(context.Background(), v.timeout)
+	defer cancel()
+
+	accounts := make(map[flow.Address]*flow.Account)
+	getAccount := func(address flow.Address) (*flow.Account, bool) {
+		account, ok := accounts[address]
+		if ok {
+			return account, account != nil
+		}
+		account, err := v.accounts.GetAccount(ctx, address)
+		if err != nil {
+			v.log.Warn().Err(err).
+				Str("address", address.Hex()).
+				Msg("could not read account, skipping account validation")
+		}
+		accounts[address] = account
+		return account, account != nil
+	}
+
+	payloadWeights, err := v.verifySignatures(tx.PayloadSignatures, tx.PayloadMessage(), getAccount)
+	if err != nil {
+		return engine.NewInvalidInputErrorf("invalid payload signature: %w", err)
+	}
+	envelopeWeights, err := v.verifySignatures(tx.EnvelopeSignatures, tx.EnvelopeMessage(), getAccount)
+	if err != nil {
+		return engine.NewInvalidInputErrorf("invalid envelope signature: %w", err)
+	}
+
+	// only check the weights of accounts we could read, we skipped the signatures of the others
+	for _, authorizer := range tx.Authorizers {
+		// an authorizer which is also the payer only signs the envelope
+		if authorizer == tx.Payer {
+			continue
+		}
+		if _, ok := getAccount(authorizer); ok && payloadWeights[authorizer] < fvm.AccountKeyWeightThreshold {
+			return engine.NewInvalidInputErrorf("authorizer %s does not have sufficient signature weight (%d < %d)",
+				authorizer, payloadWeights[authorizer], fvm.AccountKeyWeightThreshold)
+		}
+	}
+
+	payer, ok := getAccount(tx.Payer)
+	if ok {
+		if envelopeWeights[tx.Payer] < fvm.AccountKeyWeightThreshold {
+			return engine.NewInvalidInputErrorf("payer %s does not have sufficient signature weight (%d < %d)",
+				tx.Payer, envelopeWeights[tx.Payer], fvm.AccountKeyWeightThreshold)
+		}
+		if payer.Balance < v.minPayerBalance {
+			return engine.NewInvalidInputErrorf("payer %s balance is too low to cover fees (%d < %d)",
+				tx.Payer, payer.Balance, v.minPayerBalance)
+		}
+	}
+
+	proposer, ok := getAccount(tx.ProposalKey.Address)
+	if ok {
+		key, err := accountKey(proposer, tx.ProposalKey.KeyIndex)
+		if err != nil {
+			return engine.NewInvalidInputErrorf("invalid proposal key: %w", err)
+		}
+		// the sequence number may be ahead of the account state if there are pending
+		// transactions, but it can never become valid if it was already used
+		if tx.ProposalKey.SequenceNumber < key.SeqNumber {
+			return engine.NewInvalidInputErrorf("proposal key sequence number %d was already used (current: %d)",
+				tx.ProposalKey.SequenceNumber, key.SeqNumber)
+		}
+	}
+
+	return nil
+}
+
+// verifySignatures verifies the signatures of all accounts that could be read, and returns the
+// total weight of the valid signatures per account.
+func (v *AccountValidator) verifySignatures(
+	signatures []flow.TransactionSignature,
+	message []byte,
+	getAccount func(flow.Address) (*flow.Account, bool),
+) (map[flow.Address]int, error) {
+
+	weights := make(map[flow.Address]int)
+	for _, sig := range signatures {
+		account, ok := getAccount(sig.Address)
+		if !ok {
+			continue
+		}
+
+		key, err := accountKey(account, sig.KeyIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		valid, err := v.verifier.Verify(
+			sig.Signature,
+			string(flow.TransactionDomainTag[:]),
+			message,
+			key.PublicKey,
+			key.HashAlgo,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not verify signature of account %s with key %d: %w", sig.Address, sig.KeyIndex, err)
+		}
+		if !valid {
+			return nil, fmt.Errorf("signature of account %s with key %d is not valid", sig.Address, sig.KeyIndex)
+		}
+
+		weights[sig.Address] += key.Weight
+	}
+
+	return weights, nil
+}
+
+// accountKey returns the non-revoked key with the given index of the account.
+func accountKey(account *flow.Account, keyIndex uint64) (*flow.AccountPublicKey, error) {
+	if keyIndex >= uint64(len(account.Keys)) {
+		return nil, fmt.Errorf("account %s has no key with index %d", account.Address, keyIndex)
+	}
+	key := account.Keys[keyIndex]
+	if key.Revoked {
+		return nil, fmt.Errorf("key %d of account %s has been revoked", keyIndex, account.Address)
+	}
+	return &key, nil
+}
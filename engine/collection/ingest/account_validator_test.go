@@ -0,0 +1,200 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// accountsFunc is an AccountProvider backed by a function.
+type accountsFunc func(address flow.Address) (*flow.Account, error)
+
+func (f accountsFunc) GetAccount(_ context.Context, address flow.Address) (*flow.Account, error) {
+	return f(address)
+}
+
+func TestAccountValidator(t *testing.T) {
+	payerKey, err := unittest.AccountKeyDefaultFixture()
+	require.NoError(t, err)
+	authorizerKey, err := unittest.AccountKeyDefaultFixture()
+	require.NoError(t, err)
+
+	payer := unittest.RandomAddressFixture()
+	authorizer := unittest.RandomAddressFixture()
+
+	const minBalance = 100
+
+	newAccounts := func() map[flow.Address]*flow.Account {
+		return map[flow.Address]*flow.Account{
+			payer: {
+				Address: payer,
+				Balance: minBalance,
+				Keys:    []flow.AccountPublicKey{payerKey.PublicKey(1000)},
+			},
+			authorizer: {
+				Address: authorizer,
+				Balance: 0,
+				Keys:    []flow.AccountPublicKey{authorizerKey.PublicKey(1000)},
+			},
+		}
+	}
+
+	// signedTx creates a transaction proposed by the authorizer, paid by the payer, and signed by both
+	signedTx := func(seqNum uint64) *flow.TransactionBody {
+		tx := flow.NewTransactionBody().
+			SetScript([]byte("transaction {}")).
+			SetReferenceBlockID(unittest.IdentifierFixture()).
+			SetProposalKey(authorizer, 0, seqNum).
+			SetPayer(payer).
+			AddAuthorizer(authorizer)
+		require.NoError(t, tx.SignPayload(authorizer, 0, authorizerKey.PrivateKey, hash.NewSHA3_256()))
+		require.NoError(t, tx.SignEnvelope(payer, 0, payerKey.PrivateKey, hash.NewSHA3_256()))
+		return tx
+	}
+
+	validator := func(accounts map[flow.Address]*flow.Account) *AccountValidator {
+		provider := accountsFunc(func(address flow.Address) (*flow.Account, error) {
+			account, ok := accounts[address]
+			if !ok {
+				return nil, fmt.Errorf("unknown account")
+			}
+			return account, nil
+		})
+		return NewAccountValidator(zerolog.New(ioutil.Discard), provider, minBalance, time.Second)
+	}
+
+	t.Run("valid transaction", func(t *testing.T) {
+		err := validator(newAccounts()).Validate(signedTx(0))
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid envelope signature", func(t *testing.T) {
+		tx := signedTx(0)
+		tx.EnvelopeSignatures[0].Signature[0] ^= 1
+		err := validator(newAccounts()).Validate(tx)
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("invalid payload signature", func(t *testing.T) {
+		tx := signedTx(0)
+		tx.PayloadSignatures[0].Signature[0] ^= 1
+		err := validator(newAccounts()).Validate(tx)
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("missing authorizer signature", func(t *testing.T) {
+		tx := signedTx(0)
+		tx.PayloadSignatures = nil
+		err := validator(newAccounts()).Validate(tx)
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("insufficient key weight", func(t *testing.T) {
+		accounts := newAccounts()
+		accounts[payer].Keys[0].Weight = 500
+		err := validator(accounts).Validate(signedTx(0))
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		accounts := newAccounts()
+		accounts[authorizer].Keys[0].Revoked = true
+		err := validator(accounts).Validate(signedTx(0))
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("used sequence number", func(t *testing.T) {
+		accounts := newAccounts()
+		accounts[authorizer].Keys[0].SeqNumber = 5
+		err := validator(accounts).Validate(signedTx(4))
+		assert.True(t, engine.IsInvalidInputError(err))
+
+		// a sequence number ahead of the account state may be valid once pending transactions execute
+		err = validator(accounts).Validate(signedTx(6))
+		assert.NoError(t, err)
+	})
+
+	t.Run("insufficient payer balance", func(t *testing.T) {
+		accounts := newAccounts()
+		accounts[payer].Balance = minBalance - 1
+		err := validator(accounts).Validate(signedTx(0))
+		assert.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("unreadable accounts are skipped", func(t *testing.T) {
+		accounts := newAccounts()
+		delete(accounts, payer)
+		tx := signedTx(0)
+		tx.EnvelopeSignatures[0].Signature[0] ^= 1
+		err := validator(accounts).Validate(tx)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCachedAccountProvider(t *testing.T) {
+	address := unittest.RandomAddressFixture()
+	calls := 0
+	provider := accountsFunc(func(address flow.Address) (*flow.Account, error) {
+		calls++
+		return &flow.Account{Address: address, Balance: uint64(calls)}, nil
+	})
+
+	t.Run("returns cached account before expiry", func(t *testing.T) {
+		cached, err := NewCachedAccountProvider(provider, 10, time.Hour)
+		require.NoError(t, err)
+
+		first, err := cached.GetAccount(context.Background(), address)
+		require.NoError(t, err)
+		second, err := cached.GetAccount(context.Background(), address)
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("reads account again after expiry", func(t *testing.T) {
+		cached, err := NewCachedAccountProvider(provider, 10, time.Nanosecond)
+		require.NoError(t, err)
+
+		first, err := cached.GetAccount(context.Background(), address)
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+		second, err := cached.GetAccount(context.Background(), address)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Balance, second.Balance)
+	})
+}
+
+// closingProvider is an AccountProvider recording whether it was closed.
+type closingProvider struct {
+	accountsFunc
+	closed bool
+}
+
+func (p *closingProvider) Close() error {
+	p.closed = true
+	return nil
+}
+
+// TestAccountValidatorClose tests that closing the validator closes the underlying provider,
+// also through the cache.
+func TestAccountValidatorClose(t *testing.T) {
+	provider := &closingProvider{accountsFunc: func(flow.Address) (*flow.Account, error) {
+		return nil, fmt.Errorf("unknown account")
+	}}
+	cached, err := NewCachedAccountProvider(provider, 10, time.Hour)
+	require.NoError(t, err)
+
+	validator := NewAccountValidator(zerolog.New(ioutil.Discard), cached, 0, time.Second)
+	require.NoError(t, validator.Close())
+	assert.True(t, provider.closed)
+}
@@ -32,10 +32,22 @@ type Engine struct {
 	state                protocol.State
 	pools                *epochs.TransactionPools
 	transactionValidator *access.TransactionValidator
+	accountValidator     *AccountValidator
 
 	config Config
 }
 
+// Option configures optional behaviour of the ingest engine.
+type Option func(*Engine)
+
+// WithAccountValidator enables validating transactions against the state of their
+// accounts before adding them to the mempool.
+func WithAccountValidator(validator *AccountValidator) Option {
+	return func(e *Engine) {
+		e.accountValidator = validator
+	}
+}
+
 // New creates a new collection ingest engine.
 func New(
 	log zerolog.Logger,
@@ -47,6 +59,7 @@ func New(
 	chain flow.Chain,
 	pools *epochs.TransactionPools,
 	config Config,
+	opts ...Option,
 ) (*Engine, error) {
 
 	logger := log.With().Str("engine", "ingest").Logger()
@@ -77,6 +90,10 @@ func New(
 		transactionValidator: transactionValidator,
 	}
 
+	for _, apply := range opts {
+		apply(e)
+	}
+
 	conduit, err := net.Register(engine.PushTransactions, e)
 	if err != nil {
 		return nil, fmt.Errorf("could not register engine: %w", err)
@@ -94,8 +111,22 @@ func (e *Engine) Ready() <-chan struct{} {
 }
 
 // Done returns a done channel that is closed once the engine has fully stopped.
+// The account validator is closed once no transactions are processed anymore.
 func (e *Engine) Done() <-chan struct{} {
-	return e.unit.Done()
+	if e.accountValidator == nil {
+		return e.unit.Done()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		<-e.unit.Done()
+		err := e.accountValidator.Close()
+		if err != nil {
+			e.log.Warn().Err(err).Msg("could not close account validator")
+		}
+		close(done)
+	}()
+	return done
 }
 
 // SubmitLocal submits an event originating on the local node.
@@ -223,6 +254,15 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 
 	// if our cluster is responsible for the transaction, add it to the mempool
 	if localClusterFingerPrint == txClusterFingerPrint {
+		// check the transaction against the state of its accounts, if enabled.
+		// this requires remote calls, so it is only done by the responsible cluster.
+		if e.accountValidator != nil {
+			err = e.accountValidator.Validate(tx)
+			if err != nil {
+				return fmt.Errorf("invalid transaction: %w", err)
+			}
+		}
+
 		_ = pool.Add(tx)
 		e.colMetrics.TransactionIngested(txID)
 		log.Debug().Msg("added transaction to pool")
@@ -2,8 +2,10 @@ package ingest
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
@@ -334,6 +336,35 @@ func (suite *Suite) TestRoutingRemoteCluster() {
 	suite.conduit.AssertExpectations(suite.T())
 }
 
+// should only validate the accounts of transactions the local cluster is responsible for
+func (suite *Suite) TestAccountValidationRemoteCluster() {
+
+	suite.engine.accountValidator = NewAccountValidator(zerolog.New(ioutil.Discard), accountsFunc(func(flow.Address) (*flow.Account, error) {
+		suite.Fail("accounts of a transaction for a remote cluster should not be read")
+		return nil, fmt.Errorf("unexpected read")
+	}), 0, time.Second)
+
+	// find a remote cluster
+	_, index, ok := suite.clusters.ByNodeID(suite.me.NodeID())
+	suite.Require().True(ok)
+	remote, ok := suite.clusters.ByIndex((index + 1) % suite.N_CLUSTERS)
+	suite.Require().True(ok)
+
+	// get a transaction that will be routed to remote cluster
+	tx := unittest.TransactionBodyFixture()
+	tx.ReferenceBlockID = suite.root.ID()
+	tx = unittest.AlterTransactionForCluster(tx, suite.clusters, remote, func(transaction *flow.TransactionBody) {})
+
+	// should route to remote cluster
+	suite.conduit.
+		On("Multicast", &tx, suite.conf.PropagationRedundancy+1, remote[0].NodeID, remote[1].NodeID).
+		Return(nil)
+
+	err := suite.engine.ProcessLocal(&tx)
+	suite.Assert().NoError(err)
+	suite.conduit.AssertExpectations(suite.T())
+}
+
 // should not store transactions for a different cluster and should not fail when propagating
 // to an empty cluster
 func (suite *Suite) TestRoutingToRemoteClusterWithNoNodes() {
@@ -180,6 +180,107 @@ func blockSealToMessage(s *flow.Seal) *entities.BlockSeal {
 	}
 }
 
+// MessageToBlockHeader converts a block header message to a block header. Only the fields included in the
+// message are set, so the ID of the returned header differs from the ID in the message.
+func MessageToBlockHeader(m *entities.BlockHeader) *flow.Header {
+	return &flow.Header{
+		ParentID:  MessageToIdentifier(m.GetParentId()),
+		Height:    m.GetHeight(),
+		Timestamp: m.GetTimestamp().AsTime(),
+	}
+}
+
+// MessageToBlock converts a block message to a block. Only the fields included in the message are set, so the
+// ID of the returned block differs from the ID in the message.
+func MessageToBlock(m *entities.Block) *flow.Block {
+	guarantees := make([]*flow.CollectionGuarantee, len(m.GetCollectionGuarantees()))
+	for i, g := range m.GetCollectionGuarantees() {
+		guarantees[i] = &flow.CollectionGuarantee{
+			CollectionID: MessageToIdentifier(g.GetCollectionId()),
+			Signature:    firstSignature(g.GetSignatures()),
+		}
+	}
+
+	seals := make([]*flow.Seal, len(m.GetBlockSeals()))
+	for i, s := range m.GetBlockSeals() {
+		seals[i] = &flow.Seal{
+			BlockID:  MessageToIdentifier(s.GetBlockId()),
+			ResultID: MessageToIdentifier(s.GetExecutionReceiptId()),
+		}
+	}
+
+	return &flow.Block{
+		Header: &flow.Header{
+			ParentID:           MessageToIdentifier(m.GetParentId()),
+			Height:             m.GetHeight(),
+			Timestamp:          m.GetTimestamp().AsTime(),
+			ParentVoterSigData: firstSignature(m.GetSignatures()),
+		},
+		Payload: &flow.Payload{
+			Guarantees: guarantees,
+			Seals:      seals,
+		},
+	}
+}
+
+// MessageToExecutionResult converts an execution result message to an execution result
+func MessageToExecutionResult(m *entities.ExecutionResult) (*flow.ExecutionResult, error) {
+	chunks := make(flow.ChunkList, len(m.GetChunks()))
+	for i, c := range m.GetChunks() {
+		startState, err := MessageToStateCommitment(c.GetStartState())
+		if err != nil {
+			return nil, fmt.Errorf("invalid start state of chunk %d: %w", i, err)
+		}
+		endState, err := MessageToStateCommitment(c.GetEndState())
+		if err != nil {
+			return nil, fmt.Errorf("invalid end state of chunk %d: %w", i, err)
+		}
+		chunks[i] = &flow.Chunk{
+			ChunkBody: flow.ChunkBody{
+				CollectionIndex:      uint(c.GetCollectionIndex()),
+				StartState:           startState,
+				EventCollection:      MessageToIdentifier(c.GetEventCollection()),
+				BlockID:              MessageToIdentifier(c.GetBlockId()),
+				TotalComputationUsed: c.GetTotalComputationUsed(),
+				NumberOfTransactions: uint64(c.GetNumberOfTransactions()),
+			},
+			Index:    c.GetIndex(),
+			EndState: endState,
+		}
+	}
+
+	serviceEvents := make(flow.ServiceEventList, len(m.GetServiceEvents()))
+	for i, e := range m.GetServiceEvents() {
+		// the payload is the JSON encoding of the event, which is decoded by the type of the event
+		bytes, err := json.Marshal(map[string]interface{}{
+			"Type":  e.GetType(),
+			"Event": json.RawMessage(e.GetPayload()),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid service event %d: %w", i, err)
+		}
+		err = json.Unmarshal(bytes, &serviceEvents[i])
+		if err != nil {
+			return nil, fmt.Errorf("could not decode service event %d: %w", i, err)
+		}
+	}
+
+	return &flow.ExecutionResult{
+		PreviousResultID: MessageToIdentifier(m.GetPreviousResultId()),
+		BlockID:          MessageToIdentifier(m.GetBlockId()),
+		Chunks:           chunks,
+		ServiceEvents:    serviceEvents,
+	}, nil
+}
+
+// firstSignature returns the first of the given signatures, if any
+func firstSignature(signatures [][]byte) []byte {
+	if len(signatures) == 0 {
+		return nil
+	}
+	return signatures[0]
+}
+
 func CollectionToMessage(c *flow.Collection) (*entities.Collection, error) {
 	if c == nil || c.Transactions == nil {
 		return nil, fmt.Errorf("invalid collection")
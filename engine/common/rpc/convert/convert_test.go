@@ -1,9 +1,12 @@
 package convert_test
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/onflow/flow/protobuf/go/flow/entities"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/fvm"
@@ -39,3 +42,36 @@ func TestConvertAccountKey(t *testing.T) {
 	assert.Equal(t, accountKey.PublicKey, converted.PublicKey)
 	assert.Equal(t, accountKey.Revoked, converted.Revoked)
 }
+
+func TestConvertExecutionResult(t *testing.T) {
+	result := unittest.ExecutionResultFixture()
+	result.ServiceEvents = flow.ServiceEventList{unittest.EpochSetupFixture().ServiceEvent()}
+
+	chunks := make([]*entities.Chunk, len(result.Chunks))
+	for i, chunk := range result.Chunks {
+		chunks[i] = &entities.Chunk{
+			CollectionIndex:      uint32(chunk.CollectionIndex),
+			StartState:           chunk.StartState[:],
+			EventCollection:      chunk.EventCollection[:],
+			BlockId:              chunk.BlockID[:],
+			TotalComputationUsed: chunk.TotalComputationUsed,
+			NumberOfTransactions: uint32(chunk.NumberOfTransactions),
+			Index:                chunk.Index,
+			EndState:             chunk.EndState[:],
+		}
+	}
+	payload, err := json.Marshal(result.ServiceEvents[0].Event)
+	require.NoError(t, err)
+
+	msg := &entities.ExecutionResult{
+		PreviousResultId: result.PreviousResultID[:],
+		BlockId:          result.BlockID[:],
+		Chunks:           chunks,
+		ServiceEvents:    []*entities.ServiceEvent{{Type: result.ServiceEvents[0].Type, Payload: payload}},
+	}
+
+	converted, err := convert.MessageToExecutionResult(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, result.ID(), converted.ID())
+}
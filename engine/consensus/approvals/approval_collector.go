@@ -97,12 +97,20 @@ func (c *ApprovalCollector) IncorporatedResult() *flow.IncorporatedResult {
 	return c.incorporatedResult
 }
 
+// SealResult generates a seal for the incorporated result and adds it to the seals mempool.
 func (c *ApprovalCollector) SealResult() error {
+	_, err := c.sealResult()
+	return err
+}
+
+// sealResult generates a seal for the incorporated result and adds it to the seals mempool.
+// It returns whether the seal was added, i.e. it did not exist in the mempool before.
+func (c *ApprovalCollector) sealResult() (bool, error) {
 	// get final state of execution result
 	finalState, err := c.incorporatedResult.Result.FinalStateCommitment()
 	if err != nil {
 		// message correctness should have been checked before: failure here is an internal implementation bug
-		return fmt.Errorf("failed to get final state commitment from Execution Result: %w", err)
+		return false, fmt.Errorf("failed to get final state commitment from Execution Result: %w", err)
 	}
 
 	// TODO: Check SPoCK proofs
@@ -123,7 +131,7 @@ func (c *ApprovalCollector) SealResult() error {
 		Header:             c.executedBlock,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to store IncorporatedResultSeal in mempool: %w", err)
+		return false, fmt.Errorf("failed to store IncorporatedResultSeal in mempool: %w", err)
 	}
 	if added {
 		c.log.Info().
@@ -133,7 +141,7 @@ func (c *ApprovalCollector) SealResult() error {
 			Str("incorporating_block", c.IncorporatedBlockID().String()).
 			Msg("added candidate seal to IncorporatedResultSeals mempool")
 	}
-	return nil
+	return added, nil
 }
 
 // ProcessApproval performs processing of result approvals and bookkeeping of aggregated signatures
@@ -29,6 +29,7 @@ type AssignmentCollectorBase struct {
 	approvalConduit                      network.Conduit                 // used to request missing approvals from verification nodes
 	requestTracker                       *RequestTracker                 // used to keep track of number of approval requests, and blackout periods, by chunk
 	requiredApprovalsForSealConstruction uint                            // number of approvals that are required for each chunk to be sealed
+	emergencySealing                     *EmergencySealing               // decides whether results qualify for emergency sealing
 
 	result        *flow.ExecutionResult // execution result
 	resultID      flow.Identifier       // ID of execution result
@@ -46,6 +47,7 @@ func NewAssignmentCollectorBase(logger zerolog.Logger,
 	approvalConduit network.Conduit,
 	requestTracker *RequestTracker,
 	requiredApprovalsForSealConstruction uint,
+	emergencySealing *EmergencySealing,
 ) (AssignmentCollectorBase, error) {
 	executedBlock, err := headers.ByBlockID(result.BlockID)
 	if err != nil {
@@ -63,6 +65,7 @@ func NewAssignmentCollectorBase(logger zerolog.Logger,
 		approvalConduit:                      approvalConduit,
 		requestTracker:                       requestTracker,
 		requiredApprovalsForSealConstruction: requiredApprovalsForSealConstruction,
+		emergencySealing:                     emergencySealing,
 		result:                               result,
 		resultID:                             result.ID(),
 		executedBlock:                        executedBlock,
@@ -0,0 +1,126 @@
+package approvals
+
+import (
+	"fmt"
+
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultEmergencySealingThreshold is the default number of blocks which indicates that ER should be sealed using emergency
+// sealing.
+const DefaultEmergencySealingThreshold = 100
+
+// DefaultEmergencySealingRequiredReceipts is the default number of execution nodes which must
+// have committed to a result, for it to qualify for emergency sealing.
+const DefaultEmergencySealingRequiredReceipts = 2
+
+// EmergencySealingConfig configures emergency sealing.
+type EmergencySealingConfig struct {
+	Active                  bool   // whether emergency sealing is active at startup
+	UnsealedHeightThreshold uint64 // min number of finalized blocks on top of the block incorporating a result
+	RequiredReceipts        uint   // min number of distinct execution nodes with a receipt committing to a result
+}
+
+func DefaultEmergencySealingConfig() EmergencySealingConfig {
+	return EmergencySealingConfig{
+		Active:                  false,
+		UnsealedHeightThreshold: DefaultEmergencySealingThreshold,
+		RequiredReceipts:        DefaultEmergencySealingRequiredReceipts,
+	}
+}
+
+// EmergencySealing decides whether incorporated results qualify for emergency sealing, i.e.
+// whether they are sealed without the required approvals.
+// ATTENTION: emergency sealing is NOT BFT compatible. It is a fallback to keep sealing from halting
+// when verification nodes fail to approve chunks, e.g. while they are offline. A result qualifies
+// when the approval process hangs far enough behind finalization (measured in finalized blocks on
+// top of the block incorporating the result) and enough execution nodes agree on the result.
+// Emergency sealing can be (de)activated at runtime, it is safe for concurrent use.
+type EmergencySealing struct {
+	active                  *atomic.Bool
+	unsealedHeightThreshold uint64
+	requiredReceipts        uint
+	receipts                storage.ExecutionReceipts
+	metrics                 module.ConsensusMetrics
+}
+
+func NewEmergencySealing(config EmergencySealingConfig, receipts storage.ExecutionReceipts, metrics module.ConsensusMetrics) *EmergencySealing {
+	metrics.EmergencySealingActive(config.Active)
+	return &EmergencySealing{
+		active:                  atomic.NewBool(config.Active),
+		unsealedHeightThreshold: config.UnsealedHeightThreshold,
+		requiredReceipts:        config.RequiredReceipts,
+		receipts:                receipts,
+		metrics:                 metrics,
+	}
+}
+
+// Active returns whether emergency sealing is active.
+func (e *EmergencySealing) Active() bool {
+	return e.active.Load()
+}
+
+// SetActive (de)activates emergency sealing and returns whether it was active before.
+func (e *EmergencySealing) SetActive(active bool) bool {
+	previous := e.active.Swap(active)
+	e.metrics.EmergencySealingActive(active)
+	return previous
+}
+
+// Config returns the current configuration of emergency sealing.
+func (e *EmergencySealing) Config() EmergencySealingConfig {
+	return EmergencySealingConfig{
+		Active:                  e.Active(),
+		UnsealedHeightThreshold: e.unsealedHeightThreshold,
+		RequiredReceipts:        e.requiredReceipts,
+	}
+}
+
+// UnsealedHeightThreshold returns the min number of finalized blocks on top of the
+// block incorporating a result, for the result to qualify for emergency sealing.
+func (e *EmergencySealing) UnsealedHeightThreshold() uint64 {
+	return e.unsealedHeightThreshold
+}
+
+// Qualifies determines whether the incorporated result qualifies for emergency sealing,
+// given the height of the latest finalized block. Results never qualify while emergency
+// sealing is inactive.
+// All errors are unexpected and potential symptoms of internal bugs or state corruption (fatal).
+func (e *EmergencySealing) Qualifies(incorporatedResult *flow.IncorporatedResult, incorporatedBlock *flow.Header, finalizedBlockHeight uint64) (bool, error) {
+	if !e.Active() {
+		return false, nil
+	}
+
+	// there must be at least UnsealedHeightThreshold number of blocks between
+	// the block that _incorporates_ result and the latest finalized block
+	if incorporatedBlock.Height+e.unsealedHeightThreshold > finalizedBlockHeight {
+		return false, nil
+	}
+
+	if e.requiredReceipts == 0 {
+		return true, nil
+	}
+
+	// enough distinct execution nodes must have committed to the result
+	receipts, err := e.receipts.ByBlockID(incorporatedResult.Result.BlockID)
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve receipts for block %x: %w", incorporatedResult.Result.BlockID, err)
+	}
+	resultID := incorporatedResult.Result.ID()
+	executors := make(map[flow.Identifier]struct{})
+	for _, receipt := range receipts {
+		if receipt.ExecutionResult.ID() == resultID {
+			executors[receipt.ExecutorID] = struct{}{}
+		}
+	}
+	return uint(len(executors)) >= e.requiredReceipts, nil
+}
+
+// OnEmergencySeal is called when a result has been emergency sealed.
+func (e *EmergencySealing) OnEmergencySeal() {
+	e.metrics.EmergencySealConstructed()
+}
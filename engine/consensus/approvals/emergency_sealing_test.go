@@ -0,0 +1,66 @@
+package approvals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestEmergencySealing_Qualifies tests that incorporated results qualify for emergency sealing only
+// while emergency sealing is active, when the unsealed height threshold is reached and enough
+// execution nodes committed to the result.
+func TestEmergencySealing_Qualifies(t *testing.T) {
+	block := unittest.BlockHeaderFixture()
+	incorporatedBlock := unittest.BlockHeaderWithParentFixture(&block)
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&flow.Block{Header: &block}))
+	incorporatedResult := unittest.IncorporatedResult.Fixture(
+		unittest.IncorporatedResult.WithResult(result),
+		unittest.IncorporatedResult.WithIncorporatedBlockID(incorporatedBlock.ID()))
+
+	// two execution nodes committed to the result, another one to a different result
+	receipts := &storage.ExecutionReceipts{}
+	receipts.On("ByBlockID", block.ID()).Return(flow.ExecutionReceiptList{
+		unittest.ExecutionReceiptFixture(unittest.WithResult(result)),
+		unittest.ExecutionReceiptFixture(unittest.WithResult(result)),
+		unittest.ExecutionReceiptFixture(unittest.WithResult(unittest.ExecutionResultFixture())),
+	}, nil)
+
+	config := EmergencySealingConfig{
+		Active:                  false,
+		UnsealedHeightThreshold: 10,
+		RequiredReceipts:        2,
+	}
+	emergencySealing := NewEmergencySealing(config, receipts, metrics.NewNoopCollector())
+	thresholdHeight := incorporatedBlock.Height + config.UnsealedHeightThreshold
+
+	// inactive emergency sealing never qualifies results
+	qualifies, err := emergencySealing.Qualifies(incorporatedResult, &incorporatedBlock, thresholdHeight)
+	require.NoError(t, err)
+	assert.False(t, qualifies)
+
+	assert.False(t, emergencySealing.SetActive(true))
+	assert.True(t, emergencySealing.Config().Active)
+
+	// the threshold is not reached yet
+	qualifies, err = emergencySealing.Qualifies(incorporatedResult, &incorporatedBlock, thresholdHeight-1)
+	require.NoError(t, err)
+	assert.False(t, qualifies)
+
+	qualifies, err = emergencySealing.Qualifies(incorporatedResult, &incorporatedBlock, thresholdHeight)
+	require.NoError(t, err)
+	assert.True(t, qualifies)
+
+	// not enough execution nodes agree on the result
+	config.Active = true
+	config.RequiredReceipts = 3
+	emergencySealing = NewEmergencySealing(config, receipts, metrics.NewNoopCollector())
+	qualifies, err = emergencySealing.Qualifies(incorporatedResult, &incorporatedBlock, thresholdHeight)
+	require.NoError(t, err)
+	assert.False(t, qualifies)
+}
@@ -17,10 +17,6 @@ import (
 	"github.com/onflow/flow-go/state/protocol"
 )
 
-// DefaultEmergencySealingThreshold is the default number of blocks which indicates that ER should be sealed using emergency
-// sealing.
-const DefaultEmergencySealingThreshold = 100
-
 // VerifyingAssignmentCollector
 // Context:
 //  * When the same result is incorporated in multiple different forks,
@@ -67,29 +63,32 @@ func (ac *VerifyingAssignmentCollector) collectorByBlockID(incorporatedBlockID f
 	return ac.collectors[incorporatedBlockID]
 }
 
-// emergencySealable determines whether an incorporated Result qualifies for "emergency sealing".
-// ATTENTION: this is a temporary solution, which is NOT BFT compatible. When the approval process
-// hangs far enough behind finalization (measured in finalized but unsealed blocks), emergency
-// sealing kicks in. This will be removed when implementation of Sealing & Verification is finished.
-func (ac *VerifyingAssignmentCollector) emergencySealable(collector *ApprovalCollector, finalizedBlockHeight uint64) bool {
-	// Criterion for emergency sealing:
-	// there must be at least DefaultEmergencySealingThreshold number of blocks between
-	// the block that _incorporates_ result and the latest finalized block
-	return collector.IncorporatedBlock().Height+DefaultEmergencySealingThreshold <= finalizedBlockHeight
-}
-
 // CheckEmergencySealing checks the managed assignments whether their result can be emergency
 // sealed. Seals the results where possible.
 func (ac *VerifyingAssignmentCollector) CheckEmergencySealing(observer consensus.SealingObservation, finalizedBlockHeight uint64) error {
 	for _, collector := range ac.allCollectors() {
-		sealable := ac.emergencySealable(collector, finalizedBlockHeight)
+		sealable, err := ac.emergencySealing.Qualifies(collector.IncorporatedResult(), collector.IncorporatedBlock(), finalizedBlockHeight)
+		if err != nil {
+			return fmt.Errorf("could not check whether result %x incorporated at %x qualifies for emergency sealing: %w",
+				ac.ResultID(), collector.IncorporatedBlockID(), err)
+		}
 		observer.QualifiesForEmergencySealing(collector.IncorporatedResult(), sealable)
-		if sealable {
-			err := collector.SealResult()
-			if err != nil {
-				return fmt.Errorf("could not create emergency seal for result %x incorporated at %x: %w",
-					ac.ResultID(), collector.IncorporatedBlockID(), err)
-			}
+		if !sealable {
+			continue
+		}
+
+		added, err := collector.sealResult()
+		if err != nil {
+			return fmt.Errorf("could not create emergency seal for result %x incorporated at %x: %w",
+				ac.ResultID(), collector.IncorporatedBlockID(), err)
+		}
+		if added {
+			ac.emergencySealing.OnEmergencySeal()
+			ac.log.Warn().
+				Str("result_id", ac.ResultID().String()).
+				Str("incorporated_block_id", collector.IncorporatedBlockID().String()).
+				Uint64("executed_block_height", ac.Block().Height).
+				Msg("emergency sealed result without the required approvals")
 		}
 	}
 
@@ -19,6 +19,7 @@ import (
 	"github.com/onflow/flow-go/model/messages"
 	realmodule "github.com/onflow/flow-go/module"
 	realmempool "github.com/onflow/flow-go/module/mempool"
+	"github.com/onflow/flow-go/module/metrics"
 	module "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/network"
 	realproto "github.com/onflow/flow-go/state/protocol"
@@ -50,8 +51,14 @@ func newVerifyingAssignmentCollector(logger zerolog.Logger,
 	requestTracker *RequestTracker,
 	requiredApprovalsForSealConstruction uint,
 ) (*VerifyingAssignmentCollector, error) {
+	// emergency sealing without receipt agreement, so that it only depends on the finalized height
+	emergencySealing := NewEmergencySealing(EmergencySealingConfig{
+		Active:                  true,
+		UnsealedHeightThreshold: DefaultEmergencySealingThreshold,
+		RequiredReceipts:        0,
+	}, nil, metrics.NewNoopCollector())
 	b, err := NewAssignmentCollectorBase(logger, workerPool, result, state, headers, assigner, seals, sigVerifier,
-		approvalConduit, requestTracker, requiredApprovalsForSealConstruction)
+		approvalConduit, requestTracker, requiredApprovalsForSealConstruction, emergencySealing)
 	if err != nil {
 		return nil, err
 	}
@@ -2,12 +2,14 @@ package dkg
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/engine"
+	dkgmodel "github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -42,6 +44,7 @@ type ReactorEngine struct {
 	me                module.Local
 	State             protocol.State
 	keyStorage        storage.BeaconPrivateKeys
+	dkgState          storage.DKGState
 	controller        module.DKGController
 	controllerFactory module.DKGControllerFactory
 	viewEvents        events.Views
@@ -54,6 +57,7 @@ func NewReactorEngine(
 	me module.Local,
 	state protocol.State,
 	keyStorage storage.BeaconPrivateKeys,
+	dkgState storage.DKGState,
 	controllerFactory module.DKGControllerFactory,
 	viewEvents events.Views,
 ) *ReactorEngine {
@@ -68,6 +72,7 @@ func NewReactorEngine(
 		me:                me,
 		State:             state,
 		keyStorage:        keyStorage,
+		dkgState:          dkgState,
 		controllerFactory: controllerFactory,
 		viewEvents:        viewEvents,
 		pollStep:          DefaultPollStep,
@@ -76,9 +81,10 @@ func NewReactorEngine(
 
 // Ready implements the module ReadyDoneAware interface. It returns a channel
 // that will close when the engine has successfully
-// started.
+// started. If the node restarted during the epoch setup phase, the engine
+// resumes the DKG it was participating in.
 func (e *ReactorEngine) Ready() <-chan struct{} {
-	return e.unit.Ready()
+	return e.unit.Ready(e.resumeDKG)
 }
 
 // Done implements the module ReadyDoneAware interface. It returns a channel
@@ -100,11 +106,36 @@ func (e *ReactorEngine) EpochSetupPhaseStarted(currentEpochCounter uint64, first
 		Hex("block", firstID[:]).
 		Logger()
 
-	curDKGInfo, err := e.getDKGInfo(firstID)
+	curDKGInfo, err := e.getDKGInfo(first)
 	if err != nil {
 		lg.Fatal().Err(err).Msg("could not retrieve epoch info")
 	}
 
+	nextEpochCounter := currentEpochCounter + 1
+	dkgInstanceID := dkgmodule.CanonicalInstanceID(first.ChainID, nextEpochCounter)
+
+	// record the progress of the DKG, so that we can resume it if the node
+	// restarts during the epoch setup phase
+	err = e.dkgState.InsertDKGProgress(dkgInstanceID, &dkgmodel.Progress{
+		Seed:            curDKGInfo.seed,
+		Phase1FinalView: curDKGInfo.phase1FinalView,
+		Phase2FinalView: curDKGInfo.phase2FinalView,
+		Phase3FinalView: curDKGInfo.phase3FinalView,
+	})
+	if err != nil {
+		lg.Fatal().Err(err).Msg("could not store DKG progress")
+	}
+
+	e.startDKG(lg, nextEpochCounter, dkgInstanceID, curDKGInfo, first)
+}
+
+// startDKG creates and runs the controller for the given DKG instance, and
+// registers the triggers to regularly query the DKG smart-contract and
+// transition between phases at the views after the given block. Phase
+// transitions at earlier views, which can only be missed if the DKG is resumed
+// after a restart, are caught up with immediately.
+func (e *ReactorEngine) startDKG(lg zerolog.Logger, nextEpochCounter uint64, dkgInstanceID string, curDKGInfo *dkgInfo, current *flow.Header) {
+
 	committee := curDKGInfo.identities.Filter(filter.IsVotingConsensusCommitteeMember)
 
 	lg.Info().
@@ -114,9 +145,8 @@ func (e *ReactorEngine) EpochSetupPhaseStarted(currentEpochCounter uint64, first
 		Interface("members", committee.NodeIDs()).
 		Msg("epoch info")
 
-	nextEpochCounter := currentEpochCounter + 1
 	controller, err := e.controllerFactory.Create(
-		dkgmodule.CanonicalInstanceID(first.ChainID, nextEpochCounter),
+		dkgInstanceID,
 		committee,
 		curDKGInfo.seed,
 	)
@@ -145,20 +175,125 @@ func (e *ReactorEngine) EpochSetupPhaseStarted(currentEpochCounter uint64, first
 	// specifications and implementations of the DKGBroker and DKGController
 	// interfaces).
 
-	for view := curDKGInfo.phase1FinalView; view > first.View; view -= e.pollStep {
+	var missed []func() error
+	for view := curDKGInfo.phase1FinalView; view > current.View; view -= e.pollStep {
 		e.registerPoll(view)
 	}
-	e.registerPhaseTransition(curDKGInfo.phase1FinalView, dkgmodule.Phase1, e.controller.EndPhase1)
+	if curDKGInfo.phase1FinalView > current.View {
+		e.registerPhaseTransition(curDKGInfo.phase1FinalView, dkgmodule.Phase1, e.controller.EndPhase1)
+	} else {
+		missed = append(missed, e.controller.EndPhase1)
+	}
 
-	for view := curDKGInfo.phase2FinalView; view > curDKGInfo.phase1FinalView; view -= e.pollStep {
+	for view := curDKGInfo.phase2FinalView; view > curDKGInfo.phase1FinalView && view > current.View; view -= e.pollStep {
 		e.registerPoll(view)
 	}
-	e.registerPhaseTransition(curDKGInfo.phase2FinalView, dkgmodule.Phase2, e.controller.EndPhase2)
+	if curDKGInfo.phase2FinalView > current.View {
+		e.registerPhaseTransition(curDKGInfo.phase2FinalView, dkgmodule.Phase2, e.controller.EndPhase2)
+	} else {
+		missed = append(missed, e.controller.EndPhase2)
+	}
 
-	for view := curDKGInfo.phase3FinalView; view > curDKGInfo.phase2FinalView; view -= e.pollStep {
+	for view := curDKGInfo.phase3FinalView; view > curDKGInfo.phase2FinalView && view > current.View; view -= e.pollStep {
 		e.registerPoll(view)
 	}
 	e.registerPhaseTransition(curDKGInfo.phase3FinalView, dkgmodule.Phase3, e.end(nextEpochCounter))
+
+	if len(missed) > 0 {
+		e.catchUp(lg, current, missed)
+	}
+}
+
+// resumeDKG resumes the DKG for the next epoch, if the node restarted during
+// the epoch setup phase after it had started participating in the DKG. The
+// progress of the DKG is restored by the controller from its journal.
+func (e *ReactorEngine) resumeDKG() {
+	final := e.State.Final()
+	phase, err := final.Phase()
+	if err != nil {
+		e.log.Fatal().Err(err).Msg("could not get current epoch phase")
+	}
+	if phase != flow.EpochPhaseSetup {
+		return
+	}
+
+	head, err := final.Head()
+	if err != nil {
+		e.log.Fatal().Err(err).Msg("could not get finalized header")
+	}
+	currentEpochCounter, err := final.Epochs().Current().Counter()
+	if err != nil {
+		e.log.Fatal().Err(err).Msg("could not get current epoch counter")
+	}
+	nextEpochCounter := currentEpochCounter + 1
+	dkgInstanceID := dkgmodule.CanonicalInstanceID(head.ChainID, nextEpochCounter)
+
+	lg := e.log.With().
+		Uint64("current_epoch", currentEpochCounter).
+		Uint64("view", head.View).
+		Logger()
+
+	progress, err := e.dkgState.RetrieveDKGProgress(dkgInstanceID)
+	if errors.Is(err, storage.ErrNotFound) {
+		lg.Warn().Msg("node restarted during the epoch setup phase without having started the DKG, not participating in the DKG")
+		return
+	}
+	if err != nil {
+		lg.Fatal().Err(err).Msg("could not retrieve DKG progress")
+	}
+
+	_, err = e.keyStorage.RetrieveMyBeaconPrivateKey(nextEpochCounter)
+	if err == nil {
+		// the DKG was completed before the restart
+		return
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		lg.Fatal().Err(err).Msg("could not check for DKG private key")
+	}
+	if head.View >= progress.Phase3FinalView {
+		lg.Warn().Msg("DKG ended while the node was down, not resuming the DKG")
+		return
+	}
+
+	identities, err := final.Epochs().Next().InitialIdentities()
+	if err != nil {
+		lg.Fatal().Err(err).Msg("could not retrieve next epoch identities")
+	}
+
+	lg.Info().Msg("resuming DKG after restart")
+	info := &dkgInfo{
+		identities:      identities,
+		phase1FinalView: progress.Phase1FinalView,
+		phase2FinalView: progress.Phase2FinalView,
+		phase3FinalView: progress.Phase3FinalView,
+		seed:            progress.Seed,
+	}
+	e.startDKG(lg, nextEpochCounter, dkgInstanceID, info, head)
+}
+
+// catchUp polls the DKG smart-contract and performs the phase transitions which
+// were due while the node was down. Transitions which the controller already
+// performed before the restart are skipped.
+func (e *ReactorEngine) catchUp(lg zerolog.Logger, current *flow.Header, transitions []func() error) {
+	e.unit.Launch(func() {
+		e.unit.Lock()
+		defer e.unit.Unlock()
+
+		lg.Warn().Int("phases", len(transitions)).Msg("catching up with DKG phases which ended while the node was down")
+		err := e.controller.Poll(current.ID())
+		if err != nil {
+			lg.Err(err).Msg("failed to poll DKG smart-contract")
+		}
+		for _, transition := range transitions {
+			err := transition()
+			if dkgmodule.IsInvalidStateTransitionError(err) {
+				continue
+			}
+			if err != nil {
+				lg.Fatal().Err(err).Msg("node failed to catch up with DKG phase transition")
+			}
+		}
+	})
 }
 
 // EpochCommittedPhaseStarted handles the EpochCommittedPhaseStarted protocol event. It
@@ -191,7 +326,8 @@ func (e *ReactorEngine) EpochCommittedPhaseStarted(currentEpochCounter uint64, f
 	}
 }
 
-func (e *ReactorEngine) getDKGInfo(firstBlockID flow.Identifier) (*dkgInfo, error) {
+func (e *ReactorEngine) getDKGInfo(first *flow.Header) (*dkgInfo, error) {
+	firstBlockID := first.ID()
 	currEpoch := e.State.AtBlockID(firstBlockID).Epochs().Current()
 	nextEpoch := e.State.AtBlockID(firstBlockID).Epochs().Next()
 
@@ -203,6 +339,23 @@ func (e *ReactorEngine) getDKGInfo(firstBlockID flow.Identifier) (*dkgInfo, erro
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve epoch dkg final views: %w", err)
 	}
+	// In epoch emergency fallback mode, the current epoch has been extended and
+	// the next epoch may be set up after the DKG phase views of the current epoch
+	// have passed. In this case, the DKG phases are shifted to start with the
+	// first block of the setup phase, keeping the length of each phase.
+	if phase1Final <= first.View {
+		firstView, err := currEpoch.FirstView()
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve epoch first view: %w", err)
+		}
+		// the EpochSetup event does not record the view at which phase 1 was
+		// planned to start, only the first view of the epoch, so phase 1 spans
+		// from there, erring on the side of a longer phase 1
+		phase1Length := phase1Final - firstView + 1
+		phase2Length := phase2Final - phase1Final
+		phase3Length := phase3Final - phase2Final
+		phase1Final, phase2Final, phase3Final = shiftDKGPhases(first.View, phase1Length, phase2Length, phase3Length)
+	}
 	seed := make([]byte, crypto.SeedMinLenDKG)
 	_, err = rand.Read(seed)
 	if err != nil {
@@ -219,6 +372,16 @@ func (e *ReactorEngine) getDKGInfo(firstBlockID flow.Identifier) (*dkgInfo, erro
 	return info, nil
 }
 
+// shiftDKGPhases returns the final views of DKG phases with the given lengths,
+// such that phase 1 starts at the given view and each phase starts right after
+// the previous one.
+func shiftDKGPhases(start, phase1Length, phase2Length, phase3Length uint64) (uint64, uint64, uint64) {
+	phase1Final := start + phase1Length - 1
+	phase2Final := phase1Final + phase2Length
+	phase3Final := phase2Final + phase3Length
+	return phase1Final, phase2Final, phase3Final
+}
+
 // registerPoll instructs the engine to query the DKG smart-contract for new
 // broadcast messages at the specified view.
 func (e *ReactorEngine) registerPoll(view uint64) {
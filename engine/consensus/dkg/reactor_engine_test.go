@@ -10,13 +10,16 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/engine/consensus/dkg"
+	dkgmodel "github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
 	dkgmodule "github.com/onflow/flow-go/module/dkg"
 	module "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/state/protocol/events/gadgets"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storerr "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 	"github.com/onflow/flow-go/utils/unittest/mocks"
@@ -106,6 +109,20 @@ func TestEpochSetup(t *testing.T) {
 	controller.On("GetArtifacts").Return(expectedPrivKey, nil, nil).Once()
 	controller.On("SubmitResult").Return(nil).Once()
 
+	// ensure that the progress of the dkg run is recorded before the controller
+	// is created
+	dkgState := new(storage.DKGState)
+	dkgState.On("InsertDKGProgress", dkgmodule.CanonicalInstanceID(firstBlock.ChainID, nextCounter), mock.Anything).Run(
+		func(args mock.Arguments) {
+			progress := args.Get(1).(*dkgmodel.Progress)
+			require.Len(t, progress.Seed, crypto.SeedMinLenDKG)
+			require.Equal(t, uint64(150), progress.Phase1FinalView)
+			require.Equal(t, uint64(200), progress.Phase2FinalView)
+			require.Equal(t, uint64(250), progress.Phase3FinalView)
+		}).
+		Return(nil).
+		Once()
+
 	factory := new(module.DKGControllerFactory)
 	factory.On("Create",
 		dkgmodule.CanonicalInstanceID(firstBlock.ChainID, nextCounter),
@@ -122,6 +139,7 @@ func TestEpochSetup(t *testing.T) {
 		me,
 		state,
 		keyStorage,
+		dkgState,
 		factory,
 		viewEvents,
 	)
@@ -136,10 +154,191 @@ func TestEpochSetup(t *testing.T) {
 	time.Sleep(50 * time.Millisecond)
 	controller.AssertExpectations(t)
 	keyStorage.AssertExpectations(t)
+	dkgState.AssertExpectations(t)
 	// logger shouldn't be called in the happy path
 	require.Equal(t, 0, loggerCalls)
 }
 
+// TestEpochSetupFallback ensures that, if the epoch setup phase starts after
+// the DKG phase views of the current epoch have passed, which can happen in
+// epoch emergency fallback mode, the DKG phases are shifted to start with the
+// first block of the setup phase, and every phase keeps its own length.
+//
+// The current epoch starts at view 71 and is configured with DKG phase
+// transitions at views 150, 200, and 260, i.e. phases of 80, 50 and 60 views.
+// The EpochSetup event is received at view 300, so phase 1 lasts from view 300
+// to view 379.
+//
+// VIEWS
+// setup      : 300
+// Phase1Final: 379
+// Phase2Final: 429
+// Phase3Final: 489
+func TestEpochSetupFallback(t *testing.T) {
+	currentCounter := rand.Uint64()
+	nextCounter := currentCounter + 1
+	committee := unittest.IdentityListFixture(10)
+	me := new(module.Local)
+	me.On("NodeID").Return(committee[0].NodeID)
+
+	firstBlock := unittest.BlockHeaderFixture()
+	firstBlock.View = 300
+
+	currentEpoch := new(protocol.Epoch)
+	currentEpoch.On("Counter").Return(currentCounter, nil)
+	currentEpoch.On("FirstView").Return(uint64(71), nil)
+	currentEpoch.On("DKGPhase1FinalView").Return(uint64(150), nil)
+	currentEpoch.On("DKGPhase2FinalView").Return(uint64(200), nil)
+	currentEpoch.On("DKGPhase3FinalView").Return(uint64(260), nil)
+	nextEpoch := new(protocol.Epoch)
+	nextEpoch.On("Counter").Return(nextCounter, nil)
+	nextEpoch.On("InitialIdentities").Return(committee, nil)
+
+	epochQuery := mocks.NewEpochQuery(t, currentCounter)
+	epochQuery.Add(currentEpoch)
+	epochQuery.Add(nextEpoch)
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Epochs").Return(epochQuery)
+	state := new(protocol.State)
+	state.On("AtBlockID", firstBlock.ID()).Return(snapshot)
+
+	controller := new(module.DKGController)
+	controller.On("Run").Return(nil).Maybe()
+
+	dkgState := new(storage.DKGState)
+	dkgState.On("InsertDKGProgress", dkgmodule.CanonicalInstanceID(firstBlock.ChainID, nextCounter), mock.Anything).Run(
+		func(args mock.Arguments) {
+			progress := args.Get(1).(*dkgmodel.Progress)
+			require.Equal(t, uint64(379), progress.Phase1FinalView)
+			require.Equal(t, uint64(429), progress.Phase2FinalView)
+			require.Equal(t, uint64(489), progress.Phase3FinalView)
+		}).
+		Return(nil).
+		Once()
+
+	factory := new(module.DKGControllerFactory)
+	factory.On("Create",
+		dkgmodule.CanonicalInstanceID(firstBlock.ChainID, nextCounter),
+		committee,
+		mock.Anything,
+	).Return(controller, nil)
+
+	engine := dkg.NewReactorEngine(
+		zerolog.Nop(),
+		me,
+		state,
+		new(storage.BeaconPrivateKeys),
+		dkgState,
+		factory,
+		gadgets.NewViews(),
+	)
+
+	engine.EpochSetupPhaseStarted(currentCounter, &firstBlock)
+
+	dkgState.AssertExpectations(t)
+}
+
+// TestResumeDKG ensures that, when the node restarts during the epoch setup
+// phase, the engine resumes the DKG from the recorded progress. Phase
+// transitions which were due while the node was down are performed right away,
+// and the remaining ones are registered as usual.
+//
+// The current epoch is configured with DKG phase transitions at views 150, 200,
+// and 250. Before stopping, the node ended phase 1. It restarts at view 210, so
+// phase 2 ended while it was down.
+//
+// VIEWS
+// restart    : 210
+// polling    : 210 (catching up) 220 230 240 250
+// Phase3Final: 250
+func TestResumeDKG(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	currentCounter := rand.Uint64()
+	nextCounter := currentCounter + 1
+	committee := unittest.IdentityListFixture(10)
+	myIndex := 5
+	me := new(module.Local)
+	me.On("NodeID").Return(committee[myIndex].NodeID)
+
+	// create a block for each view of interest
+	blocks := make(map[uint64]*flow.Header)
+	var view uint64
+	for view = 210; view <= 250; view += dkg.DefaultPollStep {
+		header := unittest.BlockHeaderFixture()
+		header.View = view
+		blocks[view] = &header
+	}
+	restartBlock := blocks[210]
+	dkgInstanceID := dkgmodule.CanonicalInstanceID(restartBlock.ChainID, nextCounter)
+
+	currentEpoch := new(protocol.Epoch)
+	currentEpoch.On("Counter").Return(currentCounter, nil)
+	nextEpoch := new(protocol.Epoch)
+	nextEpoch.On("Counter").Return(nextCounter, nil)
+	nextEpoch.On("InitialIdentities").Return(committee, nil)
+
+	epochQuery := mocks.NewEpochQuery(t, currentCounter)
+	epochQuery.Add(currentEpoch)
+	epochQuery.Add(nextEpoch)
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Epochs").Return(epochQuery)
+	snapshot.On("Phase").Return(flow.EpochPhaseSetup, nil)
+	snapshot.On("Head").Return(restartBlock, nil)
+	state := new(protocol.State)
+	state.On("Final").Return(snapshot)
+
+	seed := unittest.SeedFixture(crypto.SeedMinLenDKG)
+	dkgState := new(storage.DKGState)
+	dkgState.On("RetrieveDKGProgress", dkgInstanceID).Return(&dkgmodel.Progress{
+		Seed:            seed,
+		Phase1FinalView: 150,
+		Phase2FinalView: 200,
+		Phase3FinalView: 250,
+	}, nil)
+
+	keyStorage := new(storage.BeaconPrivateKeys)
+	keyStorage.On("RetrieveMyBeaconPrivateKey", nextCounter).Return(nil, storerr.ErrNotFound).Once()
+	keyStorage.On("InsertMyBeaconPrivateKey", nextCounter, mock.Anything).Return(nil).Once()
+
+	// the controller was restored in phase 2 from its journal, so ending phase
+	// 1 again is an invalid state transition which is skipped
+	controller := new(module.DKGController)
+	controller.On("Run").Return(nil).Once()
+	controller.On("EndPhase1").Return(dkgmodule.NewInvalidStateTransitionError(dkgmodule.Phase2, dkgmodule.Phase2)).Once()
+	controller.On("EndPhase2").Return(nil).Once()
+	controller.On("End").Return(nil).Once()
+	controller.On("Poll", mock.Anything).Return(nil).Times(5)
+	controller.On("GetArtifacts").Return(unittest.NetworkingPrivKeyFixture(), nil, nil).Once()
+	controller.On("SubmitResult").Return(nil).Once()
+
+	factory := new(module.DKGControllerFactory)
+	factory.On("Create", dkgInstanceID, committee, seed).Return(controller, nil).Once()
+
+	viewEvents := gadgets.NewViews()
+	engine := dkg.NewReactorEngine(
+		zerolog.Nop(),
+		me,
+		state,
+		keyStorage,
+		dkgState,
+		factory,
+		viewEvents,
+	)
+
+	unittest.AssertClosesBefore(t, engine.Ready(), time.Second)
+
+	for view = 220; view <= 250; view += dkg.DefaultPollStep {
+		viewEvents.BlockFinalized(blocks[view])
+	}
+
+	// check that the missed phase transitions were caught up with, and the
+	// remaining callbacks were registered
+	time.Sleep(50 * time.Millisecond)
+	controller.AssertExpectations(t)
+	factory.AssertExpectations(t)
+	keyStorage.AssertExpectations(t)
+}
+
 // TestReactorEngine_EpochCommittedPhaseStarted ensures that we are logging
 // a warning message whenever we have a mismatch between the locally produced DKG keys
 // and the keys produced by the DKG smart contract.
@@ -197,6 +396,7 @@ func TestReactorEngine_EpochCommittedPhaseStarted(t *testing.T) {
 		me,
 		state,
 		keyStorage,
+		new(storage.DKGState),
 		factory,
 		viewEvents,
 	)
@@ -11,9 +11,11 @@ import (
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -68,7 +70,7 @@ func (e *Core) OnGuarantee(originID flow.Identifier, guarantee *flow.CollectionG
 	log := e.log.With().
 		Hex("origin_id", originID[:]).
 		Hex("collection_id", guaranteeID[:]).
-		Int("signers", len(guarantee.SignerIDs)).
+		Int("signers", signerindices.Count(guarantee.SignerIndices)).
 		Logger()
 	log.Info().Msg("collection guarantee received")
 
@@ -147,8 +149,7 @@ func (e *Core) validateExpiry(guarantee *flow.CollectionGuarantee) error {
 //	     nodes independently decide when a collection is finalized and we only check
 //	     that the guarantors are all from the same cluster. This implementation is NOT BFT.
 func (e *Core) validateGuarantors(guarantee *flow.CollectionGuarantee) error {
-	guarantors := guarantee.SignerIDs
-	if len(guarantors) == 0 {
+	if signerindices.Count(guarantee.SignerIndices) == 0 {
 		return engine.NewInvalidInputError("invalid collection guarantee with no guarantors")
 	}
 
@@ -161,18 +162,15 @@ func (e *Core) validateGuarantors(guarantee *flow.CollectionGuarantee) error {
 	if err != nil {
 		return fmt.Errorf("internal error retrieving collector clusters: %w", err)
 	}
-	cluster, _, ok := clusters.ByNodeID(guarantors[0])
-	if !ok {
-		return engine.NewInvalidInputErrorf("guarantor (id=%s) does not exist in any cluster", guarantors[0])
-	}
 
-	// ensure the guarantors are from the same cluster
-	clusterLookup := cluster.Lookup()
-	for _, guarantorID := range guarantors {
-		_, exists := clusterLookup[guarantorID]
-		if !exists {
-			return engine.NewInvalidInputError("inconsistent guarantors from different clusters")
-		}
+	// the guarantors are encoded relative to the members of a single cluster, hence
+	// successfully decoding them ensures that the guarantors are from the same cluster
+	_, err = signature.DecodeGuarantors(clusters, guarantee.SignerIndices)
+	if errors.Is(err, signerindices.ErrInvalid) {
+		return engine.NewInvalidInputErrorf("invalid guarantors: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("internal error decoding guarantors: %w", err)
 	}
 
 	return nil
@@ -195,7 +195,7 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeNotAdded() {
 func (suite *IngestionCoreSuite) TestOnGuaranteeNoGuarantors() {
 	// create a guarantee without any signers
 	guarantee := suite.validGuarantee()
-	guarantee.SignerIDs = nil
+	guarantee.SignerIndices = unittest.SignerIndicesByIdentifiers([]flow.Identifier{suite.collID}, nil)
 
 	// the guarantee is not part of the memory pool
 	suite.pool.On("Has", guarantee.ID()).Return(false)
@@ -217,7 +217,8 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeInvalidRole() {
 	for _, invalidSigner := range []flow.Identifier{suite.accessID, suite.conID, suite.execID, suite.verifID} {
 		// add signer with role other than collector
 		guarantee := suite.validGuarantee()
-		guarantee.SignerIDs = append(guarantee.SignerIDs, invalidSigner)
+		signerIDs := []flow.Identifier{suite.collID, invalidSigner}
+		guarantee.SignerIndices = unittest.SignerIndicesByIdentifiers(signerIDs, signerIDs)
 
 		// the guarantee is not part of the memory pool
 		suite.pool.On("Has", guarantee.ID()).Return(false)
@@ -262,7 +263,8 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeInvalidGuarantor() {
 
 	// create a guarantee  and add random (unknown) signer ID
 	guarantee := suite.validGuarantee()
-	guarantee.SignerIDs = append(guarantee.SignerIDs, unittest.IdentifierFixture())
+	signerIDs := []flow.Identifier{suite.collID, unittest.IdentifierFixture()}
+	guarantee.SignerIndices = unittest.SignerIndicesByIdentifiers(signerIDs, signerIDs)
 
 	// the guarantee is not part of the memory pool
 	suite.pool.On("Has", guarantee.ID()).Return(false)
@@ -324,7 +326,7 @@ func (suite *IngestionCoreSuite) TestOnGuaranteeUnknownOrigin() {
 // validGuarantee returns a valid collection guarantee based on the suite state.
 func (suite *IngestionCoreSuite) validGuarantee() *flow.CollectionGuarantee {
 	guarantee := unittest.CollectionGuaranteeFixture()
-	guarantee.SignerIDs = []flow.Identifier{suite.collID}
+	guarantee.SignerIndices = unittest.SignerIndicesByIdentifiers([]flow.Identifier{suite.collID}, []flow.Identifier{suite.collID})
 	guarantee.ReferenceBlockID = suite.head.ID()
 	return guarantee
 }
@@ -33,20 +33,14 @@ import (
 // when set to 0, it can build seal without any approval
 const DefaultRequiredApprovalsForSealConstruction = 1
 
-// DefaultEmergencySealingActive is a flag which indicates when emergency sealing is active, this is a temporary measure
-// to make fire fighting easier while seal & verification is under development.
-const DefaultEmergencySealingActive = false
-
 // Config is a structure of values that configure behavior of sealing engine
 type Config struct {
-	EmergencySealingActive               bool   // flag which indicates if emergency sealing is active or not. NOTE: this is temporary while sealing & verification is under development
 	RequiredApprovalsForSealConstruction uint   // min number of approvals required for constructing a candidate seal
 	ApprovalRequestsThreshold            uint64 // threshold for re-requesting approvals: min height difference between the latest finalized block and the block incorporating a result
 }
 
 func DefaultConfig() Config {
 	return Config{
-		EmergencySealingActive:               DefaultEmergencySealingActive,
 		RequiredApprovalsForSealConstruction: DefaultRequiredApprovalsForSealConstruction,
 		ApprovalRequestsThreshold:            10,
 	}
@@ -74,6 +68,7 @@ type Core struct {
 	metrics                    module.ConsensusMetrics            // used to track consensus metrics
 	sealingTracker             consensus.SealingTracker           // logic-aware component for tracking sealing progress.
 	tracer                     module.Tracer                      // used to trace execution
+	emergencySealing           *approvals.EmergencySealing        // decides whether stale results are sealed without approvals
 	config                     Config
 }
 
@@ -91,6 +86,7 @@ func NewCore(
 	verifier module.Verifier,
 	sealsMempool mempool.IncorporatedResultSeals,
 	approvalConduit network.Conduit,
+	emergencySealing *approvals.EmergencySealing,
 	config Config,
 ) (*Core, error) {
 	lastSealed, err := state.Sealed().Head()
@@ -112,6 +108,7 @@ func NewCore(
 		state:                      state,
 		seals:                      sealsDB,
 		sealsMempool:               sealsMempool,
+		emergencySealing:           emergencySealing,
 		config:                     config,
 		requestTracker:             approvals.NewRequestTracker(headers, 10, 30),
 	}
@@ -119,7 +116,7 @@ func NewCore(
 	factoryMethod := func(result *flow.ExecutionResult) (approvals.AssignmentCollector, error) {
 		base, err := approvals.NewAssignmentCollectorBase(core.log, core.workerPool, result, core.state, core.headers,
 			assigner, sealsMempool, verifier,
-			approvalConduit, core.requestTracker, config.RequiredApprovalsForSealConstruction)
+			approvalConduit, core.requestTracker, config.RequiredApprovalsForSealConstruction, emergencySealing)
 		if err != nil {
 			return nil, fmt.Errorf("could not create base collector: %w", err)
 		}
@@ -422,14 +419,14 @@ func (c *Core) processApproval(approval *flow.ResultApproval) error {
 }
 
 func (c *Core) checkEmergencySealing(observer consensus.SealingObservation, lastSealedHeight, lastFinalizedHeight uint64) error {
-	if !c.config.EmergencySealingActive {
+	if !c.emergencySealing.Active() {
 		return nil
 	}
 
-	emergencySealingHeight := lastSealedHeight + approvals.DefaultEmergencySealingThreshold
+	emergencySealingHeight := lastSealedHeight + c.emergencySealing.UnsealedHeightThreshold()
 
 	// we are interested in all collectors that match condition:
-	// lastSealedBlock + sealing.DefaultEmergencySealingThreshold < lastFinalizedHeight
+	// lastSealedBlock + UnsealedHeightThreshold < lastFinalizedHeight
 	// in other words we should check for emergency sealing only if threshold was reached
 	if emergencySealingHeight >= lastFinalizedHeight {
 		return nil
@@ -38,8 +38,10 @@ const RequiredApprovalsForSealConstructionTestingValue = 1
 type ApprovalProcessingCoreTestSuite struct {
 	approvals.BaseAssignmentCollectorTestSuite
 
-	sealsDB *storage.Seals
-	core    *Core
+	sealsDB          *storage.Seals
+	receiptsDB       *storage.ExecutionReceipts
+	emergencySealing *approvals.EmergencySealing
+	core             *Core
 }
 
 func (s *ApprovalProcessingCoreTestSuite) TearDownTest() {
@@ -50,6 +52,7 @@ func (s *ApprovalProcessingCoreTestSuite) SetupTest() {
 	s.BaseAssignmentCollectorTestSuite.SetupTest()
 
 	s.sealsDB = &storage.Seals{}
+	s.receiptsDB = &storage.ExecutionReceipts{}
 
 	s.State.On("Sealed").Return(unittest.StateSnapshotForKnownBlock(&s.ParentBlock, nil)).Maybe()
 
@@ -57,13 +60,13 @@ func (s *ApprovalProcessingCoreTestSuite) SetupTest() {
 	tracer := trace.NewNoopTracer()
 
 	options := Config{
-		EmergencySealingActive:               false,
 		RequiredApprovalsForSealConstruction: uint(len(s.AuthorizedVerifiers)),
 		ApprovalRequestsThreshold:            2,
 	}
+	s.emergencySealing = approvals.NewEmergencySealing(approvals.DefaultEmergencySealingConfig(), s.receiptsDB, metrics)
 
 	var err error
-	s.core, err = NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(), s.Headers, s.State, s.sealsDB, s.Assigner, s.SigVerifier, s.SealsPL, s.Conduit, options)
+	s.core, err = NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(), s.Headers, s.State, s.sealsDB, s.Assigner, s.SigVerifier, s.SealsPL, s.Conduit, s.emergencySealing, options)
 	require.NoError(s.T(), err)
 }
 
@@ -304,9 +307,39 @@ func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ApprovalVerifi
 	require.Error(s.T(), err)
 }
 
+// finalizeBlocksOnTop finalizes the given number of blocks on top of the incorporated block,
+// processing each finalized block with the core. It expects the parent block to be the last sealed block.
+func (s *ApprovalProcessingCoreTestSuite) finalizeBlocksOnTop(count int) {
+	seal := unittest.Seal.Fixture(unittest.Seal.WithBlock(&s.ParentBlock))
+	s.sealsDB.On("ByBlockID", mock.Anything).Return(seal, nil).Times(count)
+	s.State.On("Sealed").Return(unittest.StateSnapshotForKnownBlock(&s.ParentBlock, nil))
+
+	lastFinalizedBlock := &s.IncorporatedBlock
+	s.MarkFinalized(lastFinalizedBlock)
+	for i := 0; i < count; i++ {
+		finalizedBlock := unittest.BlockHeaderWithParentFixture(lastFinalizedBlock)
+		s.Blocks[finalizedBlock.ID()] = &finalizedBlock
+		s.MarkFinalized(&finalizedBlock)
+		err := s.core.ProcessFinalizedBlock(finalizedBlock.ID())
+		require.NoError(s.T(), err)
+		lastFinalizedBlock = &finalizedBlock
+	}
+}
+
+// mockAgreeingReceipts mocks the receipts of the given number of execution nodes committing to the incorporated result.
+func (s *ApprovalProcessingCoreTestSuite) mockAgreeingReceipts(count int) {
+	receipts := make(flow.ExecutionReceiptList, 0, count)
+	for i := 0; i < count; i++ {
+		receipts = append(receipts, unittest.ExecutionReceiptFixture(unittest.WithResult(s.IncorporatedResult.Result)))
+	}
+	s.receiptsDB.On("ByBlockID", s.Block.ID()).Return(receipts, nil)
+}
+
 // TestOnBlockFinalized_EmergencySealing tests that emergency sealing kicks in to resolve sealing halt
+// when verifiers don't approve the result, e.g. because they are offline
 func (s *ApprovalProcessingCoreTestSuite) TestOnBlockFinalized_EmergencySealing() {
-	s.core.config.EmergencySealingActive = true
+	s.emergencySealing.SetActive(true)
+	s.mockAgreeingReceipts(approvals.DefaultEmergencySealingRequiredReceipts)
 	s.SealsPL.On("ByID", mock.Anything).Return(nil, false).Maybe()
 	s.SealsPL.On("Add", mock.Anything).Run(
 		func(args mock.Arguments) {
@@ -316,27 +349,43 @@ func (s *ApprovalProcessingCoreTestSuite) TestOnBlockFinalized_EmergencySealing(
 		},
 	).Return(true, nil).Once()
 
-	seal := unittest.Seal.Fixture(unittest.Seal.WithBlock(&s.ParentBlock))
-	s.sealsDB.On("ByBlockID", mock.Anything).Return(seal, nil).Times(approvals.DefaultEmergencySealingThreshold)
-	s.State.On("Sealed").Return(unittest.StateSnapshotForKnownBlock(&s.ParentBlock, nil))
-
 	err := s.core.ProcessIncorporatedResult(s.IncorporatedResult)
 	require.NoError(s.T(), err)
 
-	lastFinalizedBlock := &s.IncorporatedBlock
-	s.MarkFinalized(lastFinalizedBlock)
-	for i := 0; i < approvals.DefaultEmergencySealingThreshold; i++ {
-		finalizedBlock := unittest.BlockHeaderWithParentFixture(lastFinalizedBlock)
-		s.Blocks[finalizedBlock.ID()] = &finalizedBlock
-		s.MarkFinalized(&finalizedBlock)
-		err := s.core.ProcessFinalizedBlock(finalizedBlock.ID())
-		require.NoError(s.T(), err)
-		lastFinalizedBlock = &finalizedBlock
-	}
+	s.finalizeBlocksOnTop(approvals.DefaultEmergencySealingThreshold)
 
 	s.SealsPL.AssertExpectations(s.T())
 }
 
+// TestOnBlockFinalized_EmergencySealingInactive tests that results are not emergency sealed
+// while emergency sealing is inactive
+func (s *ApprovalProcessingCoreTestSuite) TestOnBlockFinalized_EmergencySealingInactive() {
+	s.mockAgreeingReceipts(approvals.DefaultEmergencySealingRequiredReceipts)
+	s.SealsPL.On("ByID", mock.Anything).Return(nil, false).Maybe()
+
+	err := s.core.ProcessIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	s.finalizeBlocksOnTop(approvals.DefaultEmergencySealingThreshold)
+
+	s.SealsPL.AssertNotCalled(s.T(), "Add", mock.Anything)
+}
+
+// TestOnBlockFinalized_EmergencySealingReceiptDisagreement tests that results are not emergency sealed
+// unless enough execution nodes committed to the result
+func (s *ApprovalProcessingCoreTestSuite) TestOnBlockFinalized_EmergencySealingReceiptDisagreement() {
+	s.emergencySealing.SetActive(true)
+	s.mockAgreeingReceipts(approvals.DefaultEmergencySealingRequiredReceipts - 1)
+	s.SealsPL.On("ByID", mock.Anything).Return(nil, false).Maybe()
+
+	err := s.core.ProcessIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+
+	s.finalizeBlocksOnTop(approvals.DefaultEmergencySealingThreshold)
+
+	s.SealsPL.AssertNotCalled(s.T(), "Add", mock.Anything)
+}
+
 // TestOnBlockFinalized_ProcessingOrphanApprovals tests that approvals for orphan forks are rejected as outdated entries without processing
 // A <- B_1 <- C_1{ IER[B_1] }
 //	 <- B_2 <- C_2{ IER[B_2] } <- D_2{ IER[C_2] }
@@ -707,7 +756,7 @@ func (s *ApprovalProcessingCoreTestSuite) TestRepopulateAssignmentCollectorTree(
 	s.State.On("Final").Return(finalSnapShot)
 
 	core, err := NewCore(unittest.Logger(), s.WorkerPool, tracer, metrics, &tracker.NoopSealingTracker{}, engine.NewUnit(),
-		s.Headers, s.State, s.sealsDB, assigner, s.SigVerifier, s.SealsPL, s.Conduit, s.core.config)
+		s.Headers, s.State, s.sealsDB, assigner, s.SigVerifier, s.SealsPL, s.Conduit, s.emergencySealing, s.core.config)
 	require.NoError(s.T(), err)
 
 	err = core.RepopulateAssignmentCollectorTree(payloads)
@@ -245,7 +245,7 @@ func (e *blockComputer) executeSystemCollection(
 		return txIndex, fmt.Errorf("could not get system chunk transaction: %w", err)
 	}
 
-	err = e.executeTransaction(tx, colSpan, collectionView, programs, systemChunkCtx, collectionIndex, txIndex, res)
+	err = e.executeTransaction(tx, colSpan, nil, collectionView, programs, systemChunkCtx, collectionIndex, txIndex, res)
 	txIndex++
 
 	if err != nil {
@@ -299,8 +299,11 @@ func (e *blockComputer) executeCollection(
 	}()
 
 	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsReporter(e.metrics), fvm.WithTracer(e.tracer))
+	// link the transaction traces to the trace of the collection, which also contains
+	// the spans of the collection and consensus nodes
+	colLinks := trace.EntityLinks{collection.Guarantee.CollectionID}
 	for _, txBody := range collection.Transactions {
-		err := e.executeTransaction(txBody, colSpan, collectionView, programs, txCtx, collectionIndex, txIndex, res)
+		err := e.executeTransaction(txBody, colSpan, colLinks, collectionView, programs, txCtx, collectionIndex, txIndex, res)
 		txIndex++
 		if err != nil {
 			return txIndex, err
@@ -322,6 +325,7 @@ func (e *blockComputer) executeCollection(
 func (e *blockComputer) executeTransaction(
 	txBody *flow.TransactionBody,
 	colSpan opentracing.Span,
+	colLinks trace.EntityLinks,
 	collectionView state.View,
 	programs *programs.Programs,
 	ctx fvm.Context,
@@ -340,7 +344,7 @@ func (e *blockComputer) executeTransaction(
 	defer txSpan.Finish()
 
 	var traceID string
-	txInternalSpan, _, isSampled := e.tracer.StartTransactionSpan(context.Background(), txID, trace.EXERunTransaction)
+	txInternalSpan, _, isSampled := e.tracer.StartTransactionSpan(context.Background(), txID, trace.EXERunTransaction, colLinks)
 	if isSampled {
 		txInternalSpan.LogFields(log.String("tx_id", txID.String()))
 		if sc, ok := txInternalSpan.Context().(jaeger.SpanContext); ok {
@@ -365,8 +369,9 @@ func (e *blockComputer) executeTransaction(
 	}
 
 	txResult := flow.TransactionResult{
-		TransactionID:   tx.ID,
-		ComputationUsed: tx.ComputationUsed,
+		TransactionID:         tx.ID,
+		ComputationUsed:       tx.ComputationUsed,
+		ComputationUsedByKind: tx.ComputationUsedByKind,
 	}
 
 	if tx.Err != nil {
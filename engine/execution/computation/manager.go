@@ -3,6 +3,7 @@ package computation
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -31,7 +32,7 @@ type VirtualMachine interface {
 }
 
 type ComputationManager interface {
-	ExecuteScript([]byte, [][]byte, *flow.Header, state.View) ([]byte, error)
+	ExecuteScript(context.Context, []byte, [][]byte, *flow.Header, state.View) ([]byte, error)
 	ComputeBlock(
 		ctx context.Context,
 		block *entity.ExecutableBlock,
@@ -55,6 +56,8 @@ type Manager struct {
 	blockComputer      computer.BlockComputer
 	programsCache      *ProgramsCache
 	scriptLogThreshold time.Duration
+	scriptTimeout      time.Duration
+	scriptPool         *scriptPool
 	uploaders          []uploader.Uploader
 }
 
@@ -68,7 +71,7 @@ func New(
 	vmCtx fvm.Context,
 	programsCacheSize uint,
 	committer computer.ViewCommitter,
-	scriptLogThreshold time.Duration,
+	scriptConfig ScriptExecutionConfig,
 	uploaders []uploader.Uploader,
 ) (*Manager, error) {
 	log := logger.With().Str("engine", "computation").Logger()
@@ -100,7 +103,9 @@ func New(
 		vmCtx:              vmCtx,
 		blockComputer:      blockComputer,
 		programsCache:      programsCache,
-		scriptLogThreshold: scriptLogThreshold,
+		scriptLogThreshold: scriptConfig.LogThreshold,
+		scriptTimeout:      scriptConfig.Timeout,
+		scriptPool:         newScriptPool(scriptConfig),
 		uploaders:          uploaders,
 	}
 
@@ -115,57 +120,54 @@ func (e *Manager) getChildProgramsOrEmpty(blockID flow.Identifier) *programs.Pro
 	return blockPrograms.ChildPrograms()
 }
 
-func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *flow.Header, view state.View) ([]byte, error) {
+// ExecuteScript executes the script once there is capacity for it, and interrupts it once it exceeds
+// the script execution timeout. ErrScriptQueueFull is returned if too many scripts are waiting to be
+// executed, and ErrScriptTimedOut is returned if the script has been interrupted, or abandoned when
+// it could not be interrupted.
+func (e *Manager) ExecuteScript(ctx context.Context, code []byte, arguments [][]byte, blockHeader *flow.Header, view state.View) ([]byte, error) {
+
+	release, err := e.scriptPool.acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute script: %w", err)
+	}
 
 	startedAt := time.Now()
 
+	scriptCtx, cancel := context.WithTimeout(ctx, e.scriptTimeout)
+	defer cancel()
+
 	blockCtx := fvm.NewContextFromParent(e.vmCtx, fvm.WithBlockHeader(blockHeader))
 
-	script := fvm.Script(code).WithArguments(arguments...)
+	script := fvm.Script(code).WithArguments(arguments...).WithRequestContext(scriptCtx)
 
 	programs := e.getChildProgramsOrEmpty(blockHeader.ID())
 
-	err := func() (err error) {
-
-		start := time.Now()
-
-		defer func() {
-
-			prepareLog := func() *zerolog.Event {
-
-				args := make([]string, 0)
-				for _, a := range arguments {
-					args = append(args, hex.EncodeToString(a))
-				}
-				return e.log.Error().
-					Hex("script_hex", code).
-					Str("args", strings.Join(args[:], ","))
-			}
-
-			elapsed := time.Since(start)
-
-			if r := recover(); r != nil {
-				prepareLog().
-					Interface("recovered", r).
-					Msg("script execution caused runtime panic")
-
-				err = fmt.Errorf("cadence runtime error: %s", r)
-				return
-			}
-			if elapsed >= e.scriptLogThreshold {
-				prepareLog().
-					Dur("duration", elapsed).
-					Msg("script execution exceeded threshold")
-			}
-		}()
-
-		return e.vm.Run(blockCtx, script, view, programs)
+	// Cadence only checks the request context of the script when the script calls into the environment,
+	// so the script is executed in its own goroutine and abandoned once the script timeout is reached.
+	// An abandoned script keeps its slot in the pool until it finished, e.g. by exceeding the computation limit.
+	executed := make(chan error, 1)
+	go func() {
+		defer release()
+		executed <- e.runScript(blockCtx, script, view, programs, code, arguments)
 	}()
+
+	select {
+	case err = <-executed:
+	case <-scriptCtx.Done():
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to execute script: %w", ctx.Err())
+		}
+		return nil, fmt.Errorf("failed to execute script at block (%s): %w", blockHeader.ID(), ErrScriptTimedOut)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute script (internal error): %w", err)
 	}
 
 	if script.Err != nil {
+		if errors.Is(scriptCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, fmt.Errorf("failed to execute script at block (%s): %w", blockHeader.ID(), ErrScriptTimedOut)
+		}
+
 		scriptErrMsg := script.Err.Error()
 		if len(scriptErrMsg) > MaxScriptErrorMessageSize {
 			split := int(MaxScriptErrorMessageSize/2) - 1
@@ -189,6 +191,44 @@ func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *fl
 	return encodedValue, nil
 }
 
+// runScript runs the script on the VM, recovering from panics of the runtime and logging long running scripts
+func (e *Manager) runScript(blockCtx fvm.Context, script *fvm.ScriptProcedure, view state.View, programs *programs.Programs, code []byte, arguments [][]byte) (err error) {
+
+	start := time.Now()
+
+	defer func() {
+
+		prepareLog := func() *zerolog.Event {
+
+			args := make([]string, 0)
+			for _, a := range arguments {
+				args = append(args, hex.EncodeToString(a))
+			}
+			return e.log.Error().
+				Hex("script_hex", code).
+				Str("args", strings.Join(args[:], ","))
+		}
+
+		elapsed := time.Since(start)
+
+		if r := recover(); r != nil {
+			prepareLog().
+				Interface("recovered", r).
+				Msg("script execution caused runtime panic")
+
+			err = fmt.Errorf("cadence runtime error: %s", r)
+			return
+		}
+		if elapsed >= e.scriptLogThreshold {
+			prepareLog().
+				Dur("duration", elapsed).
+				Msg("script execution exceeded threshold")
+		}
+	}()
+
+	return e.vm.Run(blockCtx, script, view, programs)
+}
+
 func (e *Manager) ComputeBlock(
 	ctx context.Context,
 	block *entity.ExecutableBlock,
@@ -199,6 +239,10 @@ func (e *Manager) ComputeBlock(
 		Hex("block_id", logging.Entity(block.Block)).
 		Msg("received complete block")
 
+	// scripts are executed with lower concurrency while a block is being executed
+	e.scriptPool.blockExecutionStarted()
+	defer e.scriptPool.blockExecutionFinished()
+
 	var blockPrograms *programs.Programs
 	fromCache := e.programsCache.Get(block.ParentID())
 
@@ -34,8 +34,6 @@ import (
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
-var scriptLogThreshold = 1 * time.Second
-
 func TestComputeBlockWithStorage(t *testing.T) {
 	rt := fvm.NewInterpreterRuntime()
 
@@ -115,6 +113,7 @@ func TestComputeBlockWithStorage(t *testing.T) {
 		blockComputer: blockComputer,
 		me:            me,
 		programsCache: programsCache,
+		scriptPool:    newScriptPool(DefaultScriptExecutionConfig()),
 	}
 
 	view := delta.NewView(ledger.Get)
@@ -157,6 +156,7 @@ func TestComputeBlock_Uploader(t *testing.T) {
 		blockComputer: blockComputer,
 		me:            me,
 		programsCache: programsCache,
+		scriptPool:    newScriptPool(DefaultScriptExecutionConfig()),
 		uploaders:     []uploader.Uploader{fakeUploader},
 	}
 
@@ -200,11 +200,11 @@ func TestExecuteScript(t *testing.T) {
 		fvm.FungibleTokenAddress(execCtx.Chain).HexWithPrefix(),
 	))
 
-	engine, err := New(logger, metrics.NewNoopCollector(), nil, me, nil, vm, execCtx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), scriptLogThreshold, nil)
+	engine, err := New(logger, metrics.NewNoopCollector(), nil, me, nil, vm, execCtx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), DefaultScriptExecutionConfig(), nil)
 	require.NoError(t, err)
 
 	header := unittest.BlockHeaderFixture()
-	_, err = engine.ExecuteScript(script, nil, &header, scriptView)
+	_, err = engine.ExecuteScript(context.Background(), script, nil, &header, scriptView)
 	require.NoError(t, err)
 }
 
@@ -222,10 +222,10 @@ func TestExecuteScripPanicsAreHandled(t *testing.T) {
 	})
 	header := unittest.BlockHeaderFixture()
 
-	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), scriptLogThreshold, nil)
+	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), DefaultScriptExecutionConfig(), nil)
 	require.NoError(t, err)
 
-	_, err = manager.ExecuteScript([]byte("whatever"), nil, &header, view)
+	_, err = manager.ExecuteScript(context.Background(), []byte("whatever"), nil, &header, view)
 
 	require.Error(t, err)
 
@@ -246,10 +246,13 @@ func TestExecuteScript_LongScriptsAreLogged(t *testing.T) {
 	})
 	header := unittest.BlockHeaderFixture()
 
-	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), 1*time.Millisecond, nil)
+	scriptConfig := DefaultScriptExecutionConfig()
+	scriptConfig.LogThreshold = 1 * time.Millisecond
+
+	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), scriptConfig, nil)
 	require.NoError(t, err)
 
-	_, err = manager.ExecuteScript([]byte("whatever"), nil, &header, view)
+	_, err = manager.ExecuteScript(context.Background(), []byte("whatever"), nil, &header, view)
 
 	require.NoError(t, err)
 
@@ -270,16 +273,88 @@ func TestExecuteScript_ShortScriptsAreNotLogged(t *testing.T) {
 	})
 	header := unittest.BlockHeaderFixture()
 
-	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), 1*time.Second, nil)
+	scriptConfig := DefaultScriptExecutionConfig()
+	scriptConfig.LogThreshold = 1 * time.Second
+
+	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), scriptConfig, nil)
 	require.NoError(t, err)
 
-	_, err = manager.ExecuteScript([]byte("whatever"), nil, &header, view)
+	_, err = manager.ExecuteScript(context.Background(), []byte("whatever"), nil, &header, view)
 
 	require.NoError(t, err)
 
 	require.NotContains(t, buffer.String(), "exceeded threshold")
 }
 
+func TestExecuteScript_LongScriptsAreInterrupted(t *testing.T) {
+
+	logger := zerolog.Nop()
+
+	execCtx := fvm.NewContext(logger, fvm.WithGasLimit(1_000_000_000))
+
+	rt := fvm.NewInterpreterRuntime()
+
+	vm := fvm.NewVirtualMachine(rt)
+
+	ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+
+	view := delta.NewView(ledger.Get)
+
+	script := []byte(`
+		pub fun main() {
+			while true {
+				log("still running")
+			}
+		}
+	`)
+
+	scriptConfig := DefaultScriptExecutionConfig()
+	scriptConfig.Timeout = 10 * time.Millisecond
+
+	manager, err := New(logger, metrics.NewNoopCollector(), nil, nil, nil, vm, execCtx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), scriptConfig, nil)
+	require.NoError(t, err)
+
+	header := unittest.BlockHeaderFixture()
+	_, err = manager.ExecuteScript(context.Background(), script, nil, &header, view.NewChild())
+
+	require.ErrorIs(t, err, ErrScriptTimedOut)
+}
+
+func TestExecuteScript_TightLoopsAreInterrupted(t *testing.T) {
+
+	logger := zerolog.Nop()
+
+	execCtx := fvm.NewContext(logger, fvm.WithGasLimit(1_000_000_000))
+
+	rt := fvm.NewInterpreterRuntime()
+
+	vm := fvm.NewVirtualMachine(rt)
+
+	ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+
+	view := delta.NewView(ledger.Get)
+
+	// the loop never calls into the environment
+	script := []byte(`
+		pub fun main() {
+			while true {}
+		}
+	`)
+
+	scriptConfig := DefaultScriptExecutionConfig()
+	scriptConfig.Timeout = 10 * time.Millisecond
+
+	manager, err := New(logger, metrics.NewNoopCollector(), nil, nil, nil, vm, execCtx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), scriptConfig, nil)
+	require.NoError(t, err)
+
+	header := unittest.BlockHeaderFixture()
+	start := time.Now()
+	_, err = manager.ExecuteScript(context.Background(), script, nil, &header, view.NewChild())
+
+	require.ErrorIs(t, err, ErrScriptTimedOut)
+	require.Less(t, time.Since(start), time.Second)
+}
+
 type PanickingVM struct{}
 
 func (p *PanickingVM) Run(f fvm.Context, procedure fvm.Procedure, view state.View, p2 *programs.Programs) error {
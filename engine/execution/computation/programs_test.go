@@ -109,6 +109,7 @@ func TestPrograms_TestContractUpdates(t *testing.T) {
 		blockComputer: blockComputer,
 		me:            me,
 		programsCache: programsCache,
+		scriptPool:    newScriptPool(DefaultScriptExecutionConfig()),
 	}
 
 	view := delta.NewView(ledger.Get)
@@ -178,6 +179,7 @@ func TestPrograms_TestBlockForks(t *testing.T) {
 		blockComputer: blockComputer,
 		me:            me,
 		programsCache: programsCache,
+		scriptPool:    newScriptPool(DefaultScriptExecutionConfig()),
 	}
 
 	view := delta.NewView(ledger.Get)
@@ -0,0 +1,136 @@
+package computation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrScriptQueueFull is returned when a script is rejected because the maximum number of scripts
+// are already waiting to be executed
+var ErrScriptQueueFull = errors.New("script execution queue is full")
+
+// ErrScriptTimedOut is returned when the execution of a script exceeded the script execution timeout
+var ErrScriptTimedOut = errors.New("script execution timed out")
+
+// ScriptExecutionConfig configures how scripts are executed
+type ScriptExecutionConfig struct {
+	// MaxConcurrency is the maximum number of scripts executed concurrently
+	MaxConcurrency uint
+	// MaxConcurrencyDuringBlockExecution is the maximum number of scripts executed concurrently
+	// while blocks are being executed, which gives block execution priority over scripts
+	MaxConcurrencyDuringBlockExecution uint
+	// QueueSize is the maximum number of scripts waiting to be executed, further scripts are rejected
+	QueueSize uint
+	// Timeout is the maximum duration of the execution of a single script
+	Timeout time.Duration
+	// LogThreshold is the duration of the execution of a script above which the script is logged
+	LogThreshold time.Duration
+}
+
+// DefaultScriptExecutionConfig returns the default configuration for executing scripts
+func DefaultScriptExecutionConfig() ScriptExecutionConfig {
+	return ScriptExecutionConfig{
+		MaxConcurrency:                     16,
+		MaxConcurrencyDuringBlockExecution: 4,
+		QueueSize:                          128,
+		Timeout:                            10 * time.Second,
+		LogThreshold:                       DefaultScriptLogThreshold,
+	}
+}
+
+// scriptPool limits the number of scripts executed concurrently. Scripts exceeding the limit wait
+// in a bounded queue until a slot is released. While blocks are being executed the limit is lowered.
+type scriptPool struct {
+	mu              sync.Mutex
+	running         uint
+	waiting         uint
+	executingBlocks uint
+	// changed is closed and replaced whenever the limit may have been raised or a slot was released
+	changed chan struct{}
+
+	maxConcurrency                     uint
+	maxConcurrencyDuringBlockExecution uint
+	queueSize                          uint
+}
+
+func newScriptPool(config ScriptExecutionConfig) *scriptPool {
+	return &scriptPool{
+		changed:                            make(chan struct{}),
+		maxConcurrency:                     config.MaxConcurrency,
+		maxConcurrencyDuringBlockExecution: config.MaxConcurrencyDuringBlockExecution,
+		queueSize:                          config.QueueSize,
+	}
+}
+
+// limit returns the current maximum number of scripts executed concurrently.
+// Must be called with the lock held.
+func (p *scriptPool) limit() uint {
+	if p.executingBlocks > 0 {
+		return p.maxConcurrencyDuringBlockExecution
+	}
+	return p.maxConcurrency
+}
+
+// notify wakes up all waiting scripts. Must be called with the lock held.
+func (p *scriptPool) notify() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// acquire waits until the script can be executed and returns a function releasing its slot.
+// It returns ErrScriptQueueFull if the script cannot be executed immediately and the queue is full,
+// or the context error if the context is done before the script can be executed.
+func (p *scriptPool) acquire(ctx context.Context) (func(), error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running >= p.limit() {
+		if p.waiting >= p.queueSize {
+			return nil, ErrScriptQueueFull
+		}
+
+		p.waiting++
+		for p.running >= p.limit() {
+			changed := p.changed
+			p.mu.Unlock()
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				p.mu.Lock()
+				p.waiting--
+				return nil, ctx.Err()
+			}
+			p.mu.Lock()
+		}
+		p.waiting--
+	}
+
+	p.running++
+	return p.release, nil
+}
+
+func (p *scriptPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.running--
+	p.notify()
+}
+
+// blockExecutionStarted lowers the number of scripts executed concurrently until the block execution finished
+func (p *scriptPool) blockExecutionStarted() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.executingBlocks++
+}
+
+func (p *scriptPool) blockExecutionFinished() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.executingBlocks--
+	p.notify()
+}
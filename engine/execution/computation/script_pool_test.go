@@ -0,0 +1,114 @@
+package computation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptPool(t *testing.T) {
+
+	config := ScriptExecutionConfig{
+		MaxConcurrency:                     2,
+		MaxConcurrencyDuringBlockExecution: 1,
+		QueueSize:                          1,
+	}
+
+	t.Run("scripts exceeding the concurrency wait for a slot", func(t *testing.T) {
+		pool := newScriptPool(config)
+
+		release1, err := pool.acquire(context.Background())
+		require.NoError(t, err)
+		release2, err := pool.acquire(context.Background())
+		require.NoError(t, err)
+
+		acquired := make(chan struct{})
+		go func() {
+			release3, err := pool.acquire(context.Background())
+			assert.NoError(t, err)
+			close(acquired)
+			release3()
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("script should wait for a slot")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		release1()
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("script should have been executed once a slot was released")
+		}
+		release2()
+	})
+
+	t.Run("scripts are rejected once the queue is full", func(t *testing.T) {
+		pool := newScriptPool(config)
+
+		_, err := pool.acquire(context.Background())
+		require.NoError(t, err)
+		_, err = pool.acquire(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		waiting := make(chan error)
+		go func() {
+			_, err := pool.acquire(ctx)
+			waiting <- err
+		}()
+
+		require.Eventually(t, func() bool {
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+			return pool.waiting == 1
+		}, time.Second, 10*time.Millisecond)
+
+		_, err = pool.acquire(context.Background())
+		require.ErrorIs(t, err, ErrScriptQueueFull)
+
+		// a waiting script gives up its place in the queue once its context is done
+		cancel()
+		require.ErrorIs(t, <-waiting, context.Canceled)
+
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+		require.Equal(t, uint(0), pool.waiting)
+	})
+
+	t.Run("concurrency is lowered while blocks are executed", func(t *testing.T) {
+		pool := newScriptPool(config)
+
+		pool.blockExecutionStarted()
+
+		release1, err := pool.acquire(context.Background())
+		require.NoError(t, err)
+
+		acquired := make(chan struct{})
+		go func() {
+			release2, err := pool.acquire(context.Background())
+			assert.NoError(t, err)
+			close(acquired)
+			release2()
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("script should wait for the block execution to finish")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		pool.blockExecutionFinished()
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("script should have been executed once the block execution finished")
+		}
+		release1()
+	})
+}
@@ -93,12 +93,12 @@ func TestExecutionFlow(t *testing.T) {
 		Guarantees: []*flow.CollectionGuarantee{
 			{
 				CollectionID:     col1.ID(),
-				SignerIDs:        []flow.Identifier{colID.NodeID},
+				SignerIndices:    unittest.SignerIndicesByIdentifiers([]flow.Identifier{colID.NodeID}, []flow.Identifier{colID.NodeID}),
 				ReferenceBlockID: genesis.ID(),
 			},
 			{
 				CollectionID:     col2.ID(),
-				SignerIDs:        []flow.Identifier{colID.NodeID},
+				SignerIndices:    unittest.SignerIndicesByIdentifiers([]flow.Identifier{colID.NodeID}, []flow.Identifier{colID.NodeID}),
 				ReferenceBlockID: genesis.ID(),
 			},
 		},
@@ -225,7 +225,7 @@ func deployContractBlock(t *testing.T, conID *flow.Identity, colID *flow.Identit
 		Guarantees: []*flow.CollectionGuarantee{
 			{
 				CollectionID:     col.ID(),
-				SignerIDs:        []flow.Identifier{colID.NodeID},
+				SignerIndices:    unittest.SignerIndicesByIdentifiers([]flow.Identifier{colID.NodeID}, []flow.Identifier{colID.NodeID}),
 				ReferenceBlockID: ref.ID(),
 			},
 		},
@@ -251,7 +251,7 @@ func makePanicBlock(t *testing.T, conID *flow.Identity, colID *flow.Identity, ch
 	block := unittest.BlockWithParentAndProposerFixture(parent, conID.NodeID)
 	block.SetPayload(flow.Payload{
 		Guarantees: []*flow.CollectionGuarantee{
-			{CollectionID: col.ID(), SignerIDs: []flow.Identifier{colID.NodeID}, ReferenceBlockID: ref.ID()},
+			{CollectionID: col.ID(), SignerIndices: unittest.SignerIndicesByIdentifiers([]flow.Identifier{colID.NodeID}, []flow.Identifier{colID.NodeID}), ReferenceBlockID: ref.ID()},
 		},
 	})
 
@@ -270,7 +270,7 @@ func makeSuccessBlock(t *testing.T, conID *flow.Identity, colID *flow.Identity,
 	block := unittest.BlockWithParentAndProposerFixture(parent, conID.NodeID)
 	block.SetPayload(flow.Payload{
 		Guarantees: []*flow.CollectionGuarantee{
-			{CollectionID: col.ID(), SignerIDs: []flow.Identifier{colID.NodeID}, ReferenceBlockID: ref.ID()},
+			{CollectionID: col.ID(), SignerIndices: unittest.SignerIndicesByIdentifiers([]flow.Identifier{colID.NodeID}, []flow.Identifier{colID.NodeID}), ReferenceBlockID: ref.ID()},
 		},
 	})
 
@@ -27,6 +27,7 @@ import (
 	"github.com/onflow/flow-go/module/mempool/entity"
 	"github.com/onflow/flow-go/module/mempool/queue"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
@@ -995,7 +996,11 @@ func (e *Engine) matchOrRequestCollections(
 			Msg("requesting collection")
 
 		// queue the collection to be requested from one of the guarantors
-		e.request.EntityByID(guarantee.ID(), filter.HasNodeID(guarantee.SignerIDs...))
+		guarantors, err := signature.GuarantorsAtBlock(e.state, guarantee)
+		if err != nil {
+			return fmt.Errorf("could not decode guarantors of collection %x: %w", guarantee.ID(), err)
+		}
+		e.request.EntityByID(guarantee.ID(), filter.HasNodeID(guarantors.NodeIDs()...))
 		actualRequested++
 	}
 
@@ -1036,7 +1041,7 @@ func (e *Engine) ExecuteScriptAtBlockID(ctx context.Context, script []byte, argu
 			Str("args", strings.Join(args[:], ",")).
 			Msg("extensive log: executed script content")
 	}
-	return e.computationManager.ExecuteScript(script, arguments, block, blockView)
+	return e.computationManager.ExecuteScript(ctx, script, arguments, block, blockView)
 }
 
 func (e *Engine) GetRegisterAtBlockID(ctx context.Context, owner, controller, key []byte, blockID flow.Identifier) ([]byte, error) {
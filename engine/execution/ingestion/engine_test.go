@@ -329,6 +329,22 @@ func (ctx testingContext) mockStakedAtBlockID(blockID flow.Identifier, staked bo
 	return snap
 }
 
+// mockGuarantorsAtReferenceBlock mocks the clustering of the epoch containing the
+// guarantee's reference block, with a single cluster formed by the given guarantors.
+func (ctx testingContext) mockGuarantorsAtReferenceBlock(guarantee *flow.CollectionGuarantee, guarantorIDs ...flow.Identifier) {
+	cluster := make(flow.IdentityList, 0, len(guarantorIDs))
+	for _, guarantorID := range guarantorIDs {
+		cluster = append(cluster, unittest.IdentityFixture(unittest.WithNodeID(guarantorID), unittest.WithRole(flow.RoleCollection)))
+	}
+	epoch := new(protocol.Epoch)
+	epoch.On("Clustering").Return(flow.ClusterList{cluster}, nil)
+	epochs := new(protocol.EpochQuery)
+	epochs.On("Current").Return(epoch)
+	snap := new(protocol.Snapshot)
+	snap.On("Epochs").Return(epochs)
+	ctx.state.On("AtBlockID", guarantee.ReferenceBlockID).Return(snap)
+}
+
 func (ctx *testingContext) stateCommitmentExist(blockID flow.Identifier, commit flow.StateCommitment) {
 	ctx.executionState.On("StateCommitmentByBlockID", mock.Anything, blockID).Return(commit, nil)
 }
@@ -587,6 +603,7 @@ func TestBlocksArentExecutedMultipleTimes_multipleBlockEnqueue(t *testing.T) {
 		//blockCstartState := unittest.StateCommitmentFixture()
 
 		blockC := unittest.ExecutableBlockFixtureWithParent([][]flow.Identifier{{colSigner}}, blockB.Block.Header)
+		ctx.mockGuarantorsAtReferenceBlock(blockC.Block.Payload.Guarantees[0], colSigner)
 		//blockC.StartState = blockB.StartState //blocks are empty, so no state change is expected
 
 		logBlocks(map[string]*entity.ExecutableBlock{
@@ -692,6 +709,7 @@ func TestBlocksArentExecutedMultipleTimes_collectionArrival(t *testing.T) {
 		blockB.StartState = unittest.StateCommitmentPointerFixture()
 
 		blockC := unittest.ExecutableBlockFixtureWithParent([][]flow.Identifier{{colSigner}}, blockB.Block.Header)
+		ctx.mockGuarantorsAtReferenceBlock(blockC.Block.Payload.Guarantees[0], colSigner)
 		blockC.StartState = blockB.StartState //blocks are empty, so no state change is expected
 
 		// block D to make sure execution resumes after block C multiple execution has been prevented
@@ -946,7 +964,7 @@ func TestExecuteScriptAtBlockID(t *testing.T) {
 
 		// Successful call to computation manager
 		ctx.computationManager.
-			On("ExecuteScript", script, [][]byte(nil), blockA.Block.Header, view).
+			On("ExecuteScript", mock.Anything, script, [][]byte(nil), blockA.Block.Header, view).
 			Return(scriptResult, nil)
 
 		// Execute our script and expect no error
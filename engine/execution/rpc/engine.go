@@ -19,6 +19,7 @@ import (
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
@@ -163,6 +164,12 @@ func (h *handler) ExecuteScriptAtBlockID(
 	}
 
 	value, err := h.engine.ExecuteScriptAtBlockID(ctx, req.GetScript(), req.GetArguments(), blockID)
+	if errors.Is(err, computation.ErrScriptQueueFull) {
+		return nil, status.Errorf(codes.ResourceExhausted, "failed to execute script: %v", err)
+	}
+	if errors.Is(err, computation.ErrScriptTimedOut) {
+		return nil, status.Errorf(codes.DeadlineExceeded, "failed to execute script: %v", err)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to execute script: %v", err)
 	}
@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -15,6 +16,7 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/execution/computation"
 	ingestion "github.com/onflow/flow-go/engine/execution/ingestion/mock"
 	"github.com/onflow/flow-go/model/flow"
 	realstorage "github.com/onflow/flow-go/storage"
@@ -232,6 +234,59 @@ func (suite *Suite) TestGetAccountAtBlockID() {
 	})
 }
 
+// TestExecuteScriptAtBlockID tests the ExecuteScriptAtBlockID API call
+func (suite *Suite) TestExecuteScriptAtBlockID() {
+
+	id := unittest.IdentifierFixture()
+	script := []byte("pub fun main(): Int { return 1 }")
+	req := &execution.ExecuteScriptAtBlockIDRequest{
+		BlockId: id[:],
+		Script:  script,
+	}
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	suite.Run("happy path with valid request", func() {
+
+		// setup mock expectations
+		mockEngine.On("ExecuteScriptAtBlockID", mock.Anything, script, [][]byte(nil), id).Return([]byte{1}, nil).Once()
+
+		resp, err := handler.ExecuteScriptAtBlockID(context.Background(), req)
+
+		suite.Require().NoError(err)
+		suite.Require().Equal([]byte{1}, resp.GetValue())
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("script rejected because the queue is full", func() {
+
+		mockEngine.On("ExecuteScriptAtBlockID", mock.Anything, script, [][]byte(nil), id).
+			Return(nil, fmt.Errorf("failed to execute script: %w", computation.ErrScriptQueueFull)).Once()
+
+		_, err := handler.ExecuteScriptAtBlockID(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.ResourceExhausted, status.Code(err))
+	})
+
+	suite.Run("script timed out", func() {
+
+		mockEngine.On("ExecuteScriptAtBlockID", mock.Anything, script, [][]byte(nil), id).
+			Return(nil, fmt.Errorf("failed to execute script: %w", computation.ErrScriptTimedOut)).Once()
+
+		_, err := handler.ExecuteScriptAtBlockID(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.DeadlineExceeded, status.Code(err))
+	})
+}
+
 // Test GetRegisterAtBlockID tests the GetRegisterAtBlockID API call
 func (suite *Suite) TestGetRegisterAtBlockID() {
 
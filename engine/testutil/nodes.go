@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/engine/common/provider"
 	"github.com/onflow/flow-go/engine/common/requester"
 	"github.com/onflow/flow-go/engine/common/synchronization"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
 	"github.com/onflow/flow-go/engine/consensus/approvals/tracker"
 	consensusingest "github.com/onflow/flow-go/engine/consensus/ingestion"
 	"github.com/onflow/flow-go/engine/consensus/matching"
@@ -50,6 +51,8 @@ import (
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/buffer"
 	"github.com/onflow/flow-go/module/chunks"
@@ -399,6 +402,7 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	approvalVerifier := signature.NewAggregationVerifier(encoding.ResultApprovalTag)
 
 	sealingConfig := sealing.DefaultConfig()
+	emergencySealing := approvals.NewEmergencySealing(approvals.DefaultEmergencySealingConfig(), receiptsDB, node.Metrics)
 
 	sealingEngine, err := sealing.NewEngine(
 		node.Log,
@@ -418,6 +422,7 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		assigner,
 		approvalVerifier,
 		seals,
+		emergencySealing,
 		sealingConfig)
 	require.NoError(t, err)
 
@@ -576,7 +581,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		vmCtx,
 		computation.DefaultProgramsCacheSize,
 		committer,
-		computation.DefaultScriptLogThreshold,
+		computation.DefaultScriptExecutionConfig(),
 		nil,
 	)
 	require.NoError(t, err)
@@ -685,13 +690,14 @@ func getRoot(t *testing.T, node *testmock.GenericNode) (*flow.Header, *flow.Quor
 	signers, err := node.State.AtHeight(0).Identities(filter.HasRole(flow.RoleConsensus))
 	require.NoError(t, err)
 
-	signerIDs := signers.NodeIDs()
+	signerIndices, err := signerindices.Encode(signers.Sort(order.Canonical).NodeIDs(), signers.NodeIDs())
+	require.NoError(t, err)
 
 	rootQC := &flow.QuorumCertificate{
-		View:      rootHead.View,
-		BlockID:   rootHead.ID(),
-		SignerIDs: signerIDs,
-		SigData:   unittest.SignatureFixture(),
+		View:          rootHead.View,
+		BlockID:       rootHead.ID(),
+		SignerIndices: signerIndices,
+		SigData:       unittest.SignatureFixture(),
 	}
 
 	return rootHead, rootQC
@@ -706,6 +712,10 @@ func (s *RoundRobinLeaderSelection) Identities(blockID flow.Identifier, selector
 	return s.identities.Filter(selector), nil
 }
 
+func (s *RoundRobinLeaderSelection) CanonicalCommittee(blockID flow.Identifier) (flow.IdentityList, error) {
+	return s.identities.Sort(order.Canonical), nil
+}
+
 func (s *RoundRobinLeaderSelection) Identity(blockID flow.Identifier, participantID flow.Identifier) (*flow.Identity, error) {
 	id, found := s.identities.ByNodeID(participantID)
 	if !found {
@@ -47,7 +47,8 @@ func NewBlockConsumer(log zerolog.Logger,
 	blocks storage.Blocks,
 	state protocol.State,
 	blockProcessor assigner.FinalizedBlockProcessor,
-	maxProcessing uint64) (*BlockConsumer, uint64, error) {
+	maxProcessing uint64,
+	opts ...jobqueue.ConsumerOption) (*BlockConsumer, uint64, error) {
 
 	lg := log.With().Str("module", "block_consumer").Logger()
 
@@ -59,7 +60,7 @@ func NewBlockConsumer(log zerolog.Logger,
 	// the block reader is where the consumer reads new finalized blocks from (i.e., jobs).
 	jobs := NewFinalizedBlockReader(state, blocks)
 
-	consumer := jobqueue.NewConsumer(lg, jobs, processedHeight, worker, maxProcessing)
+	consumer := jobqueue.NewConsumer(lg, jobs, processedHeight, worker, maxProcessing, opts...)
 	defaultIndex, err := defaultProcessedIndex(state)
 	if err != nil {
 		return nil, 0, fmt.Errorf("could not read default processed index: %w", err)
@@ -83,6 +84,13 @@ func (c *BlockConsumer) NotifyJobIsDone(jobID module.JobID) {
 	c.metrics.OnBlockConsumerJobDone(processedIndex)
 }
 
+// NotifyJobFailed is invoked by the worker to let the consumer know that it could not
+// process a (block) job.
+func (c *BlockConsumer) NotifyJobFailed(jobID module.JobID, err error) {
+	processedIndex := c.consumer.NotifyJobFailed(jobID, err)
+	c.metrics.OnBlockConsumerJobDone(processedIndex)
+}
+
 // Size returns number of in-memory block jobs that block consumer is processing.
 func (c *BlockConsumer) Size() uint {
 	return c.consumer.Size()
@@ -48,3 +48,12 @@ func (w *worker) Notify(blockID flow.Identifier) {
 	jobID := JobID(blockID)
 	w.consumer.NotifyJobIsDone(jobID)
 }
+
+// NotifyFailure is a callback for engine to notify a block could not be
+// processed due to the given error.
+// The worker translates the block ID into job ID and notifies the consumer
+// that the job failed.
+func (w *worker) NotifyFailure(blockID flow.Identifier, err error) {
+	jobID := JobID(blockID)
+	w.consumer.NotifyJobFailed(jobID, err)
+}
@@ -160,15 +160,27 @@ func (e *Engine) ProcessFinalizedBlock(block *flow.Block) {
 	span, ctx, _ := e.tracer.StartBlockSpan(e.unit.Ctx(), blockID, trace.VERProcessFinalizedBlock)
 	defer span.Finish()
 
-	e.processFinalizedBlock(ctx, block)
+	err := e.processFinalizedBlock(ctx, block)
+	if err != nil {
+		// the block consumer retries the block, and records it as failed once it runs out of attempts.
+		e.log.Error().
+			Err(err).
+			Hex("block_id", logging.ID(blockID)).
+			Uint64("block_height", block.Header.Height).
+			Msg("could not process finalized block")
+		e.blockConsumerNotifier.NotifyFailure(blockID, err)
+		return
+	}
+
+	e.blockConsumerNotifier.Notify(blockID)
 }
 
 // processFinalizedBlock indexes the execution receipts included in the block, performs chunk assignment on its result, and
 // processes the chunks assigned to this verification node by pushing them to the chunks consumer.
-func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) {
+// Chunks already pushed to the chunks queue are skipped, so a block is safe to be processed again
+// after an error.
+func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) error {
 	blockID := block.ID()
-	// we should always notify block consumer before returning.
-	defer e.blockConsumerNotifier.Notify(blockID)
 
 	// keeps track of total assigned and processed chunks in
 	// this block for logging.
@@ -196,18 +208,14 @@ func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) {
 		// compute chunk assignment
 		chunkList, err := e.resultChunkAssignmentWithTracing(ctx, result, blockID)
 		if err != nil {
-			resultLog.Fatal().Err(err).Msg("could not determine assigned chunks for result")
+			return fmt.Errorf("could not determine assigned chunks for result %x: %w", resultID, err)
 		}
 
 		assignedChunksCount += uint64(len(chunkList))
 		for _, chunk := range chunkList {
 			processed, err := e.processChunkWithTracing(ctx, chunk, resultID, block.Header.Height)
 			if err != nil {
-				resultLog.Fatal().
-					Err(err).
-					Hex("chunk_id", logging.ID(chunk.ID())).
-					Uint64("chunk_index", chunk.Index).
-					Msg("could not process chunk")
+				return fmt.Errorf("could not process chunk %x (index %d) of result %x: %w", chunk.ID(), chunk.Index, resultID, err)
 			}
 
 			if processed {
@@ -221,6 +229,8 @@ func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) {
 		Uint64("total_assigned_chunks", assignedChunksCount).
 		Uint64("total_processed_chunks", processedChunksCount).
 		Msg("finished processing finalized block")
+
+	return nil
 }
 
 // chunkAssignments returns the list of chunks in the chunk list assigned to this verification node.
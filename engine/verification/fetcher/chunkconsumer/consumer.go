@@ -33,6 +33,7 @@ func NewChunkConsumer(
 	chunksQueue storage.ChunksQueue, // to read jobs (chunks) from
 	chunkProcessor fetcher.AssignedChunkProcessor, // to process jobs (chunks)
 	maxProcessing uint64, // max number of jobs to be processed in parallel
+	opts ...jobqueue.ConsumerOption, // e.g. to retry failed jobs
 ) *ChunkConsumer {
 	worker := NewWorker(chunkProcessor)
 	chunkProcessor.WithChunkConsumerNotifier(worker)
@@ -40,7 +41,7 @@ func NewChunkConsumer(
 	jobs := &ChunkJobs{locators: chunksQueue}
 
 	lg := log.With().Str("module", "chunk_consumer").Logger()
-	consumer := jobqueue.NewConsumer(lg, jobs, processedIndex, worker, maxProcessing)
+	consumer := jobqueue.NewConsumer(lg, jobs, processedIndex, worker, maxProcessing, opts...)
 
 	chunkConsumer := &ChunkConsumer{
 		consumer:       consumer,
@@ -58,6 +59,11 @@ func (c *ChunkConsumer) NotifyJobIsDone(jobID module.JobID) {
 	c.metrics.OnChunkConsumerJobDone(processedIndex)
 }
 
+func (c *ChunkConsumer) NotifyJobFailed(jobID module.JobID, err error) {
+	processedIndex := c.consumer.NotifyJobFailed(jobID, err)
+	c.metrics.OnChunkConsumerJobDone(processedIndex)
+}
+
 // Size returns number of in-memory chunk jobs that chunk consumer is processing.
 func (c *ChunkConsumer) Size() uint {
 	return c.consumer.Size()
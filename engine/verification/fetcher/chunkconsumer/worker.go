@@ -35,3 +35,8 @@ func (w *Worker) Notify(chunkLocatorID flow.Identifier) {
 	jobID := locatorIDToJobID(chunkLocatorID)
 	w.consumer.NotifyJobIsDone(jobID)
 }
+
+func (w *Worker) NotifyFailure(chunkLocatorID flow.Identifier, err error) {
+	jobID := locatorIDToJobID(chunkLocatorID)
+	w.consumer.NotifyJobFailed(jobID, err)
+}
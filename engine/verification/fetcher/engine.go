@@ -150,7 +150,10 @@ func (e *Engine) ProcessAssignedChunk(locator *chunks.Locator) {
 	lg = lg.With().Uint64("block_height", blockHeight).Logger()
 
 	if err != nil {
-		lg.Fatal().Err(err).Msg("could not process assigned chunk")
+		// the chunk consumer retries the chunk, and records it as failed once it runs out of attempts.
+		lg.Error().Err(err).Msg("could not process assigned chunk")
+		e.chunkConsumerNotifier.NotifyFailure(locatorID, err)
+		return
 	}
 
 	lg.Info().Bool("requested", requested).Msg("assigned chunk processed successfully")
@@ -202,6 +205,8 @@ func (e *Engine) processAssignedChunk(chunk *flow.Chunk, result *flow.ExecutionR
 
 	err = e.requestChunkDataPack(chunk.Index, chunkID, result.ID(), chunk.BlockID)
 	if err != nil {
+		// removes the chunk status so that a retry of this chunk requests it again.
+		e.pendingChunks.Rem(chunk.Index, result.ID())
 		return false, blockHeight, fmt.Errorf("could not request chunk data pack: %w", err)
 	}
 
@@ -0,0 +1,71 @@
+package follower
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// CollectionFetcher retrieves the full collections, including their transactions, of the
+// blocks delivered by the consensus follower. The follower only receives block payloads,
+// which reference collections by their guarantees.
+type CollectionFetcher interface {
+	CollectionByID(ctx context.Context, collectionID flow.Identifier) (*flow.Collection, error)
+}
+
+// AccessCollectionFetcher retrieves collections and their transactions through the Access API
+// of an upstream access node.
+type AccessCollectionFetcher struct {
+	client access.AccessAPIClient
+	chain  flow.Chain
+}
+
+var _ CollectionFetcher = (*AccessCollectionFetcher)(nil)
+
+// NewAccessCollectionFetcher creates a collection fetcher reading from the given Access API
+// client. The chain is used to validate the addresses of the fetched transactions.
+func NewAccessCollectionFetcher(client access.AccessAPIClient, chain flow.Chain) *AccessCollectionFetcher {
+	return &AccessCollectionFetcher{
+		client: client,
+		chain:  chain,
+	}
+}
+
+// CollectionByID returns the collection with the given ID. It returns an error if the
+// transactions returned by the access node do not match the requested collection.
+func (f *AccessCollectionFetcher) CollectionByID(ctx context.Context, collectionID flow.Identifier) (*flow.Collection, error) {
+	resp, err := f.client.GetCollectionByID(ctx, &access.GetCollectionByIDRequest{
+		Id: convert.IdentifierToMessage(collectionID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get collection %x: %w", collectionID, err)
+	}
+
+	txIDs := convert.MessagesToIdentifiers(resp.GetCollection().GetTransactionIds())
+	transactions := make([]*flow.TransactionBody, 0, len(txIDs))
+	for _, txID := range txIDs {
+		txResp, err := f.client.GetTransaction(ctx, &access.GetTransactionRequest{
+			Id: convert.IdentifierToMessage(txID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not get transaction %x: %w", txID, err)
+		}
+
+		tx, err := convert.MessageToTransaction(txResp.GetTransaction(), f.chain)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert transaction %x: %w", txID, err)
+		}
+		transactions = append(transactions, &tx)
+	}
+
+	collection := &flow.Collection{Transactions: transactions}
+	if collection.ID() != collectionID {
+		return nil, fmt.Errorf("fetched collection does not match collection %x (got: %x)", collectionID, collection.ID())
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,109 @@
+package follower
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// mockCollection sets up the client to serve the given collection and its transactions.
+func mockCollection(client *accessmock.AccessAPIClient, collectionID flow.Identifier, transactions []*flow.TransactionBody) {
+	txIDs := make([]flow.Identifier, 0, len(transactions))
+	for _, tx := range transactions {
+		txIDs = append(txIDs, tx.ID())
+	}
+
+	client.On("GetCollectionByID", mock.Anything, mock.MatchedBy(func(req *access.GetCollectionByIDRequest) bool {
+		return bytes.Equal(req.GetId(), collectionID[:])
+	})).Return(&access.CollectionResponse{
+		Collection: &entities.Collection{
+			Id:             collectionID[:],
+			TransactionIds: convert.IdentifiersToMessages(txIDs),
+		},
+	}, nil)
+
+	for _, tx := range transactions {
+		txID := tx.ID()
+		client.On("GetTransaction", mock.Anything, mock.MatchedBy(func(req *access.GetTransactionRequest) bool {
+			return bytes.Equal(req.GetId(), txID[:])
+		})).Return(&access.TransactionResponse{
+			Transaction: convert.TransactionToMessage(*tx),
+		}, nil)
+	}
+}
+
+// TestCollectionByID tests that the collection is assembled from the transactions returned by
+// the access node.
+func TestCollectionByID(t *testing.T) {
+	client := new(accessmock.AccessAPIClient)
+	fetcher := NewAccessCollectionFetcher(client, flow.Testnet.Chain())
+
+	collection := unittest.CollectionFixture(3)
+	mockCollection(client, collection.ID(), collection.Transactions)
+
+	fetched, err := fetcher.CollectionByID(context.Background(), collection.ID())
+	require.NoError(t, err)
+	assert.Equal(t, collection.ID(), fetched.ID())
+	require.Len(t, fetched.Transactions, len(collection.Transactions))
+	for i, tx := range collection.Transactions {
+		assert.Equal(t, tx.ID(), fetched.Transactions[i].ID())
+	}
+}
+
+// TestCollectionByIDMismatch tests that a collection which does not match the requested
+// collection ID is rejected.
+func TestCollectionByIDMismatch(t *testing.T) {
+	client := new(accessmock.AccessAPIClient)
+	fetcher := NewAccessCollectionFetcher(client, flow.Testnet.Chain())
+
+	collection := unittest.CollectionFixture(2)
+	other := unittest.CollectionFixture(2)
+	mockCollection(client, collection.ID(), other.Transactions)
+
+	_, err := fetcher.CollectionByID(context.Background(), collection.ID())
+	assert.Error(t, err)
+}
+
+// TestCollectionByIDErrors tests that errors of the access node are returned.
+func TestCollectionByIDErrors(t *testing.T) {
+	t.Run("collection not available", func(t *testing.T) {
+		client := new(accessmock.AccessAPIClient)
+		fetcher := NewAccessCollectionFetcher(client, flow.Testnet.Chain())
+
+		collectionID := unittest.IdentifierFixture()
+		client.On("GetCollectionByID", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("not found"))
+
+		_, err := fetcher.CollectionByID(context.Background(), collectionID)
+		assert.Error(t, err)
+	})
+
+	t.Run("transaction not available", func(t *testing.T) {
+		client := new(accessmock.AccessAPIClient)
+		fetcher := NewAccessCollectionFetcher(client, flow.Testnet.Chain())
+
+		collection := unittest.CollectionFixture(1)
+		client.On("GetCollectionByID", mock.Anything, mock.Anything).Return(&access.CollectionResponse{
+			Collection: &entities.Collection{
+				TransactionIds: convert.IdentifiersToMessages(collection.Light().Transactions),
+			},
+		}, nil)
+		client.On("GetTransaction", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("not found"))
+
+		_, err := fetcher.CollectionByID(context.Background(), collection.ID())
+		assert.Error(t, err)
+	})
+}
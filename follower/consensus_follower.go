@@ -11,7 +11,10 @@ import (
 	access "github.com/onflow/flow-go/cmd/access/node_builder"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
 )
 
 // ConsensusFollower is a standalone module run by third parties which provides
@@ -22,6 +25,13 @@ type ConsensusFollower interface {
 	Run(context.Context)
 	// AddOnBlockFinalizedConsumer adds a new block finalization subscriber.
 	AddOnBlockFinalizedConsumer(pubsub.OnBlockFinalizedConsumer)
+	// AddFinalizedBlockConsumer adds a subscriber for finalized blocks, including their payload.
+	AddFinalizedBlockConsumer(BlockConsumer)
+	// AddSealedBlockConsumer adds a subscriber for sealed blocks, including their payload.
+	AddSealedBlockConsumer(BlockConsumer)
+	// State returns the read-only protocol state of the follower, which is available once the
+	// follower has started.
+	State() protocol.State
 }
 
 // Config contains the configurable fields for a `ConsensusFollower`.
@@ -33,6 +43,8 @@ type Config struct {
 	dataDir        string              // directory to store the protocol state (if the badger storage is not provided)
 	bootstrapDir   string              // path to the bootstrap directory
 	logLevel       string              // log level
+	startHeight    *uint64             // height to resume block delivery from (if not set, only new blocks are delivered)
+	collections    CollectionFetcher   // fetcher for the collections of delivered blocks (optional)
 }
 
 type Option func(c *Config)
@@ -68,6 +80,23 @@ func WithDB(db *badger.DB) Option {
 	}
 }
 
+// WithStartHeight makes the follower deliver all finalized and sealed blocks starting at the
+// given height, for instance the height following the last block processed before a restart.
+// By default, only blocks finalized and sealed after startup are delivered.
+func WithStartHeight(height uint64) Option {
+	return func(cf *Config) {
+		cf.startHeight = &height
+	}
+}
+
+// WithCollectionFetcher makes the follower fetch the collections of each block before
+// delivering it to the block consumers. Delivery stalls until the collections are available.
+func WithCollectionFetcher(fetcher CollectionFetcher) Option {
+	return func(cf *Config) {
+		cf.collections = fetcher
+	}
+}
+
 // BootstrapNodeInfo contains the details about the upstream bootstrap peer the consensus follower uses
 type BootstrapNodeInfo struct {
 	Host             string // ip or hostname
@@ -134,9 +163,14 @@ func buildAccessNode(accessNodeOptions []access.Option) (*access.UnstakedAccessN
 }
 
 type ConsensusFollowerImpl struct {
-	NodeBuilder *access.UnstakedAccessNodeBuilder
-	consumersMu sync.RWMutex
-	consumers   []pubsub.OnBlockFinalizedConsumer
+	NodeBuilder        *access.UnstakedAccessNodeBuilder
+	consumersMu        sync.RWMutex
+	consumers          []pubsub.OnBlockFinalizedConsumer
+	finalizedConsumers []BlockConsumer
+	sealedConsumers    []BlockConsumer
+	notifier           engine.Notifier
+	stateMu            sync.RWMutex
+	state              protocol.State
 }
 
 // NewConsensusFollower creates a new consensus follower.
@@ -163,11 +197,41 @@ func NewConsensusFollower(
 		return nil, err
 	}
 
-	consensusFollower := &ConsensusFollowerImpl{NodeBuilder: anb}
+	consensusFollower := &ConsensusFollowerImpl{
+		NodeBuilder: anb,
+		notifier:    engine.NewNotifier(),
+	}
 	anb.BaseConfig.NodeRole = "consensus_follower"
 
 	anb.FinalizationDistributor.AddOnBlockFinalizedConsumer(consensusFollower.onBlockFinalized)
 
+	anb.Component("block dispatcher", func(_ cmd.NodeBuilder, node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+		dispatcher := newBlockDispatcher(
+			node.Logger,
+			node.State,
+			node.Storage.Blocks,
+			config.collections,
+			consensusFollower.notifier,
+			consensusFollower.onFinalizedBlock,
+			consensusFollower.onSealedBlock,
+		)
+		var err error
+		if config.startHeight != nil {
+			err = dispatcher.resumeFrom(*config.startHeight)
+		} else {
+			err = dispatcher.resumeFromLatest()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize block dispatcher: %w", err)
+		}
+
+		consensusFollower.stateMu.Lock()
+		consensusFollower.state = node.State
+		consensusFollower.stateMu.Unlock()
+
+		return dispatcher, nil
+	})
+
 	return consensusFollower, nil
 }
 
@@ -180,6 +244,31 @@ func (cf *ConsensusFollowerImpl) onBlockFinalized(finalizedBlockID flow.Identifi
 		cf.consumersMu.RLock()
 	}
 	cf.consumersMu.RUnlock()
+
+	// the dispatcher reads the newly finalized and sealed blocks from the protocol state
+	cf.notifier.Notify()
+}
+
+// onFinalizedBlock relays a finalized block to all registered consumers.
+func (cf *ConsensusFollowerImpl) onFinalizedBlock(block *BlockData) {
+	cf.consumersMu.RLock()
+	consumers := cf.finalizedConsumers
+	cf.consumersMu.RUnlock()
+
+	for _, consumer := range consumers {
+		consumer(block)
+	}
+}
+
+// onSealedBlock relays a sealed block to all registered consumers.
+func (cf *ConsensusFollowerImpl) onSealedBlock(block *BlockData) {
+	cf.consumersMu.RLock()
+	consumers := cf.sealedConsumers
+	cf.consumersMu.RUnlock()
+
+	for _, consumer := range consumers {
+		consumer(block)
+	}
 }
 
 // AddOnBlockFinalizedConsumer adds a new block finalization subscriber.
@@ -189,6 +278,32 @@ func (cf *ConsensusFollowerImpl) AddOnBlockFinalizedConsumer(consumer pubsub.OnB
 	cf.consumers = append(cf.consumers, consumer)
 }
 
+// AddFinalizedBlockConsumer adds a subscriber for finalized blocks. Blocks are delivered in
+// height order, starting at the configured start height. Consumers are called synchronously
+// and should not block, as they hold up the delivery of further blocks.
+func (cf *ConsensusFollowerImpl) AddFinalizedBlockConsumer(consumer BlockConsumer) {
+	cf.consumersMu.Lock()
+	defer cf.consumersMu.Unlock()
+	cf.finalizedConsumers = append(cf.finalizedConsumers, consumer)
+}
+
+// AddSealedBlockConsumer adds a subscriber for sealed blocks. Blocks are delivered in height
+// order, starting at the configured start height. Consumers are called synchronously and
+// should not block, as they hold up the delivery of further blocks.
+func (cf *ConsensusFollowerImpl) AddSealedBlockConsumer(consumer BlockConsumer) {
+	cf.consumersMu.Lock()
+	defer cf.consumersMu.Unlock()
+	cf.sealedConsumers = append(cf.sealedConsumers, consumer)
+}
+
+// State returns the read-only protocol state of the follower, or nil if the follower has not
+// started yet. It is always available from within block consumers.
+func (cf *ConsensusFollowerImpl) State() protocol.State {
+	cf.stateMu.RLock()
+	defer cf.stateMu.RUnlock()
+	return cf.state
+}
+
 // Run starts the consensus follower.
 func (cf *ConsensusFollowerImpl) Run(ctx context.Context) {
 	runAccessNode(ctx, cf.NodeBuilder)
@@ -0,0 +1,56 @@
+package follower
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestOnBlockFinalized tests that finalization events are relayed to the registered consumers,
+// and wake up the block dispatcher.
+func TestOnBlockFinalized(t *testing.T) {
+	cf := &ConsensusFollowerImpl{notifier: engine.NewNotifier()}
+
+	var received []flow.Identifier
+	cf.AddOnBlockFinalizedConsumer(func(blockID flow.Identifier) {
+		received = append(received, blockID)
+	})
+
+	blockID := unittest.IdentifierFixture()
+	cf.onBlockFinalized(blockID)
+
+	assert.Equal(t, []flow.Identifier{blockID}, received)
+	unittest.RequireCloseBefore(t, cf.notifier.Channel(), time.Second, "dispatcher was not notified")
+}
+
+// TestBlockConsumers tests that finalized and sealed blocks are relayed to the consumers
+// registered for them, in the order of registration.
+func TestBlockConsumers(t *testing.T) {
+	cf := &ConsensusFollowerImpl{notifier: engine.NewNotifier()}
+
+	var finalized, sealed []string
+	cf.AddFinalizedBlockConsumer(func(*BlockData) { finalized = append(finalized, "first") })
+	cf.AddFinalizedBlockConsumer(func(*BlockData) { finalized = append(finalized, "second") })
+	cf.AddSealedBlockConsumer(func(*BlockData) { sealed = append(sealed, "sealed") })
+
+	block := unittest.BlockFixture()
+	cf.onFinalizedBlock(&BlockData{Block: &block})
+	assert.Equal(t, []string{"first", "second"}, finalized)
+	assert.Empty(t, sealed)
+
+	cf.onSealedBlock(&BlockData{Block: &block})
+	assert.Equal(t, []string{"first", "second"}, finalized)
+	assert.Equal(t, []string{"sealed"}, sealed)
+}
+
+// TestStateBeforeStartup tests that the protocol state is not available before the follower
+// has started.
+func TestStateBeforeStartup(t *testing.T) {
+	cf := &ConsensusFollowerImpl{notifier: engine.NewNotifier()}
+	assert.Nil(t, cf.State())
+}
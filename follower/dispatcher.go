@@ -0,0 +1,201 @@
+package follower
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// retryDelay is the delay after which the dispatcher retries to deliver blocks, if reading a block
+// or fetching its collections failed.
+const retryDelay = time.Second
+
+// BlockData is a block delivered to the subscribers of the consensus follower.
+type BlockData struct {
+	Block *flow.Block
+	// Collections holds the complete collections of the block payload, in the order of the
+	// collection guarantees. It is only populated if collection fetching is enabled.
+	Collections []*flow.Collection
+}
+
+// BlockConsumer consumes the blocks delivered by the consensus follower. Blocks are delivered
+// one at a time, in increasing height order, without gaps.
+type BlockConsumer func(*BlockData)
+
+// blockDispatcher delivers finalized and sealed blocks to the subscribers in height order. It
+// keeps track of the delivered heights itself, rather than relying on the individual
+// finalization events, so that no block is skipped and delivery can resume from any height.
+type blockDispatcher struct {
+	unit        *engine.Unit
+	startup     sync.Once
+	log         zerolog.Logger
+	state       protocol.State
+	blocks      storage.Blocks
+	collections CollectionFetcher // optional
+	notifier    engine.Notifier
+	onFinalized BlockConsumer
+	onSealed    BlockConsumer
+
+	nextFinalized uint64                // height of the next finalized block to deliver
+	nextSealed    uint64                // height of the next sealed block to deliver
+	pending       map[uint64]*BlockData // finalized blocks which have not been delivered as sealed yet
+}
+
+func newBlockDispatcher(
+	log zerolog.Logger,
+	state protocol.State,
+	blocks storage.Blocks,
+	collections CollectionFetcher,
+	notifier engine.Notifier,
+	onFinalized BlockConsumer,
+	onSealed BlockConsumer,
+) *blockDispatcher {
+	return &blockDispatcher{
+		unit:        engine.NewUnit(),
+		log:         log.With().Str("component", "follower_dispatcher").Logger(),
+		state:       state,
+		blocks:      blocks,
+		collections: collections,
+		notifier:    notifier,
+		onFinalized: onFinalized,
+		onSealed:    onSealed,
+		pending:     make(map[uint64]*BlockData),
+	}
+}
+
+// resumeFrom sets up the dispatcher to deliver all finalized and sealed blocks starting at the
+// given height. Heights below the root block of the protocol state are not available and are
+// skipped.
+func (d *blockDispatcher) resumeFrom(height uint64) error {
+	root, err := d.state.Params().Root()
+	if err != nil {
+		return fmt.Errorf("could not get root header: %w", err)
+	}
+	if height < root.Height {
+		d.log.Warn().
+			Uint64("start_height", height).
+			Uint64("root_height", root.Height).
+			Msg("start height is below the root block, resuming from the root block")
+		height = root.Height
+	}
+
+	d.nextFinalized = height
+	d.nextSealed = height
+	return nil
+}
+
+// resumeFromLatest sets up the dispatcher to only deliver blocks finalized and sealed after the
+// current state.
+func (d *blockDispatcher) resumeFromLatest() error {
+	final, err := d.state.Final().Head()
+	if err != nil {
+		return fmt.Errorf("could not get finalized header: %w", err)
+	}
+	sealed, err := d.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get sealed header: %w", err)
+	}
+	d.nextFinalized = final.Height + 1
+	d.nextSealed = sealed.Height + 1
+	return nil
+}
+
+// Ready starts the delivery of blocks. Only the first call starts the delivery loop, so that
+// blocks are never delivered concurrently or twice.
+func (d *blockDispatcher) Ready() <-chan struct{} {
+	d.startup.Do(func() {
+		d.unit.Launch(d.loop)
+		// deliver blocks finalized between the start height and now
+		d.notifier.Notify()
+	})
+	return d.unit.Ready()
+}
+
+// Done stops the delivery of blocks, after the block currently being delivered.
+func (d *blockDispatcher) Done() <-chan struct{} {
+	return d.unit.Done()
+}
+
+func (d *blockDispatcher) loop() {
+	for {
+		select {
+		case <-d.unit.Quit():
+			return
+		case <-d.notifier.Channel():
+		}
+
+		err := d.dispatch()
+		if err != nil {
+			d.log.Warn().Err(err).Msg("could not deliver blocks, will retry")
+			d.unit.LaunchAfter(retryDelay, d.notifier.Notify)
+		}
+	}
+}
+
+// dispatch delivers all blocks which were finalized or sealed since the last delivered blocks.
+func (d *blockDispatcher) dispatch() error {
+	final, err := d.state.Final().Head()
+	if err != nil {
+		return fmt.Errorf("could not get finalized header: %w", err)
+	}
+	for ; d.nextFinalized <= final.Height; d.nextFinalized++ {
+		data, err := d.blockData(d.nextFinalized)
+		if err != nil {
+			return err
+		}
+		if d.nextFinalized >= d.nextSealed {
+			d.pending[d.nextFinalized] = data
+		}
+		d.onFinalized(data)
+	}
+
+	sealed, err := d.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get sealed header: %w", err)
+	}
+	for ; d.nextSealed <= sealed.Height; d.nextSealed++ {
+		data, ok := d.pending[d.nextSealed]
+		if !ok {
+			data, err = d.blockData(d.nextSealed)
+			if err != nil {
+				return err
+			}
+		}
+		d.onSealed(data)
+		delete(d.pending, d.nextSealed)
+	}
+
+	return nil
+}
+
+// blockData reads the finalized block at the given height, and fetches its collections if
+// collection fetching is enabled.
+func (d *blockDispatcher) blockData(height uint64) (*BlockData, error) {
+	block, err := d.blocks.ByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block at height %d: %w", height, err)
+	}
+
+	data := &BlockData{Block: block}
+	if d.collections == nil {
+		return data, nil
+	}
+
+	data.Collections = make([]*flow.Collection, 0, len(block.Payload.Guarantees))
+	for _, guarantee := range block.Payload.Guarantees {
+		collection, err := d.collections.CollectionByID(d.unit.Ctx(), guarantee.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch collection %x of block at height %d: %w", guarantee.CollectionID, height, err)
+		}
+		data.Collections = append(data.Collections, collection)
+	}
+
+	return data, nil
+}
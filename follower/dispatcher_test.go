@@ -0,0 +1,342 @@
+package follower
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const rootHeight = 100
+
+type DispatcherSuite struct {
+	suite.Suite
+
+	state    *protocol.State
+	blocks   *storage.Blocks
+	notifier engine.Notifier
+	fetcher  *fakeFetcher
+
+	mu           sync.Mutex
+	chain        map[uint64]*flow.Block // blocks by height
+	finalHeight  uint64
+	sealedHeight uint64
+	finalized    []uint64 // heights of the delivered finalized blocks
+	sealed       []uint64 // heights of the delivered sealed blocks
+	delivered    []*BlockData
+
+	dispatcher *blockDispatcher
+}
+
+func TestDispatcher(t *testing.T) {
+	suite.Run(t, new(DispatcherSuite))
+}
+
+func (suite *DispatcherSuite) SetupTest() {
+	suite.state = new(protocol.State)
+	suite.blocks = new(storage.Blocks)
+	suite.notifier = engine.NewNotifier()
+	suite.fetcher = newFakeFetcher()
+	suite.finalized = nil
+	suite.sealed = nil
+	suite.delivered = nil
+
+	// build a chain of 20 blocks starting at the root block, each with a single guarantee
+	suite.chain = make(map[uint64]*flow.Block)
+	parent := unittest.BlockHeaderFixture()
+	parent.Height = rootHeight - 1
+	for height := uint64(rootHeight); height < rootHeight+20; height++ {
+		collection := unittest.CollectionFixture(1)
+		suite.fetcher.add(&collection)
+		block := unittest.BlockWithParentFixture(&parent)
+		block.SetPayload(flow.Payload{
+			Guarantees: unittest.CollectionGuaranteesWithCollectionIDFixture([]*flow.Collection{&collection}),
+		})
+		suite.chain[height] = block
+		parent = *block.Header
+	}
+	suite.finalHeight = rootHeight
+	suite.sealedHeight = rootHeight
+
+	params := new(protocol.Params)
+	params.On("Root").Return(suite.chain[rootHeight].Header, nil)
+	suite.state.On("Params").Return(params)
+
+	final := new(protocol.Snapshot)
+	final.On("Head").Return(
+		func() *flow.Header {
+			suite.mu.Lock()
+			defer suite.mu.Unlock()
+			return suite.chain[suite.finalHeight].Header
+		},
+		nil,
+	)
+	suite.state.On("Final").Return(final)
+
+	sealed := new(protocol.Snapshot)
+	sealed.On("Head").Return(
+		func() *flow.Header {
+			suite.mu.Lock()
+			defer suite.mu.Unlock()
+			return suite.chain[suite.sealedHeight].Header
+		},
+		nil,
+	)
+	suite.state.On("Sealed").Return(sealed)
+
+	suite.blocks.On("ByHeight", mock.Anything).Return(
+		func(height uint64) *flow.Block {
+			return suite.chain[height]
+		},
+		func(height uint64) error {
+			if _, ok := suite.chain[height]; !ok {
+				return fmt.Errorf("no block at height %d", height)
+			}
+			return nil
+		},
+	)
+
+	suite.dispatcher = suite.newDispatcher(suite.fetcher)
+}
+
+func (suite *DispatcherSuite) newDispatcher(collections CollectionFetcher) *blockDispatcher {
+	return newBlockDispatcher(
+		zerolog.Nop(),
+		suite.state,
+		suite.blocks,
+		collections,
+		suite.notifier,
+		func(data *BlockData) {
+			suite.mu.Lock()
+			defer suite.mu.Unlock()
+			suite.finalized = append(suite.finalized, data.Block.Header.Height)
+			suite.delivered = append(suite.delivered, data)
+		},
+		func(data *BlockData) {
+			suite.mu.Lock()
+			defer suite.mu.Unlock()
+			suite.sealed = append(suite.sealed, data.Block.Header.Height)
+		},
+	)
+}
+
+// extend finalizes and seals the chain up to the given heights, and notifies the dispatcher.
+func (suite *DispatcherSuite) extend(finalHeight, sealedHeight uint64) {
+	suite.mu.Lock()
+	suite.finalHeight = finalHeight
+	suite.sealedHeight = sealedHeight
+	suite.mu.Unlock()
+	suite.notifier.Notify()
+}
+
+// requireDelivered waits until the blocks with the given heights have been delivered as
+// finalized and sealed, and checks that no other blocks have been delivered.
+func (suite *DispatcherSuite) requireDelivered(finalized []uint64, sealed []uint64) {
+	require.Eventually(suite.T(), func() bool {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+		return len(suite.finalized) >= len(finalized) && len(suite.sealed) >= len(sealed)
+	}, 3*time.Second, 10*time.Millisecond)
+
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	assert.Equal(suite.T(), finalized, suite.finalized)
+	assert.Equal(suite.T(), sealed, suite.sealed)
+}
+
+func (suite *DispatcherSuite) stop() {
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Done(), time.Second, "dispatcher did not stop")
+}
+
+func heights(from, to uint64) []uint64 {
+	var list []uint64
+	for height := from; height <= to; height++ {
+		list = append(list, height)
+	}
+	return list
+}
+
+// TestDeliverFromStartHeight tests that the blocks finalized and sealed before startup are
+// delivered in height order starting at the start height, followed by the new blocks.
+func (suite *DispatcherSuite) TestDeliverFromStartHeight() {
+	suite.extend(rootHeight+5, rootHeight+3)
+	require.NoError(suite.T(), suite.dispatcher.resumeFrom(rootHeight+1))
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Ready(), time.Second, "dispatcher did not start")
+	defer suite.stop()
+
+	suite.requireDelivered(heights(rootHeight+1, rootHeight+5), heights(rootHeight+1, rootHeight+3))
+
+	suite.extend(rootHeight+9, rootHeight+7)
+	suite.requireDelivered(heights(rootHeight+1, rootHeight+9), heights(rootHeight+1, rootHeight+7))
+}
+
+// TestStartHeightBelowRoot tests that delivery starts at the root block, if the start height
+// is below the root block.
+func (suite *DispatcherSuite) TestStartHeightBelowRoot() {
+	suite.extend(rootHeight+2, rootHeight+1)
+	require.NoError(suite.T(), suite.dispatcher.resumeFrom(rootHeight-10))
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Ready(), time.Second, "dispatcher did not start")
+	defer suite.stop()
+
+	suite.requireDelivered(heights(rootHeight, rootHeight+2), heights(rootHeight, rootHeight+1))
+}
+
+// TestResumeFromLatest tests that only blocks finalized and sealed after startup are delivered,
+// if no start height is given.
+func (suite *DispatcherSuite) TestResumeFromLatest() {
+	suite.extend(rootHeight+5, rootHeight+3)
+	require.NoError(suite.T(), suite.dispatcher.resumeFromLatest())
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Ready(), time.Second, "dispatcher did not start")
+	defer suite.stop()
+
+	suite.extend(rootHeight+7, rootHeight+6)
+	suite.requireDelivered(heights(rootHeight+6, rootHeight+7), heights(rootHeight+4, rootHeight+6))
+}
+
+// TestFetchCollections tests that the collections of each block are delivered in the order of
+// its guarantees, and that delivery is retried if fetching a collection fails.
+func (suite *DispatcherSuite) TestFetchCollections() {
+	failing := suite.chain[rootHeight+2].Payload.Guarantees[0].CollectionID
+	suite.fetcher.failOnce(failing)
+
+	suite.extend(rootHeight+3, rootHeight)
+	require.NoError(suite.T(), suite.dispatcher.resumeFrom(rootHeight+1))
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Ready(), time.Second, "dispatcher did not start")
+	defer suite.stop()
+
+	suite.requireDelivered(heights(rootHeight+1, rootHeight+3), nil)
+
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	for _, data := range suite.delivered {
+		guarantees := data.Block.Payload.Guarantees
+		require.Len(suite.T(), data.Collections, len(guarantees))
+		for i, guarantee := range guarantees {
+			assert.Equal(suite.T(), guarantee.CollectionID, data.Collections[i].ID())
+		}
+	}
+	assert.Equal(suite.T(), 2, suite.fetcher.requests(failing))
+}
+
+// TestWithoutCollectionFetcher tests that blocks are delivered without collections, if
+// collection fetching is disabled.
+func (suite *DispatcherSuite) TestWithoutCollectionFetcher() {
+	suite.dispatcher = suite.newDispatcher(nil)
+	suite.extend(rootHeight+2, rootHeight)
+	require.NoError(suite.T(), suite.dispatcher.resumeFrom(rootHeight+1))
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Ready(), time.Second, "dispatcher did not start")
+	defer suite.stop()
+
+	suite.requireDelivered(heights(rootHeight+1, rootHeight+2), nil)
+
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	for _, data := range suite.delivered {
+		assert.Nil(suite.T(), data.Collections)
+	}
+}
+
+// TestReadyTwice tests that calling Ready more than once does not deliver blocks twice.
+func (suite *DispatcherSuite) TestReadyTwice() {
+	suite.extend(rootHeight+5, rootHeight+5)
+	require.NoError(suite.T(), suite.dispatcher.resumeFrom(rootHeight))
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Ready(), time.Second, "dispatcher did not start")
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Ready(), time.Second, "dispatcher did not start")
+	defer suite.stop()
+
+	suite.requireDelivered(heights(rootHeight, rootHeight+5), heights(rootHeight, rootHeight+5))
+
+	suite.extend(rootHeight+8, rootHeight+8)
+	suite.requireDelivered(heights(rootHeight, rootHeight+8), heights(rootHeight, rootHeight+8))
+}
+
+// TestShutdown tests that no blocks are delivered once the dispatcher has stopped, including
+// blocks whose delivery was scheduled for a retry.
+func (suite *DispatcherSuite) TestShutdown() {
+	failing := suite.chain[rootHeight+2].Payload.Guarantees[0].CollectionID
+	suite.fetcher.failOnce(failing)
+
+	suite.extend(rootHeight+3, rootHeight)
+	require.NoError(suite.T(), suite.dispatcher.resumeFrom(rootHeight+1))
+	unittest.RequireCloseBefore(suite.T(), suite.dispatcher.Ready(), time.Second, "dispatcher did not start")
+
+	// stop while the delivery of the block with the failing collection is waiting for a retry
+	require.Eventually(suite.T(), func() bool {
+		return suite.fetcher.requests(failing) == 1
+	}, time.Second, 10*time.Millisecond)
+	suite.stop()
+
+	suite.extend(rootHeight+5, rootHeight+5)
+	time.Sleep(retryDelay + 100*time.Millisecond)
+
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	assert.Equal(suite.T(), heights(rootHeight+1, rootHeight+1), suite.finalized)
+	assert.Empty(suite.T(), suite.sealed)
+	assert.Equal(suite.T(), 1, suite.fetcher.requests(failing))
+}
+
+// fakeFetcher serves collections from memory, and fails the first request for selected
+// collections.
+type fakeFetcher struct {
+	mu          sync.Mutex
+	collections map[flow.Identifier]*flow.Collection
+	failing     map[flow.Identifier]bool
+	counts      map[flow.Identifier]int
+}
+
+var _ CollectionFetcher = (*fakeFetcher)(nil)
+
+func newFakeFetcher() *fakeFetcher {
+	return &fakeFetcher{
+		collections: make(map[flow.Identifier]*flow.Collection),
+		failing:     make(map[flow.Identifier]bool),
+		counts:      make(map[flow.Identifier]int),
+	}
+}
+
+func (f *fakeFetcher) add(collection *flow.Collection) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.collections[collection.ID()] = collection
+}
+
+func (f *fakeFetcher) failOnce(collectionID flow.Identifier) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failing[collectionID] = true
+}
+
+func (f *fakeFetcher) requests(collectionID flow.Identifier) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.counts[collectionID]
+}
+
+func (f *fakeFetcher) CollectionByID(_ context.Context, collectionID flow.Identifier) (*flow.Collection, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.counts[collectionID]++
+	if f.failing[collectionID] {
+		delete(f.failing, collectionID)
+		return nil, fmt.Errorf("collection %x is not available yet", collectionID)
+	}
+	collection, ok := f.collections[collectionID]
+	if !ok {
+		return nil, fmt.Errorf("unknown collection %x", collectionID)
+	}
+	return collection, nil
+}
@@ -0,0 +1,49 @@
+package blueprints
+
+import (
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go/fvm/utils"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const ComputationWeightsPathDomain = "storage"
+const ComputationWeightsPathIdentifier = "computationWeights"
+
+const setComputationWeightsTransactionTemplate = `
+transaction(weights: {String: UInt64}, path: StoragePath) {
+	prepare(signer: AuthAccount) {
+		signer.load<{String: UInt64}>(from: path)
+		signer.save(weights, to: path)
+	}
+}
+`
+
+// SetComputationWeightsTransaction returns a transaction for updating the weights computation of each kind of operation
+// is metered with, keyed by the name of the kind of operation
+func SetComputationWeightsTransaction(serviceAccount flow.Address, weights map[string]uint64) (*flow.TransactionBody, error) {
+	value, err := utils.StringUInt64MapToCadenceValue(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	arg1, err := jsoncdc.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	arg2, err := jsoncdc.Encode(cadence.Path{
+		Domain:     ComputationWeightsPathDomain,
+		Identifier: ComputationWeightsPathIdentifier,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return flow.NewTransactionBody().
+		SetScript([]byte(setComputationWeightsTransactionTemplate)).
+		AddAuthorizer(serviceAccount).
+		AddArgument(arg1).
+		AddArgument(arg2), nil
+}
@@ -0,0 +1,121 @@
+package fvm
+
+import (
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime"
+
+	"github.com/onflow/flow-go/fvm/blueprints"
+	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/handler"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/fvm/utils"
+)
+
+// loadComputationWeights sets the computation weights stored in a storage path of the service account
+// on the computation metering handler.
+//
+// Weights are stored keyed by the name of the kind of operation and override the default weights.
+// if any issue occurs on the process (missing registers, stored value properly not set)
+// it gracefully handle it and falls back to the default weights.
+//
+// The weights are read once and cached in the programs, together with the register reads of reading
+// them, which are merged into the state of every procedure using the cached weights.
+func loadComputationWeights(
+	env Environment,
+	sth *state.StateHolder,
+	programs *programs.Programs,
+	computationHandler handler.ComputationMeteringHandler,
+) {
+	ctx := env.Context()
+
+	entry, cached := programs.GetComputationWeights()
+	if cached {
+		err := sth.State().MergeState(entry.State, sth.EnforceInteractionLimits())
+		if err != nil {
+			// ignore LedgerIntractionLimitExceededError errors, like for cached programs
+			var interactionLimitExceededErr *errors.LedgerIntractionLimitExceededError
+			if !errors.As(err, &interactionLimitExceededErr) {
+				ctx.Logger.Error().Err(err).Msg("failed to merge state of cached computation weights")
+			}
+		}
+	} else {
+		entry = readComputationWeights(env, sth, computationHandler)
+		programs.SetComputationWeights(entry)
+	}
+
+	value := entry.Value
+	if value == nil {
+		ctx.Logger.Warn().Msg("failed to read computation weights from service account. using default weights instead.")
+		return
+	}
+
+	// no weights have been set
+	if optional, ok := value.(cadence.Optional); ok && optional.Value == nil {
+		return
+	}
+
+	stored, ok := utils.OptionalCadenceValueToStringUInt64Map(value)
+	if !ok {
+		ctx.Logger.Warn().Msg("failed to parse computation weights from service account. using default weights instead.")
+		return
+	}
+
+	weights := handler.DefaultComputationWeights()
+	for name, weight := range stored {
+		kind, ok := handler.ComputationKindFromString(name)
+		if !ok {
+			ctx.Logger.Warn().Str("kind", name).Msg("ignoring computation weight of unknown kind of operation")
+			continue
+		}
+		weights[kind] = weight
+	}
+
+	computationHandler.SetWeights(weights)
+}
+
+// readComputationWeights reads the computation weights stored in the service account
+// in a child state, which is merged into the active state afterwards
+func readComputationWeights(
+	env Environment,
+	sth *state.StateHolder,
+	computationHandler handler.ComputationMeteringHandler,
+) *programs.ComputationWeightsEntry {
+	ctx := env.Context()
+
+	// reading the weights is metered separately and is not charged
+	subMeter := computationHandler.StartSubMeter(DefaultGasLimit)
+	defer func() {
+		err := subMeter.Discard()
+		if err != nil {
+			ctx.Logger.Error().Err(err).Msg("error discarding computation meter used for reading computation weights")
+		}
+	}()
+
+	parentState := sth.State()
+	childState := sth.NewChild()
+	defer func() {
+		sth.SetActiveState(parentState)
+		err := parentState.MergeState(childState, sth.EnforceInteractionLimits())
+		if err != nil {
+			ctx.Logger.Error().Err(err).Msg("failed to merge state of reading computation weights")
+		}
+	}()
+
+	value, err := env.VM().Runtime.ReadStored(
+		runtime.Address(ctx.Chain.ServiceAddress()),
+		cadence.Path{
+			Domain:     blueprints.ComputationWeightsPathDomain,
+			Identifier: blueprints.ComputationWeightsPathIdentifier,
+		},
+		runtime.Context{Interface: env},
+	)
+	if err != nil {
+		value = nil
+	}
+
+	return &programs.ComputationWeightsEntry{
+		Value: value,
+		State: childState,
+	}
+}
@@ -17,6 +17,8 @@ type Context struct {
 	Metrics                       handler.MetricsReporter
 	Tracer                        module.Tracer
 	GasLimit                      uint64
+	TransactionMemoryLimit        uint64
+	ScriptMemoryLimit             uint64
 	MaxStateKeySize               uint64
 	MaxStateValueSize             uint64
 	MaxStateInteractionSize       uint64
@@ -27,6 +29,7 @@ type Context struct {
 	RestrictedDeploymentEnabled   bool
 	LimitAccountStorage           bool
 	TransactionFeesEnabled        bool
+	WeightedComputationMetering   bool
 	CadenceLoggingEnabled         bool
 	EventCollectionEnabled        bool
 	ServiceEventCollectionEnabled bool
@@ -62,6 +65,8 @@ const (
 	DefaultGasLimit                     = 100_000 // 100K
 	DefaultEventCollectionByteSizeLimit = 256_000 // 256KB
 	DefaultMaxNumOfTxRetries            = 3
+	DefaultTransactionMemoryLimit       = 2_000_000_000 // ~2GB
+	DefaultScriptMemoryLimit            = 2_000_000_000 // ~2GB
 )
 
 func defaultContext(logger zerolog.Logger) Context {
@@ -71,6 +76,8 @@ func defaultContext(logger zerolog.Logger) Context {
 		Metrics:                       &handler.NoopMetricsReporter{},
 		Tracer:                        nil,
 		GasLimit:                      DefaultGasLimit,
+		TransactionMemoryLimit:        DefaultTransactionMemoryLimit,
+		ScriptMemoryLimit:             DefaultScriptMemoryLimit,
 		MaxStateKeySize:               state.DefaultMaxKeySize,
 		MaxStateValueSize:             state.DefaultMaxValueSize,
 		MaxStateInteractionSize:       state.DefaultMaxInteractionSize,
@@ -118,6 +125,22 @@ func WithGasLimit(limit uint64) Option {
 	}
 }
 
+// WithTransactionMemoryLimit sets the memory limit of transactions for a virtual machine context.
+func WithTransactionMemoryLimit(limit uint64) Option {
+	return func(ctx Context) Context {
+		ctx.TransactionMemoryLimit = limit
+		return ctx
+	}
+}
+
+// WithScriptMemoryLimit sets the memory limit of scripts for a virtual machine context.
+func WithScriptMemoryLimit(limit uint64) Option {
+	return func(ctx Context) Context {
+		ctx.ScriptMemoryLimit = limit
+		return ctx
+	}
+}
+
 // WithMaxStateKeySize sets the byte size limit for ledger keys
 func WithMaxStateKeySize(limit uint64) Option {
 	return func(ctx Context) Context {
@@ -270,3 +293,12 @@ func WithTransactionFeesEnabled(enabled bool) Option {
 		return ctx
 	}
 }
+
+// WithWeightedComputationMetering enables or disables metering computation with the weights
+// stored in the service account
+func WithWeightedComputationMetering(enabled bool) Option {
+	return func(ctx Context) Context {
+		ctx.WeightedComputationMetering = enabled
+		return ctx
+	}
+}
@@ -1,9 +1,10 @@
-// +build !relic
-
 package crypto
 
-import "github.com/onflow/flow-go/crypto/hash"
+import (
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+)
 
-func NewBLSKMAC(_ string) hash.Hasher {
-	panic("BLSKMAC not supported when flow-go is built without relic")
+func NewBLSKMAC(tag string) hash.Hasher {
+	return crypto.NewBLSKMAC(tag)
 }
@@ -60,6 +60,9 @@ const (
 	ErrCodeStateKeySizeLimitError             ErrorCode = 1107
 	ErrCodeStateValueSizeLimitError           ErrorCode = 1108
 	ErrCodeTransactionFeeDeductionFailedError ErrorCode = 1109
+	ErrCodeComputationLimitExceededError      ErrorCode = 1110
+	ErrCodeMemoryLimitExceededError           ErrorCode = 1111
+	ErrCodeScriptExecutionCancelledError      ErrorCode = 1112
 
 	// accounts errors 1200 - 1250
 	// ErrCodeAccountError              ErrorCode = 1200 - reserved
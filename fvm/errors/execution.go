@@ -246,3 +246,69 @@ func (e *EncodingUnsupportedValueError) Error() string {
 func (e *EncodingUnsupportedValueError) Code() ErrorCode {
 	return ErrCodeEncodingUnsupportedValue
 }
+
+// ComputationLimitExceededError is returned when the weighted computation used by a transaction
+// or script exceeds its computation limit
+type ComputationLimitExceededError struct {
+	used  uint64
+	limit uint64
+}
+
+// NewComputationLimitExceededError constructs a ComputationLimitExceededError
+func NewComputationLimitExceededError(used, limit uint64) *ComputationLimitExceededError {
+	return &ComputationLimitExceededError{used: used, limit: limit}
+}
+
+func (e *ComputationLimitExceededError) Error() string {
+	return fmt.Sprintf("%s computation has exceeded the limit (used: %d, limit %d)", e.Code().String(), e.used, e.limit)
+}
+
+// Code returns the error code for this error
+func (e *ComputationLimitExceededError) Code() ErrorCode {
+	return ErrCodeComputationLimitExceededError
+}
+
+// MemoryLimitExceededError is returned when the memory used by a transaction or script exceeds its memory limit
+type MemoryLimitExceededError struct {
+	used  uint64
+	limit uint64
+}
+
+// NewMemoryLimitExceededError constructs a MemoryLimitExceededError
+func NewMemoryLimitExceededError(used, limit uint64) *MemoryLimitExceededError {
+	return &MemoryLimitExceededError{used: used, limit: limit}
+}
+
+func (e *MemoryLimitExceededError) Error() string {
+	return fmt.Sprintf("%s memory has exceeded the limit (used: %d, limit %d)", e.Code().String(), e.used, e.limit)
+}
+
+// Code returns the error code for this error
+func (e *MemoryLimitExceededError) Code() ErrorCode {
+	return ErrCodeMemoryLimitExceededError
+}
+
+// ScriptExecutionCancelledError is returned when the execution of a script has been cancelled,
+// e.g. because it timed out
+type ScriptExecutionCancelledError struct {
+	err error
+}
+
+// NewScriptExecutionCancelledError constructs a ScriptExecutionCancelledError
+func NewScriptExecutionCancelledError(err error) *ScriptExecutionCancelledError {
+	return &ScriptExecutionCancelledError{err: err}
+}
+
+func (e *ScriptExecutionCancelledError) Error() string {
+	return fmt.Sprintf("%s script execution has been cancelled: %s", e.Code().String(), e.err.Error())
+}
+
+// Code returns the error code for this error
+func (e *ScriptExecutionCancelledError) Code() ErrorCode {
+	return ErrCodeScriptExecutionCancelledError
+}
+
+// Unwrap unwraps the error
+func (e ScriptExecutionCancelledError) Unwrap() error {
+	return e.err
+}
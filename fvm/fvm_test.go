@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/onflow/cadence"
@@ -753,6 +754,336 @@ func TestBlockContext_ExecuteTransaction_GasLimit(t *testing.T) {
 	}
 }
 
+func TestBlockContext_ExecuteTransaction_WeightedComputation(t *testing.T) {
+
+	t.Parallel()
+
+	rt := fvm.NewInterpreterRuntime()
+
+	chain := flow.Mainnet.Chain()
+
+	vm := fvm.NewVirtualMachine(rt)
+
+	ctx := fvm.NewContext(
+		zerolog.Nop(),
+		fvm.WithChain(chain),
+		fvm.WithWeightedComputationMetering(true),
+	)
+
+	hashingScript := `
+		transaction {
+			prepare(signer: AuthAccount) {
+				HashAlgorithm.SHA3_256.hash([1, 2, 3])
+				HashAlgorithm.SHA3_256.hash([4, 5, 6])
+			}
+		}`
+
+	setWeights := func(t *testing.T, ledger state.View, weights map[string]uint64) {
+		txBody, err := blueprints.SetComputationWeightsTransaction(chain.ServiceAddress(), weights)
+		require.NoError(t, err)
+
+		err = testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+		require.NoError(t, err)
+
+		tx := fvm.Transaction(txBody, 0)
+		err = vm.Run(ctx, tx, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, tx.Err)
+	}
+
+	runHashing := func(t *testing.T, ledger state.View, seqNum uint64, gasLimit uint64) *fvm.TransactionProcedure {
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(hashingScript)).
+			SetGasLimit(gasLimit).
+			AddAuthorizer(chain.ServiceAddress())
+
+		err := testutil.SignTransactionAsServiceAccount(txBody, seqNum, chain)
+		require.NoError(t, err)
+
+		tx := fvm.Transaction(txBody, 0)
+		err = vm.Run(ctx, tx, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		return tx
+	}
+
+	t.Run("default weights", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+
+		tx := runHashing(t, ledger, 0, 1000)
+		require.NoError(t, tx.Err)
+
+		assert.Equal(t, map[string]uint64{"cadence": tx.ComputationUsed}, tx.ComputationUsedByKind)
+	})
+
+	t.Run("weights set by the service account", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+		setWeights(t, ledger, map[string]uint64{"hash": 50, "unknown": 10})
+
+		tx := runHashing(t, ledger, 1, 1000)
+		require.NoError(t, tx.Err)
+
+		assert.Equal(t, uint64(100), tx.ComputationUsedByKind["hash"])
+		assert.Equal(t, tx.ComputationUsedByKind["cadence"]+100, tx.ComputationUsed)
+	})
+
+	t.Run("weighted computation exceeding the limit", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+		setWeights(t, ledger, map[string]uint64{"hash": 600})
+
+		tx := runHashing(t, ledger, 1, 1000)
+		require.Error(t, tx.Err)
+		assert.Contains(t, tx.Err.Error(), errors.NewComputationLimitExceededError(1200, 1000).Error())
+	})
+
+	t.Run("weights are cached in the programs until the service account is updated", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+		blockPrograms := programs.NewEmptyPrograms()
+
+		run := func(txBody *flow.TransactionBody, seqNum uint64) *fvm.TransactionProcedure {
+			err := testutil.SignTransactionAsServiceAccount(txBody, seqNum, chain)
+			require.NoError(t, err)
+
+			tx := fvm.Transaction(txBody, 0)
+			err = vm.Run(ctx, tx, ledger, blockPrograms)
+			require.NoError(t, err)
+			require.NoError(t, tx.Err)
+			return tx
+		}
+		hashing := func() *flow.TransactionBody {
+			return flow.NewTransactionBody().
+				SetScript([]byte(hashingScript)).
+				SetGasLimit(1000).
+				AddAuthorizer(chain.ServiceAddress())
+		}
+
+		tx := run(hashing(), 0)
+		assert.Equal(t, map[string]uint64{"cadence": tx.ComputationUsed}, tx.ComputationUsedByKind)
+
+		entry, ok := blockPrograms.GetComputationWeights()
+		require.True(t, ok)
+		assert.Equal(t, cadence.NewOptional(nil), entry.Value)
+
+		// updating the weights updates the service account, which drops the cached weights
+		txBody, err := blueprints.SetComputationWeightsTransaction(chain.ServiceAddress(), map[string]uint64{"hash": 50})
+		require.NoError(t, err)
+		run(txBody, 1)
+
+		_, ok = blockPrograms.GetComputationWeights()
+		require.False(t, ok)
+
+		tx = run(hashing(), 2)
+		assert.Equal(t, uint64(100), tx.ComputationUsedByKind["hash"])
+
+		entry, ok = blockPrograms.GetComputationWeights()
+		require.True(t, ok)
+		assert.NotNil(t, entry.Value)
+	})
+
+	t.Run("fee deduction is recorded separately", newVMTest().
+		withBootstrapProcedureOptions(fvm.WithTransactionFee(fvm.DefaultTransactionFees)).
+		withContextOptions(
+			fvm.WithTransactionFeesEnabled(true),
+			fvm.WithWeightedComputationMetering(true),
+		).
+		run(
+			func(t *testing.T, vm *fvm.VirtualMachine, chain flow.Chain, ctx fvm.Context, view state.View, programs *programs.Programs) {
+				// meter the register accesses of the fee deduction
+				txBody, err := blueprints.SetComputationWeightsTransaction(
+					chain.ServiceAddress(),
+					map[string]uint64{"cadence": 1, "get_value": 1, "set_value": 1},
+				)
+				require.NoError(t, err)
+				err = testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+				require.NoError(t, err)
+
+				tx := fvm.Transaction(txBody, 0)
+				err = vm.Run(ctx, tx, view, programs)
+				require.NoError(t, err)
+				require.NoError(t, tx.Err)
+
+				txBody = flow.NewTransactionBody().
+					SetScript([]byte(hashingScript)).
+					SetGasLimit(1000).
+					AddAuthorizer(chain.ServiceAddress())
+
+				err = testutil.SignTransactionAsServiceAccount(txBody, 1, chain)
+				require.NoError(t, err)
+
+				tx = fvm.Transaction(txBody, 0)
+				err = vm.Run(ctx, tx, view, programs)
+				require.NoError(t, err)
+				require.NoError(t, tx.Err)
+
+				// the fee deduction is recorded, but not charged
+				feeDeduction := tx.ComputationUsedByKind["fee_deduction"]
+				assert.Greater(t, feeDeduction, uint64(0))
+				total := uint64(0)
+				for _, used := range tx.ComputationUsedByKind {
+					total += used
+				}
+				assert.Equal(t, total-feeDeduction, tx.ComputationUsed)
+			}),
+	)
+}
+
+func TestBlockContext_MemoryLimit(t *testing.T) {
+
+	t.Parallel()
+
+	rt := fvm.NewInterpreterRuntime()
+
+	chain := flow.Mainnet.Chain()
+
+	vm := fvm.NewVirtualMachine(rt)
+
+	ctx := fvm.NewContext(
+		zerolog.Nop(),
+		fvm.WithChain(chain),
+	)
+
+	readStorageTx := func(t *testing.T) *flow.TransactionBody {
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`
+				transaction {
+					prepare(signer: AuthAccount) {
+						signer.borrow<&AnyResource>(from: /storage/flowTokenVault)
+					}
+				}`)).
+			AddAuthorizer(chain.ServiceAddress())
+
+		err := testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+		require.NoError(t, err)
+		return txBody
+	}
+
+	t.Run("transaction within the limit", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+
+		tx := fvm.Transaction(readStorageTx(t), 0)
+		err := vm.Run(ctx, tx, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, tx.Err)
+
+		assert.Greater(t, tx.MemoryUsed, uint64(0))
+	})
+
+	t.Run("transaction exceeding the limit", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+		txCtx := fvm.NewContextFromParent(ctx, fvm.WithTransactionMemoryLimit(1))
+
+		tx := fvm.Transaction(readStorageTx(t), 0)
+		err := vm.Run(txCtx, tx, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.Error(t, tx.Err)
+		assert.Contains(t, tx.Err.Error(), errors.ErrCodeMemoryLimitExceededError.String())
+	})
+
+	arg, err := jsoncdc.Encode(cadence.String(strings.Repeat("a", 200)))
+	require.NoError(t, err)
+
+	scriptCode := []byte(`
+		pub fun main(value: String): Int {
+			return value.length
+		}`)
+
+	t.Run("script within the limit", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+
+		script := fvm.Script(scriptCode).WithArguments(arg)
+		err := vm.Run(ctx, script, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, script.Err)
+
+		result, err := jsoncdc.Encode(script.Value)
+		require.NoError(t, err)
+
+		// the source of the script, its argument and its result
+		assert.Equal(t, uint64(len(scriptCode)+len(arg)+len(result)), script.MemoryUsed)
+	})
+
+	t.Run("script exceeding the limit", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+		scriptCtx := fvm.NewContextFromParent(ctx, fvm.WithScriptMemoryLimit(uint64(len(scriptCode)+100)))
+
+		script := fvm.Script(scriptCode).WithArguments(arg)
+		err := vm.Run(scriptCtx, script, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.Error(t, script.Err)
+		assert.Contains(t, script.Err.Error(), errors.ErrCodeMemoryLimitExceededError.String())
+	})
+
+	t.Run("script building an array exceeding the limit", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+		scriptCtx := fvm.NewContextFromParent(ctx, fvm.WithScriptMemoryLimit(10_000))
+
+		script := fvm.Script([]byte(`
+			pub fun main(): [Int] {
+				let values: [Int] = []
+				var i = 0
+				while i < 10000 {
+					values.append(i)
+					i = i + 1
+				}
+				return values
+			}`))
+		err := vm.Run(scriptCtx, script, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.Error(t, script.Err)
+		assert.Contains(t, script.Err.Error(), errors.ErrCodeMemoryLimitExceededError.String())
+	})
+
+	t.Run("script limit doesn't apply to transactions", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+		limitCtx := fvm.NewContextFromParent(ctx, fvm.WithScriptMemoryLimit(uint64(len(scriptCode)+100)))
+
+		// a transaction taking the argument which exceeds the script limit
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`
+				transaction(value: String) {
+					prepare(signer: AuthAccount) {
+						log(value.length)
+					}
+				}`)).
+			AddArgument(arg).
+			AddAuthorizer(chain.ServiceAddress())
+
+		err := testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+		require.NoError(t, err)
+
+		tx := fvm.Transaction(txBody, 0)
+		err = vm.Run(limitCtx, tx, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, tx.Err)
+		assert.Greater(t, tx.MemoryUsed, uint64(len(arg)))
+
+		// and exceeds a transaction limit of the same size
+		ledger = testutil.RootBootstrappedLedger(vm, ctx)
+		limitCtx = fvm.NewContextFromParent(ctx, fvm.WithTransactionMemoryLimit(uint64(len(scriptCode)+100)))
+
+		tx = fvm.Transaction(txBody, 0)
+		err = vm.Run(limitCtx, tx, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.Error(t, tx.Err)
+		assert.Contains(t, tx.Err.Error(), errors.ErrCodeMemoryLimitExceededError.String())
+	})
+
+	t.Run("imported contract code is metered", func(t *testing.T) {
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+
+		script := fvm.Script([]byte(fmt.Sprintf(`
+			import FlowServiceAccount from 0x%s
+
+			pub fun main(): UFix64 {
+				return FlowServiceAccount.transactionFee
+			}`, chain.ServiceAddress())))
+		err := vm.Run(ctx, script, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, script.Err)
+
+		assert.Greater(t, script.MemoryUsed, uint64(len(script.Script)))
+	})
+}
+
 func TestBlockContext_ExecuteTransaction_StorageLimit(t *testing.T) {
 
 	t.Parallel()
@@ -1445,7 +1776,7 @@ func TestBlockContext_UnsafeRandom(t *testing.T) {
 
 		num, err := strconv.ParseUint(tx.Logs[0], 10, 64)
 		require.NoError(t, err)
-		require.Equal(t, uint64(0xb9c618010e32a0fb), num)
+		require.Equal(t, uint64(0x8872445cb397f6d2), num)
 	})
 }
 
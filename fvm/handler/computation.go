@@ -1,18 +1,102 @@
 package handler
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+
+	"github.com/onflow/flow-go/fvm/errors"
+)
+
+// ComputationKind is the kind of operation computation is metered for
+type ComputationKind uint8
+
+const (
+	// ComputationKindCadence is the computation reported by Cadence (statements, loop iterations and function invocations)
+	ComputationKindCadence ComputationKind = iota
+	ComputationKindGetValue
+	ComputationKindSetValue
+	ComputationKindHash
+	ComputationKindVerifySignature
+	ComputationKindEmitEvent
+	ComputationKindCreateAccount
+	// ComputationKindFeeDeduction is the computation used for deducting transaction fees.
+	// It is metered by a sub meter and only recorded, so it is not a weighted kind.
+	ComputationKindFeeDeduction
+)
+
+// ComputationKinds lists all kinds of operations computation is metered for
+var ComputationKinds = []ComputationKind{
+	ComputationKindCadence,
+	ComputationKindGetValue,
+	ComputationKindSetValue,
+	ComputationKindHash,
+	ComputationKindVerifySignature,
+	ComputationKindEmitEvent,
+	ComputationKindCreateAccount,
+}
+
+func (k ComputationKind) String() string {
+	switch k {
+	case ComputationKindCadence:
+		return "cadence"
+	case ComputationKindGetValue:
+		return "get_value"
+	case ComputationKindSetValue:
+		return "set_value"
+	case ComputationKindHash:
+		return "hash"
+	case ComputationKindVerifySignature:
+		return "verify_signature"
+	case ComputationKindEmitEvent:
+		return "emit_event"
+	case ComputationKindCreateAccount:
+		return "create_account"
+	case ComputationKindFeeDeduction:
+		return "fee_deduction"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(k))
+	}
+}
+
+// ComputationKindFromString returns the kind of operation with the given name
+func ComputationKindFromString(name string) (ComputationKind, bool) {
+	for _, kind := range ComputationKinds {
+		if kind.String() == name {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// ComputationWeights are the weights computation of each kind of operation is multiplied with
+// before it is added to the computation used. Kinds without a weight are not metered.
+type ComputationWeights map[ComputationKind]uint64
+
+// DefaultComputationWeights returns the weights used if none are configured. Only the computation
+// reported by Cadence is metered.
+func DefaultComputationWeights() ComputationWeights {
+	return ComputationWeights{
+		ComputationKindCadence: 1,
+	}
+}
 
 // ComputationMeter meters computation usage
 type ComputationMeter interface {
 	// Limit gets computation limit
 	Limit() uint64
-	// AddUsed adds more computation used to the current computation used
+	// AddUsed adds more computation used by Cadence to the current computation used.
+	// Cadence enforces the limit on its own computation, so the limit is not checked here.
 	AddUsed(used uint64) error
+	// MeterComputation adds the weighted intensity of an operation of the given kind to the current
+	// computation used, and returns a ComputationLimitExceededError if the limit is exceeded
+	MeterComputation(kind ComputationKind, intensity uint64) error
 	// Used gets the current computation used
 	Used() uint64
+	// UsedByKind gets the current computation used broken down by the kind of operation
+	UsedByKind() map[ComputationKind]uint64
 }
 
-// SubComputationMeter meters computation usage. Currently, can only be discarded,
+// SubComputationMeter meters computation usage. Currently, can only be discarded or recorded,
 // which can be used to meter fees separately from the transaction invocation.
 // A future expansion is to meter (and charge?) different part of the transaction separately
 type SubComputationMeter interface {
@@ -21,21 +105,26 @@ type SubComputationMeter interface {
 	// This resets the ComputationMeteringHandler to the previous ComputationMeter,
 	// without updating the limit or computation used
 	Discard() error
-	// TODO: this functionality isn't currently needed for anything.
-	// It might be needed in the future, if we need to meter any execution separately and then include it in the base metering.
-	// I left this TODO just as a reminder that this was the idea.
-	// Commit()
+	// CommitAs resets the ComputationMeteringHandler to the previous ComputationMeter like Discard,
+	// but records the computation used by this meter in the computation used by kind of the previous
+	// ComputationMeter under the given kind. The computation used of the previous ComputationMeter
+	// is not updated, so the recorded computation is not charged nor checked against its limit.
+	CommitAs(kind ComputationKind) error
 }
 
 // ComputationMeteringHandler handles computation metering on a transaction level
 type ComputationMeteringHandler interface {
 	ComputationMeter
 	StartSubMeter(limit uint64) SubComputationMeter
+	// SetWeights replaces the weights of all current and future meters
+	SetWeights(weights ComputationWeights)
 }
 
 type computationMeter struct {
-	used  uint64
-	limit uint64
+	used       uint64
+	usedByKind map[ComputationKind]uint64
+	limit      uint64
+	handler    *computationMeteringHandler
 }
 
 func (c *computationMeter) Limit() uint64 {
@@ -43,20 +132,72 @@ func (c *computationMeter) Limit() uint64 {
 }
 
 func (c *computationMeter) AddUsed(used uint64) error {
-	c.used += used
+	c.add(ComputationKindCadence, used)
+	return nil
+}
+
+func (c *computationMeter) MeterComputation(kind ComputationKind, intensity uint64) error {
+	c.add(kind, intensity)
+	if c.used > c.limit {
+		return errors.NewComputationLimitExceededError(c.used, c.limit)
+	}
 	return nil
 }
 
+// add adds the weighted intensity to the computation used, saturating instead of overflowing
+func (c *computationMeter) add(kind ComputationKind, intensity uint64) {
+	weight, ok := c.handler.weights[kind]
+	if !ok || weight == 0 || intensity == 0 {
+		return
+	}
+
+	weighted := uint64(math.MaxUint64)
+	if intensity <= math.MaxUint64/weight {
+		weighted = intensity * weight
+	}
+
+	if c.usedByKind == nil {
+		c.usedByKind = make(map[ComputationKind]uint64)
+	}
+	c.usedByKind[kind] = saturatingAdd(c.usedByKind[kind], weighted)
+	c.used = saturatingAdd(c.used, weighted)
+}
+
 func (c *computationMeter) Used() uint64 {
 	return c.used
 }
 
+func (c *computationMeter) UsedByKind() map[ComputationKind]uint64 {
+	usedByKind := make(map[ComputationKind]uint64, len(c.usedByKind))
+	for kind, used := range c.usedByKind {
+		usedByKind[kind] = used
+	}
+	return usedByKind
+}
+
+// record records computation used under the given kind, without adding it to the computation used
+func (c *computationMeter) record(kind ComputationKind, used uint64) {
+	if used == 0 {
+		return
+	}
+	if c.usedByKind == nil {
+		c.usedByKind = make(map[ComputationKind]uint64)
+	}
+	c.usedByKind[kind] = saturatingAdd(c.usedByKind[kind], used)
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
 var _ ComputationMeter = &computationMeter{}
 
 type subComputationMeter struct {
 	computationMeter
-	parent  ComputationMeter
-	handler *computationMeteringHandler
+	parent ComputationMeter
 }
 
 func (s *subComputationMeter) Discard() error {
@@ -67,33 +208,57 @@ func (s *subComputationMeter) Discard() error {
 	return nil
 }
 
+func (s *subComputationMeter) CommitAs(kind ComputationKind) error {
+	err := s.Discard()
+	if err != nil {
+		return err
+	}
+	parent, ok := s.parent.(interface {
+		record(kind ComputationKind, used uint64)
+	})
+	if !ok {
+		return fmt.Errorf("cannot record computation of SubComputationMeter in %T", s.parent)
+	}
+	parent.record(kind, s.used)
+	return nil
+}
+
 var _ SubComputationMeter = &subComputationMeter{}
 
 type computationMeteringHandler struct {
 	computation ComputationMeter
+	weights     ComputationWeights
 }
 
 func (c *computationMeteringHandler) StartSubMeter(limit uint64) SubComputationMeter {
 	m := &subComputationMeter{
 		computationMeter: computationMeter{
-			limit: limit,
+			limit:   limit,
+			handler: c,
 		},
-		parent:  c.computation,
-		handler: c,
+		parent: c.computation,
 	}
 
 	c.computation = m
 	return m
 }
 
+func (c *computationMeteringHandler) SetWeights(weights ComputationWeights) {
+	c.weights = weights
+}
+
 var _ ComputationMeteringHandler = &computationMeteringHandler{}
 
+// NewComputationMeteringHandler creates a new ComputationMeteringHandler using the default computation weights
 func NewComputationMeteringHandler(computationLimit uint64) ComputationMeteringHandler {
-	return &computationMeteringHandler{
-		computation: &computationMeter{
-			limit: computationLimit,
-		},
+	h := &computationMeteringHandler{
+		weights: DefaultComputationWeights(),
+	}
+	h.computation = &computationMeter{
+		limit:   computationLimit,
+		handler: h,
 	}
+	return h
 }
 
 func (c *computationMeteringHandler) Limit() uint64 {
@@ -104,6 +269,14 @@ func (c *computationMeteringHandler) AddUsed(used uint64) error {
 	return c.computation.AddUsed(used)
 }
 
+func (c *computationMeteringHandler) MeterComputation(kind ComputationKind, intensity uint64) error {
+	return c.computation.MeterComputation(kind, intensity)
+}
+
 func (c *computationMeteringHandler) Used() uint64 {
 	return c.computation.Used()
 }
+
+func (c *computationMeteringHandler) UsedByKind() map[ComputationKind]uint64 {
+	return c.computation.UsedByKind()
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/errors"
 )
 
 func TestComputationMeteringHandler(t *testing.T) {
@@ -111,4 +114,127 @@ func TestComputationMeteringHandler(t *testing.T) {
 		err := subMeter.Discard()
 		require.Error(t, err)
 	})
+
+	t.Run("Default weights only meter Cadence", func(t *testing.T) {
+		h := NewComputationMeteringHandler(limit)
+
+		err := h.AddUsed(used)
+		require.NoError(t, err)
+		err = h.MeterComputation(ComputationKindGetValue, 1000)
+		require.NoError(t, err)
+
+		require.Equal(t, used, h.Used())
+		require.Equal(t, map[ComputationKind]uint64{ComputationKindCadence: used}, h.UsedByKind())
+	})
+
+	t.Run("Weighted computation", func(t *testing.T) {
+		h := NewComputationMeteringHandler(limit)
+		h.SetWeights(ComputationWeights{
+			ComputationKindCadence:  1,
+			ComputationKindGetValue: 2,
+			ComputationKindHash:     5,
+		})
+
+		err := h.AddUsed(used)
+		require.NoError(t, err)
+		err = h.MeterComputation(ComputationKindGetValue, 3)
+		require.NoError(t, err)
+		err = h.MeterComputation(ComputationKindHash, 1)
+		require.NoError(t, err)
+		err = h.MeterComputation(ComputationKindSetValue, 1)
+		require.NoError(t, err)
+
+		require.Equal(t, used+6+5, h.Used())
+		require.Equal(t, map[ComputationKind]uint64{
+			ComputationKindCadence:  used,
+			ComputationKindGetValue: 6,
+			ComputationKindHash:     5,
+		}, h.UsedByKind())
+	})
+
+	t.Run("Weighted computation exceeding the limit", func(t *testing.T) {
+		h := NewComputationMeteringHandler(limit)
+		h.SetWeights(ComputationWeights{ComputationKindVerifySignature: 60})
+
+		err := h.MeterComputation(ComputationKindVerifySignature, 1)
+		require.NoError(t, err)
+
+		err = h.MeterComputation(ComputationKindVerifySignature, 1)
+		require.Error(t, err)
+		var limitErr *errors.ComputationLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+	})
+
+	t.Run("Weighted computation saturates instead of overflowing", func(t *testing.T) {
+		h := NewComputationMeteringHandler(math.MaxUint64)
+		h.SetWeights(ComputationWeights{ComputationKindEmitEvent: math.MaxUint64 / 2})
+
+		err := h.MeterComputation(ComputationKindEmitEvent, 3)
+		require.NoError(t, err)
+		err = h.MeterComputation(ComputationKindEmitEvent, 1)
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(math.MaxUint64), h.Used())
+	})
+
+	t.Run("Sub Meter meters with the same weights separately", func(t *testing.T) {
+		h := NewComputationMeteringHandler(limit)
+		h.SetWeights(ComputationWeights{ComputationKindSetValue: 10})
+
+		err := h.MeterComputation(ComputationKindSetValue, 1)
+		require.NoError(t, err)
+
+		subMeter := h.StartSubMeter(2 * limit)
+		err = h.MeterComputation(ComputationKindSetValue, 2)
+		require.NoError(t, err)
+		require.Equal(t, uint64(20), subMeter.Used())
+		require.Equal(t, map[ComputationKind]uint64{ComputationKindSetValue: 20}, subMeter.UsedByKind())
+
+		err = subMeter.Discard()
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(10), h.Used())
+		require.Equal(t, map[ComputationKind]uint64{ComputationKindSetValue: 10}, h.UsedByKind())
+	})
+
+	t.Run("Sub Meter committed as a kind", func(t *testing.T) {
+		h := NewComputationMeteringHandler(limit)
+
+		err := h.AddUsed(used)
+		require.NoError(t, err)
+
+		subMeter := h.StartSubMeter(2 * limit)
+		err = h.AddUsed(3 * used)
+		require.NoError(t, err)
+
+		subSubMeter := h.StartSubMeter(2 * limit)
+		err = h.AddUsed(used)
+		require.NoError(t, err)
+
+		err = subMeter.CommitAs(ComputationKindFeeDeduction)
+		require.Error(t, err)
+
+		err = subSubMeter.CommitAs(ComputationKindFeeDeduction)
+		require.NoError(t, err)
+		err = subMeter.CommitAs(ComputationKindFeeDeduction)
+		require.NoError(t, err)
+
+		require.Equal(t, limit, h.Limit())
+		require.Equal(t, used, h.Used())
+		require.Equal(t, map[ComputationKind]uint64{
+			ComputationKindCadence:      used,
+			ComputationKindFeeDeduction: 3 * used,
+		}, h.UsedByKind())
+	})
+}
+
+func TestComputationKind(t *testing.T) {
+	for _, kind := range ComputationKinds {
+		parsed, ok := ComputationKindFromString(kind.String())
+		require.True(t, ok)
+		require.Equal(t, kind, parsed)
+	}
+
+	_, ok := ComputationKindFromString("unknown")
+	require.False(t, ok)
 }
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"math"
+
+	"github.com/onflow/flow-go/fvm/errors"
+)
+
+// MemoryMeteringHandler meters memory usage.
+//
+// The memory used is estimated from the sizes of the data exchanged with Cadence: the source of
+// the procedure and of the imported contracts, arguments, register values, logs and the results
+// of scripts.
+//
+// TODO: also meter the memory allocated by the interpreter, once Cadence reports its memory usage.
+// The version of Cadence in use doesn't provide a memory gauge yet.
+type MemoryMeteringHandler interface {
+	// Limit gets memory limit
+	Limit() uint64
+	// MeterMemory adds more memory used to the current memory used,
+	// and returns a MemoryLimitExceededError if the limit is exceeded
+	MeterMemory(used uint64) error
+	// Used gets the current memory used
+	Used() uint64
+}
+
+type memoryMeteringHandler struct {
+	used  uint64
+	limit uint64
+}
+
+var _ MemoryMeteringHandler = &memoryMeteringHandler{}
+
+// NewMemoryMeteringHandler creates a new MemoryMeteringHandler
+func NewMemoryMeteringHandler(memoryLimit uint64) MemoryMeteringHandler {
+	return &memoryMeteringHandler{
+		limit: memoryLimit,
+	}
+}
+
+func (m *memoryMeteringHandler) Limit() uint64 {
+	return m.limit
+}
+
+func (m *memoryMeteringHandler) MeterMemory(used uint64) error {
+	if m.used > math.MaxUint64-used {
+		m.used = math.MaxUint64
+	} else {
+		m.used += used
+	}
+
+	if m.used > m.limit {
+		return errors.NewMemoryLimitExceededError(m.used, m.limit)
+	}
+	return nil
+}
+
+func (m *memoryMeteringHandler) Used() uint64 {
+	return m.used
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/errors"
+)
+
+func TestMemoryMeteringHandler(t *testing.T) {
+	const limit = uint64(100)
+
+	t.Run("Get Limit", func(t *testing.T) {
+		h := NewMemoryMeteringHandler(limit)
+
+		require.Equal(t, limit, h.Limit())
+	})
+
+	t.Run("Meter Memory", func(t *testing.T) {
+		h := NewMemoryMeteringHandler(limit)
+
+		err := h.MeterMemory(40)
+		require.NoError(t, err)
+		err = h.MeterMemory(60)
+		require.NoError(t, err)
+
+		require.Equal(t, limit, h.Used())
+	})
+
+	t.Run("Meter Memory exceeding the limit", func(t *testing.T) {
+		h := NewMemoryMeteringHandler(limit)
+
+		err := h.MeterMemory(limit + 1)
+		require.Error(t, err)
+		var limitErr *errors.MemoryLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+	})
+
+	t.Run("Meter Memory saturates instead of overflowing", func(t *testing.T) {
+		h := NewMemoryMeteringHandler(math.MaxUint64)
+
+		err := h.MeterMemory(math.MaxUint64 - 1)
+		require.NoError(t, err)
+		err = h.MeterMemory(2)
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(math.MaxUint64), h.Used())
+	})
+}
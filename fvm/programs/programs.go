@@ -3,6 +3,7 @@ package programs
 import (
 	"sync"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 
@@ -33,22 +34,41 @@ func emptyProgramGetFunc(_ common.Location) (*ProgramEntry, bool) {
 	return nil, false
 }
 
+// ComputationWeightsEntry is the value of the computation weights stored in the service account,
+// nil if they could not be read, and the state containing the register reads of reading them.
+type ComputationWeightsEntry struct {
+	Value cadence.Value
+	State *state.State
+}
+
+type ComputationWeightsGetFunc func() (*ComputationWeightsEntry, bool)
+
+func emptyComputationWeightsGetFunc() (*ComputationWeightsEntry, bool) {
+	return nil, false
+}
+
 // Programs is a cumulative cache-like storage for Programs helping speed up execution of Cadence
 // Programs don't evict elements at will, like a typical cache would, but it does it only
 // during a cleanup method, which must be called only when the Cadence execution has finished.
 // It it also fork-aware, support cheap creation of children capturing local changes.
+//
+// Besides programs, the computation weights read from the service account are cached,
+// so they are read once and not by every procedure.
 type Programs struct {
-	lock       sync.RWMutex
-	programs   map[common.LocationID]ProgramEntry
-	parentFunc ProgramGetFunc
-	cleaned    bool
+	lock                         sync.RWMutex
+	programs                     map[common.LocationID]ProgramEntry
+	parentFunc                   ProgramGetFunc
+	cleaned                      bool
+	computationWeights           *ComputationWeightsEntry
+	parentComputationWeightsFunc ComputationWeightsGetFunc
 }
 
 func NewEmptyPrograms() *Programs {
 
 	return &Programs{
-		programs:   map[common.LocationID]ProgramEntry{},
-		parentFunc: emptyProgramGetFunc,
+		programs:                     map[common.LocationID]ProgramEntry{},
+		parentFunc:                   emptyProgramGetFunc,
+		parentComputationWeightsFunc: emptyComputationWeightsGetFunc,
 	}
 }
 
@@ -58,7 +78,46 @@ func (p *Programs) ChildPrograms() *Programs {
 		parentFunc: func(location common.Location) (*ProgramEntry, bool) {
 			return p.get(location)
 		},
+		parentComputationWeightsFunc: p.getComputationWeights,
+	}
+}
+
+// GetComputationWeights returns the cached computation weights, and boolean indicating if they were found
+func (p *Programs) GetComputationWeights() (*ComputationWeightsEntry, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.getComputationWeights()
+}
+
+func (p *Programs) getComputationWeights() (*ComputationWeightsEntry, bool) {
+	if p.computationWeights != nil {
+		return p.computationWeights, true
 	}
+	return p.parentComputationWeightsFunc()
+}
+
+// SetComputationWeights caches the computation weights
+func (p *Programs) SetComputationWeights(entry *ComputationWeightsEntry) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.computationWeights = entry
+}
+
+// InvalidateComputationWeights removes the cached computation weights, including the ones
+// cached by the parent. It must be called when the service account storing them was updated.
+func (p *Programs) InvalidateComputationWeights() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.invalidateComputationWeights()
+}
+
+func (p *Programs) invalidateComputationWeights() {
+	p.cleaned = true
+	p.computationWeights = nil
+	p.parentComputationWeightsFunc = emptyComputationWeightsGetFunc
 }
 
 // Get returns stored program, state which contains changes which correspond to loading this program,
@@ -103,7 +162,7 @@ func (p *Programs) Set(location common.Location, program *interpreter.Program, s
 // HasChanges indicates if any changes has been introduced
 // essentially telling if this object is identical to its parent
 func (p *Programs) HasChanges() bool {
-	return len(p.programs) > 0 || p.cleaned
+	return len(p.programs) > 0 || p.computationWeights != nil || p.cleaned
 }
 
 // ForceCleanup is used to force a complete cleanup
@@ -122,6 +181,8 @@ func (p *Programs) ForceCleanup() {
 
 	// start with empty storage
 	p.programs = make(map[common.LocationID]ProgramEntry)
+
+	p.invalidateComputationWeights()
 }
 
 func (p *Programs) Cleanup(changedContracts []ContractUpdateKey) {
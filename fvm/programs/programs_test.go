@@ -3,6 +3,7 @@ package programs
 import (
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
@@ -169,4 +170,35 @@ func Test_Programs(t *testing.T) {
 		require.True(t, child.HasChanges())
 	})
 
+	t.Run("computation weights", func(t *testing.T) {
+		parent := NewEmptyPrograms()
+
+		_, has := parent.GetComputationWeights()
+		require.False(t, has)
+
+		entry := &ComputationWeightsEntry{
+			Value: cadence.NewOptional(nil),
+			State: newState,
+		}
+		parent.SetComputationWeights(entry)
+		require.True(t, parent.HasChanges())
+
+		// weights are inherited by children
+		child := parent.ChildPrograms()
+		retrieved, has := child.GetComputationWeights()
+		require.True(t, has)
+		require.Equal(t, entry, retrieved)
+		require.False(t, child.HasChanges())
+
+		// invalidating the weights of the child doesn't affect the parent
+		child.InvalidateComputationWeights()
+		_, has = child.GetComputationWeights()
+		require.False(t, has)
+		require.True(t, child.HasChanges())
+
+		retrieved, has = parent.GetComputationWeights()
+		require.True(t, has)
+		require.Equal(t, entry, retrieved)
+	})
+
 }
@@ -1,9 +1,11 @@
 package fvm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime"
 	"github.com/onflow/cadence/runtime/common"
 
@@ -18,8 +20,9 @@ func Script(code []byte) *ScriptProcedure {
 	scriptHash := hash.DefaultHasher.ComputeHash(code)
 
 	return &ScriptProcedure{
-		Script: code,
-		ID:     flow.HashToID(scriptHash),
+		Script:         code,
+		ID:             flow.HashToID(scriptHash),
+		RequestContext: context.Background(),
 	}
 }
 
@@ -27,11 +30,14 @@ type ScriptProcedure struct {
 	ID        flow.Identifier
 	Script    []byte
 	Arguments [][]byte
-	Value     cadence.Value
-	Logs      []string
-	Events    []flow.Event
-	GasUsed   uint64
-	Err       errors.Error
+	// RequestContext interrupts the execution of the script once it is done
+	RequestContext context.Context
+	Value          cadence.Value
+	Logs           []string
+	Events         []flow.Event
+	GasUsed        uint64
+	MemoryUsed     uint64
+	Err            errors.Error
 }
 
 type ScriptProcessor interface {
@@ -40,9 +46,23 @@ type ScriptProcessor interface {
 
 func (proc *ScriptProcedure) WithArguments(args ...[]byte) *ScriptProcedure {
 	return &ScriptProcedure{
-		ID:        proc.ID,
-		Script:    proc.Script,
-		Arguments: args,
+		ID:             proc.ID,
+		Script:         proc.Script,
+		Arguments:      args,
+		RequestContext: proc.RequestContext,
+	}
+}
+
+// WithRequestContext returns a copy of the script which is interrupted once the given context is done.
+//
+// Cadence cannot be interrupted directly, so the context is checked whenever the script calls
+// into the environment (e.g. to read storage). Computation between such calls is bounded by the gas limit.
+func (proc *ScriptProcedure) WithRequestContext(ctx context.Context) *ScriptProcedure {
+	return &ScriptProcedure{
+		ID:             proc.ID,
+		Script:         proc.Script,
+		Arguments:      proc.Arguments,
+		RequestContext: ctx,
 	}
 }
 
@@ -78,8 +98,15 @@ func (i ScriptInvoker) Process(
 	sth *state.StateHolder,
 	programs *programs.Programs,
 ) error {
-	env := NewScriptEnvironment(ctx, vm, sth, programs)
+	env := NewScriptEnvironment(proc.RequestContext, ctx, vm, sth, programs)
 	location := common.ScriptLocation(proc.ID[:])
+
+	// the script is parsed and checked by Cadence
+	err := env.memoryHandler.MeterMemory(uint64(len(proc.Script)))
+	if err != nil {
+		return err
+	}
+
 	value, err := vm.Runtime.ExecuteScript(
 		runtime.Script{
 			Source:    proc.Script,
@@ -95,9 +122,20 @@ func (i ScriptInvoker) Process(
 		return errors.HandleRuntimeError(err)
 	}
 
+	// the allocations of the interpreter are not metered, but the values it built are once they are
+	// returned, e.g. a large array. Values which can't be encoded are reported by the caller.
+	encoded, err := jsoncdc.Encode(value)
+	if err == nil {
+		err = env.memoryHandler.MeterMemory(uint64(len(encoded)))
+		if err != nil {
+			return err
+		}
+	}
+
 	proc.Value = value
 	proc.Logs = env.Logs()
 	proc.Events = env.Events()
 	proc.GasUsed = env.GetComputationUsed()
+	proc.MemoryUsed = env.GetMemoryUsed()
 	return nil
 }
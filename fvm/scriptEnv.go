@@ -1,6 +1,7 @@
 package fvm
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -33,6 +34,7 @@ var _ Environment = &ScriptEnv{}
 
 // ScriptEnv is a read-only mostly used for executing scripts.
 type ScriptEnv struct {
+	reqContext         context.Context
 	ctx                Context
 	sth                *state.StateHolder
 	vm                 *VirtualMachine
@@ -42,6 +44,7 @@ type ScriptEnv struct {
 	accountKeys        *handler.AccountKeyHandler
 	metrics            *handler.MetricsHandler
 	computationHandler handler.ComputationMeteringHandler
+	memoryHandler      handler.MemoryMeteringHandler
 	uuidGenerator      *state.UUIDGenerator
 	logs               []string
 	rng                *rand.Rand
@@ -57,6 +60,7 @@ func (e *ScriptEnv) VM() *VirtualMachine {
 }
 
 func NewScriptEnvironment(
+	reqContext context.Context,
 	ctx Context,
 	vm *VirtualMachine,
 	sth *state.StateHolder,
@@ -69,8 +73,14 @@ func NewScriptEnvironment(
 	accountKeys := handler.NewAccountKeyHandler(accounts)
 	metrics := handler.NewMetricsHandler(ctx.Metrics)
 	computationHandler := handler.NewComputationMeteringHandler(ctx.GasLimit)
+	memoryHandler := handler.NewMemoryMeteringHandler(ctx.ScriptMemoryLimit)
+
+	if reqContext == nil {
+		reqContext = context.Background()
+	}
 
 	env := &ScriptEnv{
+		reqContext:         reqContext,
 		ctx:                ctx,
 		sth:                sth,
 		vm:                 vm,
@@ -80,6 +90,7 @@ func NewScriptEnvironment(
 		uuidGenerator:      uuidGenerator,
 		programs:           programsHandler,
 		computationHandler: computationHandler,
+		memoryHandler:      memoryHandler,
 	}
 
 	env.contracts = handler.NewContractHandler(
@@ -91,6 +102,10 @@ func NewScriptEnvironment(
 		env.seedRNG(ctx.BlockHeader)
 	}
 
+	if ctx.WeightedComputationMetering {
+		loadComputationWeights(env, sth, programs, computationHandler)
+	}
+
 	return env
 }
 
@@ -102,6 +117,16 @@ func (e *ScriptEnv) seedRNG(header *flow.Header) {
 	e.rng = rand.New(source)
 }
 
+// checkInterrupted returns a ScriptExecutionCancelledError if the request context of the script is done.
+// It is called whenever the script calls into the environment, as Cadence cannot be interrupted directly.
+func (e *ScriptEnv) checkInterrupted() error {
+	err := e.reqContext.Err()
+	if err != nil {
+		return errors.NewScriptExecutionCancelledError(err)
+	}
+	return nil
+}
+
 func (e *ScriptEnv) isTraceable() bool {
 	return e.ctx.Tracer != nil && e.traceSpan != nil
 }
@@ -120,6 +145,16 @@ func (e *ScriptEnv) GetValue(owner, key []byte) ([]byte, error) {
 		}()
 	}
 
+	err := e.checkInterrupted()
+	if err != nil {
+		return nil, fmt.Errorf("getting value failed: %w", err)
+	}
+
+	err = e.computationHandler.MeterComputation(handler.ComputationKindGetValue, 1)
+	if err != nil {
+		return nil, fmt.Errorf("getting value failed: %w", err)
+	}
+
 	v, err := e.accounts.GetValue(
 		flow.BytesToAddress(owner),
 		string(key),
@@ -128,6 +163,12 @@ func (e *ScriptEnv) GetValue(owner, key []byte) ([]byte, error) {
 		return nil, fmt.Errorf("getting value failed: %w", err)
 	}
 	valueByteSize = len(v)
+
+	// the value is decoded by Cadence after it has been read
+	err = e.memoryHandler.MeterMemory(uint64(len(v)))
+	if err != nil {
+		return nil, fmt.Errorf("getting value failed: %w", err)
+	}
 	return v, nil
 }
 
@@ -142,7 +183,22 @@ func (e *ScriptEnv) SetValue(owner, key, value []byte) error {
 		defer sp.Finish()
 	}
 
-	err := e.accounts.SetValue(
+	err := e.checkInterrupted()
+	if err != nil {
+		return fmt.Errorf("setting value failed: %w", err)
+	}
+
+	err = e.computationHandler.MeterComputation(handler.ComputationKindSetValue, 1)
+	if err != nil {
+		return fmt.Errorf("setting value failed: %w", err)
+	}
+
+	err = e.memoryHandler.MeterMemory(uint64(len(value)))
+	if err != nil {
+		return fmt.Errorf("setting value failed: %w", err)
+	}
+
+	err = e.accounts.SetValue(
 		flow.BytesToAddress(owner),
 		string(key),
 		value,
@@ -350,6 +406,12 @@ func (e *ScriptEnv) GetCode(location runtime.Location) ([]byte, error) {
 		return nil, fmt.Errorf("get code failed: %w", err)
 	}
 
+	// the code is parsed and checked by Cadence
+	err = e.memoryHandler.MeterMemory(uint64(len(add)))
+	if err != nil {
+		return nil, fmt.Errorf("get code failed: %w", err)
+	}
+
 	return add, nil
 }
 
@@ -359,6 +421,11 @@ func (e *ScriptEnv) GetProgram(location common.Location) (*interpreter.Program,
 		defer sp.Finish()
 	}
 
+	err := e.checkInterrupted()
+	if err != nil {
+		return nil, fmt.Errorf("get program failed: %w", err)
+	}
+
 	if addressLocation, ok := location.(common.AddressLocation); ok {
 		address := flow.Address(addressLocation.Address)
 
@@ -395,6 +462,16 @@ func (e *ScriptEnv) ProgramLog(message string) error {
 		defer sp.Finish()
 	}
 
+	err := e.checkInterrupted()
+	if err != nil {
+		return fmt.Errorf("logging failed: %w", err)
+	}
+
+	err = e.memoryHandler.MeterMemory(uint64(len(message)))
+	if err != nil {
+		return fmt.Errorf("logging failed: %w", err)
+	}
+
 	if e.ctx.CadenceLoggingEnabled {
 		e.logs = append(e.logs, message)
 	}
@@ -434,7 +511,13 @@ func (e *ScriptEnv) GetComputationLimit() uint64 {
 	return e.computationHandler.Limit()
 }
 
+// SetComputationUsed is called by Cadence when the script finished or exceeded the computation limit,
+// a script which ran past its deadline is reported as cancelled even if it never called into the environment.
 func (e *ScriptEnv) SetComputationUsed(used uint64) error {
+	err := e.checkInterrupted()
+	if err != nil {
+		return err
+	}
 	return e.computationHandler.AddUsed(used)
 }
 
@@ -442,12 +525,31 @@ func (e *ScriptEnv) GetComputationUsed() uint64 {
 	return e.computationHandler.Used()
 }
 
+func (e *ScriptEnv) GetMemoryUsed() uint64 {
+	return e.memoryHandler.Used()
+}
+
+// GetComputationUsedByKind returns the computation used broken down by the name of the kind of operation
+func (e *ScriptEnv) GetComputationUsedByKind() map[string]uint64 {
+	usedByKind := e.computationHandler.UsedByKind()
+	byName := make(map[string]uint64, len(usedByKind))
+	for kind, used := range usedByKind {
+		byName[kind.String()] = used
+	}
+	return byName
+}
+
 func (e *ScriptEnv) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, error) {
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvDecodeArgument)
 		defer sp.Finish()
 	}
 
+	err := e.memoryHandler.MeterMemory(uint64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("decodeing argument failed: %w", err)
+	}
+
 	v, err := jsoncdc.Decode(b)
 	if err != nil {
 		err = errors.NewInvalidArgumentErrorf("argument is not json decodable: %w", err)
@@ -463,6 +565,16 @@ func (e *ScriptEnv) Hash(data []byte, tag string, hashAlgorithm runtime.HashAlgo
 		defer sp.Finish()
 	}
 
+	err := e.checkInterrupted()
+	if err != nil {
+		return nil, fmt.Errorf("hashing failed: %w", err)
+	}
+
+	err = e.computationHandler.MeterComputation(handler.ComputationKindHash, 1)
+	if err != nil {
+		return nil, fmt.Errorf("hashing failed: %w", err)
+	}
+
 	hashAlgo := crypto.RuntimeToCryptoHashingAlgorithm(hashAlgorithm)
 	return crypto.HashWithTag(hashAlgo, tag, data)
 }
@@ -480,6 +592,16 @@ func (e *ScriptEnv) VerifySignature(
 		defer sp.Finish()
 	}
 
+	err := e.checkInterrupted()
+	if err != nil {
+		return false, fmt.Errorf("verifying signature failed: %w", err)
+	}
+
+	err = e.computationHandler.MeterComputation(handler.ComputationKindVerifySignature, 1)
+	if err != nil {
+		return false, fmt.Errorf("verifying signature failed: %w", err)
+	}
+
 	valid, err := crypto.VerifySignatureFromRuntime(
 		e.ctx.SignatureVerifier,
 		signature,
@@ -33,9 +33,12 @@ type TransactionProcedure struct {
 	Events          []flow.Event
 	ServiceEvents   []flow.Event
 	ComputationUsed uint64
-	Err             errors.Error
-	Retried         int
-	TraceSpan       opentracing.Span
+	// ComputationUsedByKind is the computation used broken down by the name of the kind of operation
+	ComputationUsedByKind map[string]uint64
+	MemoryUsed            uint64
+	Err                   errors.Error
+	Retried               int
+	TraceSpan             opentracing.Span
 }
 
 func (proc *TransactionProcedure) SetTraceSpan(traceSpan opentracing.Span) {
@@ -43,6 +43,7 @@ type TransactionEnv struct {
 	accountKeys        *handler.AccountKeyHandler
 	metrics            *handler.MetricsHandler
 	computationHandler handler.ComputationMeteringHandler
+	memoryHandler      handler.MemoryMeteringHandler
 	eventHandler       *handler.EventHandler
 	addressGenerator   flow.AddressGenerator
 	rng                *rand.Rand
@@ -77,6 +78,7 @@ func NewTransactionEnvironment(
 	accountKeys := handler.NewAccountKeyHandler(accounts)
 	metrics := handler.NewMetricsHandler(ctx.Metrics)
 	computationHandler := handler.NewComputationMeteringHandler(computationLimit(ctx, tx))
+	memoryHandler := handler.NewMemoryMeteringHandler(ctx.TransactionMemoryLimit)
 
 	env := &TransactionEnv{
 		vm:                 vm,
@@ -90,6 +92,7 @@ func NewTransactionEnvironment(
 		uuidGenerator:      uuidGenerator,
 		eventHandler:       eventHandler,
 		computationHandler: computationHandler,
+		memoryHandler:      memoryHandler,
 		tx:                 tx,
 		txIndex:            txIndex,
 		txID:               tx.ID(),
@@ -105,6 +108,10 @@ func NewTransactionEnvironment(
 		env.seedRNG(ctx.BlockHeader)
 	}
 
+	if ctx.WeightedComputationMetering {
+		loadComputationWeights(env, sth, programs, computationHandler)
+	}
+
 	return env
 }
 
@@ -206,6 +213,11 @@ func (e *TransactionEnv) GetValue(owner, key []byte) ([]byte, error) {
 		}()
 	}
 
+	err := e.computationHandler.MeterComputation(handler.ComputationKindGetValue, 1)
+	if err != nil {
+		return nil, fmt.Errorf("getting value failed: %w", err)
+	}
+
 	v, err := e.accounts.GetValue(
 		flow.BytesToAddress(owner),
 		string(key),
@@ -214,6 +226,12 @@ func (e *TransactionEnv) GetValue(owner, key []byte) ([]byte, error) {
 		return nil, fmt.Errorf("getting value failed: %w", err)
 	}
 	valueByteSize = len(v)
+
+	// the value is decoded by Cadence after it has been read
+	err = e.memoryHandler.MeterMemory(uint64(len(v)))
+	if err != nil {
+		return nil, fmt.Errorf("getting value failed: %w", err)
+	}
 	return v, nil
 }
 
@@ -227,7 +245,17 @@ func (e *TransactionEnv) SetValue(owner, key, value []byte) error {
 		defer sp.Finish()
 	}
 
-	err := e.accounts.SetValue(
+	err := e.computationHandler.MeterComputation(handler.ComputationKindSetValue, 1)
+	if err != nil {
+		return fmt.Errorf("setting value failed: %w", err)
+	}
+
+	err = e.memoryHandler.MeterMemory(uint64(len(value)))
+	if err != nil {
+		return fmt.Errorf("setting value failed: %w", err)
+	}
+
+	err = e.accounts.SetValue(
 		flow.BytesToAddress(owner),
 		string(key),
 		value,
@@ -429,6 +457,12 @@ func (e *TransactionEnv) GetCode(location runtime.Location) ([]byte, error) {
 		return nil, fmt.Errorf("get code failed: %w", err)
 	}
 
+	// the code is parsed and checked by Cadence
+	err = e.memoryHandler.MeterMemory(uint64(len(add)))
+	if err != nil {
+		return nil, fmt.Errorf("get code failed: %w", err)
+	}
+
 	return add, nil
 }
 
@@ -490,6 +524,11 @@ func (e *TransactionEnv) ProgramLog(message string) error {
 		defer sp.Finish()
 	}
 
+	err := e.memoryHandler.MeterMemory(uint64(len(message)))
+	if err != nil {
+		return fmt.Errorf("logging failed: %w", err)
+	}
+
 	if e.ctx.CadenceLoggingEnabled {
 		e.logs = append(e.logs, message)
 	}
@@ -507,6 +546,11 @@ func (e *TransactionEnv) EmitEvent(event cadence.Event) error {
 		defer sp.Finish()
 	}
 
+	err := e.computationHandler.MeterComputation(handler.ComputationKindEmitEvent, 1)
+	if err != nil {
+		return fmt.Errorf("emitting event failed: %w", err)
+	}
+
 	return e.eventHandler.EmitEvent(event, e.txID, e.txIndex, e.tx.Payer)
 }
 
@@ -547,6 +591,20 @@ func (e *TransactionEnv) GetComputationUsed() uint64 {
 	return e.computationHandler.Used()
 }
 
+func (e *TransactionEnv) GetMemoryUsed() uint64 {
+	return e.memoryHandler.Used()
+}
+
+// GetComputationUsedByKind returns the computation used broken down by the name of the kind of operation
+func (e *TransactionEnv) GetComputationUsedByKind() map[string]uint64 {
+	usedByKind := e.computationHandler.UsedByKind()
+	byName := make(map[string]uint64, len(usedByKind))
+	for kind, used := range usedByKind {
+		byName[kind.String()] = used
+	}
+	return byName
+}
+
 func (e *TransactionEnv) SetAccountFrozen(address common.Address, frozen bool) error {
 
 	flowAddress := flow.Address(address)
@@ -574,6 +632,11 @@ func (e *TransactionEnv) DecodeArgument(b []byte, t cadence.Type) (cadence.Value
 		defer sp.Finish()
 	}
 
+	err := e.memoryHandler.MeterMemory(uint64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("decodeing argument failed: %w", err)
+	}
+
 	v, err := jsoncdc.Decode(b)
 	if err != nil {
 		err = errors.NewInvalidArgumentErrorf("argument is not json decodable: %w", err)
@@ -589,6 +652,11 @@ func (e *TransactionEnv) Hash(data []byte, tag string, hashAlgorithm runtime.Has
 		defer sp.Finish()
 	}
 
+	err := e.computationHandler.MeterComputation(handler.ComputationKindHash, 1)
+	if err != nil {
+		return nil, fmt.Errorf("hashing failed: %w", err)
+	}
+
 	hashAlgo := crypto.RuntimeToCryptoHashingAlgorithm(hashAlgorithm)
 	return crypto.HashWithTag(hashAlgo, tag, data)
 }
@@ -606,6 +674,11 @@ func (e *TransactionEnv) VerifySignature(
 		defer sp.Finish()
 	}
 
+	err := e.computationHandler.MeterComputation(handler.ComputationKindVerifySignature, 1)
+	if err != nil {
+		return false, fmt.Errorf("verifying signature failed: %w", err)
+	}
+
 	valid, err := crypto.VerifySignatureFromRuntime(
 		e.ctx.SignatureVerifier,
 		signature,
@@ -693,6 +766,11 @@ func (e *TransactionEnv) CreateAccount(payer runtime.Address) (address runtime.A
 		defer sp.Finish()
 	}
 
+	err = e.computationHandler.MeterComputation(handler.ComputationKindCreateAccount, 1)
+	if err != nil {
+		return address, fmt.Errorf("creating account failed: %w", err)
+	}
+
 	e.sth.DisableLimitEnforcement() // don't enforce limit during account creation
 	defer e.sth.EnableLimitEnforcement()
 
@@ -17,6 +17,7 @@ import (
 
 	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/extralog"
+	"github.com/onflow/flow-go/fvm/handler"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -120,7 +121,14 @@ func (i *TransactionInvoker) Process(
 
 		location := common.TransactionLocation(proc.ID[:])
 
-		err := vm.Runtime.ExecuteTransaction(
+		// the transaction is parsed and checked by Cadence
+		err := env.memoryHandler.MeterMemory(uint64(len(proc.Transaction.Script)))
+		if err != nil {
+			txError = fmt.Errorf("transaction invocation failed: %w", err)
+			break
+		}
+
+		err = vm.Runtime.ExecuteTransaction(
 			runtime.Script{
 				Source:    proc.Transaction.Script,
 				Arguments: proc.Transaction.Arguments,
@@ -225,12 +233,28 @@ func (i *TransactionInvoker) Process(
 	// if tx failed this will only contain fee deduction logs and computation
 	proc.Logs = append(proc.Logs, env.Logs()...)
 	proc.ComputationUsed = proc.ComputationUsed + env.GetComputationUsed()
+	if proc.ComputationUsedByKind == nil {
+		proc.ComputationUsedByKind = make(map[string]uint64)
+	}
+	for kind, used := range env.GetComputationUsedByKind() {
+		proc.ComputationUsedByKind[kind] += used
+	}
+	proc.MemoryUsed = proc.MemoryUsed + env.GetMemoryUsed()
 
 	// based on the contract updates we decide how to clean up the programs
 	// for failed transactions we also do the same as
 	// transaction without any deployed contracts
 	programs.Cleanup(updatedKeys)
 
+	// the computation weights are stored in the service account,
+	// so the cached weights are dropped if the service account was updated
+	for _, address := range sth.State().UpdatedAddresses() {
+		if address == ctx.Chain.ServiceAddress() {
+			programs.InvalidateComputationWeights()
+			break
+		}
+	}
+
 	// if tx failed this will only contain fee deduction events
 	proc.Events = append(proc.Events, env.Events()...)
 	proc.ServiceEvents = append(proc.ServiceEvents, env.ServiceEvents()...)
@@ -244,17 +268,18 @@ func (i *TransactionInvoker) deductTransactionFees(env *TransactionEnv, proc *Tr
 	}
 
 	// start a new computation meter for deducting transaction fees.
+	// the computation used is not charged, but recorded as fee deduction computation
 	subMeter := env.computationHandler.StartSubMeter(DefaultGasLimit)
 	defer func() {
-		merr := subMeter.Discard()
+		merr := subMeter.CommitAs(handler.ComputationKindFeeDeduction)
 		if merr == nil {
 			return
 		}
 		if err != nil {
-			// The error merr (from discarding the subMeter) will be hidden by err (transaction fee deduction error)
+			// The error merr (from committing the subMeter) will be hidden by err (transaction fee deduction error)
 			// as it has priority. So log merr.
 			i.logger.Error().Err(merr).
-				Msg("error discarding computation meter in deductTransactionFees (while also handling a deductTransactionFees error)")
+				Msg("error committing computation meter in deductTransactionFees (while also handling a deductTransactionFees error)")
 			return
 		}
 		err = merr
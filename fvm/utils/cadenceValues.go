@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"sort"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
 
@@ -47,3 +49,51 @@ func OptionalCadenceValueToAddressSlice(value cadence.Value) (addresses []common
 	}
 	return addresses, true
 }
+
+func StringUInt64MapToCadenceValue(values map[string]uint64) (cadence.Value, error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]cadence.KeyValuePair, 0, len(values))
+	for _, k := range keys {
+		key, err := cadence.NewString(k)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, cadence.KeyValuePair{Key: key, Value: cadence.NewUInt64(values[k])})
+	}
+	return cadence.NewDictionary(pairs), nil
+}
+
+func OptionalCadenceValueToStringUInt64Map(value cadence.Value) (values map[string]uint64, ok bool) {
+
+	// cast to optional
+	optV, ok := value.(cadence.Optional)
+	if !ok {
+		return nil, false
+	}
+
+	// cast to dictionary
+	v, ok := optV.Value.(cadence.Dictionary)
+	if !ok {
+		return nil, false
+	}
+
+	// parse pairs
+	values = make(map[string]uint64, len(v.Pairs))
+	for _, pair := range v.Pairs {
+		key, ok := pair.Key.(cadence.String)
+		if !ok {
+			return nil, false
+		}
+		value, ok := pair.Value.(cadence.UInt64)
+		if !ok {
+			return nil, false
+		}
+		values[string(key)] = uint64(value)
+	}
+	return values, true
+}
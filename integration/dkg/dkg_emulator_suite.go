@@ -413,6 +413,11 @@ func (s *DKGSuite) initEngines(node *node, ids flow.IdentityList) {
 	dkgKeys, err := badger.NewBeaconPrivateKeys(core.Metrics, core.SecretsDB)
 	s.Require().NoError(err)
 
+	// dkgState is used to record the progress of the node's participation in
+	// the DKG run
+	dkgState, err := badger.NewDKGState(core.SecretsDB)
+	s.Require().NoError(err)
+
 	// brokerTunnel is used to communicate between the messaging engine and the
 	// DKG broker/controller
 	brokerTunnel := dkg.NewBrokerTunnel()
@@ -452,12 +457,14 @@ func (s *DKGSuite) initEngines(node *node, ids flow.IdentityList) {
 		core.Me,
 		core.State,
 		dkgKeys,
+		dkgState,
 		dkg.NewControllerFactory(
 			controllerFactoryLogger,
 			core.Me,
 			[]module.DKGContractClient{node.dkgContractClient},
 			brokerTunnel,
 			config,
+			dkgState,
 		),
 		viewsObserver,
 	)
@@ -85,6 +85,11 @@ func createNode(
 	dkgKeys, err := badger.NewBeaconPrivateKeys(core.Metrics, core.SecretsDB)
 	require.NoError(t, err)
 
+	// dkgState is used to record the progress of the node's participation in
+	// the DKG run
+	dkgState, err := badger.NewDKGState(core.SecretsDB)
+	require.NoError(t, err)
+
 	// configure the state snapthost at firstBlock to return the desired
 	// Epochs
 	currentEpoch := new(protocolmock.Epoch)
@@ -105,8 +110,11 @@ func createNode(
 	epochQuery.Add(nextEpoch)
 	snapshot := new(protocolmock.Snapshot)
 	snapshot.On("Epochs").Return(epochQuery)
+	// the nodes start before the epoch setup phase, so there is no DKG to resume
+	snapshot.On("Phase").Return(flow.EpochPhaseStaking, nil)
 	state := new(protocolmock.MutableState)
 	state.On("AtBlockID", firstBlock).Return(snapshot)
+	state.On("Final").Return(snapshot)
 	core.State = state
 
 	// brokerTunnel is used to communicate between the messaging engine and the
@@ -145,12 +153,14 @@ func createNode(
 		core.Me,
 		core.State,
 		dkgKeys,
+		dkgState,
 		dkg.NewControllerFactory(
 			controllerFactoryLogger,
 			core.Me,
 			[]module.DKGContractClient{NewWhiteboardClient(id.NodeID, whiteboard)},
 			brokerTunnel,
 			config,
+			dkgState,
 		),
 		viewsObserver,
 	)
@@ -37,6 +37,7 @@ import (
 	"github.com/onflow/flow-go/network/p2p"
 	"github.com/onflow/flow-go/network/p2p/keyutils"
 	clusterstate "github.com/onflow/flow-go/state/cluster"
+	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/inmem"
 	"github.com/onflow/flow-go/utils/io"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -949,9 +950,18 @@ func BootstrapNetwork(networkConf NetworkConfig, bootstrapDir string) (*flow.Blo
 		RandomSource:       randomSource,
 	}
 
+	clustering, err := flow.NewClusterList(clusterAssignments, participants.Filter(filter.HasRole(flow.RoleCollection)))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	qcsWithVoterIDs, err := protocol.ClusterQCVoteDatasFromQCs(clustering, clusterQCs)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
 	epochCommit := &flow.EpochCommit{
 		Counter:            epochCounter,
-		ClusterQCs:         flow.ClusterQCVoteDatasFromQCs(clusterQCs),
+		ClusterQCs:         qcsWithVoterIDs,
 		DKGGroupKey:        dkg.PubGroupKey,
 		DKGParticipantKeys: dkg.PubKeyShares,
 	}
@@ -1134,7 +1144,7 @@ func setupClusterGenesisBlockQCs(nClusters uint, epochCounter uint64, confs []Co
 		}
 
 		// generate qc for root cluster block
-		qc, err := run.GenerateClusterRootQC(participants, block)
+		qc, err := run.GenerateClusterRootQC(participants, cluster, block)
 		if err != nil {
 			return nil, nil, err
 		}
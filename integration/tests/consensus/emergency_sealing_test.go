@@ -0,0 +1,215 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine"
+	exeUtils "github.com/onflow/flow-go/engine/execution/utils"
+	"github.com/onflow/flow-go/engine/ghost/client"
+	"github.com/onflow/flow-go/integration/testnet"
+	"github.com/onflow/flow-go/integration/tests/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// emergencySealingThreshold is the number of finalized blocks on top of the block incorporating
+// a result, after which the consensus nodes emergency seal the result.
+const emergencySealingThreshold = 5
+
+func TestEmergencySealing(t *testing.T) {
+	suite.Run(t, new(EmergencySealingSuite))
+}
+
+// EmergencySealingSuite tests that results are emergency sealed while the verification node is
+// offline, i.e. no approvals are ever sent, once both execution nodes agree on the result.
+type EmergencySealingSuite struct {
+	suite.Suite
+	cancel context.CancelFunc
+	net    *testnet.FlowNetwork
+	conIDs []flow.Identifier
+	exeIDs []flow.Identifier
+	exeSKs []crypto.PrivateKey
+	reader *client.FlowMessageStreamReader
+}
+
+func (es *EmergencySealingSuite) ghost(id flow.Identifier) *client.GhostClient {
+	ghost := es.net.ContainerByID(id)
+	client, err := common.GetGhostClient(ghost)
+	require.NoError(es.T(), err, "could not get ghost client")
+	return client
+}
+
+func (es *EmergencySealingSuite) SetupTest() {
+	var nodeConfigs []testnet.NodeConfig
+
+	// need one dummy collection node (unused ghost)
+	colConfig := testnet.NewNodeConfig(flow.RoleCollection, testnet.WithLogLevel(zerolog.FatalLevel), testnet.AsGhost())
+	nodeConfigs = append(nodeConfigs, colConfig)
+
+	// need three real consensus nodes, which require approvals to seal, but emergency seal
+	// results both execution nodes agree on
+	for n := 0; n < 3; n++ {
+		conID := unittest.IdentifierFixture()
+		nodeConfig := testnet.NewNodeConfig(flow.RoleConsensus,
+			testnet.WithLogLevel(zerolog.WarnLevel),
+			testnet.WithID(conID),
+			testnet.WithAdditionalFlag("--required-construction-seal-approvals=1"),
+			testnet.WithAdditionalFlag("--required-verification-seal-approvals=0"),
+			testnet.WithAdditionalFlag("--emergency-sealing-active=true"),
+			testnet.WithAdditionalFlag(fmt.Sprintf("--emergency-sealing-unsealed-height-threshold=%d", emergencySealingThreshold)),
+			testnet.WithAdditionalFlag("--emergency-sealing-required-receipts=2"),
+		)
+		nodeConfigs = append(nodeConfigs, nodeConfig)
+		es.conIDs = append(es.conIDs, conID)
+	}
+
+	// need two controllable execution nodes (used ghosts)
+	for n := 0; n < 2; n++ {
+		exeID := unittest.IdentifierFixture()
+		exeConfig := testnet.NewNodeConfig(flow.RoleExecution, testnet.WithLogLevel(zerolog.FatalLevel), testnet.WithID(exeID), testnet.AsGhost())
+		nodeConfigs = append(nodeConfigs, exeConfig)
+		es.exeIDs = append(es.exeIDs, exeID)
+	}
+
+	// the verification node is offline, it is a ghost which never sends approvals
+	verConfig := testnet.NewNodeConfig(flow.RoleVerification, testnet.WithLogLevel(zerolog.FatalLevel), testnet.AsGhost())
+	nodeConfigs = append(nodeConfigs, verConfig)
+
+	nodeConfigs = append(nodeConfigs,
+		testnet.NewNodeConfig(flow.RoleAccess, testnet.WithLogLevel(zerolog.FatalLevel)),
+	)
+
+	netConfig := testnet.NewNetworkConfig("consensus_emergency_sealing", nodeConfigs)
+	es.net = testnet.PrepareFlowNetwork(es.T(), netConfig)
+
+	for _, exeID := range es.exeIDs {
+		keys, err := es.net.ContainerByID(exeID).Config.NodeInfo.PrivateKeys()
+		require.NoError(es.T(), err)
+		es.exeSKs = append(es.exeSKs, keys.StakingKey)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	es.cancel = cancel
+	es.net.Start(ctx)
+
+	// subscribe to the ghost
+	for attempts := 0; ; attempts++ {
+		var err error
+		es.reader, err = es.ghost(es.exeIDs[0]).Subscribe(context.Background())
+		if err == nil {
+			break
+		}
+		if attempts >= 10 {
+			require.NoError(es.T(), err, "could not subscribe to ghost (%d attempts)", attempts)
+		}
+	}
+}
+
+func (es *EmergencySealingSuite) TearDownTest() {
+	es.net.Remove()
+	es.cancel()
+}
+
+// nextProposal returns the next block proposal received by the ghost before the deadline.
+func (es *EmergencySealingSuite) nextProposal(deadline time.Time) *messages.BlockProposal {
+	for time.Now().Before(deadline) {
+		_, msg, err := es.reader.Next()
+		if err != nil {
+			es.T().Logf("could not read next message: %s\n", err)
+			continue
+		}
+		if proposal, ok := msg.(*messages.BlockProposal); ok {
+			return proposal
+		}
+	}
+	return nil
+}
+
+func (es *EmergencySealingSuite) TestEmergencySealingWithoutApprovals() {
+
+	// fix the deadline of the entire test, emergency sealing requires a few more blocks
+	deadline := time.Now().Add(90 * time.Second)
+
+	// wait for a finalized block to seal, i.e. a block with three descendants
+	var target *flow.Header
+	headers := make(map[flow.Identifier]*flow.Header)
+	for target == nil {
+		proposal := es.nextProposal(deadline)
+		require.NotNil(es.T(), proposal, "should have received block proposals")
+		headers[proposal.Header.ID()] = proposal.Header
+
+		ancestor := proposal.Header
+		for i := 0; i < 3 && ancestor != nil; i++ {
+			ancestor = headers[ancestor.ParentID]
+		}
+		target = ancestor
+	}
+	targetID := target.ID()
+	es.T().Logf("target for sealing found (block: %x)\n", targetID)
+
+	// create the execution result for the target block
+	seal := es.net.Seal()
+	chunk := flow.Chunk{
+		ChunkBody: flow.ChunkBody{
+			StartState: seal.FinalState,
+			BlockID:    targetID,
+		},
+		Index:    0,
+		EndState: unittest.StateCommitmentFixture(),
+	}
+	result := flow.ExecutionResult{
+		PreviousResultID: seal.ResultID,
+		BlockID:          targetID,
+		Chunks:           flow.ChunkList{&chunk},
+	}
+	resultID := result.ID()
+
+	// both execution nodes commit to the result
+	for i, exeID := range es.exeIDs {
+		receipt := flow.ExecutionReceipt{
+			ExecutorID:      exeID,
+			ExecutionResult: result,
+		}
+		id := receipt.ID()
+		sig, err := es.exeSKs[i].Sign(id[:], exeUtils.NewExecutionReceiptHasher())
+		require.NoError(es.T(), err)
+		receipt.ExecutorSignature = sig
+
+		for time.Now().Before(deadline) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			err := es.ghost(exeID).Send(ctx, engine.PushReceipts, &receipt, es.conIDs...)
+			cancel()
+			if err == nil {
+				break
+			}
+			es.T().Logf("could not send execution receipt: %s\n", err)
+		}
+		es.T().Logf("execution receipt submitted (receipt: %x, result: %x)\n", receipt.ID(), resultID)
+	}
+
+	// without any approvals, the result is sealed once the emergency sealing threshold is reached
+	var sealingBlock *flow.Header
+	for sealingBlock == nil {
+		proposal := es.nextProposal(deadline)
+		require.NotNil(es.T(), proposal, "block seal should have been included in at least one block")
+		for _, seal := range proposal.Payload.Seals {
+			if seal.ResultID == resultID {
+				sealingBlock = proposal.Header
+				break
+			}
+		}
+	}
+	es.T().Logf("%x: block seal included!\n", sealingBlock.ID())
+
+	require.GreaterOrEqual(es.T(), sealingBlock.Height, target.Height+emergencySealingThreshold,
+		"result should only be emergency sealed after the threshold is reached")
+}
@@ -108,7 +108,7 @@ func (is *InclusionSuite) TestCollectionGuaranteeIncluded() {
 
 	// generate a sentinel collection guarantee
 	sentinel := unittest.CollectionGuaranteeFixture()
-	sentinel.SignerIDs = []flow.Identifier{is.collID}
+	sentinel.SignerIndices = unittest.SignerIndicesByIdentifiers([]flow.Identifier{is.collID}, []flow.Identifier{is.collID})
 	sentinel.ReferenceBlockID = is.net.Root().ID()
 
 	is.T().Logf("collection guarantee generated: %x\n", sentinel.CollectionID)
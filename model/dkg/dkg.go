@@ -11,3 +11,44 @@ type DKGData struct {
 	PubGroupKey   crypto.PublicKey
 	PubKeyShares  []crypto.PublicKey
 }
+
+// Progress holds the information required to resume the local participation in
+// a DKG instance after a restart. It is stored in the secrets database, together
+// with the log of inputs processed by the local Joint-Feldman state machine (see
+// Event).
+type Progress struct {
+	// Seed is the seed the local Joint-Feldman state machine was started with.
+	Seed []byte
+	// Phase1FinalView, Phase2FinalView and Phase3FinalView are the views at
+	// which the phases of the DKG instance end.
+	Phase1FinalView uint64
+	Phase2FinalView uint64
+	Phase3FinalView uint64
+	// BroadcastOffset is the index of the first broadcast message to read from
+	// the DKG smart contract when resuming. All messages before it have been
+	// processed, the message at the offset may have been processed as well.
+	BroadcastOffset uint
+	// SentBroadcasts is the number of our own broadcast messages which were
+	// published to the DKG smart contract.
+	SentBroadcasts uint
+}
+
+// EventType is the type of an input to the local Joint-Feldman state machine.
+type EventType uint8
+
+const (
+	PrivateMessageEvent EventType = iota + 1
+	BroadcastMessageEvent
+	TimeoutEvent
+)
+
+// Event is an input processed by the local Joint-Feldman state machine. As the
+// state machine is deterministic given its seed, replaying the events in order
+// restores its state.
+type Event struct {
+	Type EventType
+	// Orig is the index of the sender of a private or broadcast message.
+	Orig uint64
+	// Data is the content of a private or broadcast message.
+	Data []byte
+}
@@ -11,7 +11,7 @@ import (
 type CollectionGuarantee struct {
 	CollectionID     Identifier       // ID of the collection being guaranteed
 	ReferenceBlockID Identifier       // defines expiry of the collection
-	SignerIDs        []Identifier     // list of guarantors
+	SignerIndices    []byte           // encoded guarantors, relative to the canonically ordered cluster members
 	Signature        crypto.Signature // guarantor signatures
 }
 
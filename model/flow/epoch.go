@@ -133,22 +133,16 @@ func (c *ClusterQCVoteData) EqualTo(other *ClusterQCVoteData) bool {
 
 // ClusterQCVoteDataFromQC converts a quorum certificate to the representation
 // used by the smart contract, essentially discarding the block ID and view
-// (which are protocol-defined given the EpochSetup event).
-func ClusterQCVoteDataFromQC(qc *QuorumCertificate) ClusterQCVoteData {
+// (which are protocol-defined given the EpochSetup event). The smart contract
+// lists the voters explicitly, so the caller provides the voter IDs decoded
+// from the signer indices of the QC.
+func ClusterQCVoteDataFromQC(qc *QuorumCertificate, voterIDs []Identifier) ClusterQCVoteData {
 	return ClusterQCVoteData{
 		SigData:  qc.SigData,
-		VoterIDs: qc.SignerIDs,
+		VoterIDs: voterIDs,
 	}
 }
 
-func ClusterQCVoteDatasFromQCs(qcs []*QuorumCertificate) []ClusterQCVoteData {
-	qcVotes := make([]ClusterQCVoteData, 0, len(qcs))
-	for _, qc := range qcs {
-		qcVotes = append(qcVotes, ClusterQCVoteDataFromQC(qc))
-	}
-	return qcVotes
-}
-
 func (commit *EpochCommit) ServiceEvent() ServiceEvent {
 	return ServiceEvent{
 		Type:  ServiceEventCommit,
@@ -395,6 +389,10 @@ type EpochStatus struct {
 	PreviousEpoch EventIDs // EpochSetup and EpochCommit events for the previous epoch
 	CurrentEpoch  EventIDs // EpochSetup and EpochCommit events for the current epoch
 	NextEpoch     EventIDs // EpochSetup and EpochCommit events for the next epoch
+	// EmergencyFallbackTriggered indicates that the preparation of the next epoch
+	// failed and the current epoch is extended beyond its final view, with the
+	// same committee, until a valid next epoch has been committed.
+	EmergencyFallbackTriggered bool
 }
 
 // Copy returns a copy of the epoch status.
@@ -403,6 +401,8 @@ func (es *EpochStatus) Copy() *EpochStatus {
 		PreviousEpoch: es.PreviousEpoch,
 		CurrentEpoch:  es.CurrentEpoch,
 		NextEpoch:     es.NextEpoch,
+
+		EmergencyFallbackTriggered: es.EmergencyFallbackTriggered,
 	}
 }
 
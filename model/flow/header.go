@@ -30,9 +30,9 @@ type Header struct {
 
 	View uint64 // View is the view number at which this block was proposed.
 
-	ParentVoterIDs []Identifier // List of voters who signed the parent block.
+	ParentVoterIndices []byte // encoded list of voters who signed the parent block.
 	// A quorum certificate can be extrated from the header.
-	// This field is the SignerIDs field of the extracted quorum certificate.
+	// This field is the SignerIndices field of the extracted quorum certificate.
 
 	ParentVoterSigData []byte // aggregated signature over the parent block. Not a single cryptographic
 	// signature since the data represents cryptographic signatures serialized in some way (concatenation or other)
@@ -54,7 +54,7 @@ func (h Header) Body() interface{} {
 		PayloadHash        Identifier
 		Timestamp          uint64
 		View               uint64
-		ParentVoterIDs     []Identifier
+		ParentVoterIndices []byte
 		ParentVoterSigData []byte
 		ProposerID         Identifier
 	}{
@@ -64,7 +64,7 @@ func (h Header) Body() interface{} {
 		PayloadHash:        h.PayloadHash,
 		Timestamp:          uint64(h.Timestamp.UnixNano()),
 		View:               h.View,
-		ParentVoterIDs:     h.ParentVoterIDs,
+		ParentVoterIndices: h.ParentVoterIndices,
 		ParentVoterSigData: h.ParentVoterSigData,
 		ProposerID:         h.ProposerID,
 	}
@@ -93,22 +93,14 @@ func (h Header) ID() Identifier {
 	defer mutexHeader.Unlock()
 
 	// compare these elements individually
-	if prevHeader.ParentVoterIDs != nil &&
+	if prevHeader.ParentVoterIndices != nil &&
 		prevHeader.ParentVoterSigData != nil &&
 		prevHeader.ProposerSigData != nil &&
-		len(h.ParentVoterIDs) == len(prevHeader.ParentVoterIDs) &&
+		len(h.ParentVoterIndices) == len(prevHeader.ParentVoterIndices) &&
 		len(h.ParentVoterSigData) == len(prevHeader.ParentVoterSigData) &&
 		len(h.ProposerSigData) == len(prevHeader.ProposerSigData) {
-		bNotEqual := false
-
-		for i, v := range h.ParentVoterIDs {
-			if v == prevHeader.ParentVoterIDs[i] {
-				continue
-			}
-			bNotEqual = true
-			break
-		}
-		if !bNotEqual &&
+
+		if bytes.Equal(h.ParentVoterIndices, prevHeader.ParentVoterIndices) &&
 			h.ChainID == prevHeader.ChainID &&
 			h.Timestamp == prevHeader.Timestamp &&
 			h.Height == prevHeader.Height &&
@@ -4,8 +4,10 @@ package flow
 // A quorum certificate is a collection of votes for a particular block proposal. Valid quorum certificates contain
 // signatures from a super-majority of consensus committee members.
 type QuorumCertificate struct {
-	View      uint64
-	BlockID   Identifier
-	SignerIDs []Identifier
-	SigData   []byte
+	View    uint64
+	BlockID Identifier
+	// SignerIndices encodes the signers of the QC relative to the canonically ordered committee
+	// of the epoch (or cluster) the block belongs to. See module/signature for the encoding.
+	SignerIndices []byte
+	SigData       []byte
 }
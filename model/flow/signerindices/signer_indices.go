@@ -0,0 +1,175 @@
+// Package signerindices implements the compact encoding of a set of signers
+// relative to a committee in canonical order (see order.Canonical). Signer
+// indices are used by quorum certificates and collection guarantees in place
+// of the full list of signer IDs.
+//
+// The encoding is structured as follows:
+//   [version (1 byte)] [checksum (4 bytes)] [bit vector (ceil(n/8) bytes)]
+//   * version: the version of the encoding, currently Version
+//   * checksum: the CRC-32 checksum of the canonical committee the indices are
+//     encoded against, which allows to detect decoding relative to a wrong committee
+//   * bit vector: the i-th bit (in big-endian bit order) is set if and only if the
+//     i-th member of the canonical committee is a signer. The vector is padded with
+//     zeros to the next full byte.
+package signerindices
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Version is the current version of the signer indices encoding.
+const Version byte = 1
+
+const (
+	versionLen  = 1
+	checksumLen = 4
+	headerLen   = versionLen + checksumLen
+)
+
+// ErrInvalid is returned when decoding malformed signer indices, signer indices of
+// an unsupported version or signer indices encoded relative to another committee.
+var ErrInvalid = errors.New("invalid signer indices")
+
+// Encode encodes the given signers as signer indices relative to the canonical
+// committee. The canonical committee must be ordered canonically and the signers
+// must be distinct members of it, in any order.
+func Encode(canonicalIdentifiers flow.IdentifierList, signerIDs flow.IdentifierList) ([]byte, error) {
+	indices := make(map[flow.Identifier]int, len(canonicalIdentifiers))
+	for i, nodeID := range canonicalIdentifiers {
+		indices[nodeID] = i
+	}
+
+	signerIndices := make([]byte, headerLen+bitVectorLength(len(canonicalIdentifiers)))
+	signerIndices[0] = Version
+	binary.BigEndian.PutUint32(signerIndices[versionLen:], Checksum(canonicalIdentifiers))
+	bitVector := signerIndices[headerLen:]
+	for _, signerID := range signerIDs {
+		index, ok := indices[signerID]
+		if !ok {
+			return nil, fmt.Errorf("signer %x is not a member of the canonical committee", signerID)
+		}
+		if bitVector[index/8]&bitMask(index) != 0 {
+			return nil, fmt.Errorf("duplicated signer %x", signerID)
+		}
+		bitVector[index/8] |= bitMask(index)
+	}
+
+	return signerIndices, nil
+}
+
+// DecodeToIdentifiers decodes the given signer indices relative to the canonical
+// committee and returns the signer IDs in canonical order.
+// Expected error returns during normal operations:
+//  * ErrInvalid if the signer indices are malformed, use an unsupported version
+//    or were not encoded relative to the given committee
+func DecodeToIdentifiers(canonicalIdentifiers flow.IdentifierList, signerIndices []byte) (flow.IdentifierList, error) {
+	bitVector, err := validBitVector(canonicalIdentifiers, signerIndices)
+	if err != nil {
+		return nil, err
+	}
+
+	signerIDs := make(flow.IdentifierList, 0, len(canonicalIdentifiers))
+	for i, nodeID := range canonicalIdentifiers {
+		if bitVector[i/8]&bitMask(i) != 0 {
+			signerIDs = append(signerIDs, nodeID)
+		}
+	}
+	return signerIDs, nil
+}
+
+// DecodeToIdentities decodes the given signer indices relative to the canonical
+// committee and returns the signer identities in canonical order.
+// Expected error returns during normal operations:
+//  * ErrInvalid if the signer indices are malformed, use an unsupported version
+//    or were not encoded relative to the given committee
+func DecodeToIdentities(canonicalIdentities flow.IdentityList, signerIndices []byte) (flow.IdentityList, error) {
+	bitVector, err := validBitVector(canonicalIdentities.NodeIDs(), signerIndices)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make(flow.IdentityList, 0, len(canonicalIdentities))
+	for i, identity := range canonicalIdentities {
+		if bitVector[i/8]&bitMask(i) != 0 {
+			signers = append(signers, identity)
+		}
+	}
+	return signers, nil
+}
+
+// Count returns the number of signers in the given signer indices, without
+// decoding them. It returns zero for malformed signer indices.
+func Count(signerIndices []byte) int {
+	if len(signerIndices) < headerLen {
+		return 0
+	}
+	count := 0
+	for _, b := range signerIndices[headerLen:] {
+		for ; b != 0; b &= b - 1 {
+			count++
+		}
+	}
+	return count
+}
+
+// Checksum returns the checksum of the canonical committee, which is included in
+// signer indices encoded against the committee.
+func Checksum(canonicalIdentifiers flow.IdentifierList) uint32 {
+	checksum := crc32.NewIEEE()
+	for _, nodeID := range canonicalIdentifiers {
+		_, _ = checksum.Write(nodeID[:])
+	}
+	return checksum.Sum32()
+}
+
+// EncodedAgainst returns true if the signer indices carry the checksum of the given
+// canonical committee, i.e. if they were encoded against the committee. It does not
+// validate the remaining encoding.
+func EncodedAgainst(canonicalIdentifiers flow.IdentifierList, signerIndices []byte) bool {
+	if len(signerIndices) < headerLen {
+		return false
+	}
+	return binary.BigEndian.Uint32(signerIndices[versionLen:]) == Checksum(canonicalIdentifiers)
+}
+
+// validBitVector checks the signer indices against the canonical committee and
+// returns the bit vector.
+func validBitVector(canonicalIdentifiers flow.IdentifierList, signerIndices []byte) ([]byte, error) {
+	if len(signerIndices) < headerLen {
+		return nil, fmt.Errorf("signer indices too short (%d bytes): %w", len(signerIndices), ErrInvalid)
+	}
+	if signerIndices[0] != Version {
+		return nil, fmt.Errorf("unsupported signer indices version %d (expected: %d): %w", signerIndices[0], Version, ErrInvalid)
+	}
+	if !EncodedAgainst(canonicalIdentifiers, signerIndices) {
+		return nil, fmt.Errorf("signer indices checksum does not match the canonical committee: %w", ErrInvalid)
+	}
+
+	bitVector := signerIndices[headerLen:]
+	if len(bitVector) != bitVectorLength(len(canonicalIdentifiers)) {
+		return nil, fmt.Errorf("bit vector has %d bytes for a committee of %d members: %w", len(bitVector), len(canonicalIdentifiers), ErrInvalid)
+	}
+	// the padding bits must be zero, so that the encoding of a set of signers is unique
+	for i := len(canonicalIdentifiers); i < 8*len(bitVector); i++ {
+		if bitVector[i/8]&bitMask(i) != 0 {
+			return nil, fmt.Errorf("bit vector has non-zero padding: %w", ErrInvalid)
+		}
+	}
+
+	return bitVector, nil
+}
+
+// bitVectorLength returns the number of bytes of a bit vector with the given number of bits.
+func bitVectorLength(bits int) int {
+	return (bits + 7) / 8
+}
+
+// bitMask returns the mask of the bit at the given index within its byte.
+func bitMask(index int) byte {
+	return 1 << (7 - uint(index%8))
+}
@@ -0,0 +1,92 @@
+package signerindices_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestEncodeDecode checks that decoding encoded signer indices returns the
+// signers in canonical order, for committees of different sizes.
+func TestEncodeDecode(t *testing.T) {
+	for size := 0; size < 20; size++ {
+		committee := unittest.IdentityListFixture(size).Sort(order.Canonical)
+		signers := committee.Sample(uint(rand.Intn(size + 1)))
+		expected := committee.Filter(filter.HasNodeID(signers.NodeIDs()...)).NodeIDs()
+
+		signerIndices, err := signerindices.Encode(committee.NodeIDs(), signers.NodeIDs())
+		require.NoError(t, err)
+		assert.Len(t, signerIndices, 5+(size+7)/8)
+		assert.Equal(t, len(signers), signerindices.Count(signerIndices))
+
+		decodedIDs, err := signerindices.DecodeToIdentifiers(committee.NodeIDs(), signerIndices)
+		require.NoError(t, err)
+		assert.Equal(t, expected, []flow.Identifier(decodedIDs))
+
+		decodedIdentities, err := signerindices.DecodeToIdentities(committee, signerIndices)
+		require.NoError(t, err)
+		assert.Equal(t, expected, decodedIdentities.NodeIDs())
+	}
+}
+
+// TestEncode_InvalidSigners checks that only distinct members of the committee
+// can be encoded.
+func TestEncode_InvalidSigners(t *testing.T) {
+	committee := unittest.IdentityListFixture(10).Sort(order.Canonical)
+
+	t.Run("non-member", func(t *testing.T) {
+		_, err := signerindices.Encode(committee.NodeIDs(), []flow.Identifier{unittest.IdentifierFixture()})
+		require.Error(t, err)
+	})
+
+	t.Run("duplicated signer", func(t *testing.T) {
+		_, err := signerindices.Encode(committee.NodeIDs(), []flow.Identifier{committee[3].NodeID, committee[3].NodeID})
+		require.Error(t, err)
+	})
+}
+
+// TestDecode_Invalid checks that malformed signer indices are rejected with ErrInvalid.
+func TestDecode_Invalid(t *testing.T) {
+	committee := unittest.IdentityListFixture(10).Sort(order.Canonical)
+	valid, err := signerindices.Encode(committee.NodeIDs(), committee[:7].NodeIDs())
+	require.NoError(t, err)
+
+	t.Run("too short", func(t *testing.T) {
+		_, err := signerindices.DecodeToIdentifiers(committee.NodeIDs(), valid[:3])
+		require.ErrorIs(t, err, signerindices.ErrInvalid)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		invalid := append([]byte{}, valid...)
+		invalid[0]++
+		_, err := signerindices.DecodeToIdentifiers(committee.NodeIDs(), invalid)
+		require.ErrorIs(t, err, signerindices.ErrInvalid)
+	})
+
+	t.Run("different committee", func(t *testing.T) {
+		other := unittest.IdentityListFixture(10).Sort(order.Canonical)
+		_, err := signerindices.DecodeToIdentifiers(other.NodeIDs(), valid)
+		require.ErrorIs(t, err, signerindices.ErrInvalid)
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		invalid := append(append([]byte{}, valid...), 0)
+		_, err := signerindices.DecodeToIdentifiers(committee.NodeIDs(), invalid)
+		require.ErrorIs(t, err, signerindices.ErrInvalid)
+	})
+
+	t.Run("non-zero padding", func(t *testing.T) {
+		invalid := append([]byte{}, valid...)
+		invalid[len(invalid)-1] |= 0x01
+		_, err := signerindices.DecodeToIdentities(committee, invalid)
+		require.ErrorIs(t, err, signerindices.ErrInvalid)
+	})
+}
@@ -13,6 +13,8 @@ type TransactionResult struct {
 	ErrorMessage string
 	// Computation used
 	ComputationUsed uint64
+	// Computation used broken down by the name of the kind of operation
+	ComputationUsedByKind map[string]uint64
 }
 
 // String returns the string representation of this error.
@@ -200,6 +200,8 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 		// start with the finalized reference ID (longest expiry time)
 		minRefID := refChainFinalizedID
 
+		// transactions are considered in the order the mempool returns them, which
+		// is highest priority first for a priority-ordered mempool
 		var transactions []*flow.TransactionBody
 		var totalByteSize uint64
 		var totalGas uint64
@@ -331,9 +333,18 @@ func (b *Builder) BuildOn(parentID flow.Identifier, setter func(*flow.Header) er
 		return nil, fmt.Errorf("could not build block: %w", err)
 	}
 
-	span, ctx, _ := b.tracer.StartCollectionSpan(context.Background(), proposal.ID(), trace.COLBuildOn, opentracing.StartTime(startTime))
+	collectionID := proposal.Payload.Collection.ID()
+	span, ctx, _ := b.tracer.StartCollectionSpan(context.Background(), proposal.ID(), trace.COLBuildOn,
+		opentracing.StartTime(startTime), trace.EntityLinks{collectionID})
 	defer span.Finish()
 
+	// record the inclusion within the transaction traces, linked to the trace of the
+	// collection, so that a transaction can be followed through consensus and execution
+	for _, tx := range proposal.Payload.Collection.Transactions {
+		txSpan, _, _ := b.tracer.StartTransactionSpan(ctx, tx.ID(), trace.COLBuildOnIncludeTransaction, trace.EntityLinks{collectionID})
+		txSpan.Finish()
+	}
+
 	dbInsertSpan, _ := b.tracer.StartSpanFromContext(ctx, trace.COLBuildOnDBInsert)
 	defer dbInsertSpan.Finish()
 
@@ -788,6 +788,60 @@ func (suite *BuilderSuite) TestBuildOn_UnlimitedPayer() {
 	}
 }
 
+// with a priority-ordered mempool, the highest priority transactions should be
+// included first, while still excluding expired and duplicate transactions
+func (suite *BuilderSuite) TestBuildOn_TransactionPriority() {
+
+	pool := stdmap.NewPriorityTransactions(1000, func(tx *flow.TransactionBody) uint64 {
+		return tx.GasLimit
+	})
+	suite.builder = builder.NewBuilder(suite.db, trace.NewNoopTracer(), suite.headers, suite.headers, suite.payloads, pool,
+		builder.WithMaxCollectionSize(5),
+	)
+
+	// fill the pool with transactions of increasing priority
+	var txs []*flow.TransactionBody
+	for i := 0; i < 20; i++ {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.ReferenceBlockID = suite.ProtoStateRoot().ID()
+			tx.GasLimit = uint64(100 + i)
+		})
+		txs = append(txs, &tx)
+		suite.Require().True(pool.Add(&tx))
+	}
+
+	// the highest priority transaction has an unknown reference block, and should be skipped
+	unknownRef := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.ReferenceBlockID = unittest.IdentifierFixture()
+		tx.GasLimit = 1000
+	})
+	suite.Require().True(pool.Add(&unknownRef))
+
+	// the first collection should contain the 5 highest priority valid transactions
+	header, err := suite.builder.BuildOn(suite.genesis.ID(), noopSetter)
+	suite.Require().Nil(err)
+	var built model.Block
+	err = suite.db.View(procedure.RetrieveClusterBlock(header.ID(), &built))
+	suite.Require().Nil(err)
+	suite.Assert().Equal(flow.GetIDs(txs[15:]), flow.GetIDs(reversed(built.Payload.Collection.Transactions)))
+
+	// the next collection should not repeat transactions of its un-finalized parent
+	header, err = suite.builder.BuildOn(header.ID(), noopSetter)
+	suite.Require().Nil(err)
+	err = suite.db.View(procedure.RetrieveClusterBlock(header.ID(), &built))
+	suite.Require().Nil(err)
+	suite.Assert().Equal(flow.GetIDs(txs[10:15]), flow.GetIDs(reversed(built.Payload.Collection.Transactions)))
+}
+
+// helper to reverse a list of transactions
+func reversed(txs []*flow.TransactionBody) []*flow.TransactionBody {
+	out := make([]*flow.TransactionBody, 0, len(txs))
+	for i := len(txs) - 1; i >= 0; i-- {
+		out = append(out, txs[i])
+	}
+	return out
+}
+
 // helper to check whether a collection contains each of the given transactions.
 func collectionContains(collection flow.Collection, txIDs ...flow.Identifier) bool {
 
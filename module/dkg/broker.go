@@ -57,15 +57,20 @@ type Broker struct {
 	broadcastMsgCh            chan messages.DKGMessage   // channel to forward incoming broadcast messages to consumers
 	messageOffset             uint                       // offset for next broadcast messages to fetch
 	shutdownCh                chan struct{}              // channel to stop the broker from listening
+	journal                   *Journal                   // records the progress of the DKG to resume it after a restart (optional)
 
-	broadcasts    uint       // broadcasts counts the number of successful broadcasts
-	broadcastLock sync.Mutex // protects access to broadcasts count variable
+	broadcasts        uint       // broadcasts counts the number of successful broadcasts
+	resumedBroadcasts uint       // number of broadcasts published before resuming, which are not published again
+	broadcastLock     sync.Mutex // protects access to broadcasts count variables
 
 	pollLock sync.Mutex // lock around polls to read inbound broadcasts
 }
 
 // NewBroker instantiates a new epoch-specific broker capable of communicating
-// with other nodes via a network engine and dkg smart-contract.
+// with other nodes via a network engine and dkg smart-contract. If a journal is
+// given, the broker resumes reading broadcast messages from the offset recorded
+// in the journal, and does not publish the broadcast messages it has published
+// before resuming again.
 func NewBroker(
 	log zerolog.Logger,
 	dkgInstanceID string,
@@ -73,7 +78,8 @@ func NewBroker(
 	me module.Local,
 	myIndex int,
 	dkgContractClients []module.DKGContractClient,
-	tunnel *BrokerTunnel) *Broker {
+	tunnel *BrokerTunnel,
+	journal *Journal) *Broker {
 
 	b := &Broker{
 		log:                log.With().Str("component", "broker").Str("dkg_instance_id", dkgInstanceID).Logger(),
@@ -87,6 +93,10 @@ func NewBroker(
 		privateMsgCh:       make(chan messages.DKGMessage),
 		broadcastMsgCh:     make(chan messages.DKGMessage),
 		shutdownCh:         make(chan struct{}),
+		journal:            journal,
+		messageOffset:      journal.BroadcastOffset(),
+		broadcasts:         journal.SentBroadcasts(),
+		resumedBroadcasts:  journal.SentBroadcasts(),
 	}
 
 	go b.listen()
@@ -110,6 +120,12 @@ func (b *Broker) PrivateSend(dest int, data []byte) {
 		b.log.Error().Msgf("destination id out of range: %d", dest)
 		return
 	}
+	// private messages produced while replaying the journal were sent before
+	// resuming
+	if b.journal.Replaying() {
+		b.log.Debug().Msgf("skipping private message to %d while resuming", dest)
+		return
+	}
 	dkgMessageOut := messages.PrivDKGMessageOut{
 		DKGMessage: messages.NewDKGMessage(b.myIndex, data, b.dkgInstanceID),
 		DestID:     b.committee[dest].NodeID,
@@ -119,6 +135,17 @@ func (b *Broker) PrivateSend(dest int, data []byte) {
 
 // Broadcast signs and broadcasts a message to all participants.
 func (b *Broker) Broadcast(data []byte) {
+	// the first broadcasts requested while replaying the journal were published
+	// before resuming
+	b.broadcastLock.Lock()
+	if b.resumedBroadcasts > 0 && b.journal.Replaying() {
+		b.resumedBroadcasts--
+		b.broadcastLock.Unlock()
+		b.log.Debug().Msgf("skipping DKG message broadcast with header %d published before resuming", data[0])
+		return
+	}
+	b.broadcastLock.Unlock()
+
 	b.unit.Launch(func() {
 		// NOTE: We're counting the number of times the underlying DKG
 		// requested a broadcast so we can detect an unhappy path. Thus incrementing
@@ -166,6 +193,12 @@ func (b *Broker) Broadcast(data []byte) {
 			b.updateLastSuccessfulClient(clientIndex)
 			return nil
 		})
+		if err == nil {
+			err := b.journal.RecordSentBroadcast()
+			if err != nil {
+				b.log.Error().Err(err).Msg("could not record broadcast in the journal")
+			}
+		}
 
 		// Various network can conditions can result in errors while broadcasting DKG messages,
 		// because failure to send an individual DKG message doesn't necessarily result in local or global DKG failure
@@ -248,7 +281,13 @@ func (b *Broker) Poll(referenceBlock flow.Identifier) error {
 
 	b.unit.Lock()
 	defer b.unit.Unlock()
-	for _, msg := range msgs {
+
+	// The consumer records a forwarded message in the journal before receiving
+	// the next one, but the last forwarded message may not be recorded yet when
+	// forwarding completes. Hence, we resume reading from the last forwarded
+	// message, which the consumer discards if it has recorded it already.
+	resumeOffset := b.journal.BroadcastOffset()
+	for i, msg := range msgs {
 		ok, err := b.verifyBroadcastMessage(msg)
 		if err != nil {
 			b.log.Error().Err(err).Msg("bad broadcast message")
@@ -260,6 +299,11 @@ func (b *Broker) Poll(referenceBlock flow.Identifier) error {
 		}
 		b.log.Debug().Msgf("forwarding broadcast message to controller")
 		b.broadcastMsgCh <- msg.DKGMessage
+		resumeOffset = b.messageOffset + uint(i)
+	}
+	err = b.journal.SetBroadcastOffset(resumeOffset)
+	if err != nil {
+		b.log.Error().Err(err).Msg("could not record broadcast offset in the journal")
 	}
 
 	// update message offset to use for future polls, this avoids forwarding the
@@ -6,14 +6,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
+	testifymock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	dkgmodel "github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
 	msg "github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/local"
 	"github.com/onflow/flow-go/module/mock"
+	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -58,6 +62,7 @@ func TestPrivateSend_Valid(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	// expected DKGMessageOut
@@ -100,6 +105,7 @@ func TestPrivateSend_IndexOutOfRange(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	// Launch a background routine to capture messages sent through the tunnel.
@@ -134,6 +140,7 @@ func TestReceivePrivateMessage_Valid(t *testing.T) {
 		dest,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	expectedMsg := msg.NewDKGMessage(
@@ -181,6 +188,7 @@ func TestProcessPrivateMessage_InvalidOrigin(t *testing.T) {
 		dest,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	// Launch a background routine to capture messages forwared to the private
@@ -246,6 +254,7 @@ func TestBroadcastMessage(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}, &mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	expectedMsg, err := sender.prepareBroadcastMessage(msgb)
@@ -284,6 +293,7 @@ func TestPoll(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	recipient := NewBroker(
@@ -294,6 +304,7 @@ func TestPoll(t *testing.T) {
 		dest,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	blockID := unittest.IdentifierFixture()
@@ -341,6 +352,115 @@ func TestPoll(t *testing.T) {
 	require.Equal(t, uint(len(bcastMsgs)), sender.messageOffset)
 }
 
+// TestPoll_Resume checks that a broker resumes reading broadcast messages from
+// the offset recorded in its journal, and that it records the offset of the
+// last forwarded message, which may not have been recorded by the consumer yet.
+func TestPoll_Resume(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitSecret, func(db *badger.DB) {
+		committee, locals := initCommittee(2)
+		journal := openTestJournal(t, db, &dkgmodel.Progress{BroadcastOffset: 2})
+
+		sender := NewBroker(
+			zerolog.Logger{},
+			dkgInstanceID,
+			committee,
+			locals[orig],
+			orig,
+			[]module.DKGContractClient{&mock.DKGContractClient{}},
+			NewBrokerTunnel(),
+			journal,
+		)
+		require.Equal(t, uint(2), sender.messageOffset)
+
+		blockID := unittest.IdentifierFixture()
+		bcastMsgs := []msg.BroadcastDKGMessage{}
+		for i := 0; i < 3; i++ {
+			bmsg, err := sender.prepareBroadcastMessage([]byte(fmt.Sprintf("msg%d", i)))
+			require.NoError(t, err)
+			bcastMsgs = append(bcastMsgs, bmsg)
+		}
+
+		contractClient := &mock.DKGContractClient{}
+		contractClient.On("ReadBroadcast", uint(2), blockID).
+			Return(bcastMsgs, nil).
+			Once()
+		sender.dkgContractClients[0] = contractClient
+
+		go func() {
+			msgCh := sender.GetBroadcastMsgCh()
+			for range bcastMsgs {
+				<-msgCh
+			}
+		}()
+
+		err := sender.Poll(blockID)
+		require.NoError(t, err)
+		contractClient.AssertExpectations(t)
+
+		require.Equal(t, uint(5), sender.messageOffset)
+		require.Equal(t, uint(4), journal.BroadcastOffset())
+	})
+}
+
+// TestBroadcast_Resume checks that, while replaying its journal, a broker does
+// not publish the broadcast messages it published before resuming again, and
+// that it does not send private messages.
+func TestBroadcast_Resume(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitSecret, func(db *badger.DB) {
+		committee, locals := initCommittee(2)
+		journal := openTestJournal(t, db, &dkgmodel.Progress{SentBroadcasts: 1})
+
+		sender := NewBroker(
+			zerolog.Logger{},
+			dkgInstanceID,
+			committee,
+			locals[orig],
+			orig,
+			[]module.DKGContractClient{&mock.DKGContractClient{}},
+			NewBrokerTunnel(),
+			journal,
+		)
+
+		// only the second broadcast message is published
+		contractClient := &mock.DKGContractClient{}
+		contractClient.On("Broadcast", testifymock.MatchedBy(func(bmsg msg.BroadcastDKGMessage) bool {
+			return string(bmsg.Data) == "msg1"
+		})).
+			Return(nil).
+			Once()
+		sender.dkgContractClients[0] = contractClient
+
+		doneCh := make(chan struct{})
+		go func() {
+			<-sender.tunnel.MsgChOut
+			close(doneCh)
+		}()
+
+		journal.SetReplaying(true)
+		sender.PrivateSend(dest, msgb)
+		sender.Broadcast([]byte("msg0"))
+		sender.Broadcast([]byte("msg1"))
+		journal.SetReplaying(false)
+
+		unittest.AssertClosesBefore(t, sender.unit.Done(), time.Second)
+		unittest.RequireNeverClosedWithin(t, doneCh, 50*time.Millisecond, "no private message should be sent while replaying")
+		contractClient.AssertExpectations(t)
+		require.Equal(t, uint(2), journal.SentBroadcasts())
+	})
+}
+
+// openTestJournal inserts the given DKG progress and opens the journal of the
+// test DKG instance.
+func openTestJournal(t *testing.T, db *badger.DB, progress *dkgmodel.Progress) *Journal {
+	store, err := bstorage.NewDKGState(db)
+	require.NoError(t, err)
+	err = store.InsertDKGProgress(dkgInstanceID, progress)
+	require.NoError(t, err)
+	journal, err := OpenJournal(store, dkgInstanceID)
+	require.NoError(t, err)
+	return journal
+}
+
 // TestLogHook checks that the Disqualify and FlagMisbehaviour functions call a
 // Warn log, and that we can hook a logger to react to such logs.
 func TestLogHook(t *testing.T) {
@@ -364,6 +484,7 @@ func TestLogHook(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	sender.Disqualify(1, "testing")
@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/crypto"
+	dkgmodel "github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 )
@@ -84,6 +85,18 @@ type Controller struct {
 	// seed is required by DKGState
 	seed []byte
 
+	// journal records the inputs of the DKG, so that it can be resumed after a
+	// restart
+	journal *Journal
+
+	// timeouts counts the phases of the DKG which have been ended, it is
+	// protected by dkgLock
+	timeouts int
+
+	// replayed holds the IDs of the broadcast messages replayed from the
+	// journal, which the broker may read again from the DKG smart contract
+	replayed map[flow.Identifier]struct{}
+
 	// broker enables the controller to communicate with other nodes
 	broker module.DKGBroker
 
@@ -106,7 +119,10 @@ type Controller struct {
 	once   *sync.Once
 }
 
-// NewController instantiates a new Joint Feldman DKG controller.
+// NewController instantiates a new Joint Feldman DKG controller. If the journal
+// holds events recorded by a previous run of the DKG instance, the controller
+// resumes the DKG in the phase it was in when the previous run stopped. The
+// journal may be nil, in which case the DKG cannot be resumed.
 func NewController(
 	log zerolog.Logger,
 	dkgInstanceID string,
@@ -114,6 +130,7 @@ func NewController(
 	seed []byte,
 	broker module.DKGBroker,
 	config ControllerConfig,
+	journal *Journal,
 ) *Controller {
 
 	logger := log.With().
@@ -121,10 +138,12 @@ func NewController(
 		Str("dkg_instance_id", dkgInstanceID).
 		Logger()
 
-	return &Controller{
+	c := &Controller{
 		log:        logger,
 		dkg:        dkg,
 		seed:       seed,
+		journal:    journal,
+		replayed:   make(map[flow.Identifier]struct{}),
 		broker:     broker,
 		h1Ch:       make(chan struct{}),
 		h2Ch:       make(chan struct{}),
@@ -133,6 +152,22 @@ func NewController(
 		once:       new(sync.Once),
 		config:     config,
 	}
+
+	if journal.Resuming() {
+		// every recorded timeout ended one phase of the DKG
+		timeouts := 0
+		for _, event := range journal.Events() {
+			if event.Type == dkgmodel.TimeoutEvent {
+				timeouts++
+			}
+		}
+		if timeouts > 2 {
+			timeouts = 2
+		}
+		c.SetState(Phase1 + State(timeouts))
+	}
+
+	return c
 }
 
 /*******************************************************************************
@@ -144,8 +179,13 @@ Implement DKGController
 // the protocol phases.
 func (c *Controller) Run() error {
 
-	// Start DKG and transition to phase 1
-	err := c.start()
+	// Start DKG and transition to phase 1, or resume the DKG from the journal
+	var err error
+	if c.journal.Resuming() {
+		err = c.resume()
+	} else {
+		err = c.start()
+	}
 	if err != nil {
 		return err
 	}
@@ -286,6 +326,7 @@ func (c *Controller) doBackgroundWork() {
 		select {
 		case msg := <-privateMsgCh:
 			c.dkgLock.Lock()
+			c.record(dkgmodel.PrivateMessageEvent, msg.Orig, msg.Data)
 			err := c.dkg.HandlePrivateMsg(int(msg.Orig), msg.Data)
 			c.dkgLock.Unlock()
 			if err != nil {
@@ -294,6 +335,15 @@ func (c *Controller) doBackgroundWork() {
 
 		case msg := <-broadcastMsgCh:
 
+			// after resuming, the broker may read broadcast messages from the
+			// DKG smart contract which were already replayed from the journal
+			id := eventID(dkgmodel.BroadcastMessageEvent, msg.Orig, msg.Data)
+			if _, ok := c.replayed[id]; ok {
+				delete(c.replayed, id)
+				c.log.Debug().Msgf("skipping DKG broadcast message from %d replayed from the journal", msg.Orig)
+				continue
+			}
+
 			// before processing a broadcast message during phase 1, sleep for a
 			// random delay to avoid synchronizing this expensive operation across
 			// all consensus nodes
@@ -318,6 +368,7 @@ func (c *Controller) doBackgroundWork() {
 			}
 
 			c.dkgLock.Lock()
+			c.record(dkgmodel.BroadcastMessageEvent, msg.Orig, msg.Data)
 			err := c.dkg.HandleBroadcastMsg(int(msg.Orig), msg.Data)
 			c.dkgLock.Unlock()
 			if err != nil {
@@ -354,6 +405,52 @@ func (c *Controller) start() error {
 	return nil
 }
 
+// resume restores the DKG after a restart: it starts the Joint-Feldman state
+// machine with the seed of the previous run and replays the events recorded in
+// the journal. The messages produced while replaying were already sent by the
+// previous run, so the broker drops them. The controller state was restored
+// when the controller was created.
+func (c *Controller) resume() error {
+	c.dkgLock.Lock()
+	defer c.dkgLock.Unlock()
+
+	c.journal.SetReplaying(true)
+	defer c.journal.SetReplaying(false)
+
+	err := c.dkg.Start(c.seed)
+	if err != nil {
+		return fmt.Errorf("Error starting DKG: %w", err)
+	}
+
+	events := c.journal.Events()
+	for i, event := range events {
+		switch event.Type {
+		case dkgmodel.PrivateMessageEvent:
+			err := c.dkg.HandlePrivateMsg(int(event.Orig), event.Data)
+			if err != nil {
+				c.log.Err(err).Msg("error replaying DKG private message")
+			}
+		case dkgmodel.BroadcastMessageEvent:
+			err := c.dkg.HandleBroadcastMsg(int(event.Orig), event.Data)
+			if err != nil {
+				c.log.Err(err).Msg("error replaying DKG broadcast message")
+			}
+			c.replayed[eventID(event.Type, event.Orig, event.Data)] = struct{}{}
+		case dkgmodel.TimeoutEvent:
+			err := c.dkg.NextTimeout()
+			if err != nil {
+				return fmt.Errorf("Error replaying NextTimeout: %w", err)
+			}
+			c.timeouts++
+		default:
+			return fmt.Errorf("unknown DKG event type %d at index %d", event.Type, i)
+		}
+	}
+
+	c.log.Info().Int("events", len(events)).Msgf("DKG engine resumed in %s", c.GetState())
+	return nil
+}
+
 func (c *Controller) phase1() error {
 	state := c.GetState()
 	if state != Phase1 {
@@ -377,11 +474,9 @@ func (c *Controller) phase2() error {
 		return fmt.Errorf("Cannot execute phase2 routine in state %s", state)
 	}
 
-	c.dkgLock.Lock()
-	err := c.dkg.NextTimeout()
-	c.dkgLock.Unlock()
+	err := c.nextTimeout(Phase2)
 	if err != nil {
-		return fmt.Errorf("Error calling NextTimeout: %w", err)
+		return err
 	}
 
 	c.log.Debug().Msg("Waiting for end of phase 2")
@@ -401,11 +496,9 @@ func (c *Controller) phase3() error {
 		return fmt.Errorf("Cannot execute phase3 routine in state %s", state)
 	}
 
-	c.dkgLock.Lock()
-	err := c.dkg.NextTimeout()
-	c.dkgLock.Unlock()
+	err := c.nextTimeout(Phase3)
 	if err != nil {
-		return fmt.Errorf("Error calling NextTimeout: %w", err)
+		return err
 	}
 
 	c.log.Debug().Msg("Waiting for end of phase 3")
@@ -419,6 +512,51 @@ func (c *Controller) phase3() error {
 	}
 }
 
+// nextTimeout ends the current phase of the Joint-Feldman state machine, to
+// start the given phase. Phases which were already ended before the DKG was
+// resumed are not ended again.
+func (c *Controller) nextTimeout(phase State) error {
+	c.dkgLock.Lock()
+	defer c.dkgLock.Unlock()
+
+	// phase 2 starts with the first timeout, phase 3 with the second one
+	if c.timeouts >= int(phase-Phase1) {
+		return nil
+	}
+
+	c.record(dkgmodel.TimeoutEvent, 0, nil)
+	err := c.dkg.NextTimeout()
+	if err != nil {
+		return fmt.Errorf("Error calling NextTimeout: %w", err)
+	}
+	c.timeouts++
+	return nil
+}
+
+// record records an input of the Joint-Feldman state machine in the journal,
+// before it is processed. Must be called while holding dkgLock.
+func (c *Controller) record(eventType dkgmodel.EventType, orig uint64, data []byte) {
+	err := c.journal.Record(&dkgmodel.Event{
+		Type: eventType,
+		Orig: orig,
+		Data: data,
+	})
+	if err != nil {
+		// the DKG can proceed, but it cannot be resumed consistently after a
+		// restart anymore
+		c.log.Err(err).Msgf("could not record DKG event of type %d in the journal", eventType)
+	}
+}
+
+// eventID returns the ID of an input of the Joint-Feldman state machine.
+func eventID(eventType dkgmodel.EventType, orig uint64, data []byte) flow.Identifier {
+	return flow.MakeID(dkgmodel.Event{
+		Type: eventType,
+		Orig: orig,
+		Data: data,
+	})
+}
+
 // preStartDelay returns a duration to delay prior to starting the DKG process.
 // This prevents synchronization of the DKG starting (an expensive operation)
 // across the network, which can impact finalization.
@@ -9,19 +9,22 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/storage"
 )
 
 // ControllerFactory is a factory object that creates new Controllers for new
 // epochs. Each Controller produced by a factory shares the same underlying
 // Local object to sign broadcast messages, the same tunnel tying it to the
 // MessagingEngine, and the same client to communicate with the DKG
-// smart-contract.
+// smart-contract. The progress of the DKG instances is recorded in the given
+// DKG state storage, so that they can be resumed after a restart.
 type ControllerFactory struct {
 	log                zerolog.Logger
 	me                 module.Local
 	dkgContractClients []module.DKGContractClient
 	tunnel             *BrokerTunnel
 	config             ControllerConfig
+	dkgState           storage.DKGState
 }
 
 // NewControllerFactory creates a new factory that generates Controllers with
@@ -31,7 +34,8 @@ func NewControllerFactory(
 	me module.Local,
 	dkgContractClients []module.DKGContractClient,
 	tunnel *BrokerTunnel,
-	config ControllerConfig) *ControllerFactory {
+	config ControllerConfig,
+	dkgState storage.DKGState) *ControllerFactory {
 
 	return &ControllerFactory{
 		log:                log,
@@ -39,11 +43,14 @@ func NewControllerFactory(
 		dkgContractClients: dkgContractClients,
 		tunnel:             tunnel,
 		config:             config,
+		dkgState:           dkgState,
 	}
 }
 
 // Create creates a new epoch-specific Controller equipped with a broker which
-// is capable of communicating with other nodes.
+// is capable of communicating with other nodes. The progress of the DKG instance
+// must have been inserted into the DKG state storage beforehand. If the node
+// participated in the DKG instance before a restart, the controller resumes it.
 func (f *ControllerFactory) Create(
 	dkgInstanceID string,
 	participants flow.IdentityList,
@@ -60,6 +67,11 @@ func (f *ControllerFactory) Create(
 		return nil, fmt.Errorf("node does not belong to dkg committee")
 	}
 
+	journal, err := OpenJournal(f.dkgState, dkgInstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("could not open dkg journal: %w", err)
+	}
+
 	broker := NewBroker(
 		f.log,
 		dkgInstanceID,
@@ -68,6 +80,7 @@ func (f *ControllerFactory) Create(
 		myIndex,
 		f.dkgContractClients,
 		f.tunnel,
+		journal,
 	)
 
 	n := len(participants)
@@ -84,6 +97,7 @@ func (f *ControllerFactory) Create(
 		seed,
 		broker,
 		f.config,
+		journal,
 	)
 
 	return controller, nil
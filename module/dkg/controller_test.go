@@ -6,14 +6,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/crypto"
+	dkgmodel "github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
 	msg "github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module/signature"
+	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -22,12 +25,13 @@ import (
 type node struct {
 	id             int
 	controller     *Controller
+	broker         *broker
 	phase1Duration time.Duration
 	phase2Duration time.Duration
 	phase3Duration time.Duration
 }
 
-func newNode(id int, controller *Controller,
+func newNode(id int, controller *Controller, broker *broker,
 	phase1Duration time.Duration,
 	phase2Duration time.Duration,
 	phase3Duration time.Duration) *node {
@@ -35,6 +39,7 @@ func newNode(id int, controller *Controller,
 	return &node{
 		id:             id,
 		controller:     controller,
+		broker:         broker,
 		phase1Duration: phase1Duration,
 		phase2Duration: phase2Duration,
 		phase3Duration: phase3Duration,
@@ -94,10 +99,15 @@ type broker struct {
 	broadcastChannels []chan msg.DKGMessage
 	logger            zerolog.Logger
 	dkgInstanceID     string
+	journal           *Journal
 }
 
 // PrivateSend implements the crypto.DKGProcessor interface.
 func (b *broker) PrivateSend(dest int, data []byte) {
+	// messages produced while replaying the journal were sent before resuming
+	if b.journal.Replaying() {
+		return
+	}
 	b.privateChannels[dest] <- msg.NewDKGMessage(b.id, data, b.dkgInstanceID)
 }
 
@@ -109,6 +119,11 @@ func (b *broker) PrivateSend(dest int, data []byte) {
 // 3, all nodes are guaranteed to see everyone's messages. So it is important
 // to set timeouts carefully in the tests.
 func (b *broker) Broadcast(data []byte) {
+	// as broadcasts are delivered synchronously, all broadcasts produced while
+	// replaying the journal were delivered before resuming
+	if b.journal.Replaying() {
+		return
+	}
 	for i := 0; i < len(b.broadcastChannels); i++ {
 		if i == b.id {
 			continue
@@ -243,32 +258,110 @@ func initNodes(t *testing.T, n int, phase1Duration, phase2Duration, phase3Durati
 		}
 
 		seed := unittest.SeedFixture(20)
+		controller := newController(t, n, broker, seed, nil)
+
+		node := newNode(i, controller, broker, phase1Duration, phase2Duration, phase3Duration)
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// newController creates a controller running a new Joint Feldman DKG instance
+// for the participant of the given broker.
+func newController(t *testing.T, n int, broker *broker, seed []byte, journal *Journal) *Controller {
+	dkg, err := crypto.NewJointFeldman(n, signature.RandomBeaconThreshold(n), broker.id, broker)
+	require.NoError(t, err)
+
+	// create a config with no delays for tests
+	config := ControllerConfig{
+		BaseStartDelay:                 0,
+		BaseHandleFirstBroadcastDelay:  0,
+		HandleSubsequentBroadcastDelay: 0,
+	}
+
+	return NewController(
+		broker.logger,
+		"dkg_test",
+		dkg,
+		seed,
+		broker,
+		config,
+		journal,
+	)
+}
+
+// TestDKGResume tests that a participant which is killed during phase 2 resumes
+// the DKG from its journal after restarting, and computes the same artifacts as
+// the other participants.
+func TestDKGResume(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitSecret, func(db *badger.DB) {
+		totalNodes := 5
+		phase1Duration := 2 * time.Second
+		phase2Duration := time.Second
+		phase3Duration := 500 * time.Millisecond
 
-		dkg, err := crypto.NewJointFeldman(n, signature.RandomBeaconThreshold(n), i, broker)
+		nodes := initNodes(t, totalNodes, phase1Duration, phase2Duration, phase3Duration)
+
+		// the first participant records its progress in a journal
+		store, err := bstorage.NewDKGState(db)
+		require.NoError(t, err)
+		seed := unittest.SeedFixture(20)
+		err = store.InsertDKGProgress("dkg_test", &dkgmodel.Progress{Seed: seed})
 		require.NoError(t, err)
+		journal, err := OpenJournal(store, "dkg_test")
+		require.NoError(t, err)
+
+		killed := nodes[0]
+		killed.broker.journal = journal
+		killed.controller = newController(t, totalNodes, killed.broker, seed, journal)
 
-		// create a config with no delays for tests
-		config := ControllerConfig{
-			BaseStartDelay:                 0,
-			BaseHandleFirstBroadcastDelay:  0,
-			HandleSubsequentBroadcastDelay: 0,
+		// start all the other nodes in parallel
+		for _, n := range nodes[1:] {
+			go func(node *node) {
+				err := node.run()
+				require.NoError(t, err)
+			}(n)
 		}
 
-		controller := NewController(
-			logger,
-			"dkg_test",
-			dkg,
-			seed,
-			broker,
-			config,
-		)
+		// run the first participant until the middle of phase 2
+		go func(controller *Controller) {
+			err := controller.Run()
+			require.NoError(t, err)
+		}(killed.controller)
+		time.Sleep(phase1Duration)
+		require.NoError(t, killed.controller.EndPhase1())
+		time.Sleep(phase2Duration / 2)
+
+		// kill it, and give its background worker time to stop
+		killed.controller.Shutdown()
+		time.Sleep(50 * time.Millisecond)
+
+		// restart it from the journal, which holds the phase 1 messages and the
+		// timeout ending phase 1
+		journal, err = OpenJournal(store, "dkg_test")
 		require.NoError(t, err)
+		require.True(t, journal.Resuming())
+		killed.broker.journal = journal
+		killed.controller = newController(t, totalNodes, killed.broker, seed, journal)
+		require.Equal(t, Phase2, killed.controller.GetState())
 
-		node := newNode(i, controller, phase1Duration, phase2Duration, phase3Duration)
-		nodes = append(nodes, node)
-	}
-
-	return nodes
+		go func(controller *Controller) {
+			err := controller.Run()
+			require.NoError(t, err)
+		}(killed.controller)
+		time.Sleep(phase2Duration / 2)
+		require.NoError(t, killed.controller.EndPhase2())
+		time.Sleep(phase3Duration)
+		require.NoError(t, killed.controller.End())
+
+		// Wait until all nodes are shutdown
+		wait(t, nodes, 5*phase1Duration)
+
+		// Check that all nodes, including the resumed one, have agreed on the
+		// same set of public keys
+		checkArtifacts(t, nodes, totalNodes)
+	})
 }
 
 // Wait for all the nodes to reach the SHUTDOWN state, or timeout.
@@ -0,0 +1,171 @@
+package dkg
+
+import (
+	"fmt"
+	"sync"
+
+	dkgmodel "github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/storage"
+)
+
+// Journal records the progress of the local participant in a DKG instance, so
+// that the DKG can be resumed after a restart. It is shared by the Controller,
+// which records the inputs of the Joint-Feldman state machine, and the Broker,
+// which records the broadcast messages it has read from and published to the
+// DKG smart contract.
+//
+// A nil Journal is valid and records nothing, it is used for DKG instances which
+// cannot be resumed.
+type Journal struct {
+	mu            sync.Mutex
+	store         storage.DKGState
+	dkgInstanceID string
+	progress      *dkgmodel.Progress
+	events        []*dkgmodel.Event // events recorded before the journal was opened
+	nextIndex     uint64            // index of the next event to record
+	replaying     bool              // whether the recorded events are being replayed
+}
+
+// OpenJournal opens the journal of the DKG instance with the given ID. The
+// progress of the instance must have been inserted into the store beforehand.
+// Events recorded by a previous run of the instance are loaded, so that they can
+// be replayed.
+func OpenJournal(store storage.DKGState, dkgInstanceID string) (*Journal, error) {
+	progress, err := store.RetrieveDKGProgress(dkgInstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dkg progress: %w", err)
+	}
+	events, err := store.RetrieveDKGEvents(dkgInstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dkg events: %w", err)
+	}
+
+	j := &Journal{
+		store:         store,
+		dkgInstanceID: dkgInstanceID,
+		progress:      progress,
+		events:        events,
+		nextIndex:     uint64(len(events)),
+	}
+	return j, nil
+}
+
+// Resuming returns true if a previous run of the DKG instance has recorded
+// events, which must be replayed before processing new inputs.
+func (j *Journal) Resuming() bool {
+	if j == nil {
+		return false
+	}
+	return len(j.events) > 0
+}
+
+// Events returns the events recorded by a previous run of the DKG instance.
+func (j *Journal) Events() []*dkgmodel.Event {
+	if j == nil {
+		return nil
+	}
+	return j.events
+}
+
+// SetReplaying sets whether the recorded events are being replayed. While
+// replaying, the messages produced by the Joint-Feldman state machine have
+// already been sent by the previous run and must not be sent again.
+func (j *Journal) SetReplaying(replaying bool) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.replaying = replaying
+}
+
+// Replaying returns true while the recorded events are being replayed.
+func (j *Journal) Replaying() bool {
+	if j == nil {
+		return false
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.replaying
+}
+
+// Record appends an event to the journal. Events must be recorded before they
+// are processed by the Joint-Feldman state machine.
+func (j *Journal) Record(event *dkgmodel.Event) error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	err := j.store.InsertDKGEvent(j.dkgInstanceID, j.nextIndex, event)
+	if err != nil {
+		return fmt.Errorf("could not insert dkg event %d: %w", j.nextIndex, err)
+	}
+	j.nextIndex++
+	return nil
+}
+
+// BroadcastOffset returns the index of the first broadcast message to read from
+// the DKG smart contract when resuming.
+func (j *Journal) BroadcastOffset() uint {
+	if j == nil {
+		return 0
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.progress.BroadcastOffset
+}
+
+// SetBroadcastOffset records the index of the first broadcast message to read
+// from the DKG smart contract when resuming.
+func (j *Journal) SetBroadcastOffset(offset uint) error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.progress.BroadcastOffset == offset {
+		return nil
+	}
+	progress := *j.progress
+	progress.BroadcastOffset = offset
+	return j.updateProgress(&progress)
+}
+
+// SentBroadcasts returns the number of our own broadcast messages which were
+// published to the DKG smart contract.
+func (j *Journal) SentBroadcasts() uint {
+	if j == nil {
+		return 0
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.progress.SentBroadcasts
+}
+
+// RecordSentBroadcast records that one of our own broadcast messages was
+// published to the DKG smart contract.
+func (j *Journal) RecordSentBroadcast() error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	progress := *j.progress
+	progress.SentBroadcasts++
+	return j.updateProgress(&progress)
+}
+
+// updateProgress persists the given progress and replaces the cached one.
+// Must be called while holding the lock.
+func (j *Journal) updateProgress(progress *dkgmodel.Progress) error {
+	err := j.store.UpdateDKGProgress(j.dkgInstanceID, progress)
+	if err != nil {
+		return fmt.Errorf("could not update dkg progress: %w", err)
+	}
+	j.progress = progress
+	return nil
+}
@@ -150,7 +150,7 @@ func (f *Finalizer) MakeFinal(blockID flow.Identifier) error {
 				Guarantee: flow.CollectionGuarantee{
 					CollectionID:     payload.Collection.ID(),
 					ReferenceBlockID: payload.ReferenceBlockID,
-					SignerIDs:        step.ParentVoterIDs,
+					SignerIndices:    step.ParentVoterIndices,
 					Signature:        step.ParentVoterSigData,
 				},
 			})
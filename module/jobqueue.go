@@ -36,6 +36,10 @@ type JobConsumer interface {
 	// the next job from the job queue if there are workers available. It returns the last processed job index.
 	NotifyJobIsDone(JobID) uint64
 
+	// NotifyJobFailed let the consumer know a job could not be processed, so that consumer either
+	// retries it, or records it as failed and moves on to the next job. It returns the last processed job index.
+	NotifyJobFailed(JobID, error) uint64
+
 	// Size returns the number of processing jobs in consumer.
 	Size() uint
 }
@@ -62,9 +66,12 @@ type JobQueue interface {
 // ProcessingNotifier is for the worker's underneath engine to report an entity
 // has been processed without knowing the job queue.
 // It is a callback so that the worker can convert the entity id into a job
-// id, and notify the consumer about a finished job.
+// id, and notify the consumer about a finished or failed job.
 //
 // At the current version, entities used in this interface are chunks and blocks ids.
 type ProcessingNotifier interface {
 	Notify(entityID flow.Identifier)
+
+	// NotifyFailure reports the entity could not be processed due to the given error.
+	NotifyFailure(entityID flow.Identifier, err error)
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 	"go.uber.org/atomic"
@@ -29,7 +30,11 @@ type Consumer struct {
 	worker Worker // to process job and notify consumer when finish processing a job
 
 	// Config
-	maxProcessing uint64 // max number of jobs to be processed concurrently
+	maxProcessing uint64      // max number of jobs to be processed concurrently
+	retry         RetryConfig // how failed jobs are retried
+
+	// Dead-letter queue
+	failedJobs storage.FailedJobs // to record the jobs that could not be processed even after retrying
 
 	// State Variables
 	running bool // a signal to control whether to start processing more jobs. Useful for waiting
@@ -37,11 +42,25 @@ type Consumer struct {
 	isChecking *atomic.Bool // allow only one process checking job processable
 	// are ready, and stop when shutting down.
 	runningJobs sync.WaitGroup // to wait for all existing jobs to finish for graceful shutdown
+	quit        chan struct{}  // closed on shutdown to cancel the pending retries
 
 	processedIndex   uint64
 	processings      map[uint64]*jobStatus   // keep track of the status of each on going job
 	processingsIndex map[module.JobID]uint64 // lookup the index of the job, useful when fast forwarding the
 	// `processed` variable
+	requeued map[module.JobID]*requeuedJob // keep track of the re-queued failed jobs being processed
+}
+
+// ConsumerOption configures optional behaviour of the Consumer.
+type ConsumerOption func(*Consumer)
+
+// WithRetries makes the consumer retry the failed jobs according to the given config, and record the
+// jobs which exhausted their attempts in the given storage.
+func WithRetries(config RetryConfig, failedJobs storage.FailedJobs) ConsumerOption {
+	return func(c *Consumer) {
+		c.retry = config
+		c.failedJobs = failedJobs
+	}
 }
 
 func NewConsumer(
@@ -50,8 +69,9 @@ func NewConsumer(
 	progress storage.ConsumerProgress,
 	worker Worker,
 	maxProcessing uint64,
+	opts ...ConsumerOption,
 ) *Consumer {
-	return &Consumer{
+	consumer := &Consumer{
 		log: log.With().Str("sub_module", "job_queue").Logger(),
 
 		// store dependency
@@ -61,14 +81,23 @@ func NewConsumer(
 
 		// update config
 		maxProcessing: maxProcessing,
+		retry:         RetryConfig{MaxAttempts: 1}, // no retries by default
 
 		// init state variables
 		running:          false,
 		isChecking:       atomic.NewBool(false),
+		quit:             make(chan struct{}),
 		processedIndex:   0,
 		processings:      make(map[uint64]*jobStatus),
 		processingsIndex: make(map[module.JobID]uint64),
+		requeued:         make(map[module.JobID]*requeuedJob),
 	}
+
+	for _, apply := range opts {
+		apply(consumer)
+	}
+
+	return consumer
 }
 
 // Start starts consuming the jobs from the job queue.
@@ -81,6 +110,7 @@ func (c *Consumer) Start(defaultIndex uint64) error {
 	}
 
 	c.running = true
+	c.quit = make(chan struct{})
 
 	// on startup, sync with storage for the processed index
 	// to ensure the consistency
@@ -119,6 +149,9 @@ func (c *Consumer) Start(defaultIndex uint64) error {
 // Note, it won't stop the existing worker from finishing their job
 func (c *Consumer) Stop() {
 	c.mu.Lock()
+	if c.running {
+		close(c.quit)
+	}
 	c.running = false
 	// not to use `defer`, otherwise runningJobs.Wait will hold the lock and cause deadlock
 	c.mu.Unlock()
@@ -140,6 +173,76 @@ func (c *Consumer) NotifyJobIsDone(jobID module.JobID) uint64 {
 	defer c.mu.Unlock()
 	c.log.Debug().Str("job_id", string(jobID)).Msg("finishing job")
 
+	if requeued, ok := c.requeued[jobID]; ok {
+		// a re-queued failed job has been processed, it is no longer failed
+		delete(c.requeued, jobID)
+		err := c.failedJobs.Remove(requeued.index)
+		if err != nil {
+			c.log.Error().Err(err).Uint64("index", requeued.index).Msg("could not remove re-queued job from failed jobs")
+		}
+		return c.processedIndex
+	}
+
+	if c.doneJob(jobID) {
+		c.checkProcessable()
+	}
+
+	return c.processedIndex
+}
+
+// NotifyJobFailed let the consumer know a job could not be processed. The job is retried with
+// backoff until it runs out of attempts, after which it is recorded as failed and treated as done,
+// so that the consumer moves on to the next jobs. It returns the last processed job index.
+func (c *Consumer) NotifyJobFailed(jobID module.JobID, jobErr error) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lg := c.log.With().Str("job_id", string(jobID)).Logger()
+
+	if requeued, ok := c.requeued[jobID]; ok {
+		requeued.attempts++
+		if c.shouldRetry(requeued.attempts) {
+			if !c.running {
+				// the job remains re-queued, so it is processed again after restarting
+				lg.Warn().Err(jobErr).Msg("re-queued job failed while stopping, retrying it after restarting")
+				delete(c.requeued, jobID)
+				return c.processedIndex
+			}
+			lg.Warn().Err(jobErr).Uint("attempts", requeued.attempts).Msg("re-queued job failed, retrying")
+			c.retryJob(requeued.job, requeued.attempts)
+			return c.processedIndex
+		}
+
+		delete(c.requeued, jobID)
+		c.recordFailedJob(requeued.index, jobID, jobErr, requeued.attempts)
+		return c.processedIndex
+	}
+
+	index, ok := c.processingsIndex[jobID]
+	if !ok {
+		// job must has been processed
+		return c.processedIndex
+	}
+
+	status, ok := c.processings[index]
+	if !ok || status.done {
+		return c.processedIndex
+	}
+
+	status.attempts++
+	if c.shouldRetry(status.attempts) {
+		if !c.running {
+			// the job is not done, so the processed index does not move past it and it is
+			// processed again after restarting
+			lg.Warn().Err(jobErr).Msg("job failed while stopping, retrying it after restarting")
+			return c.processedIndex
+		}
+		lg.Warn().Err(jobErr).Uint("attempts", status.attempts).Msg("job failed, retrying")
+		c.retryJob(status.job, status.attempts)
+		return c.processedIndex
+	}
+
+	c.recordFailedJob(index, jobID, jobErr, status.attempts)
 	if c.doneJob(jobID) {
 		c.checkProcessable()
 	}
@@ -147,6 +250,60 @@ func (c *Consumer) NotifyJobIsDone(jobID module.JobID) uint64 {
 	return c.processedIndex
 }
 
+// shouldRetry returns true if a job which failed for the given number of attempts should be retried.
+func (c *Consumer) shouldRetry(attempts uint) bool {
+	return attempts < c.retry.MaxAttempts
+}
+
+// retryJob runs the job again once the backoff for the given number of failed attempts has passed.
+// Pending retries are dropped on shutdown, the job is then processed again after restarting since
+// the processed index has not moved past it.
+func (c *Consumer) retryJob(job module.Job, attempts uint) {
+	backoff := c.retry.backoff(attempts)
+	quit := c.quit
+
+	c.runningJobs.Add(1)
+	go func() {
+		defer c.runningJobs.Done()
+
+		select {
+		case <-time.After(backoff):
+		case <-quit:
+			return
+		}
+
+		c.work(job)
+	}()
+}
+
+// recordFailedJob records a job that exhausted its attempts in the failed jobs storage.
+func (c *Consumer) recordFailedJob(index uint64, jobID module.JobID, jobErr error, attempts uint) {
+	lg := c.log.With().
+		Str("job_id", string(jobID)).
+		Uint64("index", index).
+		Uint("attempts", attempts).
+		Logger()
+
+	if c.failedJobs == nil {
+		lg.Error().Err(jobErr).Msg("job failed, skipping it")
+		return
+	}
+
+	err := c.failedJobs.Store(&storage.FailedJob{
+		Index:    index,
+		JobID:    string(jobID),
+		Error:    jobErr.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now(),
+		Requeued: false,
+	})
+	if err != nil {
+		lg.Fatal().Err(err).Msg("could not record failed job")
+	}
+
+	lg.Error().Err(jobErr).Msg("job failed, recorded it as failed job")
+}
+
 // Check allows the job publisher to notify the consumer that a new job has been added, so that
 // the consumer can check if the job is processable
 // since multiple checks at the same time are unnecessary, we could only keep one check by checking.
@@ -177,6 +334,13 @@ func (c *Consumer) checkProcessable() {
 		return
 	}
 
+	requeuedCount, err := c.runRequeued()
+	if err != nil {
+		c.log.Error().Err(err).Msg("failed to check re-queued jobs")
+		return
+	}
+	processingCount += requeuedCount
+
 	if processingCount > 0 {
 		c.log.Info().Int64("processing", processingCount).Msg("processing jobs")
 	} else {
@@ -209,15 +373,13 @@ func (c *Consumer) run() (int64, error) {
 		c.processingsIndex[jobID] = indexedJob.index
 		c.processings[indexedJob.index] = &jobStatus{
 			jobID: jobID,
+			job:   indexedJob.job,
 			done:  false,
 		}
 
 		c.runningJobs.Add(1)
 		go func(j *jobAtIndex) {
-			err := c.worker.Run(j.job)
-			if err != nil {
-				c.log.Fatal().Err(err).Msg("could not run the job")
-			}
+			c.work(j.job)
 			c.runningJobs.Done()
 		}(indexedJob)
 	}
@@ -242,6 +404,56 @@ func (c *Consumer) run() (int64, error) {
 	return int64(len(processables)), nil
 }
 
+// runRequeued processes the failed jobs which have been re-queued, e.g. by an operator.
+func (c *Consumer) runRequeued() (int64, error) {
+	if !c.running || c.failedJobs == nil {
+		return 0, nil
+	}
+
+	requeuedJobs, err := c.failedJobs.Requeued()
+	if err != nil {
+		return 0, fmt.Errorf("could not read re-queued jobs: %w", err)
+	}
+
+	count := int64(0)
+	for _, failed := range requeuedJobs {
+		jobID := module.JobID(failed.JobID)
+		if _, ok := c.requeued[jobID]; ok {
+			// already being processed
+			continue
+		}
+
+		job, err := c.jobs.AtIndex(failed.Index)
+		if err != nil {
+			return count, fmt.Errorf("could not read re-queued job at index %v: %w", failed.Index, err)
+		}
+
+		c.requeued[jobID] = &requeuedJob{
+			jobAtIndex: jobAtIndex{
+				job:   job,
+				index: failed.Index,
+			},
+		}
+		count++
+
+		c.runningJobs.Add(1)
+		go func(j module.Job) {
+			c.work(j)
+			c.runningJobs.Done()
+		}(job)
+	}
+
+	return count, nil
+}
+
+// work runs the job with the worker.
+func (c *Consumer) work(job module.Job) {
+	err := c.worker.Run(job)
+	if err != nil {
+		c.log.Fatal().Err(err).Msg("could not run the job")
+	}
+}
+
 func (c *Consumer) processableJobs() ([]*jobAtIndex, uint64, error) {
 	processables, processedTo, err := processableJobs(
 		c.jobs,
@@ -350,6 +562,13 @@ type jobAtIndex struct {
 }
 
 type jobStatus struct {
-	jobID module.JobID
-	done  bool
+	jobID    module.JobID
+	job      module.Job
+	attempts uint // number of failed attempts
+	done     bool
+}
+
+type requeuedJob struct {
+	jobAtIndex
+	attempts uint // number of failed attempts since re-queued
 }
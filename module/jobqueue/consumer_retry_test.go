@@ -0,0 +1,243 @@
+package jobqueue_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	badgerdb "github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/jobqueue"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestConsumerRetries evaluates that failed jobs are retried with backoff, and recorded as failed
+// once they run out of attempts.
+func TestConsumerRetries(t *testing.T) {
+	t.Parallel()
+
+	// [+1, 1x, 1*] => [0#, 1#]
+	// when job 1 fails once and succeeds on retry, it will be marked as processed
+	t.Run("testRetrySucceeds", testRetrySucceeds)
+
+	// [+1, +2, 1x, 1x, 1x, 2*] => [0#, 1#, 2#]
+	// when job 1 runs out of attempts, it will be recorded as failed and marked as processed
+	t.Run("testRetriesExhausted", testRetriesExhausted)
+
+	// [+1, 1x, 1x, 1x, requeue 1, 1*] => [0#, 1#]
+	// when failed job 1 is re-queued, it will be processed again and removed from the failed jobs
+	t.Run("testRequeueFailedJob", testRequeueFailedJob)
+
+	// [+1, 1x, Stop] => [0#, 1!]
+	// when the consumer is stopped, pending retries are dropped
+	t.Run("testStopWithPendingRetry", testStopWithPendingRetry)
+
+	// [+1, Stop, 1x, Start, 1*] => [0#, 1!] => [0#, 1#]
+	// when job 1 fails while the consumer is stopping, it is processed again after restarting
+	t.Run("testFailWhileStopping", testFailWhileStopping)
+}
+
+func testRetrySucceeds(t *testing.T) {
+	runWithRetries(t, func(c *jobqueue.Consumer, cp storage.ConsumerProgress, failed storage.FailedJobs, w *mockWorker, j *jobqueue.MockJobs) {
+		attempts := 0
+		w.fn = func(job Job) {
+			attempts++
+			if attempts == 1 {
+				go c.NotifyJobFailed(job.ID(), errors.New("transient error"))
+				return
+			}
+			go c.NotifyJobIsDone(job.ID())
+		}
+
+		require.NoError(t, c.Start(DefaultIndex))
+		require.NoError(t, j.PushOne()) // +1
+		c.Check()
+
+		require.Eventually(t, func() bool {
+			processed, err := cp.ProcessedIndex()
+			return err == nil && processed == 1
+		}, time.Second, 10*time.Millisecond)
+
+		w.AssertCalled(t, []int64{1, 1})
+
+		jobs, err := failed.All()
+		require.NoError(t, err)
+		require.Empty(t, jobs)
+	})
+}
+
+func testRetriesExhausted(t *testing.T) {
+	runWithRetries(t, func(c *jobqueue.Consumer, cp storage.ConsumerProgress, failed storage.FailedJobs, w *mockWorker, j *jobqueue.MockJobs) {
+		w.fn = func(job Job) {
+			if job.ID() == jobqueue.JobIDAtIndex(1) {
+				go c.NotifyJobFailed(job.ID(), errors.New("permanent error"))
+				return
+			}
+			go c.NotifyJobIsDone(job.ID())
+		}
+
+		require.NoError(t, c.Start(DefaultIndex))
+		require.NoError(t, j.PushN(2)) // +1, +2
+		c.Check()
+
+		require.Eventually(t, func() bool {
+			processed, err := cp.ProcessedIndex()
+			return err == nil && processed == 2
+		}, time.Second, 10*time.Millisecond)
+
+		w.AssertCalled(t, []int64{1, 1, 1, 2})
+
+		job, err := failed.ByIndex(1)
+		require.NoError(t, err)
+		require.Equal(t, string(jobqueue.JobIDAtIndex(1)), job.JobID)
+		require.Equal(t, "permanent error", job.Error)
+		require.Equal(t, uint(3), job.Attempts)
+		require.False(t, job.Requeued)
+
+		_, err = failed.ByIndex(2)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
+
+func testRequeueFailedJob(t *testing.T) {
+	runWithRetries(t, func(c *jobqueue.Consumer, cp storage.ConsumerProgress, failed storage.FailedJobs, w *mockWorker, j *jobqueue.MockJobs) {
+		fail := true
+		w.fn = func(job Job) {
+			if fail {
+				go c.NotifyJobFailed(job.ID(), errors.New("permanent error"))
+				return
+			}
+			go c.NotifyJobIsDone(job.ID())
+		}
+
+		require.NoError(t, c.Start(DefaultIndex))
+		require.NoError(t, j.PushOne()) // +1
+		c.Check()
+
+		require.Eventually(t, func() bool {
+			_, err := failed.ByIndex(1)
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+		assertProcessed(t, cp, 1)
+
+		// the cause of the failure is fixed, and the job re-queued
+		w.Lock()
+		fail = false
+		w.Unlock()
+		require.NoError(t, failed.Requeue(1))
+		c.Check()
+
+		require.Eventually(t, func() bool {
+			_, err := failed.ByIndex(1)
+			return errors.Is(err, storage.ErrNotFound)
+		}, time.Second, 10*time.Millisecond)
+
+		w.AssertCalled(t, []int64{1, 1, 1, 1})
+		assertProcessed(t, cp, 1)
+	})
+}
+
+func testStopWithPendingRetry(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badgerdb.DB) {
+		jobs := jobqueue.NewMockJobs()
+		worker := newMockWorker()
+		progress := badger.NewConsumerProgress(db, ConsumerTag)
+		failed := badger.NewFailedJobs(db, ConsumerTag)
+		config := jobqueue.RetryConfig{
+			MaxAttempts: 3,
+			Backoff:     time.Hour,
+			MaxBackoff:  time.Hour,
+		}
+		c := jobqueue.NewConsumer(unittest.Logger(), jobs, progress, worker, 3, jobqueue.WithRetries(config, failed))
+		worker.fn = func(job Job) {
+			go c.NotifyJobFailed(job.ID(), errors.New("transient error"))
+		}
+
+		require.NoError(t, c.Start(DefaultIndex))
+		require.NoError(t, jobs.PushOne()) // +1
+		c.Check()
+
+		time.Sleep(100 * time.Millisecond)
+
+		unittest.RequireReturnsBefore(t, c.Stop, time.Second, "stop should not wait for pending retries")
+
+		worker.AssertCalled(t, []int64{1})
+		assertProcessed(t, progress, 0)
+	})
+}
+
+func testFailWhileStopping(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badgerdb.DB) {
+		jobs := jobqueue.NewMockJobs()
+		progress := badger.NewConsumerProgress(db, ConsumerTag)
+		failed := badger.NewFailedJobs(db, ConsumerTag)
+		config := jobqueue.RetryConfig{
+			MaxAttempts: 3,
+			Backoff:     time.Millisecond,
+			MaxBackoff:  10 * time.Millisecond,
+		}
+
+		worker := newMockWorker()
+		c := jobqueue.NewConsumer(unittest.Logger(), jobs, progress, worker, 3, jobqueue.WithRetries(config, failed))
+		started := make(chan struct{})
+		stopping := make(chan struct{})
+		worker.fn = func(job Job) {
+			close(started)
+			<-stopping
+			c.NotifyJobFailed(job.ID(), errors.New("interrupted"))
+		}
+
+		require.NoError(t, c.Start(DefaultIndex))
+		require.NoError(t, jobs.PushOne()) // +1
+		c.Check()
+
+		unittest.RequireCloseBefore(t, started, time.Second, "job was not processed")
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			close(stopping)
+		}()
+		unittest.RequireReturnsBefore(t, c.Stop, time.Second, "could not stop consumer")
+
+		// the job was neither recorded as failed nor marked as processed
+		worker.AssertCalled(t, []int64{1})
+		_, err := failed.ByIndex(1)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		assertProcessed(t, progress, 0)
+
+		// after restarting, the job is processed again
+		restarted := newMockWorker()
+		c = jobqueue.NewConsumer(unittest.Logger(), jobs, progress, restarted, 3, jobqueue.WithRetries(config, failed))
+		restarted.fn = func(job Job) {
+			go c.NotifyJobIsDone(job.ID())
+		}
+		require.NoError(t, c.Start(DefaultIndex))
+		defer c.Stop()
+
+		require.Eventually(t, func() bool {
+			processed, err := progress.ProcessedIndex()
+			return err == nil && processed == 1
+		}, time.Second, 10*time.Millisecond)
+		restarted.AssertCalled(t, []int64{1})
+	})
+}
+
+func runWithRetries(t testing.TB, runTestWith func(*jobqueue.Consumer, storage.ConsumerProgress, storage.FailedJobs, *mockWorker, *jobqueue.MockJobs)) {
+	unittest.RunWithBadgerDB(t, func(db *badgerdb.DB) {
+		jobs := jobqueue.NewMockJobs()
+		worker := newMockWorker()
+		progress := badger.NewConsumerProgress(db, ConsumerTag)
+		failed := badger.NewFailedJobs(db, ConsumerTag)
+		config := jobqueue.RetryConfig{
+			MaxAttempts: 3,
+			Backoff:     time.Millisecond,
+			MaxBackoff:  10 * time.Millisecond,
+		}
+		log := unittest.Logger().With().Str("module", "consumer").Logger()
+		consumer := jobqueue.NewConsumer(log, jobs, progress, worker, 3, jobqueue.WithRetries(config, failed))
+		runTestWith(consumer, progress, failed, worker, jobs)
+		consumer.Stop()
+	})
+}
@@ -158,6 +158,22 @@ func TestProcessedIndexDeletion(t *testing.T) {
 	})
 }
 
+// Test the backoff before retrying a failed job doubles with every attempt, up to the max backoff
+func TestRetryBackoff(t *testing.T) {
+	config := RetryConfig{
+		MaxAttempts: 10,
+		Backoff:     time.Second,
+		MaxBackoff:  10 * time.Second,
+	}
+
+	require.Equal(t, time.Second, config.backoff(1))
+	require.Equal(t, 2*time.Second, config.backoff(2))
+	require.Equal(t, 4*time.Second, config.backoff(3))
+	require.Equal(t, 8*time.Second, config.backoff(4))
+	require.Equal(t, 10*time.Second, config.backoff(5))
+	require.Equal(t, 10*time.Second, config.backoff(100))
+}
+
 func assertJobs(t *testing.T, expectedIndex []uint64, jobsToRun []*jobAtIndex) {
 	actualIndex := make([]uint64, 0, len(jobsToRun))
 	for _, jobAtIndex := range jobsToRun {
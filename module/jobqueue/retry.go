@@ -0,0 +1,38 @@
+package jobqueue
+
+import (
+	"time"
+)
+
+const (
+	DefaultMaxAttempts = uint(5)
+	DefaultBackoff     = time.Second
+	DefaultMaxBackoff  = time.Minute
+)
+
+// RetryConfig configures how the consumer retries jobs that could not be processed.
+type RetryConfig struct {
+	MaxAttempts uint          // max number of attempts to process a job, including the first one
+	Backoff     time.Duration // delay before the first retry, doubled for every further retry
+	MaxBackoff  time.Duration // upper bound of the delay before a retry
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// backoff returns the delay before retrying a job which failed the given number of attempts.
+func (c RetryConfig) backoff(attempts uint) time.Duration {
+	backoff := c.Backoff
+	for i := uint(1); i < attempts && backoff < c.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > c.MaxBackoff {
+		return c.MaxBackoff
+	}
+	return backoff
+}
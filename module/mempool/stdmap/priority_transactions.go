@@ -0,0 +1,236 @@
+package stdmap
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
+)
+
+// TransactionPriority computes the priority of a transaction. Transactions with a higher priority
+// are returned first by PriorityTransactions.All, and are evicted last when the pool is full.
+type TransactionPriority func(tx *flow.TransactionBody) uint64
+
+// NoTransactionPriority assigns the same priority to all transactions, so that they are ordered
+// by arrival time.
+func NoTransactionPriority(*flow.TransactionBody) uint64 {
+	return 0
+}
+
+// PriorityByPayerAndGas prioritizes transactions paid by one of the priority payers over all other
+// transactions. Within each of the two groups, transactions with a higher gas limit come first.
+func PriorityByPayerAndGas(priorityPayers ...flow.Address) TransactionPriority {
+	lookup := make(map[flow.Address]struct{}, len(priorityPayers))
+	for _, payer := range priorityPayers {
+		lookup[payer] = struct{}{}
+	}
+	return func(tx *flow.TransactionBody) uint64 {
+		// gas limits are bounded far below 2^63, so the top bit is free to mark priority payers
+		priority := tx.GasLimit &^ (1 << 63)
+		if _, ok := lookup[tx.Payer]; ok {
+			priority |= 1 << 63
+		}
+		return priority
+	}
+}
+
+var _ mempool.Transactions = (*PriorityTransactions)(nil)
+
+// PriorityTransactions implements the transactions memory pool of the collection nodes, ordered
+// by a configurable priority. All returns the transactions with the highest priority first, in
+// arrival order for equal priorities. Once the pool exceeds its limit, the transactions with the
+// lowest priority are evicted, and among those the most recently added ones.
+type PriorityTransactions struct {
+	sync.RWMutex
+	limit    uint
+	priority TransactionPriority
+	items    map[flow.Identifier]*priorityItem
+	queue    priorityQueue
+	sequence uint64
+}
+
+// NewPriorityTransactions creates a new priority-ordered memory pool for transactions, holding at
+// most limit transactions.
+func NewPriorityTransactions(limit uint, priority TransactionPriority) *PriorityTransactions {
+	return &PriorityTransactions{
+		limit:    limit,
+		priority: priority,
+		items:    make(map[flow.Identifier]*priorityItem),
+	}
+}
+
+// Has checks whether the transaction with the given ID is in the mempool.
+func (t *PriorityTransactions) Has(txID flow.Identifier) bool {
+	t.RLock()
+	defer t.RUnlock()
+	_, exists := t.items[txID]
+	return exists
+}
+
+// Add adds a transaction to the mempool. It returns false if the transaction was already in the
+// mempool, or if it was evicted right away because the pool is full of transactions with a higher
+// priority.
+func (t *PriorityTransactions) Add(tx *flow.TransactionBody) bool {
+	txID := tx.ID()
+	priority := t.priority(tx)
+
+	t.Lock()
+	defer t.Unlock()
+
+	if _, exists := t.items[txID]; exists {
+		return false
+	}
+
+	item := &priorityItem{
+		txID:     txID,
+		tx:       tx,
+		priority: priority,
+		sequence: t.sequence,
+	}
+	t.sequence++
+	t.items[txID] = item
+	heap.Push(&t.queue, item)
+
+	// evict the lowest priority transactions until we are within the limit again
+	added := true
+	for uint(len(t.items)) > t.limit {
+		evicted := heap.Pop(&t.queue).(*priorityItem)
+		delete(t.items, evicted.txID)
+		if evicted == item {
+			added = false
+		}
+	}
+
+	return added
+}
+
+// Rem removes the transaction with the given ID from the mempool.
+func (t *PriorityTransactions) Rem(txID flow.Identifier) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	item, exists := t.items[txID]
+	if !exists {
+		return false
+	}
+	heap.Remove(&t.queue, item.index)
+	delete(t.items, txID)
+	return true
+}
+
+// ByID returns the transaction with the given ID from the mempool.
+func (t *PriorityTransactions) ByID(txID flow.Identifier) (*flow.TransactionBody, bool) {
+	t.RLock()
+	defer t.RUnlock()
+
+	item, exists := t.items[txID]
+	if !exists {
+		return nil, false
+	}
+	return item.tx, true
+}
+
+// Size returns the number of transactions in the mempool.
+func (t *PriorityTransactions) Size() uint {
+	t.RLock()
+	defer t.RUnlock()
+	return uint(len(t.items))
+}
+
+// Limit returns the maximum number of transactions in the mempool.
+func (t *PriorityTransactions) Limit() uint {
+	return t.limit
+}
+
+// All returns all transactions from the mempool, with the highest priority first. Transactions
+// with equal priority are returned in the order they were added.
+func (t *PriorityTransactions) All() []*flow.TransactionBody {
+	t.RLock()
+	items := make([]*priorityItem, 0, len(t.items))
+	for _, item := range t.items {
+		items = append(items, item)
+	}
+	t.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[j].lowerThan(items[i])
+	})
+
+	txs := make([]*flow.TransactionBody, 0, len(items))
+	for _, item := range items {
+		txs = append(txs, item.tx)
+	}
+	return txs
+}
+
+// Clear removes all transactions from the mempool.
+func (t *PriorityTransactions) Clear() {
+	t.Lock()
+	defer t.Unlock()
+	t.items = make(map[flow.Identifier]*priorityItem)
+	t.queue = nil
+}
+
+// Hash returns a fingerprint of the contents of the mempool.
+func (t *PriorityTransactions) Hash() flow.Identifier {
+	t.RLock()
+	defer t.RUnlock()
+
+	txIDs := make([]flow.Identifier, 0, len(t.items))
+	for txID := range t.items {
+		txIDs = append(txIDs, txID)
+	}
+	return flow.MerkleRoot(txIDs...)
+}
+
+// priorityItem is a transaction in the priority mempool.
+type priorityItem struct {
+	txID     flow.Identifier
+	tx       *flow.TransactionBody
+	priority uint64
+	sequence uint64 // order in which the transaction was added
+	index    int    // position in the priority queue, maintained by the heap
+}
+
+// lowerThan returns true if the item should be evicted before the other item, which is the case
+// if it has a lower priority, or the same priority and was added later.
+func (i *priorityItem) lowerThan(other *priorityItem) bool {
+	if i.priority != other.priority {
+		return i.priority < other.priority
+	}
+	return i.sequence > other.sequence
+}
+
+// priorityQueue implements heap.Interface, with the next transaction to evict at the root.
+type priorityQueue []*priorityItem
+
+func (q priorityQueue) Len() int {
+	return len(q)
+}
+
+func (q priorityQueue) Less(i, j int) bool {
+	return q[i].lowerThan(q[j])
+}
+
+func (q priorityQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *priorityQueue) Push(x interface{}) {
+	item := x.(*priorityItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *priorityQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,117 @@
+package stdmap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPriorityTransactionPool(t *testing.T) {
+	gasPriority := func(tx *flow.TransactionBody) uint64 { return tx.GasLimit }
+
+	withGas := func(gas uint64) *flow.TransactionBody {
+		tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+			tx.GasLimit = gas
+		})
+		return &tx
+	}
+
+	t.Run("should return transactions in priority order", func(t *testing.T) {
+		pool := stdmap.NewPriorityTransactions(10, gasPriority)
+		low, high, mid := withGas(10), withGas(30), withGas(20)
+		require.True(t, pool.Add(low))
+		require.True(t, pool.Add(high))
+		require.True(t, pool.Add(mid))
+
+		assert.Equal(t, []*flow.TransactionBody{high, mid, low}, pool.All())
+	})
+
+	t.Run("should return equal priorities in arrival order", func(t *testing.T) {
+		pool := stdmap.NewPriorityTransactions(10, stdmap.NoTransactionPriority)
+		var txs []*flow.TransactionBody
+		for i := 0; i < 5; i++ {
+			tx := withGas(uint64(i))
+			txs = append(txs, tx)
+			require.True(t, pool.Add(tx))
+		}
+
+		assert.Equal(t, txs, pool.All())
+	})
+
+	t.Run("should not add duplicates", func(t *testing.T) {
+		pool := stdmap.NewPriorityTransactions(10, gasPriority)
+		tx := withGas(10)
+		require.True(t, pool.Add(tx))
+		assert.False(t, pool.Add(tx))
+		assert.Equal(t, uint(1), pool.Size())
+	})
+
+	t.Run("should evict lowest priority when full", func(t *testing.T) {
+		pool := stdmap.NewPriorityTransactions(2, gasPriority)
+		low, mid, high := withGas(10), withGas(20), withGas(30)
+		require.True(t, pool.Add(low))
+		require.True(t, pool.Add(mid))
+
+		// adding a higher priority transaction evicts the lowest one
+		require.True(t, pool.Add(high))
+		assert.False(t, pool.Has(low.ID()))
+		assert.Equal(t, []*flow.TransactionBody{high, mid}, pool.All())
+
+		// adding a lower priority transaction to a full pool is rejected
+		assert.False(t, pool.Add(withGas(5)))
+		assert.Equal(t, []*flow.TransactionBody{high, mid}, pool.All())
+	})
+
+	t.Run("should evict most recent among equal priorities", func(t *testing.T) {
+		pool := stdmap.NewPriorityTransactions(2, stdmap.NoTransactionPriority)
+		first, second, third := withGas(1), withGas(2), withGas(3)
+		require.True(t, pool.Add(first))
+		require.True(t, pool.Add(second))
+		assert.False(t, pool.Add(third))
+		assert.Equal(t, []*flow.TransactionBody{first, second}, pool.All())
+	})
+
+	t.Run("should remove and clear", func(t *testing.T) {
+		pool := stdmap.NewPriorityTransactions(10, gasPriority)
+		low, mid, high := withGas(10), withGas(20), withGas(30)
+		require.True(t, pool.Add(low))
+		require.True(t, pool.Add(mid))
+		require.True(t, pool.Add(high))
+
+		assert.True(t, pool.Rem(mid.ID()))
+		assert.False(t, pool.Rem(mid.ID()))
+		got, exists := pool.ByID(high.ID())
+		assert.True(t, exists)
+		assert.Equal(t, high, got)
+		assert.Equal(t, []*flow.TransactionBody{high, low}, pool.All())
+
+		pool.Clear()
+		assert.Equal(t, uint(0), pool.Size())
+		assert.Empty(t, pool.All())
+	})
+}
+
+func TestPriorityByPayerAndGas(t *testing.T) {
+	payer := unittest.RandomAddressFixture()
+	priority := stdmap.PriorityByPayerAndGas(payer)
+
+	prioritized := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.Payer = payer
+		tx.GasLimit = 1
+	})
+	other := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.GasLimit = 1000
+	})
+	otherLow := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.GasLimit = 10
+	})
+
+	// priority payers always come first, then higher gas limits
+	assert.Greater(t, priority(&prioritized), priority(&other))
+	assert.Greater(t, priority(&other), priority(&otherLow))
+}
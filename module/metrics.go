@@ -83,12 +83,21 @@ type ComplianceMetrics interface {
 	CurrentDKGPhase2FinalView(view uint64)
 	CurrentDKGPhase3FinalView(view uint64)
 	EpochEmergencyFallbackTriggered()
+	EpochEmergencyFallbackRecovered()
 }
 
 type CleanerMetrics interface {
 	RanGC(took time.Duration)
 }
 
+type PrunerMetrics interface {
+	// PrunedHeight reports the height up to which historical data has been pruned
+	PrunedHeight(height uint64)
+	// PrunedBatch reports a pruned batch of heights, with the number and the estimated
+	// size of the removed entries
+	PrunedBatch(entries uint64, bytes uint64, took time.Duration)
+}
+
 type CacheMetrics interface {
 	// report the total number of cached items
 	CacheEntries(resource string, entries uint)
@@ -130,6 +139,15 @@ type HotstuffMetrics interface {
 	// SetTimeout sets the current timeout duration
 	SetTimeout(duration time.Duration)
 
+	// SetMinTimeout sets the current lower bound of the timeout duration
+	SetMinTimeout(duration time.Duration)
+
+	// SetBlockRateDelay sets the current delay for broadcasting block proposals
+	SetBlockRateDelay(delay time.Duration)
+
+	// CountTimeoutConfigUpdate reports the number of runtime updates of the timeout configuration.
+	CountTimeoutConfigUpdate()
+
 	// CommitteeProcessingDuration measures the time which the HotStuff's core logic
 	// spends in the hotstuff.Committee component, i.e. the time determining consensus
 	// committee relations.
@@ -179,6 +197,13 @@ type ConsensusMetrics interface {
 	// EmergencySeal increments the number of seals that were created in emergency mode
 	EmergencySeal()
 
+	// EmergencySealConstructed increments the number of seals constructed by the sealing engine in
+	// emergency mode, i.e. without the required approvals
+	EmergencySealConstructed()
+
+	// EmergencySealingActive reports whether emergency sealing is active
+	EmergencySealingActive(active bool)
+
 	// OnReceiptProcessingDuration records the number of seconds spent processing a receipt
 	OnReceiptProcessingDuration(duration time.Duration)
 
@@ -412,6 +437,21 @@ type TransactionMetrics interface {
 	TransactionSubmissionFailed()
 }
 
+type AccessMetrics interface {
+	// ConnectionPoolSize reports the number of cached connections to upstream nodes
+	ConnectionPoolSize(size int)
+
+	// ConnectionDialed reports a new connection to an upstream node has been dialed
+	ConnectionDialed()
+
+	// CircuitBreakerTripped reports an upstream node has been taken out of rotation after failing requests
+	CircuitBreakerTripped()
+
+	// ExecutionResultMismatch reports execution nodes which committed to the same execution result
+	// returned different responses for the same request
+	ExecutionResultMismatch()
+}
+
 type PingMetrics interface {
 	// NodeReachable tracks the round trip time in milliseconds taken to ping a node
 	// The nodeInfo provides additional information about the node such as the name of the node operator
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type AccessCollector struct {
+	connectionPoolSize     prometheus.Gauge
+	connectionsDialed      prometheus.Counter
+	circuitBreakersTripped prometheus.Counter
+	resultMismatches       prometheus.Counter
+}
+
+func NewAccessCollector() *AccessCollector {
+	ac := &AccessCollector{
+		connectionPoolSize: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "size",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemConnectionPool,
+			Help:      "the number of cached connections to upstream nodes",
+		}),
+		connectionsDialed: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "dials_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemConnectionPool,
+			Help:      "the number of connections dialed to upstream nodes",
+		}),
+		circuitBreakersTripped: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "circuit_breaker_trips_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemConnectionPool,
+			Help:      "the number of times an upstream node has been taken out of rotation after failing requests",
+		}),
+		resultMismatches: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "execution_result_mismatches_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemExecutionAgreement,
+			Help:      "the number of requests for which execution nodes committed to the same result returned different responses",
+		}),
+	}
+
+	return ac
+}
+
+func (ac *AccessCollector) ConnectionPoolSize(size int) {
+	ac.connectionPoolSize.Set(float64(size))
+}
+
+func (ac *AccessCollector) ConnectionDialed() {
+	ac.connectionsDialed.Inc()
+}
+
+func (ac *AccessCollector) CircuitBreakerTripped() {
+	ac.circuitBreakersTripped.Inc()
+}
+
+func (ac *AccessCollector) ExecutionResultMismatch() {
+	ac.resultMismatches.Inc()
+}
@@ -24,6 +24,12 @@ type ConsensusCollector struct {
 
 	// The number of emergency seals
 	emergencySealedBlocks prometheus.Counter
+
+	// The number of emergency seals constructed by the sealing engine
+	emergencySealsConstructed prometheus.Counter
+
+	// Whether emergency sealing is active
+	emergencySealingActive prometheus.Gauge
 }
 
 // NewConsensusCollector created a new consensus collector
@@ -52,18 +58,34 @@ func NewConsensusCollector(tracer module.Tracer, registerer prometheus.Registere
 		Subsystem: subsystemCompliance,
 		Help:      "the number of blocks sealed in emergency mode",
 	})
+	emergencySealsConstructed := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "emergency_seals_constructed_total",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemMatchEngine,
+		Help:      "the number of seals constructed in emergency mode, without the required approvals",
+	})
+	emergencySealingActive := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "emergency_sealing_active",
+		Namespace: namespaceConsensus,
+		Subsystem: subsystemMatchEngine,
+		Help:      "whether emergency sealing is active (1) or not (0)",
+	})
 	registerer.MustRegister(
 		onReceiptDuration,
 		onApprovalDuration,
 		checkSealingDuration,
 		emergencySealedBlocks,
+		emergencySealsConstructed,
+		emergencySealingActive,
 	)
 	cc := &ConsensusCollector{
-		tracer:                tracer,
-		onReceiptDuration:     onReceiptDuration,
-		onApprovalDuration:    onApprovalDuration,
-		checkSealingDuration:  checkSealingDuration,
-		emergencySealedBlocks: emergencySealedBlocks,
+		tracer:                    tracer,
+		onReceiptDuration:         onReceiptDuration,
+		onApprovalDuration:        onApprovalDuration,
+		checkSealingDuration:      checkSealingDuration,
+		emergencySealedBlocks:     emergencySealedBlocks,
+		emergencySealsConstructed: emergencySealsConstructed,
+		emergencySealingActive:    emergencySealingActive,
 	}
 	return cc
 }
@@ -89,6 +111,20 @@ func (cc *ConsensusCollector) EmergencySeal() {
 	cc.emergencySealedBlocks.Inc()
 }
 
+// EmergencySealConstructed increments the counter of seals constructed in emergency mode.
+func (cc *ConsensusCollector) EmergencySealConstructed() {
+	cc.emergencySealsConstructed.Inc()
+}
+
+// EmergencySealingActive reports whether emergency sealing is active.
+func (cc *ConsensusCollector) EmergencySealingActive(active bool) {
+	if active {
+		cc.emergencySealingActive.Set(1)
+	} else {
+		cc.emergencySealingActive.Set(0)
+	}
+}
+
 // OnReceiptProcessingDuration increases the number of seconds spent processing receipts
 func (cc *ConsensusCollector) OnReceiptProcessingDuration(duration time.Duration) {
 	cc.onReceiptDuration.Add(duration.Seconds())
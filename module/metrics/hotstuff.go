@@ -32,6 +32,9 @@ type HotstuffCollector struct {
 	skips                         prometheus.Counter
 	timeouts                      prometheus.Counter
 	timeoutDuration               prometheus.Gauge
+	minTimeoutDuration            prometheus.Gauge
+	blockRateDelay                prometheus.Gauge
+	timeoutConfigUpdates          prometheus.Counter
 	committeeComputationsDuration prometheus.Histogram
 	signerComputationsDuration    prometheus.Histogram
 	validatorComputationsDuration prometheus.Histogram
@@ -109,6 +112,30 @@ func NewHotstuffCollector(chain flow.ChainID) *HotstuffCollector {
 			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
 		}),
 
+		minTimeoutDuration: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:        "min_timeout_seconds",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "The current lower bound of the timeout",
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}),
+
+		blockRateDelay: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:        "block_rate_delay_seconds",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "The current delay for broadcasting block proposals",
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}),
+
+		timeoutConfigUpdates: promauto.NewCounter(prometheus.CounterOpts{
+			Name:        "timeout_config_updates_total",
+			Namespace:   namespaceConsensus,
+			Subsystem:   subsystemHotstuff,
+			Help:        "The number of runtime updates of the timeout configuration",
+			ConstLabels: prometheus.Labels{LabelChain: chain.String()},
+		}),
+
 		committeeComputationsDuration: promauto.NewHistogram(prometheus.HistogramOpts{
 			Name:        "committee_computations_seconds",
 			Namespace:   namespaceConsensus,
@@ -189,6 +216,21 @@ func (hc *HotstuffCollector) SetTimeout(duration time.Duration) {
 	hc.timeoutDuration.Set(duration.Seconds()) // unit: seconds; with float64 precision
 }
 
+// SetMinTimeout sets the current lower bound of the timeout duration.
+func (hc *HotstuffCollector) SetMinTimeout(duration time.Duration) {
+	hc.minTimeoutDuration.Set(duration.Seconds()) // unit: seconds; with float64 precision
+}
+
+// SetBlockRateDelay sets the current delay for broadcasting block proposals.
+func (hc *HotstuffCollector) SetBlockRateDelay(delay time.Duration) {
+	hc.blockRateDelay.Set(delay.Seconds()) // unit: seconds; with float64 precision
+}
+
+// CountTimeoutConfigUpdate counts the number of runtime updates of the timeout configuration.
+func (hc *HotstuffCollector) CountTimeoutConfigUpdate() {
+	hc.timeoutConfigUpdates.Inc()
+}
+
 // CommitteeProcessingDuration measures the time which the HotStuff's core logic
 // spends in the hotstuff.Committee component, i.e. the time determining consensus
 // committee relations.
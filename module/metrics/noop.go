@@ -34,6 +34,8 @@ func (nc *NoopCollector) OnDNSCacheHit()
 func (nc *NoopCollector) UnstakedOutboundConnections(_ uint)                                     {}
 func (nc *NoopCollector) UnstakedInboundConnections(_ uint)                                      {}
 func (nc *NoopCollector) RanGC(duration time.Duration)                                           {}
+func (nc *NoopCollector) PrunedHeight(height uint64)                                             {}
+func (nc *NoopCollector) PrunedBatch(entries uint64, bytes uint64, took time.Duration)           {}
 func (nc *NoopCollector) BadgerLSMSize(sizeBytes int64)                                          {}
 func (nc *NoopCollector) BadgerVLogSize(sizeBytes int64)                                         {}
 func (nc *NoopCollector) BadgerNumReads(n int64)                                                 {}
@@ -58,6 +60,7 @@ func (nc *NoopCollector) CurrentDKGPhase1FinalView(view uint64)
 func (nc *NoopCollector) CurrentDKGPhase2FinalView(view uint64)                                  {}
 func (nc *NoopCollector) CurrentDKGPhase3FinalView(view uint64)                                  {}
 func (nc *NoopCollector) EpochEmergencyFallbackTriggered()                                       {}
+func (nc *NoopCollector) EpochEmergencyFallbackRecovered()                                       {}
 func (nc *NoopCollector) CacheEntries(resource string, entries uint)                             {}
 func (nc *NoopCollector) CacheHit(resource string)                                               {}
 func (nc *NoopCollector) CacheNotFound(resource string)                                          {}
@@ -72,6 +75,9 @@ func (nc *NoopCollector) SetQCView(view uint64)
 func (nc *NoopCollector) CountSkipped()                                                          {}
 func (nc *NoopCollector) CountTimeout()                                                          {}
 func (nc *NoopCollector) SetTimeout(duration time.Duration)                                      {}
+func (nc *NoopCollector) SetMinTimeout(duration time.Duration)                                   {}
+func (nc *NoopCollector) SetBlockRateDelay(delay time.Duration)                                  {}
+func (nc *NoopCollector) CountTimeoutConfigUpdate()                                              {}
 func (nc *NoopCollector) CommitteeProcessingDuration(duration time.Duration)                     {}
 func (nc *NoopCollector) SignerProcessingDuration(duration time.Duration)                        {}
 func (nc *NoopCollector) ValidatorProcessingDuration(duration time.Duration)                     {}
@@ -84,6 +90,8 @@ func (nc *NoopCollector) FinishCollectionToFinalized(collectionID flow.Identifie
 func (nc *NoopCollector) StartBlockToSeal(blockID flow.Identifier)                               {}
 func (nc *NoopCollector) FinishBlockToSeal(blockID flow.Identifier)                              {}
 func (nc *NoopCollector) EmergencySeal()                                                         {}
+func (nc *NoopCollector) EmergencySealConstructed()                                              {}
+func (nc *NoopCollector) EmergencySealingActive(active bool)                                     {}
 func (nc *NoopCollector) OnReceiptProcessingDuration(duration time.Duration)                     {}
 func (nc *NoopCollector) OnApprovalProcessingDuration(duration time.Duration)                    {}
 func (nc *NoopCollector) CheckSealingDuration(duration time.Duration)                            {}
@@ -142,6 +150,10 @@ func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Ti
 func (nc *NoopCollector) TransactionExecuted(txID flow.Identifier, when time.Time)              {}
 func (nc *NoopCollector) TransactionExpired(txID flow.Identifier)                               {}
 func (nc *NoopCollector) TransactionSubmissionFailed()                                          {}
+func (nc *NoopCollector) ConnectionPoolSize(size int)                                           {}
+func (nc *NoopCollector) ConnectionDialed()                                                     {}
+func (nc *NoopCollector) CircuitBreakerTripped()                                                {}
+func (nc *NoopCollector) ExecutionResultMismatch()                                              {}
 func (nc *NoopCollector) ChunkDataPackRequested()                                               {}
 func (nc *NoopCollector) ExecutionSync(syncing bool)                                            {}
 func (nc *NoopCollector) DiskSize(uint64)                                                       {}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type PrunerCollector struct {
+	prunedHeight   prometheus.Gauge
+	prunedEntries  prometheus.Counter
+	reclaimedBytes prometheus.Counter
+	batchDuration  prometheus.Histogram
+}
+
+func NewPrunerCollector() *PrunerCollector {
+	pc := &PrunerCollector{
+		prunedHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceStorage,
+			Subsystem: subsystemBadger,
+			Name:      "pruned_height",
+			Help:      "the height up to which historical data has been pruned",
+		}),
+		prunedEntries: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceStorage,
+			Subsystem: subsystemBadger,
+			Name:      "pruned_entries_total",
+			Help:      "the number of database entries removed by the pruner",
+		}),
+		reclaimedBytes: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceStorage,
+			Subsystem: subsystemBadger,
+			Name:      "pruned_bytes_total",
+			Help:      "the estimated size of the database entries removed by the pruner",
+		}),
+		batchDuration: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceStorage,
+			Subsystem: subsystemBadger,
+			Name:      "pruning_batch_duration_s",
+			Buckets:   []float64{0.01, 0.1, 1, 10, 60},
+			Help:      "the time spent on pruning a batch of heights",
+		}),
+	}
+	return pc
+}
+
+// PrunedHeight records the height up to which historical data has been pruned.
+func (pc *PrunerCollector) PrunedHeight(height uint64) {
+	pc.prunedHeight.Set(float64(height))
+}
+
+// PrunedBatch records a pruned batch of heights.
+func (pc *PrunerCollector) PrunedBatch(entries uint64, bytes uint64, took time.Duration) {
+	pc.prunedEntries.Add(float64(entries))
+	pc.reclaimedBytes.Add(float64(bytes))
+	pc.batchDuration.Observe(took.Seconds())
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -9,10 +10,16 @@ import (
 
 type StorageCollector struct {
 	storageOperationModified *prometheus.CounterVec
+	operationDuration        *prometheus.HistogramVec
+	operationBytes           *prometheus.CounterVec
+	commitDuration           *prometheus.HistogramVec
 }
 
 const (
-	modifierLabel = "modifier"
+	modifierLabel  = "modifier"
+	operationLabel = "operation"
+	prefixLabel    = "prefix"
+	kindLabel      = "kind"
 
 	skipDuplicates  = "skip_duplicates"
 	retryOnConflict = "retry_on_conflict"
@@ -33,6 +40,26 @@ func GetStorageCollector() *StorageCollector {
 					Subsystem: "badger",
 					Help:      "report number of times a storage operation was modified using a modifier",
 				}, []string{modifierLabel}),
+				operationDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+					Name:      "operation_duration_seconds",
+					Namespace: "storage",
+					Subsystem: "badger",
+					Buckets:   []float64{.00001, .0001, .001, .01, .1, 1},
+					Help:      "the duration of low-level storage operations by operation and key prefix",
+				}, []string{operationLabel, prefixLabel}),
+				operationBytes: promauto.NewCounterVec(prometheus.CounterOpts{
+					Name:      "operation_bytes_total",
+					Namespace: "storage",
+					Subsystem: "badger",
+					Help:      "the number of bytes read or written by low-level storage operations by operation and key prefix",
+				}, []string{operationLabel, prefixLabel}),
+				commitDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+					Name:      "commit_duration_seconds",
+					Namespace: "storage",
+					Subsystem: "badger",
+					Buckets:   []float64{.0001, .001, .01, .1, 1, 10},
+					Help:      "the duration of committing database transactions and write batches",
+				}, []string{kindLabel}),
 			}
 		},
 	)
@@ -47,3 +74,15 @@ func (sc *StorageCollector) SkipDuplicate() {
 func (sc *StorageCollector) RetryOnConflict() {
 	sc.storageOperationModified.With(prometheus.Labels{modifierLabel: retryOnConflict}).Inc()
 }
+
+// Operation reports the duration of a low-level operation on the keys with the given
+// prefix, together with the number of bytes read or written.
+func (sc *StorageCollector) Operation(operation string, prefix string, bytes int, took time.Duration) {
+	sc.operationDuration.With(prometheus.Labels{operationLabel: operation, prefixLabel: prefix}).Observe(took.Seconds())
+	sc.operationBytes.With(prometheus.Labels{operationLabel: operation, prefixLabel: prefix}).Add(float64(bytes))
+}
+
+// Commit reports the duration of committing a database transaction or write batch.
+func (sc *StorageCollector) Commit(kind string, took time.Duration) {
+	sc.commitDuration.With(prometheus.Labels{kindLabel: kind}).Observe(took.Seconds())
+}
@@ -24,6 +24,16 @@ func (_m *ConsensusMetrics) EmergencySeal() {
 	_m.Called()
 }
 
+// EmergencySealConstructed provides a mock function with given fields:
+func (_m *ConsensusMetrics) EmergencySealConstructed() {
+	_m.Called()
+}
+
+// EmergencySealingActive provides a mock function with given fields: active
+func (_m *ConsensusMetrics) EmergencySealingActive(active bool) {
+	_m.Called(active)
+}
+
 // FinishBlockToSeal provides a mock function with given fields: blockID
 func (_m *ConsensusMetrics) FinishBlockToSeal(blockID flow.Identifier) {
 	_m.Called(blockID)
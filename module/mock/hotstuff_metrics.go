@@ -28,6 +28,11 @@ func (_m *HotstuffMetrics) CountTimeout() {
 	_m.Called()
 }
 
+// CountTimeoutConfigUpdate provides a mock function with given fields:
+func (_m *HotstuffMetrics) CountTimeoutConfigUpdate() {
+	_m.Called()
+}
+
 // HotStuffBusyDuration provides a mock function with given fields: duration, event
 func (_m *HotstuffMetrics) HotStuffBusyDuration(duration time.Duration, event string) {
 	_m.Called(duration, event)
@@ -48,11 +53,21 @@ func (_m *HotstuffMetrics) PayloadProductionDuration(duration time.Duration) {
 	_m.Called(duration)
 }
 
+// SetBlockRateDelay provides a mock function with given fields: delay
+func (_m *HotstuffMetrics) SetBlockRateDelay(delay time.Duration) {
+	_m.Called(delay)
+}
+
 // SetCurView provides a mock function with given fields: view
 func (_m *HotstuffMetrics) SetCurView(view uint64) {
 	_m.Called(view)
 }
 
+// SetMinTimeout provides a mock function with given fields: duration
+func (_m *HotstuffMetrics) SetMinTimeout(duration time.Duration) {
+	_m.Called(duration)
+}
+
 // SetQCView provides a mock function with given fields: view
 func (_m *HotstuffMetrics) SetQCView(view uint64) {
 	_m.Called(view)
@@ -16,3 +16,8 @@ type ProcessingNotifier struct {
 func (_m *ProcessingNotifier) Notify(entityID flow.Identifier) {
 	_m.Called(entityID)
 }
+
+// NotifyFailure provides a mock function with given fields: entityID, err
+func (_m *ProcessingNotifier) NotifyFailure(entityID flow.Identifier, err error) {
+	_m.Called(entityID, err)
+}
@@ -36,6 +36,22 @@ func (_m *Tracer) Done() <-chan struct{} {
 	return r0
 }
 
+// InjectSpanContext provides a mock function with given fields: span
+func (_m *Tracer) InjectSpanContext(span opentracing.Span) []byte {
+	ret := _m.Called(span)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(opentracing.Span) []byte); ok {
+		r0 = rf(span)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	return r0
+}
+
 // Ready provides a mock function with given fields:
 func (_m *Tracer) Ready() <-chan struct{} {
 	ret := _m.Called()
@@ -197,6 +213,29 @@ func (_m *Tracer) StartSpanFromParent(span opentracing.Span, operationName trace
 	return r0
 }
 
+// StartSpanFromRemoteParent provides a mock function with given fields: spanContext, operationName, opts
+func (_m *Tracer) StartSpanFromRemoteParent(spanContext []byte, operationName trace.SpanName, opts ...opentracing.StartSpanOption) opentracing.Span {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, spanContext, operationName)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 opentracing.Span
+	if rf, ok := ret.Get(0).(func([]byte, trace.SpanName, ...opentracing.StartSpanOption) opentracing.Span); ok {
+		r0 = rf(spanContext, operationName, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(opentracing.Span)
+		}
+	}
+
+	return r0
+}
+
 // StartTransactionSpan provides a mock function with given fields: ctx, transactionID, spanName, opts
 func (_m *Tracer) StartTransactionSpan(ctx context.Context, transactionID flow.Identifier, spanName trace.SpanName, opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context, bool) {
 	_va := make([]interface{}, len(opts))
@@ -1,5 +1,3 @@
-// +build relic
-
 package signature
 
 import (
@@ -0,0 +1,60 @@
+package signature
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// EpochConsensusCommittee returns the consensus committee of the given epoch in
+// canonical order. Signer indices of the quorum certificates for blocks within the
+// epoch are encoded relative to this committee. As it is derived from the initial
+// identities of the epoch, the committee does not change over the course of the epoch.
+func EpochConsensusCommittee(epoch protocol.Epoch) (flow.IdentityList, error) {
+	identities, err := epoch.InitialIdentities()
+	if err != nil {
+		return nil, fmt.Errorf("could not get initial identities of epoch: %w", err)
+	}
+	return identities.Filter(filter.HasRole(flow.RoleConsensus)).Sort(order.Canonical), nil
+}
+
+// ClusterCommittee returns the members of the given cluster in canonical order.
+// Signer indices of the quorum certificates for blocks of the cluster and of the
+// collection guarantees produced by the cluster are encoded relative to this committee.
+func ClusterCommittee(cluster protocol.Cluster) flow.IdentityList {
+	return cluster.Members().Sort(order.Canonical)
+}
+
+// DecodeGuarantors decodes the signer indices of a collection guarantee. The
+// cluster which produced the guarantee is identified among the given clustering
+// by the checksum of the signer indices.
+// Expected error returns during normal operations:
+//  * signerindices.ErrInvalid if the signer indices are malformed or were not
+//    encoded relative to any of the clusters
+func DecodeGuarantors(clustering flow.ClusterList, signerIndices []byte) (flow.IdentityList, error) {
+	for _, members := range clustering {
+		committee := members.Sort(order.Canonical)
+		if !signerindices.EncodedAgainst(committee.NodeIDs(), signerIndices) {
+			continue
+		}
+		return signerindices.DecodeToIdentities(committee, signerIndices)
+	}
+	return nil, fmt.Errorf("signer indices were not encoded against any cluster: %w", signerindices.ErrInvalid)
+}
+
+// GuarantorsAtBlock decodes the signer indices of a collection guarantee relative to
+// the clustering of the epoch containing the guarantee's reference block.
+// Expected error returns during normal operations:
+//  * signerindices.ErrInvalid if the signer indices were not encoded relative to any
+//    cluster of the epoch
+func GuarantorsAtBlock(state protocol.State, guarantee *flow.CollectionGuarantee) (flow.IdentityList, error) {
+	clustering, err := state.AtBlockID(guarantee.ReferenceBlockID).Epochs().Current().Clustering()
+	if err != nil {
+		return nil, fmt.Errorf("could not get clustering for reference block %x: %w", guarantee.ReferenceBlockID, err)
+	}
+	return DecodeGuarantors(clustering, guarantee.SignerIndices)
+}
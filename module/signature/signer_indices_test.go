@@ -0,0 +1,32 @@
+package signature_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestDecodeGuarantors checks that the cluster of a guarantee is identified by the
+// checksum of the signer indices.
+func TestDecodeGuarantors(t *testing.T) {
+	collectors := unittest.IdentityListFixture(12, unittest.WithRole(flow.RoleCollection))
+	clustering := flow.ClusterList{collectors[:4], collectors[4:8], collectors[8:]}
+
+	cluster := clustering[1].Sort(order.Canonical)
+	signerIndices, err := signerindices.Encode(cluster.NodeIDs(), cluster[1:].NodeIDs())
+	require.NoError(t, err)
+
+	guarantors, err := signature.DecodeGuarantors(clustering, signerIndices)
+	require.NoError(t, err)
+	assert.Equal(t, cluster[1:], guarantors)
+
+	_, err = signature.DecodeGuarantors(clustering[:1], signerIndices)
+	require.ErrorIs(t, err, signerindices.ErrInvalid)
+}
@@ -61,13 +61,14 @@ const (
 	//
 
 	// Builder
-	COLBuildOn                  SpanName = "col.builder"
-	COLBuildOnSetup             SpanName = "col.builder.setup"
-	COLBuildOnUnfinalizedLookup SpanName = "col.builder.unfinalizedLookup"
-	COLBuildOnFinalizedLookup   SpanName = "col.builder.finalizedLookup"
-	COLBuildOnCreatePayload     SpanName = "col.builder.createPayload"
-	COLBuildOnCreateHeader      SpanName = "col.builder.createHeader"
-	COLBuildOnDBInsert          SpanName = "col.builder.dbInsert"
+	COLBuildOn                   SpanName = "col.builder"
+	COLBuildOnSetup              SpanName = "col.builder.setup"
+	COLBuildOnUnfinalizedLookup  SpanName = "col.builder.unfinalizedLookup"
+	COLBuildOnFinalizedLookup    SpanName = "col.builder.finalizedLookup"
+	COLBuildOnCreatePayload      SpanName = "col.builder.createPayload"
+	COLBuildOnCreateHeader       SpanName = "col.builder.createHeader"
+	COLBuildOnDBInsert           SpanName = "col.builder.dbInsert"
+	COLBuildOnIncludeTransaction SpanName = "col.builder.includeTransaction"
 
 	// Cluster State
 	COLClusterStateMutatorExtend                       SpanName = "col.state.mutator.extend"
@@ -103,6 +104,13 @@ const (
 	EXEUpdateHighestExecutedBlockIfHigher SpanName = "exe.state.updateHighestExecutedBlockIfHigher"
 	EXEHashEvents                         SpanName = "exe.state.hashEvents"
 
+	// Networking
+	//
+
+	// sending a message is the remote parent of processing it on the receiving node
+	NETSendMessage    SpanName = "net.sendMessage"
+	NETProcessMessage SpanName = "net.processMessage"
+
 	// Verification node
 	//
 	// assigner engine
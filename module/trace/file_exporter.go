@@ -0,0 +1,127 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+// FileExporter is a span exporter which appends the finished spans as JSON lines to
+// a local file, so that traces can be analyzed offline without a tracing backend.
+type FileExporter struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+var _ sdktrace.SpanExporter = (*FileExporter)(nil)
+
+// fileSpan is the JSON representation of a span written by the FileExporter.
+type fileSpan struct {
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Name         string                 `json:"name"`
+	Service      string                 `json:"service,omitempty"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Events       []fileSpanEvent        `json:"events,omitempty"`
+	Links        []fileSpanLink         `json:"links,omitempty"`
+}
+
+type fileSpanEvent struct {
+	Name       string                 `json:"name"`
+	Time       time.Time              `json:"time"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+type fileSpanLink struct {
+	TraceID string `json:"trace_id"`
+	SpanID  string `json:"span_id"`
+}
+
+// NewFileExporter creates a span exporter which appends spans to the file at the given path.
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open trace file: %w", err)
+	}
+
+	return &FileExporter{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// ExportSpans writes the given spans to the file, one JSON object per line.
+func (e *FileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, span := range spans {
+		err := e.encoder.Encode(toFileSpan(span))
+		if err != nil {
+			return fmt.Errorf("could not write span: %w", err)
+		}
+	}
+	return nil
+}
+
+// Shutdown closes the file. Spans exported afterwards are rejected.
+func (e *FileExporter) Shutdown(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.file.Close()
+}
+
+func toFileSpan(span sdktrace.ReadOnlySpan) fileSpan {
+	fs := fileSpan{
+		TraceID: span.SpanContext().TraceID().String(),
+		SpanID:  span.SpanContext().SpanID().String(),
+		Name:    span.Name(),
+		Start:   span.StartTime(),
+		End:     span.EndTime(),
+	}
+	if span.Parent().IsValid() {
+		fs.ParentSpanID = span.Parent().SpanID().String()
+	}
+	if service, ok := span.Resource().Set().Value(semconv.ServiceNameKey); ok {
+		fs.Service = service.AsString()
+	}
+
+	if attributes := span.Attributes(); len(attributes) > 0 {
+		fs.Attributes = make(map[string]interface{}, len(attributes))
+		for _, attribute := range attributes {
+			fs.Attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+		}
+	}
+	for _, event := range span.Events() {
+		fe := fileSpanEvent{
+			Name: event.Name,
+			Time: event.Time,
+		}
+		if len(event.Attributes) > 0 {
+			fe.Attributes = make(map[string]interface{}, len(event.Attributes))
+			for _, attribute := range event.Attributes {
+				fe.Attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+			}
+		}
+		fs.Events = append(fs.Events, fe)
+	}
+	for _, link := range span.Links() {
+		fs.Links = append(fs.Links, fileSpanLink{
+			TraceID: link.SpanContext.TraceID().String(),
+			SpanID:  link.SpanContext.SpanID().String(),
+		})
+	}
+
+	return fs
+}
@@ -2,6 +2,7 @@ package trace
 
 import (
 	"context"
+	"encoding/binary"
 	"math/rand"
 	"time"
 
@@ -92,6 +93,28 @@ func (t *LogTracer) StartSpanFromParent(
 	return NewLogSpanWithParent(t, operationName, parentSpan.spanID)
 }
 
+// InjectSpanContext serializes the ID of the given span.
+func (t *LogTracer) InjectSpanContext(span opentracing.Span) []byte {
+	logSpan, ok := span.(*LogSpan)
+	if !ok {
+		return nil
+	}
+	spanContext := make([]byte, 8)
+	binary.BigEndian.PutUint64(spanContext, logSpan.spanID)
+	return spanContext
+}
+
+func (t *LogTracer) StartSpanFromRemoteParent(
+	spanContext []byte,
+	operationName SpanName,
+	opts ...opentracing.StartSpanOption,
+) opentracing.Span {
+	if len(spanContext) != 8 {
+		return NewLogSpan(t, operationName)
+	}
+	return NewLogSpanWithParent(t, operationName, binary.BigEndian.Uint64(spanContext))
+}
+
 func (t *LogTracer) RecordSpanFromParent(
 	span opentracing.Span,
 	operationName SpanName,
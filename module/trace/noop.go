@@ -76,6 +76,18 @@ func (t *NoopTracer) StartSpanFromParent(
 	return &NoopSpan{t}
 }
 
+func (t *NoopTracer) InjectSpanContext(span opentracing.Span) []byte {
+	return nil
+}
+
+func (t *NoopTracer) StartSpanFromRemoteParent(
+	spanContext []byte,
+	operationName SpanName,
+	opts ...opentracing.StartSpanOption,
+) opentracing.Span {
+	return &NoopSpan{t}
+}
+
 func (t *NoopTracer) RecordSpanFromParent(
 	span opentracing.Span,
 	operationName SpanName,
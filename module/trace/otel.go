@@ -0,0 +1,382 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/rs/zerolog"
+	otbridge "go.opentelemetry.io/otel/bridge/opentracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// ExporterJaeger exports spans with the jaeger client, configured through the
+	// JAEGER_* environment variables (see OpenTracer).
+	ExporterJaeger = "jaeger"
+	// ExporterOTLP exports spans via OTLP over gRPC.
+	ExporterOTLP = "otlp"
+	// ExporterFile appends spans as JSON lines to a local file.
+	ExporterFile = "file"
+)
+
+// instrumentationName is the name of the OpenTelemetry tracer creating all spans.
+const instrumentationName = "github.com/onflow/flow-go"
+
+// traceParentHeader is the header of the W3C trace context carrying the span context.
+const traceParentHeader = "traceparent"
+
+// shutdownTimeout is the maximum time to flush the buffered spans when shutting down.
+const shutdownTimeout = 5 * time.Second
+
+// OtelConfig configures the OpenTelemetry tracer.
+type OtelConfig struct {
+	// Exporter is either ExporterOTLP or ExporterFile.
+	Exporter string
+	// OTLPEndpoint is the address of the OTLP collector, used with ExporterOTLP.
+	OTLPEndpoint string
+	// FilePath is the file the spans are appended to, used with ExporterFile.
+	FilePath string
+	// Sensitivity is the sampling sensitivity of entities, see flow.Identifier.IsSampled.
+	Sensitivity uint
+	// EntitySensitivity overrides the sampling sensitivity for the given entity types.
+	EntitySensitivity map[string]uint
+}
+
+// EntityLinks is a span start option linking the span to the traces of the given
+// entities, e.g. the span of a transaction to the trace of the collection including
+// it. Tracers which do not support links ignore it.
+type EntityLinks []flow.Identifier
+
+// Apply is a no-op, the links are resolved by the OtelTracer.
+func (EntityLinks) Apply(*opentracing.StartSpanOptions) {}
+
+// OtelTracer is the implementation of the Tracer interface built on OpenTelemetry.
+// Spans are created through the OpenTracing bridge, so that they can be used with
+// the opentracing API of the Tracer interface.
+//
+// The trace of an entity is derived from its ID, the first 16 bytes are the trace ID
+// and the next 8 bytes the ID of the remote parent span. All nodes therefore record
+// the spans of the same block, collection or transaction within the same trace.
+// Within this trace, the network messages carry the W3C trace context of the sending
+// span, which is the parent of the span processing the message on the receiving node.
+type OtelTracer struct {
+	bridge    *otbridge.BridgeTracer
+	provider  *sdktrace.TracerProvider
+	log       zerolog.Logger
+	spanCache *lru.Cache
+	config    OtelConfig
+	chainID   string
+}
+
+// NewOtelTracer creates a new OpenTelemetry tracer exporting spans as configured.
+func NewOtelTracer(log zerolog.Logger,
+	serviceName string,
+	chainID string,
+	config OtelConfig) (*OtelTracer, error) {
+
+	exporter, err := newSpanExporter(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not create span exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+		)),
+	)
+
+	bridge, _ := otbridge.NewTracerPair(provider.Tracer(instrumentationName))
+	bridge.SetTextMapPropagator(propagation.TraceContext{})
+	bridge.SetWarningHandler(func(msg string) {
+		log.Warn().Msg(msg)
+	})
+
+	spanCache, err := lru.New(int(DefaultEntityCacheSize))
+	if err != nil {
+		return nil, err
+	}
+
+	t := &OtelTracer{
+		bridge:    bridge,
+		provider:  provider,
+		log:       log,
+		spanCache: spanCache,
+		config:    config,
+		chainID:   chainID,
+	}
+
+	return t, nil
+}
+
+func newSpanExporter(config OtelConfig) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case ExporterOTLP:
+		return otlptracegrpc.New(context.Background(),
+			otlptracegrpc.WithEndpoint(config.OTLPEndpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+	case ExporterFile:
+		return NewFileExporter(config.FilePath)
+	default:
+		return nil, fmt.Errorf("unsupported exporter: %s", config.Exporter)
+	}
+}
+
+// Ready returns a channel that will close when the tracer is ready.
+func (t *OtelTracer) Ready() <-chan struct{} {
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+// Done returns a channel that will close when the buffered spans are exported.
+func (t *OtelTracer) Done() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err := t.provider.Shutdown(ctx)
+		if err != nil {
+			t.log.Error().Err(err).Msg("could not shut down tracer")
+		}
+	}()
+	return done
+}
+
+// sensitivity returns the sampling sensitivity for the given entity type.
+func (t *OtelTracer) sensitivity(entityType string) uint {
+	if sensitivity, ok := t.config.EntitySensitivity[entityType]; ok {
+		return sensitivity
+	}
+	return t.config.Sensitivity
+}
+
+// entitySpanContext returns the span context of the trace of the given entity. It is
+// the same on all nodes, as it is derived from the entity ID only.
+func (t *OtelTracer) entitySpanContext(entityID flow.Identifier) (opentracing.SpanContext, error) {
+	var traceID oteltrace.TraceID
+	var spanID oteltrace.SpanID
+	copy(traceID[:], entityID[:16])
+	copy(spanID[:], entityID[16:24])
+	return t.remoteSpanContext(oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: oteltrace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
+// remoteSpanContext converts the span context of a span recorded by another node into
+// a span context of the bridge, which can be referenced by the spans of this node.
+func (t *OtelTracer) remoteSpanContext(spanContext oteltrace.SpanContext) (opentracing.SpanContext, error) {
+	if !spanContext.IsValid() {
+		return nil, fmt.Errorf("invalid span context")
+	}
+	header := http.Header{}
+	ctx := oteltrace.ContextWithRemoteSpanContext(context.Background(), spanContext)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(header))
+	return t.bridge.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header))
+}
+
+// entityRootSpan returns the root span for the given entity from the cache
+// and if not exist it would construct it and cache it and return it
+// This should be used mostly for the very first span created for an entity on the service
+func (t *OtelTracer) entityRootSpan(entityID flow.Identifier, entityType string) opentracing.Span {
+	if span, ok := t.spanCache.Get(entityID); ok {
+		return span.(opentracing.Span)
+	}
+
+	entityContext, err := t.entitySpanContext(entityID)
+	if err != nil {
+		// don't panic, gracefully move forward with a new trace
+		return t.bridge.StartSpan("entity tracing started")
+	}
+
+	span := t.bridge.StartSpan(entityType, opentracing.ChildOf(entityContext))
+	// keep full entityID
+	span.LogFields(log.String("entity_id", entityID.String()))
+	// set chainID as tag for filtering traces from different networks
+	span.SetTag("chainID", t.chainID)
+	t.spanCache.Add(entityID, span)
+
+	span.Finish() // finish span right away
+	return span
+}
+
+func (t *OtelTracer) startEntitySpan(
+	ctx context.Context,
+	entityID flow.Identifier,
+	entityType string,
+	spanName SpanName,
+	opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context, bool) {
+
+	if !entityID.IsSampled(t.sensitivity(entityType)) {
+		return &NoopSpan{&NoopTracer{}}, ctx, false
+	}
+
+	rootSpan := t.entityRootSpan(entityID, entityType)
+	ctx = opentracing.ContextWithSpan(ctx, rootSpan)
+	return t.StartSpanFromParent(rootSpan, spanName, opts...), ctx, true
+}
+
+// startSpan starts a span with the given options, resolving the entity links to
+// references of the entity traces.
+func (t *OtelTracer) startSpan(operationName SpanName, opts []opentracing.StartSpanOption) opentracing.Span {
+	resolved := make([]opentracing.StartSpanOption, 0, len(opts))
+	for _, opt := range opts {
+		links, ok := opt.(EntityLinks)
+		if !ok {
+			resolved = append(resolved, opt)
+			continue
+		}
+		for _, entityID := range links {
+			entityContext, err := t.entitySpanContext(entityID)
+			if err != nil {
+				continue
+			}
+			resolved = append(resolved, opentracing.FollowsFrom(entityContext))
+		}
+	}
+	return t.bridge.StartSpan(string(operationName), resolved...)
+}
+
+func (t *OtelTracer) StartBlockSpan(
+	ctx context.Context,
+	blockID flow.Identifier,
+	spanName SpanName,
+	opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context, bool) {
+	return t.startEntitySpan(ctx, blockID, EntityTypeBlock, spanName, opts...)
+}
+
+func (t *OtelTracer) StartCollectionSpan(
+	ctx context.Context,
+	collectionID flow.Identifier,
+	spanName SpanName,
+	opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context, bool) {
+	return t.startEntitySpan(ctx, collectionID, EntityTypeCollection, spanName, opts...)
+}
+
+// StartTransactionSpan starts a span that will be aggregated under the given transaction.
+// All spans for the same transaction will be aggregated under a root span
+func (t *OtelTracer) StartTransactionSpan(
+	ctx context.Context,
+	transactionID flow.Identifier,
+	spanName SpanName,
+	opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context, bool) {
+	return t.startEntitySpan(ctx, transactionID, EntityTypeTransaction, spanName, opts...)
+}
+
+func (t *OtelTracer) StartSpanFromContext(
+	ctx context.Context,
+	operationName SpanName,
+	opts ...opentracing.StartSpanOption,
+) (opentracing.Span, context.Context) {
+	parentSpan := opentracing.SpanFromContext(ctx)
+	if parentSpan == nil {
+		return &NoopSpan{&NoopTracer{}}, ctx
+	}
+	if _, ok := parentSpan.(*NoopSpan); ok {
+		return &NoopSpan{&NoopTracer{}}, ctx
+	}
+
+	opts = append(opts, opentracing.ChildOf(parentSpan.Context()))
+	span := t.startSpan(operationName, opts)
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
+
+func (t *OtelTracer) StartSpanFromParent(
+	span opentracing.Span,
+	operationName SpanName,
+	opts ...opentracing.StartSpanOption,
+) opentracing.Span {
+	if _, ok := span.(*NoopSpan); ok {
+		return &NoopSpan{&NoopTracer{}}
+	}
+	opts = append(opts, opentracing.ChildOf(span.Context()))
+	return t.startSpan(operationName, opts)
+}
+
+// InjectSpanContext serializes the context of the given span as W3C traceparent.
+func (t *OtelTracer) InjectSpanContext(span opentracing.Span) []byte {
+	if _, ok := span.(*NoopSpan); ok {
+		return nil
+	}
+	header := http.Header{}
+	err := t.bridge.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header))
+	if err != nil {
+		t.log.Debug().Err(err).Msg("could not inject span context")
+		return nil
+	}
+	traceParent := header.Get(traceParentHeader)
+	if traceParent == "" {
+		return nil
+	}
+	return []byte(traceParent)
+}
+
+func (t *OtelTracer) StartSpanFromRemoteParent(
+	spanContext []byte,
+	operationName SpanName,
+	opts ...opentracing.StartSpanOption,
+) opentracing.Span {
+	if len(spanContext) == 0 {
+		return &NoopSpan{&NoopTracer{}}
+	}
+	header := http.Header{}
+	header.Set(traceParentHeader, string(spanContext))
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+	parent, err := t.remoteSpanContext(oteltrace.SpanContextFromContext(ctx))
+	if err != nil {
+		t.log.Debug().Err(err).Msg("could not extract span context")
+		return &NoopSpan{&NoopTracer{}}
+	}
+	opts = append(opts, opentracing.ChildOf(parent))
+	return t.startSpan(operationName, opts)
+}
+
+func (t *OtelTracer) RecordSpanFromParent(
+	span opentracing.Span,
+	operationName SpanName,
+	duration time.Duration,
+	logs []opentracing.LogRecord,
+	opts ...opentracing.StartSpanOption,
+) {
+	if _, ok := span.(*NoopSpan); ok {
+		return
+	}
+	end := time.Now()
+	start := end.Add(-duration)
+	// contrary to jaeger, the bridge only accepts a child-of reference as parent,
+	// follows-from references are converted to links
+	opts = append(opts, opentracing.ChildOf(span.Context()))
+	opts = append(opts, opentracing.StartTime(start))
+	sp := t.startSpan(operationName, opts)
+	sp.FinishWithOptions(opentracing.FinishOptions{FinishTime: end, LogRecords: logs})
+}
+
+// WithSpanFromContext encapsulates executing a function within an span, i.e., it starts a span with the specified SpanName from the context,
+// executes the function f, and finishes the span once the function returns.
+func (t *OtelTracer) WithSpanFromContext(ctx context.Context,
+	operationName SpanName,
+	f func(),
+	opts ...opentracing.StartSpanOption) {
+	span, _ := t.StartSpanFromContext(ctx, operationName, opts...)
+	defer span.Finish()
+
+	f()
+}
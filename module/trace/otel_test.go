@@ -0,0 +1,172 @@
+package trace_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"trace_id"`
+	SpanID       string `json:"span_id"`
+	ParentSpanID string `json:"parent_span_id"`
+	Name         string `json:"name"`
+	Service      string `json:"service"`
+	Links        []struct {
+		TraceID string `json:"trace_id"`
+	} `json:"links"`
+}
+
+// readSpans reads the spans exported to the given file.
+func readSpans(t *testing.T, path string) []exportedSpan {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var spans []exportedSpan
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var span exportedSpan
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		spans = append(spans, span)
+	}
+	require.NoError(t, scanner.Err())
+	return spans
+}
+
+func newFileTracer(t *testing.T, path string, serviceName string, config trace.OtelConfig) *trace.OtelTracer {
+	config.Exporter = trace.ExporterFile
+	config.FilePath = path
+	tracer, err := trace.NewOtelTracer(unittest.Logger(), serviceName, "test", config)
+	require.NoError(t, err)
+	unittest.AssertClosesBefore(t, tracer.Ready(), 5*time.Second)
+	return tracer
+}
+
+// TestOtelTracer_EntityTrace checks that the spans of the same entity recorded by
+// different nodes are exported within the same trace, derived from the entity ID.
+func TestOtelTracer_EntityTrace(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		blockID := unittest.IdentifierFixture()
+		paths := []string{filepath.Join(dir, "consensus.jsonl"), filepath.Join(dir, "execution.jsonl")}
+
+		for i, service := range []string{"consensus", "execution"} {
+			tracer := newFileTracer(t, paths[i], service, trace.OtelConfig{})
+			span, ctx, sampled := tracer.StartBlockSpan(context.Background(), blockID, trace.SpanName(service+".block"))
+			require.True(t, sampled)
+			child, _ := tracer.StartSpanFromContext(ctx, trace.SpanName(service+".child"))
+			child.Finish()
+			span.Finish()
+			unittest.AssertClosesBefore(t, tracer.Done(), 5*time.Second)
+		}
+
+		traceID := hex.EncodeToString(blockID[:16])
+		for i, service := range []string{"consensus", "execution"} {
+			spans := readSpans(t, paths[i])
+			require.Len(t, spans, 3)
+			names := make(map[string]exportedSpan)
+			for _, span := range spans {
+				assert.Equal(t, traceID, span.TraceID)
+				assert.Equal(t, service, span.Service)
+				names[span.Name] = span
+			}
+			// the entity root span is the parent of the spans of the node
+			root := names[trace.EntityTypeBlock]
+			assert.Equal(t, hex.EncodeToString(blockID[16:24]), root.ParentSpanID)
+			assert.Equal(t, root.SpanID, names[service+".block"].ParentSpanID)
+		}
+	})
+}
+
+// TestOtelTracer_EntityLinks checks that spans are linked to the traces of the entities
+// given as EntityLinks option.
+func TestOtelTracer_EntityLinks(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		path := filepath.Join(dir, "collection.jsonl")
+		tracer := newFileTracer(t, path, "collection", trace.OtelConfig{})
+
+		txID := unittest.IdentifierFixture()
+		collectionID := unittest.IdentifierFixture()
+		span, _, sampled := tracer.StartTransactionSpan(context.Background(), txID, trace.COLBuildOnIncludeTransaction, trace.EntityLinks{collectionID})
+		require.True(t, sampled)
+		span.Finish()
+		unittest.AssertClosesBefore(t, tracer.Done(), 5*time.Second)
+
+		spans := readSpans(t, path)
+		require.Len(t, spans, 2)
+		for _, span := range spans {
+			if span.Name != string(trace.COLBuildOnIncludeTransaction) {
+				continue
+			}
+			assert.Equal(t, hex.EncodeToString(txID[:16]), span.TraceID)
+			require.Len(t, span.Links, 1)
+			assert.Equal(t, hex.EncodeToString(collectionID[:16]), span.Links[0].TraceID)
+		}
+	})
+}
+
+// TestOtelTracer_EntitySensitivity checks that the sampling sensitivity can be set per entity type.
+func TestOtelTracer_EntitySensitivity(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tracer := newFileTracer(t, filepath.Join(dir, "spans.jsonl"), "execution", trace.OtelConfig{
+			Sensitivity:       0,
+			EntitySensitivity: map[string]uint{trace.EntityTypeTransaction: 64},
+		})
+		defer unittest.AssertClosesBefore(t, tracer.Done(), 5*time.Second)
+
+		_, _, sampled := tracer.StartBlockSpan(context.Background(), unittest.IdentifierFixture(), trace.EXEExecuteBlock)
+		assert.True(t, sampled)
+		_, _, sampled = tracer.StartTransactionSpan(context.Background(), unittest.IdentifierFixture(), trace.EXERunTransaction)
+		assert.False(t, sampled)
+	})
+}
+
+// TestOtelTracer_RemoteParent checks that the span processing a message on the receiving
+// node is a child of the span sending it, whose context is carried by the message.
+func TestOtelTracer_RemoteParent(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		blockID := unittest.IdentifierFixture()
+		senderPath := filepath.Join(dir, "sender.jsonl")
+		receiverPath := filepath.Join(dir, "receiver.jsonl")
+
+		sender := newFileTracer(t, senderPath, "sender", trace.OtelConfig{})
+		span, _, sampled := sender.StartBlockSpan(context.Background(), blockID, trace.NETSendMessage)
+		require.True(t, sampled)
+		spanContext := sender.InjectSpanContext(span)
+		require.NotEmpty(t, spanContext)
+		span.Finish()
+		unittest.AssertClosesBefore(t, sender.Done(), 5*time.Second)
+
+		receiver := newFileTracer(t, receiverPath, "receiver", trace.OtelConfig{})
+		receiver.StartSpanFromRemoteParent(spanContext, trace.NETProcessMessage).Finish()
+		// spans without a valid remote parent are not recorded
+		receiver.StartSpanFromRemoteParent(nil, trace.NETProcessMessage).Finish()
+		receiver.StartSpanFromRemoteParent([]byte("invalid"), trace.NETProcessMessage).Finish()
+		unittest.AssertClosesBefore(t, receiver.Done(), 5*time.Second)
+
+		var sent exportedSpan
+		for _, span := range readSpans(t, senderPath) {
+			if span.Name == string(trace.NETSendMessage) {
+				sent = span
+			}
+		}
+		require.NotEmpty(t, sent.SpanID)
+
+		received := readSpans(t, receiverPath)
+		require.Len(t, received, 1)
+		assert.Equal(t, string(trace.NETProcessMessage), received[0].Name)
+		assert.Equal(t, hex.EncodeToString(blockID[:16]), received[0].TraceID)
+		assert.Equal(t, sent.SpanID, received[0].ParentSpanID)
+	})
+}
@@ -1,6 +1,7 @@
 package trace
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"math/rand"
@@ -221,6 +222,37 @@ func (t *OpenTracer) StartSpanFromParent(
 	return t.Tracer.StartSpan(string(operationName), opts...)
 }
 
+// InjectSpanContext serializes the context of the given span in the binary format of jaeger.
+func (t *OpenTracer) InjectSpanContext(span opentracing.Span) []byte {
+	if _, ok := span.(*NoopSpan); ok {
+		return nil
+	}
+	var buf bytes.Buffer
+	err := t.Tracer.Inject(span.Context(), opentracing.Binary, &buf)
+	if err != nil {
+		t.log.Debug().Err(err).Msg("could not inject span context")
+		return nil
+	}
+	return buf.Bytes()
+}
+
+func (t *OpenTracer) StartSpanFromRemoteParent(
+	spanContext []byte,
+	operationName SpanName,
+	opts ...opentracing.StartSpanOption,
+) opentracing.Span {
+	if len(spanContext) == 0 {
+		return &NoopSpan{&NoopTracer{}}
+	}
+	parent, err := t.Tracer.Extract(opentracing.Binary, bytes.NewReader(spanContext))
+	if err != nil {
+		t.log.Debug().Err(err).Msg("could not extract span context")
+		return &NoopSpan{&NoopTracer{}}
+	}
+	opts = append(opts, opentracing.ChildOf(parent))
+	return t.Tracer.StartSpan(string(operationName), opts...)
+}
+
 func (t *OpenTracer) RecordSpanFromParent(
 	span opentracing.Span,
 	operationName SpanName,
@@ -58,6 +58,19 @@ type Tracer interface {
 		opts ...opentracing.StartSpanOption,
 	) opentracing.Span
 
+	// InjectSpanContext serializes the context of the given span, so that it can be sent
+	// to other nodes within a network message. It returns nil if the span is not recorded.
+	InjectSpanContext(span opentracing.Span) []byte
+
+	// StartSpanFromRemoteParent starts a span as a child of the span context serialized by
+	// another node, see InjectSpanContext. If the span context is empty or invalid, the
+	// returned span is not recorded.
+	StartSpanFromRemoteParent(
+		spanContext []byte,
+		operationName trace.SpanName,
+		opts ...opentracing.StartSpanOption,
+	) opentracing.Span
+
 	// RecordSpanFromParent records an span at finish time
 	// start time will be computed by reducing time.Now() - duration
 	RecordSpanFromParent(
@@ -25,12 +25,15 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // Message models a single message that is supposed to get exchanged by the
 // gossip network
 type Message struct {
-	ChannelID            string   `protobuf:"bytes,1,opt,name=ChannelID,proto3" json:"ChannelID,omitempty"`
-	EventID              []byte   `protobuf:"bytes,2,opt,name=EventID,proto3" json:"EventID,omitempty"`
-	OriginID             []byte   `protobuf:"bytes,3,opt,name=OriginID,proto3" json:"OriginID,omitempty"` // Deprecated: Do not use.
-	TargetIDs            [][]byte `protobuf:"bytes,4,rep,name=TargetIDs,proto3" json:"TargetIDs,omitempty"`
-	Payload              []byte   `protobuf:"bytes,5,opt,name=Payload,proto3" json:"Payload,omitempty"`
-	Type                 string   `protobuf:"bytes,6,opt,name=Type,proto3" json:"Type,omitempty"`
+	ChannelID string   `protobuf:"bytes,1,opt,name=ChannelID,proto3" json:"ChannelID,omitempty"`
+	EventID   []byte   `protobuf:"bytes,2,opt,name=EventID,proto3" json:"EventID,omitempty"`
+	OriginID  []byte   `protobuf:"bytes,3,opt,name=OriginID,proto3" json:"OriginID,omitempty"` // Deprecated: Do not use.
+	TargetIDs [][]byte `protobuf:"bytes,4,rep,name=TargetIDs,proto3" json:"TargetIDs,omitempty"`
+	Payload   []byte   `protobuf:"bytes,5,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	Type      string   `protobuf:"bytes,6,opt,name=Type,proto3" json:"Type,omitempty"`
+	// TraceContext carries the context of the span of the sender, it is the parent
+	// of the span processing the message on receipt.
+	TraceContext         []byte   `protobuf:"bytes,7,opt,name=TraceContext,proto3" json:"TraceContext,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -112,6 +115,13 @@ func (m *Message) GetType() string {
 	return ""
 }
 
+func (m *Message) GetTraceContext() []byte {
+	if m != nil {
+		return m.TraceContext
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Message)(nil), "message.Message")
 }
@@ -119,9 +129,9 @@ func init() {
 func init() { proto.RegisterFile("message.proto", fileDescriptor_33c57e4bae7b9afd) }
 
 var fileDescriptor_33c57e4bae7b9afd = []byte{
-	// 188 bytes of a gzipped FileDescriptorProto
+	// 207 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0x4d, 0x2d, 0x2e,
-	0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x87, 0x72, 0x95, 0xd6, 0x32,
+	0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x87, 0x72, 0x95, 0x2e, 0x32,
 	0x72, 0xb1, 0xfb, 0x42, 0xd8, 0x42, 0x32, 0x5c, 0x9c, 0xce, 0x19, 0x89, 0x79, 0x79, 0xa9, 0x39,
 	0x9e, 0x2e, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x08, 0x01, 0x21, 0x09, 0x2e, 0x76, 0xd7,
 	0xb2, 0xd4, 0xbc, 0x12, 0x4f, 0x17, 0x09, 0x26, 0x05, 0x46, 0x0d, 0x9e, 0x20, 0x18, 0x57, 0x48,
@@ -129,9 +139,10 @@ var fileDescriptor_33c57e4bae7b9afd = []byte{
 	0x24, 0xc1, 0x18, 0x04, 0x17, 0x03, 0x99, 0x1b, 0x92, 0x58, 0x94, 0x9e, 0x5a, 0xe2, 0xe9, 0x52,
 	0x2c, 0xc1, 0xa2, 0xc0, 0xac, 0xc1, 0x13, 0x84, 0x10, 0x00, 0x99, 0x1b, 0x90, 0x58, 0x99, 0x93,
 	0x9f, 0x98, 0x22, 0xc1, 0x0a, 0x31, 0x17, 0xca, 0x15, 0x12, 0xe2, 0x62, 0x09, 0xa9, 0x2c, 0x48,
-	0x95, 0x60, 0x03, 0x3b, 0x05, 0xcc, 0x76, 0x12, 0x38, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39,
-	0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x67, 0x3c, 0x96, 0x63, 0x48, 0x62, 0x03, 0xfb, 0xc8, 0x18, 0x10,
-	0x00, 0x00, 0xff, 0xff, 0xee, 0xe2, 0xac, 0x4c, 0xe2, 0x00, 0x00, 0x00,
+	0x95, 0x60, 0x03, 0x3b, 0x05, 0xcc, 0x16, 0x52, 0xe2, 0xe2, 0x09, 0x29, 0x4a, 0x4c, 0x4e, 0x75,
+	0xce, 0xcf, 0x2b, 0x49, 0xad, 0x28, 0x91, 0x60, 0x07, 0x6b, 0x41, 0x11, 0x73, 0x12, 0x38, 0xf1,
+	0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x67, 0x3c, 0x96, 0x63, 0x48,
+	0x62, 0x03, 0xfb, 0xda, 0x18, 0x30, 0x00, 0x29, 0x55, 0x32, 0x99, 0x06, 0x01, 0x00, 0x00,
 }
 
 func (m *Message) Marshal() (dAtA []byte, err error) {
@@ -158,6 +169,13 @@ func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.TraceContext) > 0 {
+		i -= len(m.TraceContext)
+		copy(dAtA[i:], m.TraceContext)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.TraceContext)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.Type) > 0 {
 		i -= len(m.Type)
 		copy(dAtA[i:], m.Type)
@@ -248,6 +266,10 @@ func (m *Message) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMessage(uint64(l))
 	}
+	l = len(m.TraceContext)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -487,6 +509,40 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Type = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceContext", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceContext = append(m.TraceContext[:0], dAtA[iNdEx:postIndex]...)
+			if m.TraceContext == nil {
+				m.TraceContext = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
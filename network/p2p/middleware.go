@@ -79,6 +79,7 @@ type Middleware struct {
 	connectionGating           bool
 	idTranslator               IDTranslator
 	previousProtocolStatePeers []peer.AddrInfo
+	recorder                   network.MessageRecorder
 	*component.ComponentManager
 }
 
@@ -108,6 +109,14 @@ func WithConnectionGating(enabled bool) MiddlewareOption {
 	}
 }
 
+// WithMessageRecorder makes the middleware record every incoming message that passed validation
+// before it is delivered to the overlay.
+func WithMessageRecorder(recorder network.MessageRecorder) MiddlewareOption {
+	return func(mw *Middleware) {
+		mw.recorder = recorder
+	}
+}
+
 // NewMiddleware creates a new middleware instance
 // libP2PNodeFactory is the factory used to create a LibP2PNode
 // flowID is this node's Flow ID
@@ -480,6 +489,11 @@ func (m *Middleware) processMessage(msg *message.Message) {
 		}
 	}
 
+	// record the message in the order it is delivered to the overlay
+	if m.recorder != nil {
+		m.recorder.Record(network.Channel(msg.ChannelID), originID, msg.Type, msg.Payload)
+	}
+
 	// if validation passed, send the message to the overlay
 	err := m.ov.Receive(originID, msg)
 	if err != nil {
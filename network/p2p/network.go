@@ -10,16 +10,19 @@ import (
 
 	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/opentracing/opentracing-go"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/crypto/hash"
 	channels "github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/component"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/message"
 	"github.com/onflow/flow-go/network/queue"
@@ -47,6 +50,7 @@ type Network struct {
 	mw                          network.Middleware
 	top                         network.Topology // used to determine fanout connections
 	metrics                     module.NetworkMetrics
+	tracer                      module.Tracer
 	rcache                      *RcvCache // used to deduplicate incoming messages
 	queue                       network.MessageQueue
 	subMngr                     network.SubscriptionManager // used to keep track of subscribed channels
@@ -81,10 +85,14 @@ type registerBlobServiceResp struct {
 
 var ErrNetworkShutdown = errors.New("network has already shutdown")
 
+// noopTracer starts the spans of sending events which are not traced.
+var noopTracer = trace.NewNoopTracer()
+
 // NewNetwork creates a new naive overlay network, using the given middleware to
 // communicate to direct peers, using the given codec for serialization, and
 // using the given state & cache interfaces to track volatile information.
-// csize determines the size of the cache dedicated to keep track of received messages
+// csize determines the size of the cache dedicated to keep track of received messages.
+// The tracer records the sending and processing of messages, see startSendSpan.
 func NewNetwork(
 	log zerolog.Logger,
 	codec network.Codec,
@@ -94,6 +102,7 @@ func NewNetwork(
 	top network.Topology,
 	sm network.SubscriptionManager,
 	metrics module.NetworkMetrics,
+	tracer module.Tracer,
 	identityProvider id.IdentityProvider,
 ) (*Network, error) {
 
@@ -115,6 +124,7 @@ func NewNetwork(
 		rcache:                      rcache,
 		top:                         top,
 		metrics:                     metrics,
+		tracer:                      tracer,
 		subMngr:                     sm,
 		identityProvider:            identityProvider,
 		registerEngineRequests:      make(chan *registerEngineRequest),
@@ -356,10 +366,11 @@ func (n *Network) processNetworkMessage(senderID flow.Identifier, message *messa
 
 	// create queue message
 	qm := queue.QMessage{
-		Payload:  decodedMessage,
-		Size:     message.Size(),
-		Target:   network.Channel(message.ChannelID),
-		SenderID: senderID,
+		Payload:      decodedMessage,
+		Size:         message.Size(),
+		Target:       network.Channel(message.ChannelID),
+		SenderID:     senderID,
+		TraceContext: message.TraceContext,
 	}
 
 	// insert the message in the queue
@@ -371,8 +382,10 @@ func (n *Network) processNetworkMessage(senderID flow.Identifier, message *messa
 	return nil
 }
 
-// genNetworkMessage uses the codec to encode an event into a NetworkMessage
-func (n *Network) genNetworkMessage(channel network.Channel, event interface{}, targetIDs ...flow.Identifier) (*message.Message, error) {
+// genNetworkMessage uses the codec to encode an event into a NetworkMessage.
+// The message carries the context of the given span, which is the parent of the
+// span processing the message on the receiving node.
+func (n *Network) genNetworkMessage(channel network.Channel, event interface{}, span opentracing.Span, targetIDs ...flow.Identifier) (*message.Message, error) {
 	// encode the payload using the configured codec
 	payload, err := n.codec.Encode(event)
 	if err != nil {
@@ -411,12 +424,13 @@ func (n *Network) genNetworkMessage(channel network.Channel, event interface{},
 
 	// cast event to a libp2p.Message
 	msg := &message.Message{
-		ChannelID: channel.String(),
-		EventID:   payloadHash,
-		OriginID:  originID,
-		TargetIDs: emTargets,
-		Payload:   payload,
-		Type:      msgType,
+		ChannelID:    channel.String(),
+		EventID:      payloadHash,
+		OriginID:     originID,
+		TargetIDs:    emTargets,
+		Payload:      payload,
+		Type:         msgType,
+		TraceContext: n.tracer.InjectSpanContext(span),
 	}
 
 	return msg, nil
@@ -431,8 +445,11 @@ func (n *Network) unicast(channel network.Channel, message interface{}, targetID
 		return nil
 	}
 
+	span := n.startSendSpan(channel, message)
+	defer span.Finish()
+
 	// generates network message (encoding) based on list of recipients
-	msg, err := n.genNetworkMessage(channel, message, targetID)
+	msg, err := n.genNetworkMessage(channel, message, span, targetID)
 	if err != nil {
 		return fmt.Errorf("unicast could not generate network message: %w", err)
 	}
@@ -499,8 +516,11 @@ func (n *Network) sendOnChannel(channel network.Channel, message interface{}, ta
 		Str("target_ids", fmt.Sprintf("%v", targetIDs)).
 		Msg("sending new message on channel")
 
+	span := n.startSendSpan(channel, message)
+	defer span.Finish()
+
 	// generate network message (encoding) based on list of recipients
-	msg, err := n.genNetworkMessage(channel, message, targetIDs...)
+	msg, err := n.genNetworkMessage(channel, message, span, targetIDs...)
 	if err != nil {
 		return fmt.Errorf("failed to generate network message for channel %s: %w", channel, err)
 	}
@@ -529,6 +549,10 @@ func (n *Network) queueSubmitFunc(message interface{}) {
 		return
 	}
 
+	span := n.tracer.StartSpanFromRemoteParent(qm.TraceContext, trace.NETProcessMessage)
+	span.SetTag("channel", qm.Target.String())
+	defer span.Finish()
+
 	// submits the message to the engine synchronously and
 	// tracks its processing time.
 	startTimestamp := time.Now()
@@ -544,3 +568,33 @@ func (n *Network) queueSubmitFunc(message interface{}) {
 
 	n.metrics.InboundProcessDuration(qm.Target.String(), time.Since(startTimestamp))
 }
+
+// startSendSpan starts the span of sending the given event within the trace of the
+// entity the event is about, e.g. the block of a block proposal. The context of this
+// span is carried by the network message. Events about other entities are sent
+// without a recorded span.
+func (n *Network) startSendSpan(channel network.Channel, event interface{}) opentracing.Span {
+	var span opentracing.Span
+	ctx := context.Background()
+	switch ev := event.(type) {
+	case *messages.BlockProposal:
+		span, _, _ = n.tracer.StartBlockSpan(ctx, ev.Header.ID(), trace.NETSendMessage)
+	case *messages.BlockVote:
+		span, _, _ = n.tracer.StartBlockSpan(ctx, ev.BlockID, trace.NETSendMessage)
+	case *messages.ClusterBlockProposal:
+		span, _, _ = n.tracer.StartCollectionSpan(ctx, ev.Header.ID(), trace.NETSendMessage)
+	case *messages.ClusterBlockVote:
+		span, _, _ = n.tracer.StartCollectionSpan(ctx, ev.BlockID, trace.NETSendMessage)
+	case *flow.CollectionGuarantee:
+		span, _, _ = n.tracer.StartCollectionSpan(ctx, ev.CollectionID, trace.NETSendMessage)
+	case *flow.TransactionBody:
+		span, _, _ = n.tracer.StartTransactionSpan(ctx, ev.ID(), trace.NETSendMessage)
+	case *flow.ExecutionReceipt:
+		span, _, _ = n.tracer.StartBlockSpan(ctx, ev.ExecutionResult.BlockID, trace.NETSendMessage)
+	default:
+		span, _ = noopTracer.StartSpanFromContext(ctx, trace.NETSendMessage)
+		return span
+	}
+	span.SetTag("channel", channel.String())
+	return span
+}
@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/message"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestNetwork_TraceContext checks that the network messages about traced entities carry
+// the context of the span sending them.
+func TestNetwork_TraceContext(t *testing.T) {
+	me := &mock.Local{}
+	me.On("NodeID").Return(unittest.IdentifierFixture())
+	tracer := trace.NewLogTracer(unittest.Logger())
+	net := &Network{
+		codec:  cbor.NewCodec(),
+		me:     me,
+		tracer: tracer,
+	}
+	targetID := unittest.IdentifierFixture()
+
+	t.Run("traced entity", func(t *testing.T) {
+		vote := &messages.BlockVote{BlockID: unittest.IdentifierFixture()}
+		span := net.startSendSpan(engine.ConsensusCommittee, vote)
+		defer span.Finish()
+
+		msg, err := net.genNetworkMessage(engine.ConsensusCommittee, vote, span, targetID)
+		require.NoError(t, err)
+		require.NotEmpty(t, msg.TraceContext)
+		assert.Equal(t, tracer.InjectSpanContext(span), msg.TraceContext)
+
+		// the trace context is transmitted with the message
+		data, err := msg.Marshal()
+		require.NoError(t, err)
+		var received message.Message
+		require.NoError(t, received.Unmarshal(data))
+		assert.Equal(t, msg.TraceContext, received.TraceContext)
+	})
+
+	t.Run("untraced event", func(t *testing.T) {
+		request := &messages.SyncRequest{Nonce: 1, Height: 2}
+		span := net.startSendSpan(engine.SyncCommittee, request)
+		defer span.Finish()
+
+		msg, err := net.genNetworkMessage(engine.SyncCommittee, request, span, targetID)
+		require.NoError(t, err)
+		assert.Empty(t, msg.TraceContext)
+	})
+}
@@ -18,10 +18,11 @@ const (
 
 // QMessage is the message that is enqueued for each incoming message
 type QMessage struct {
-	Payload  interface{}     // the decoded message
-	Size     int             // the size of the message in bytes
-	Target   network.Channel // the target channel to lookup the engine
-	SenderID flow.Identifier // senderID for logging
+	Payload      interface{}     // the decoded message
+	Size         int             // the size of the message in bytes
+	Target       network.Channel // the target channel to lookup the engine
+	SenderID     flow.Identifier // senderID for logging
+	TraceContext []byte          // span context of the sender, the parent of processing the message
 }
 
 // GetEventPriority returns the priority of the flow event message.
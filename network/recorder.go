@@ -0,0 +1,15 @@
+package network
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// MessageRecorder records incoming messages that passed authentication and validation in the middleware,
+// right before they are delivered to the overlay. It is meant for offline inspection and replay of the
+// exact message sequence a node received.
+type MessageRecorder interface {
+	// Record stores the encoded payload of a message received on the channel from the origin.
+	// msgType is the decoded type of the message as reported by the sender.
+	// Implementations must be safe for concurrent use and must not retain the payload slice.
+	Record(channel Channel, originID flow.Identifier, msgType string, payload []byte)
+}
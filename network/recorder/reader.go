@@ -0,0 +1,64 @@
+package recorder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ReadRecords reads all records from a recording. The path can either be a single recording file,
+// or a recording directory, in which case the records of all its files are returned in recording order.
+func ReadRecords(path string) ([]Record, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat recording: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = Files(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var records []Record
+	for _, file := range files {
+		fileRecords, err := readFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read recording file %s: %w", file, err)
+		}
+		records = append(records, fileRecords...)
+	}
+
+	return records, nil
+}
+
+func readFile(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []Record
+	decoder := json.NewDecoder(file)
+	for {
+		var record Record
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			// a truncated last line is expected if the node crashed while writing, so
+			// we return what we have read so far
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, nil
+			}
+			return nil, fmt.Errorf("could not decode record %d: %w", len(records), err)
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,229 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/network"
+)
+
+const (
+	// DefaultMaxFileSize is the size in bytes after which the recorder rotates to a new file.
+	DefaultMaxFileSize = 64 * 1024 * 1024 // 64 mb
+
+	// DefaultMaxFiles is the number of recording files kept on disk, the oldest ones are removed first.
+	DefaultMaxFiles = 8
+
+	filePrefix    = "messages-"
+	fileExtension = ".jsonl"
+)
+
+// Record is a single incoming message captured by the recorder.
+type Record struct {
+	Channel   network.Channel `json:"channel"`
+	OriginID  flow.Identifier `json:"origin_id"`
+	Type      string          `json:"type"`
+	Payload   []byte          `json:"payload"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+var _ network.MessageRecorder = (*FileRecorder)(nil)
+
+// FileRecorder writes incoming messages as JSON lines into a directory of rotating files.
+// Each file is named after the time it was opened, so the lexicographic order of the file
+// names matches the order in which messages were recorded.
+type FileRecorder struct {
+	sync.Mutex
+	log         zerolog.Logger
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+	file        *os.File
+	encoder     *json.Encoder
+	written     int64
+	sequence    uint64
+	closed      bool
+}
+
+type Option func(*FileRecorder)
+
+// WithMaxFileSize sets the size in bytes after which the recorder rotates to a new file.
+func WithMaxFileSize(size int64) Option {
+	return func(r *FileRecorder) {
+		r.maxFileSize = size
+	}
+}
+
+// WithMaxFiles sets the number of recording files kept on disk.
+func WithMaxFiles(n int) Option {
+	return func(r *FileRecorder) {
+		r.maxFiles = n
+	}
+}
+
+// NewFileRecorder creates a recorder writing into the given directory, which is created if it does not exist.
+func NewFileRecorder(log zerolog.Logger, dir string, opts ...Option) (*FileRecorder, error) {
+	r := &FileRecorder{
+		log:         log.With().Str("component", "message_recorder").Logger(),
+		dir:         dir,
+		maxFileSize: DefaultMaxFileSize,
+		maxFiles:    DefaultMaxFiles,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.maxFileSize <= 0 {
+		return nil, fmt.Errorf("max file size must be positive, got %d", r.maxFileSize)
+	}
+	if r.maxFiles <= 0 {
+		return nil, fmt.Errorf("max files must be positive, got %d", r.maxFiles)
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create recording directory: %w", err)
+	}
+
+	err = r.rotate()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Record writes the message to the current recording file. Errors are logged rather than returned,
+// as recording must never interfere with message delivery.
+func (r *FileRecorder) Record(channel network.Channel, originID flow.Identifier, msgType string, payload []byte) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.closed {
+		return
+	}
+
+	if r.written >= r.maxFileSize {
+		err := r.rotate()
+		if err != nil {
+			r.log.Error().Err(err).Msg("could not rotate recording file")
+			return
+		}
+	}
+
+	record := Record{
+		Channel:   channel,
+		OriginID:  originID,
+		Type:      msgType,
+		Payload:   payload,
+		Timestamp: time.Now().UTC(),
+	}
+
+	// the encoder issues a single write per record, which we count towards the file size
+	err := r.encoder.Encode(&record)
+	if err != nil {
+		r.log.Error().Err(err).Str("channel", channel.String()).Msg("could not record message")
+		return
+	}
+}
+
+// Close closes the current recording file. Messages recorded afterwards are dropped.
+func (r *FileRecorder) Close() error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	return r.file.Close()
+}
+
+// Ready implements module.ReadyDoneAware.
+func (r *FileRecorder) Ready() <-chan struct{} {
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+// Done implements module.ReadyDoneAware, it closes the current recording file.
+func (r *FileRecorder) Done() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := r.Close()
+		if err != nil {
+			r.log.Error().Err(err).Msg("could not close recording file")
+		}
+	}()
+	return done
+}
+
+// rotate closes the current file if any, opens a new one and removes the oldest files exceeding
+// the configured maximum number of files.
+// Must be called with the lock held.
+func (r *FileRecorder) rotate() error {
+	if r.file != nil {
+		err := r.file.Close()
+		if err != nil {
+			return fmt.Errorf("could not close recording file: %w", err)
+		}
+	}
+
+	// the sequence number keeps file names unique and ordered within one recorder's lifetime
+	name := filepath.Join(r.dir, fmt.Sprintf("%s%020d-%08d%s", filePrefix, time.Now().UnixNano(), r.sequence, fileExtension))
+	r.sequence++
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open recording file: %w", err)
+	}
+
+	r.file = file
+	r.written = 0
+	r.encoder = json.NewEncoder(&countingWriter{w: file, n: &r.written})
+
+	files, err := Files(r.dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > r.maxFiles {
+		err = os.Remove(files[0])
+		if err != nil {
+			return fmt.Errorf("could not remove old recording file: %w", err)
+		}
+		files = files[1:]
+	}
+
+	return nil
+}
+
+// Files returns the recording files in the directory, ordered from oldest to newest.
+func Files(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, filePrefix+"*"+fileExtension))
+	if err != nil {
+		return nil, fmt.Errorf("could not list recording files: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// countingWriter counts the bytes written to the underlying file.
+type countingWriter struct {
+	w *os.File
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,61 @@
+package recorder_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/network/recorder"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestRecordAndRead checks that recorded messages are read back in order, including across rotated files.
+func TestRecordAndRead(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		// rotate after every record, and keep all files
+		r, err := recorder.NewFileRecorder(unittest.Logger(), dir, recorder.WithMaxFileSize(1), recorder.WithMaxFiles(10))
+		require.NoError(t, err)
+
+		originID := unittest.IdentifierFixture()
+		for i := 0; i < 5; i++ {
+			r.Record(engine.SyncCommittee, originID, "messages.SyncRequest", []byte{byte(i)})
+		}
+		require.NoError(t, r.Close())
+
+		files, err := recorder.Files(dir)
+		require.NoError(t, err)
+		// the first record goes into the initially opened file
+		assert.Len(t, files, 5)
+
+		records, err := recorder.ReadRecords(dir)
+		require.NoError(t, err)
+		require.Len(t, records, 5)
+		for i, record := range records {
+			assert.Equal(t, engine.SyncCommittee, record.Channel)
+			assert.Equal(t, originID, record.OriginID)
+			assert.Equal(t, "messages.SyncRequest", record.Type)
+			assert.Equal(t, []byte{byte(i)}, record.Payload)
+		}
+	})
+}
+
+// TestRotationRemovesOldFiles checks that the recorder only keeps the configured number of files.
+func TestRotationRemovesOldFiles(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		r, err := recorder.NewFileRecorder(unittest.Logger(), dir, recorder.WithMaxFileSize(1), recorder.WithMaxFiles(2))
+		require.NoError(t, err)
+
+		for i := 0; i < 5; i++ {
+			r.Record(engine.SyncCommittee, unittest.IdentifierFixture(), "", []byte{byte(i)})
+		}
+		require.NoError(t, r.Close())
+
+		records, err := recorder.ReadRecords(dir)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, []byte{3}, records[0].Payload)
+		assert.Equal(t, []byte{4}, records[1].Payload)
+	})
+}
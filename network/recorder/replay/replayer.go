@@ -0,0 +1,75 @@
+package replay
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/recorder"
+	"github.com/onflow/flow-go/network/stub"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// Replayer delivers recorded messages, one at a time and in recording order, to the engines
+// registered on a stub network representing the recording node. Each message is processed
+// synchronously before the next one is delivered, which makes the replay deterministic.
+// Messages sent by the engines while processing are dropped.
+type Replayer struct {
+	codec network.Codec
+	hub   *stub.Hub
+	net   *stub.Network
+}
+
+// NewReplayer creates a new replayer for the node represented by me. Engines receiving the
+// recorded messages must be registered on the network returned by Network.
+func NewReplayer(codec network.Codec, state protocol.State, me module.Local) *Replayer {
+	hub := stub.NewNetworkHub()
+	return &Replayer{
+		codec: codec,
+		hub:   hub,
+		net:   stub.NewNetwork(state, me, hub),
+	}
+}
+
+// Network returns the stub network engines should be registered on.
+func (r *Replayer) Network() *stub.Network {
+	return r.net
+}
+
+// Replay decodes and delivers the records in order. Records on channels without a registered
+// engine are skipped. It returns the number of delivered records, and stops at the first record
+// that can not be decoded or whose processing fails. Records are delivered as often as they were
+// recorded, i.e. repeated messages are not deduplicated.
+func (r *Replayer) Replay(records []recorder.Record) (uint, error) {
+	delivered := uint(0)
+	for i, record := range records {
+		if !r.net.IsRegistered(record.Channel) {
+			continue
+		}
+
+		err := r.replay(record)
+		if err != nil {
+			return delivered, fmt.Errorf("could not replay record %d (channel: %s, origin: %x, type: %s): %w",
+				i, record.Channel, record.OriginID, record.Type, err)
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+func (r *Replayer) replay(record recorder.Record) error {
+	event, err := r.codec.Decode(record.Payload)
+	if err != nil {
+		return fmt.Errorf("could not decode payload: %w", err)
+	}
+
+	err = r.net.Deliver(record.Channel, record.OriginID, event)
+	if err != nil {
+		return err
+	}
+
+	// drop whatever the engines sent in response, there is no one to receive it
+	r.hub.Buffer.DeliverRecursive(func(*stub.PendingMessage) {})
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package replay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/local"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/network/recorder"
+	"github.com/onflow/flow-go/network/recorder/replay"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestReplay checks that recorded messages are decoded and delivered in order to the registered engine,
+// including repeated messages, and that messages on other channels are skipped.
+func TestReplay(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		codec := cbor.NewCodec()
+		r, err := recorder.NewFileRecorder(unittest.Logger(), dir)
+		require.NoError(t, err)
+
+		originID := unittest.IdentifierFixture()
+		requests := make([]*messages.SyncRequest, 4)
+		for i := range requests[:3] {
+			requests[i] = &messages.SyncRequest{Nonce: uint64(i), Height: uint64(i)}
+		}
+		// the same message received again
+		requests[3] = requests[1]
+
+		for i := range requests {
+			payload, err := codec.Encode(requests[i])
+			require.NoError(t, err)
+			r.Record(engine.SyncCommittee, originID, "messages.SyncRequest", payload)
+
+			// a message on a channel no engine is registered on
+			r.Record(engine.PushBlocks, originID, "messages.SyncRequest", payload)
+		}
+		require.NoError(t, r.Close())
+
+		records, err := recorder.ReadRecords(dir)
+		require.NoError(t, err)
+		require.Len(t, records, 8)
+
+		me, err := local.NewNoKey(&flow.Identity{NodeID: unittest.IdentifierFixture()})
+		require.NoError(t, err)
+		replayer := replay.NewReplayer(codec, nil, me)
+
+		var received []*messages.SyncRequest
+		eng := &mocknetwork.Engine{}
+		eng.On("Process", engine.SyncCommittee, originID, mock.Anything).
+			Run(func(args mock.Arguments) {
+				received = append(received, args.Get(2).(*messages.SyncRequest))
+			}).
+			Return(nil)
+		_, err = replayer.Network().Register(engine.SyncCommittee, eng)
+		require.NoError(t, err)
+
+		delivered, err := replayer.Replay(records)
+		require.NoError(t, err)
+		assert.Equal(t, uint(4), delivered)
+		assert.Equal(t, requests, received)
+	})
+}
@@ -207,6 +207,33 @@ func (n *Network) DeliverSome(syncOnProcess bool, shouldDeliver func(*PendingMes
 	})
 }
 
+// Deliver synchronously delivers an event to the engine of the attached node registered on the
+// channel. Unlike the other delivery methods, it neither goes through the buffer nor skips events
+// the node has seen before, so the same event can be delivered repeatedly. It returns once the
+// event is processed, along with any error the engine returned.
+func (n *Network) Deliver(channel network.Channel, originID flow.Identifier, event interface{}) error {
+	n.Lock()
+	engine, ok := n.engines[channel]
+	n.Unlock()
+	if !ok {
+		return fmt.Errorf("could not find engine ID: %v for node: %v", channel, n.GetID())
+	}
+
+	err := engine.Process(channel, originID, event)
+	if err != nil {
+		return fmt.Errorf("receiver engine failed to process event (%v): %w", event, err)
+	}
+	return nil
+}
+
+// IsRegistered returns true if an engine of the attached node is registered on the channel.
+func (n *Network) IsRegistered(channel network.Channel) bool {
+	n.Lock()
+	defer n.Unlock()
+	_, ok := n.engines[channel]
+	return ok
+}
+
 // sendToAllTargets send a message to all its targeted nodes if the targeted
 // node has not yet seen it.
 // sync parameter defines whether the sender and receiver are synced over processing or delivery of
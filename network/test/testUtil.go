@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/observable"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/module/util"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/codec/cbor"
@@ -215,6 +216,7 @@ func GenerateNetworks(
 			tops[i],
 			sms[i],
 			metrics,
+			trace.NewNoopTracer(),
 			id.NewFixedIdentityProvider(ids),
 		)
 		require.NoError(t, err)
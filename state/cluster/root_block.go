@@ -26,7 +26,7 @@ func CanonicalRootBlock(epoch uint64, participants flow.IdentityList) *cluster.B
 		PayloadHash:        payload.Hash(),
 		Timestamp:          flow.GenesisTime,
 		View:               0,
-		ParentVoterIDs:     nil,
+		ParentVoterIndices: nil,
 		ParentVoterSigData: nil,
 		ProposerID:         flow.ZeroID,
 		ProposerSigData:    nil,
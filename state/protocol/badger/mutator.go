@@ -21,10 +21,6 @@ import (
 	"github.com/onflow/flow-go/storage/badger/transaction"
 )
 
-// errIncompleteEpochConfiguration is a sentinel error returned when there are
-// still epoch service events missing and the new epoch can't be constructed.
-var errIncompleteEpochConfiguration = errors.New("block beyond epoch boundary")
-
 // FollowerState implements a lighter version of a mutable protocol state.
 // When extending the state, it performs hardly any checks on the block payload.
 // Instead, the FollowerState relies on the consensus nodes to run the full
@@ -529,22 +525,14 @@ func (m *FollowerState) Finalize(ctx context.Context, blockID flow.Identifier) e
 	if err != nil {
 		return fmt.Errorf("could not get parent (id=%x): %w", header.ParentID, err)
 	}
-
-	// EECC - check whether the epoch emergency fallback flag has been set
-	// in the database. If so, skip updating any epoch-related metrics.
-	epochFallbackTriggered, err := m.isEpochEmergencyFallbackTriggered()
+	parentEpochStatus, err := m.epoch.statuses.ByBlockID(header.ParentID)
 	if err != nil {
-		return fmt.Errorf("could not check epoch emergency fallback flag: %w", err)
+		return fmt.Errorf("could not retrieve epoch state for parent: %w", err)
 	}
 
 	// track service event driven metrics and protocol events that should be emitted
 	var events []func()
 	for _, seal := range parent.Payload.Seals {
-		// skip updating epoch-related metrics if EECC is triggered
-		if epochFallbackTriggered {
-			break
-		}
-
 		result, err := m.results.ByID(seal.ResultID)
 		if err != nil {
 			return fmt.Errorf("could not retrieve result (id=%x) for seal (id=%x): %w", seal.ResultID, seal.ID(), err)
@@ -552,11 +540,19 @@ func (m *FollowerState) Finalize(ctx context.Context, blockID flow.Identifier) e
 		for _, event := range result.ServiceEvents {
 			switch ev := event.Event.(type) {
 			case *flow.EpochSetup:
+				// skip service events which were rejected as invalid (EECC)
+				if ev.ID() != epochStatus.NextEpoch.SetupID {
+					continue
+				}
 				// update current epoch phase
 				events = append(events, func() { m.metrics.CurrentEpochPhase(flow.EpochPhaseSetup) })
 				// track epoch phase transition (staking->setup)
 				events = append(events, func() { m.consumer.EpochSetupPhaseStarted(ev.Counter-1, header) })
 			case *flow.EpochCommit:
+				// skip service events which were rejected as invalid (EECC)
+				if ev.ID() != epochStatus.NextEpoch.CommitID {
+					continue
+				}
 				// update current epoch phase
 				events = append(events, func() { m.metrics.CurrentEpochPhase(flow.EpochPhaseCommitted) })
 				// track epoch phase transition (setup->committed)
@@ -577,20 +573,10 @@ func (m *FollowerState) Finalize(ctx context.Context, blockID flow.Identifier) e
 	// Convention:
 	// Service notifications and updating metrics happen when we finalize the _first_
 	// block of the new Epoch (same convention as for Epoch-Phase-Changes)
-	// Approach: We retrieve the parent block's epoch information. If this block's view
-	// exceeds the final view of its parent's current epoch, this block begins the next epoch.
-	parentBlocksEpoch := m.AtBlockID(header.ParentID).Epochs().Current()
-	parentEpochFinalView, err := parentBlocksEpoch.FinalView()
-	if err != nil {
-		return fmt.Errorf("could not get parent epoch final view: %w", err)
-	}
-
-	// When this block's view exceeds the parent epoch's final view, this block
-	// represents the first block of the next epoch. Therefore we update metrics
-	// related to the epoch transition here.
-	//
-	// We skip updating these metrics when EECC has been triggered
-	if header.View > parentEpochFinalView && !epochFallbackTriggered {
+	// Approach: We compare this block's current epoch with the parent block's
+	// current epoch. If they differ, this block begins the next epoch. Blocks in
+	// an extended epoch (EECC) remain in their parent's epoch.
+	if epochStatus.CurrentEpoch.SetupID != parentEpochStatus.CurrentEpoch.SetupID {
 		events = append(events, func() { m.consumer.EpochTransition(currentEpochSetup.Counter, header) })
 
 		// set current epoch counter corresponding to new epoch
@@ -599,9 +585,13 @@ func (m *FollowerState) Finalize(ctx context.Context, blockID flow.Identifier) e
 		events = append(events, func() { m.metrics.CurrentEpochPhase(flow.EpochPhaseStaking) })
 	}
 
-	// if EECC is triggered, update metric
-	if epochFallbackTriggered {
-		m.metrics.EpochEmergencyFallbackTriggered()
+	// EECC - track entering and leaving epoch emergency fallback mode
+	if epochStatus.EmergencyFallbackTriggered && !parentEpochStatus.EmergencyFallbackTriggered {
+		events = append(events, func() { m.metrics.EpochEmergencyFallbackTriggered() })
+		events = append(events, func() { m.consumer.EpochEmergencyFallbackTriggered(currentEpochSetup.Counter, header) })
+	}
+	if !epochStatus.EmergencyFallbackTriggered && parentEpochStatus.EmergencyFallbackTriggered {
+		events = append(events, func() { m.metrics.EpochEmergencyFallbackRecovered() })
 	}
 
 	// FIFTH: Persist updates in database
@@ -658,12 +648,14 @@ func (m *FollowerState) Finalize(ctx context.Context, blockID flow.Identifier) e
 //           the parent's EpochStatus.CurrentEpoch also applies for the current block
 // case (b): block starts new Epoch in its respective fork.
 //           the parent's EpochStatus.NextEpoch is the current block's EpochStatus.CurrentEpoch
+// case (c): block is beyond the final view of the parent's epoch, but the next
+//           epoch has not been committed (or, in fallback mode, has not started yet).
+//           The current epoch is extended: the block remains in the parent's epoch
+//           and the status is flagged with EmergencyFallbackTriggered.
 // As the parent was a valid extension of the chain, by induction, the parent satisfies all
 // consistency requirements of the protocol.
 //
-// Returns:
-// * errIncompleteEpochConfiguration if the epoch has ended before processing
-//   both an EpochSetup and EpochCommit event; so the new epoch can't be constructed.
+// No errors are expected during normal operations.
 func (m *FollowerState) epochStatus(block *flow.Header) (*flow.EpochStatus, error) {
 
 	parentStatus, err := m.epoch.statuses.ByBlockID(block.ParentID)
@@ -677,26 +669,47 @@ func (m *FollowerState) epochStatus(block *flow.Header) (*flow.EpochStatus, erro
 		return nil, fmt.Errorf("could not retrieve EpochSetup event for parent: %w", err)
 	}
 
-	if parentSetup.FinalView < block.View { // first block of a new epoch
-		// sanity check: parent's epoch Preparation should be completed and have EpochSetup and EpochCommit events
-		if parentStatus.NextEpoch.SetupID == flow.ZeroID {
-			return nil, fmt.Errorf("missing setup event for starting next epoch: %w", errIncompleteEpochConfiguration)
+	// Block is in the same epoch as its parent, re-use the same epoch status
+	// IMPORTANT: copy the status to avoid modifying the parent status in the cache
+	if block.View <= parentSetup.FinalView {
+		return parentStatus.Copy(), nil
+	}
+
+	// The block is beyond the final view of the parent's epoch. If the next epoch
+	// has been committed and has started, the block is the first block of the next
+	// epoch. During normal operations, the next epoch starts right after the final
+	// view of the current epoch. In fallback mode, the current epoch is extended up
+	// to the first view of a recovery epoch.
+	currentStatus := parentStatus.Copy()
+	if parentStatus.NextEpoch.SetupID != flow.ZeroID {
+		nextSetup, err := m.epoch.setups.ByID(parentStatus.NextEpoch.SetupID)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve EpochSetup event for next epoch: %w", err)
 		}
-		if parentStatus.NextEpoch.CommitID == flow.ZeroID {
-			return nil, fmt.Errorf("missing commit event for starting next epoch: %w", errIncompleteEpochConfiguration)
+		if block.View >= nextSetup.FirstView {
+			if parentStatus.NextEpoch.CommitID != flow.ZeroID {
+				// first block of a new epoch
+				return flow.NewEpochStatus(
+					parentStatus.CurrentEpoch.SetupID, parentStatus.CurrentEpoch.CommitID,
+					parentStatus.NextEpoch.SetupID, parentStatus.NextEpoch.CommitID,
+					flow.ZeroID, flow.ZeroID,
+				)
+			}
+			// the next epoch was not committed in time and can not be started anymore
+			currentStatus.NextEpoch = flow.EventIDs{}
 		}
-		status, err := flow.NewEpochStatus(
-			parentStatus.CurrentEpoch.SetupID, parentStatus.CurrentEpoch.CommitID,
-			parentStatus.NextEpoch.SetupID, parentStatus.NextEpoch.CommitID,
-			flow.ZeroID, flow.ZeroID,
-		)
-		return status, err
 	}
 
-	// Block is in the same epoch as its parent, re-use the same epoch status
-	// IMPORTANT: copy the status to avoid modifying the parent status in the cache
-	currentStatus := parentStatus.Copy()
-	return currentStatus, err
+	// EPOCH EMERGENCY FALLBACK
+	//
+	// We are proposing or processing a block beyond the final view of the current
+	// epoch, but the next epoch has not been set up and committed in time. Rather
+	// than halting block production, the current epoch is extended, so that the
+	// block is considered by the protocol state to fall in the same epoch as its
+	// parent. A valid next epoch can still be committed in fallback mode, which
+	// ends the fallback once its first view is reached.
+	currentStatus.EmergencyFallbackTriggered = true
+	return currentStatus, nil
 }
 
 // handleServiceEvents handles applying state changes which occur as a result
@@ -720,6 +733,11 @@ func (m *FollowerState) epochStatus(block *flow.Header) (*flow.EpochStatus, erro
 // input block has the form of block D (ie. has a parent, which contains a seal
 // for a block in which a service event was emitted).
 //
+// Invalid service events, as well as reaching the final view of the current epoch
+// before the next epoch has been committed, trigger epoch emergency fallback mode
+// (see epochStatus). Service events are still applied in fallback mode, so that
+// the network can recover once a valid next epoch has been committed.
+//
 // If the service events are valid, or there are no service events, this method
 // returns a slice of Badger operations to apply while storing the block. This
 // includes an operation to index the epoch status for every block, and
@@ -737,30 +755,8 @@ func (m *FollowerState) handleServiceEvents(block *flow.Block) ([]func(*transact
 	// This yields the tentative protocol state BEFORE applying the block payload.
 	// As we don't have slashing yet, there is nothing in the payload which could
 	// modify the protocol state for the current epoch.
-
 	epochStatus, err := m.epochStatus(block.Header)
-	if errors.Is(err, errIncompleteEpochConfiguration) {
-		// TMP: EMERGENCY EPOCH CHAIN CONTINUATION
-		//
-		// We are proposing or processing the first block of the next epoch,
-		// but that epoch has not been setup. Rather than returning an error
-		// which prevents further block production, we store the block with
-		// the same epoch status as its parent, resulting in it being considered
-		// by the protocol state to fall in the same epoch as its parent.
-		//
-		// CAUTION: this is inconsistent with the FinalView value specified in the epoch.
-		parentStatus, err := m.epoch.statuses.ByBlockID(block.Header.ParentID)
-		if err != nil {
-			return nil, fmt.Errorf("internal error constructing EECC from parent's epoch status: %w", err)
-		}
-		ops = append(ops, m.epoch.statuses.StoreTx(blockID, parentStatus.Copy()))
-		ops = append(ops, transaction.WithTx(operation.SetEpochEmergencyFallbackTriggered(blockID)))
-		ops = append(ops, func(tx *transaction.Tx) error {
-			tx.OnSucceed(m.metrics.EpochEmergencyFallbackTriggered)
-			return nil
-		})
-		return ops, nil
-	} else if err != nil {
+	if err != nil {
 		return nil, fmt.Errorf("could not determine epoch status: %w", err)
 	}
 
@@ -774,14 +770,25 @@ func (m *FollowerState) handleServiceEvents(block *flow.Block) ([]func(*transact
 	if err != nil {
 		return nil, fmt.Errorf("could not get parent (id=%x): %w", block.Header.ParentID, err)
 	}
+	parentStatus, err := m.epoch.statuses.ByBlockID(block.Header.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve epoch state for parent: %w", err)
+	}
+
+	// triggerFallback is called when we observe an invalid service event. The
+	// preparation of the next epoch is abandoned and the current epoch will be
+	// extended, unless a valid next epoch is committed later on.
+	triggerFallback := func() {
+		epochStatus.EmergencyFallbackTriggered = true
+		epochStatus.NextEpoch = flow.EventIDs{}
+	}
 
 	// The payload might contain epoch preparation service events for the next
 	// epoch. In this case, we need to update the tentative protocol state.
 	// We need to validate whether all information is available in the protocol
 	// state to go to the next epoch when needed. In cases where there is a bug
-	// in the smart contract, it could be that this happens too late and the
-	// chain finalization should halt.
-SealLoop:
+	// in the smart contract, it could be that this happens too late, in which
+	// case the current epoch is extended.
 	for _, seal := range parent.Payload.Seals {
 		result, err := m.results.ByID(seal.ResultID)
 		if err != nil {
@@ -794,12 +801,12 @@ SealLoop:
 			case *flow.EpochSetup:
 
 				// validate the service event
-				err := isValidExtendingEpochSetup(ev, activeSetup, epochStatus)
+				err := isValidExtendingEpochSetup(ev, activeSetup, epochStatus, block.Header.View)
 				if protocol.IsInvalidServiceEventError(err) {
-					// EECC - we have observed an invalid service event, which is
-					// an unrecoverable failure. Flag this in the DB and exit
-					ops = append(ops, transaction.WithTx(operation.SetEpochEmergencyFallbackTriggered(blockID)))
-					break SealLoop
+					// EECC - we have observed an invalid service event, the next
+					// epoch can not be started as planned
+					triggerFallback()
+					continue
 				}
 
 				// prevents multiple setup events for same Epoch (including multiple setup events in payload of same block)
@@ -810,6 +817,11 @@ SealLoop:
 
 			case *flow.EpochCommit:
 
+				if epochStatus.NextEpoch.SetupID == flow.ZeroID {
+					// EECC - a commit without a setup event is an invalid service event
+					triggerFallback()
+					continue
+				}
 				extendingSetup, err := m.epoch.setups.ByID(epochStatus.NextEpoch.SetupID)
 				if err != nil {
 					return nil, state.NewInvalidExtensionErrorf("could not retrieve next epoch setup: %s", err)
@@ -817,10 +829,10 @@ SealLoop:
 				// validate the service event
 				err = isValidExtendingEpochCommit(ev, extendingSetup, activeSetup, epochStatus)
 				if protocol.IsInvalidServiceEventError(err) {
-					// EECC - we have observed an invalid service event, which is
-					// an unrecoverable failure. Flag this in the DB and exit
-					ops = append(ops, transaction.WithTx(operation.SetEpochEmergencyFallbackTriggered(blockID)))
-					break SealLoop
+					// EECC - we have observed an invalid service event, the next
+					// epoch can not be started as planned
+					triggerFallback()
+					continue
 				}
 
 				// prevents multiple setup events for same Epoch (including multiple setup events in payload of same block)
@@ -835,8 +847,17 @@ SealLoop:
 		}
 	}
 
+	// flag newly triggered fallback mode in the DB
+	if epochStatus.EmergencyFallbackTriggered && !parentStatus.EmergencyFallbackTriggered {
+		ops = append(ops, transaction.WithTx(operation.SetEpochEmergencyFallbackTriggered(blockID)))
+		ops = append(ops, func(tx *transaction.Tx) error {
+			tx.OnSucceed(m.metrics.EpochEmergencyFallbackTriggered)
+			return nil
+		})
+	}
+
 	// we always index the epoch status, even when there are no service events
-	ops = append(ops, m.epoch.statuses.StoreTx(block.ID(), epochStatus))
+	ops = append(ops, m.epoch.statuses.StoreTx(blockID, epochStatus))
 
 	return ops, nil
 }
@@ -594,6 +594,7 @@ func TestExtendEpochTransitionValid(t *testing.T) {
 
 		epoch2Commit := unittest.EpochCommitFixture(
 			unittest.CommitWithCounter(epoch2Setup.Counter),
+			unittest.WithClusterQCsFromAssignments(epoch2Setup.Assignments),
 			unittest.WithDKGFromParticipants(epoch2Participants),
 		)
 
@@ -1069,15 +1070,16 @@ func TestExtendEpochCommitInvalid(t *testing.T) {
 			return commit, receipt, seal
 		}
 
-		t.Run("without setup", func(t *testing.T) {
+		// expect a commit event without a preceding setup event to trigger EECC without error
+		t.Run("without setup (EECC)", func(t *testing.T) {
 			_, receipt, seal := createCommit(block1)
 
 			sealingBlock := unittest.SealBlock(t, state, block1, receipt, seal)
 
 			qcBlock := unittest.BlockWithParentFixture(sealingBlock)
 			err = state.Extend(context.Background(), qcBlock)
-			require.Error(t, err)
-			require.True(t, st.IsInvalidExtensionError(err), err)
+			require.NoError(t, err)
+			assertEpochEmergencyFallbackTriggered(t, db)
 		})
 
 		// seal block 1, in which EpochSetup was emitted
@@ -1106,7 +1108,7 @@ func TestExtendEpochCommitInvalid(t *testing.T) {
 		// expect a commit event with wrong cluster QCs to trigger EECC without error
 		t.Run("inconsistent cluster QCs (EECC)", func(t *testing.T) {
 			_, receipt, seal := createCommit(block3, func(commit *flow.EpochCommit) {
-				commit.ClusterQCs = append(commit.ClusterQCs, flow.ClusterQCVoteDataFromQC(unittest.QuorumCertificateFixture()))
+				commit.ClusterQCs = append(commit.ClusterQCs, flow.ClusterQCVoteDataFromQC(unittest.QuorumCertificateFixture(), unittest.IdentifierListFixture(3)))
 			})
 
 			sealingBlock := unittest.SealBlock(t, state, block3, receipt, seal)
@@ -1244,6 +1246,7 @@ func TestEmergencyEpochChainContinuation(t *testing.T) {
 
 			receipt1, seal1 := unittest.ReceiptAndSealForBlock(block1)
 			receipt1.ExecutionResult.ServiceEvents = []flow.ServiceEvent{epoch2Setup.ServiceEvent()}
+			seal1.ResultID = receipt1.ExecutionResult.ID()
 
 			// add a block containing a receipt for block 1
 			block2 := unittest.BlockWithParentFixture(block1.Header)
@@ -1385,6 +1388,7 @@ func TestEmergencyEpochChainContinuation(t *testing.T) {
 
 			receipt1, seal1 := unittest.ReceiptAndSealForBlock(block1)
 			receipt1.ExecutionResult.ServiceEvents = []flow.ServiceEvent{epoch2Setup.ServiceEvent()}
+			seal1.ResultID = receipt1.ExecutionResult.ID()
 
 			// incorporating the service event should trigger EECC
 			metricsMock.On("EpochEmergencyFallbackTriggered").Once()
@@ -1422,6 +1426,110 @@ func TestEmergencyEpochChainContinuation(t *testing.T) {
 	})
 }
 
+// TestEmergencyEpochChainContinuation_Recovery tests that the network recovers from
+// epoch emergency fallback mode, once a valid next epoch has been committed.
+//
+// ROOT <- B1 <- B2 <- B3(R1) <- B4(S1) <- B5 <- B6(R2) <- B7(S2) <- B8 <- B9 <- B10
+//
+// B2 is the first block past the final view of epoch 1 and triggers EECC. B5 sets
+// up the recovery epoch, B8 commits it. B10 is the first block of the recovery epoch.
+func TestEmergencyEpochChainContinuation_Recovery(t *testing.T) {
+	rootSnapshot := unittest.RootSnapshotFixture(participants)
+	util.RunWithFullProtocolState(t, rootSnapshot, func(db *badger.DB, state *protocol.MutableState) {
+		head, err := rootSnapshot.Head()
+		require.NoError(t, err)
+		result, _, err := rootSnapshot.SealedResult()
+		require.NoError(t, err)
+		epoch1Setup := result.ServiceEvents[0].Event.(*flow.EpochSetup)
+
+		// extend and finalize the chain with the given block
+		extend := func(block *flow.Block) {
+			err := state.Extend(context.Background(), block)
+			require.NoError(t, err)
+			err = state.Finalize(context.Background(), block.ID())
+			require.NoError(t, err)
+		}
+		// seal the given block with a result containing the service event
+		seal := func(block *flow.Block, event flow.ServiceEvent) *flow.Block {
+			receipt, seal := unittest.ReceiptAndSealForBlock(block)
+			receipt.ExecutionResult.ServiceEvents = []flow.ServiceEvent{event}
+			seal.ResultID = receipt.ExecutionResult.ID()
+
+			receiptBlock := unittest.BlockWithParentFixture(block.Header)
+			receiptBlock.SetPayload(unittest.PayloadFixture(unittest.WithReceipts(receipt)))
+			extend(receiptBlock)
+
+			sealingBlock := unittest.BlockWithParentFixture(receiptBlock.Header)
+			sealingBlock.SetPayload(flow.Payload{Seals: []*flow.Seal{seal}})
+			extend(sealingBlock)
+			return sealingBlock
+		}
+		// check the epoch and fallback status with respect to the given block
+		assertStatus := func(block *flow.Block, counter uint64, phase flow.EpochPhase, fallback bool) {
+			snapshot := state.AtBlockID(block.ID())
+			actualCounter, err := snapshot.Epochs().Current().Counter()
+			require.NoError(t, err)
+			assert.Equal(t, counter, actualCounter)
+			actualPhase, err := snapshot.Phase()
+			require.NoError(t, err)
+			assert.Equal(t, phase, actualPhase)
+			triggered, err := snapshot.EpochFallbackTriggered()
+			require.NoError(t, err)
+			assert.Equal(t, fallback, triggered)
+		}
+
+		block1 := unittest.BlockWithParentFixture(head)
+		block1.SetPayload(flow.EmptyPayload())
+		extend(block1)
+		assertStatus(block1, epoch1Setup.Counter, flow.EpochPhaseStaking, false)
+
+		// block 2 is past the final view of epoch 1, without a next epoch
+		block2 := unittest.BlockWithParentFixture(block1.Header)
+		block2.SetPayload(flow.EmptyPayload())
+		block2.Header.View = epoch1Setup.FinalView + 1
+		extend(block2)
+		assertStatus(block2, epoch1Setup.Counter, flow.EpochPhaseStaking, true)
+		assertEpochEmergencyFallbackTriggered(t, db)
+
+		// set up the recovery epoch, which starts after the current view
+		epoch2Setup := unittest.EpochSetupFixture(
+			unittest.WithParticipants(participants),
+			unittest.SetupWithCounter(epoch1Setup.Counter+1),
+			unittest.WithFirstView(block2.Header.View+100),
+			unittest.WithFinalView(block2.Header.View+1000),
+		)
+		block4 := seal(block2, epoch2Setup.ServiceEvent())
+		block5 := unittest.BlockWithParentFixture(block4.Header)
+		extend(block5)
+		assertStatus(block5, epoch1Setup.Counter, flow.EpochPhaseSetup, true)
+
+		// commit the recovery epoch
+		epoch2Commit := unittest.EpochCommitFixture(
+			unittest.CommitWithCounter(epoch2Setup.Counter),
+			unittest.WithClusterQCsFromAssignments(epoch2Setup.Assignments),
+			unittest.WithDKGFromParticipants(participants),
+		)
+		block7 := seal(block5, epoch2Commit.ServiceEvent())
+		block8 := unittest.BlockWithParentFixture(block7.Header)
+		extend(block8)
+		assertStatus(block8, epoch1Setup.Counter, flow.EpochPhaseCommitted, true)
+
+		// the current epoch is extended until the first view of the recovery epoch
+		block9 := unittest.BlockWithParentFixture(block8.Header)
+		block9.SetPayload(flow.EmptyPayload())
+		block9.Header.View = epoch2Setup.FirstView - 1
+		extend(block9)
+		assertStatus(block9, epoch1Setup.Counter, flow.EpochPhaseCommitted, true)
+
+		// block 10 is the first block of the recovery epoch
+		block10 := unittest.BlockWithParentFixture(block9.Header)
+		block10.SetPayload(flow.EmptyPayload())
+		block10.Header.View = epoch2Setup.FirstView
+		extend(block10)
+		assertStatus(block10, epoch2Setup.Counter, flow.EpochPhaseStaking, false)
+	})
+}
+
 func TestExtendInvalidSealsInBlock(t *testing.T) {
 	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
 		metrics := metrics.NewNoopCollector()
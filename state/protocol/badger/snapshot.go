@@ -86,10 +86,10 @@ func (s *Snapshot) QuorumCertificate() (*flow.QuorumCertificate, error) {
 	}
 
 	qc := &flow.QuorumCertificate{
-		View:      head.View,
-		BlockID:   s.blockID,
-		SignerIDs: child.ParentVoterIDs,
-		SigData:   child.ParentVoterSigData,
+		View:          head.View,
+		BlockID:       s.blockID,
+		SignerIndices: child.ParentVoterIndices,
+		SigData:       child.ParentVoterSigData,
 	}
 
 	return qc, nil
@@ -144,6 +144,14 @@ func (s *Snapshot) Phase() (flow.EpochPhase, error) {
 	return phase, err
 }
 
+func (s *Snapshot) EpochFallbackTriggered() (bool, error) {
+	status, err := s.state.epoch.statuses.ByBlockID(s.blockID)
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve epoch status: %w", err)
+	}
+	return status.EmergencyFallbackTriggered, nil
+}
+
 func (s *Snapshot) Identities(selector flow.IdentityFilter) (flow.IdentityList, error) {
 
 	// TODO: CAUTION SHORTCUT
@@ -212,7 +212,12 @@ func TestClusters(t *testing.T) {
 	commit := result.ServiceEvents[1].Event.(*flow.EpochCommit)
 	setup.Assignments = unittest.ClusterAssignment(uint(nClusters), collectors)
 	clusterQCs := unittest.QuorumCertificatesFixtures(uint(nClusters))
-	commit.ClusterQCs = flow.ClusterQCVoteDatasFromQCs(clusterQCs)
+	clustering, err := flow.NewClusterList(setup.Assignments, collectors)
+	require.NoError(t, err)
+	commit.ClusterQCs = make([]flow.ClusterQCVoteData, 0, len(clustering))
+	for i, cluster := range clustering {
+		commit.ClusterQCs = append(commit.ClusterQCs, flow.ClusterQCVoteDataFromQC(clusterQCs[i], cluster.NodeIDs()))
+	}
 	seal.ResultID = result.ID()
 
 	rootSnapshot, err := inmem.SnapshotFromBootstrapState(root, result, seal, qc)
@@ -691,7 +696,7 @@ func TestQuorumCertificate(t *testing.T) {
 			qc, err := state.AtBlockID(block1.ID()).QuorumCertificate()
 			assert.Nil(t, err)
 			// should have signatures from valid child (block 2)
-			assert.Equal(t, block2.Header.ParentVoterIDs, qc.SignerIDs)
+			assert.Equal(t, block2.Header.ParentVoterIndices, qc.SignerIndices)
 			assert.Equal(t, block2.Header.ParentVoterSigData, qc.SigData)
 			// should have view matching block1 view
 			assert.Equal(t, block1.Header.View, qc.View)
@@ -377,6 +377,13 @@ func (state *State) bootstrapEpoch(root protocol.Snapshot, verifyNetworkAddress
 			return fmt.Errorf("could not get next epoch: %w", err)
 		}
 
+		// the root snapshot might be taken while the current epoch is extended
+		// in epoch emergency fallback mode
+		status.EmergencyFallbackTriggered, err = root.EpochFallbackTriggered()
+		if err != nil {
+			return fmt.Errorf("could not get epoch fallback status: %w", err)
+		}
+
 		// sanity check: ensure epoch status is valid
 		err = status.Check()
 		if err != nil {
@@ -598,11 +605,10 @@ func (state *State) updateEpochMetrics(snap protocol.Snapshot) error {
 	state.metrics.CurrentDKGPhase2FinalView(dkgPhase2FinalView)
 	state.metrics.CurrentDKGPhase3FinalView(dkgPhase3FinalView)
 
-	// EECC - check whether the epoch emergency fallback flag has been set
-	// in the database. If so, skip updating any epoch-related metrics.
-	epochFallbackTriggered, err := state.isEpochEmergencyFallbackTriggered()
+	// EECC - check whether the finalized state is in epoch emergency fallback mode
+	epochFallbackTriggered, err := snap.EpochFallbackTriggered()
 	if err != nil {
-		return fmt.Errorf("could not check epoch emergency fallback flag: %w", err)
+		return fmt.Errorf("could not check epoch emergency fallback status: %w", err)
 	}
 	if epochFallbackTriggered {
 		state.metrics.EpochEmergencyFallbackTriggered()
@@ -649,9 +655,3 @@ func (state *State) updateCommittedEpochFinalView(snap protocol.Snapshot) error
 
 	return nil
 }
-
-func (m *State) isEpochEmergencyFallbackTriggered() (bool, error) {
-	var triggered bool
-	err := m.db.View(operation.CheckEpochEmergencyFallbackTriggered(&triggered))
-	return triggered, err
-}
@@ -14,7 +14,10 @@ import (
 // added to the state is valid. In addition to intrinsic validitym, we also
 // check that it is valid w.r.t. the previous epoch setup event, and the
 // current epoch status.
-func isValidExtendingEpochSetup(extendingSetup *flow.EpochSetup, activeSetup *flow.EpochSetup, status *flow.EpochStatus) error {
+// The view is the view of the block in which the setup event is applied. It is
+// only relevant in epoch emergency fallback mode, where the current epoch has
+// been extended and the next epoch can only start after that view.
+func isValidExtendingEpochSetup(extendingSetup *flow.EpochSetup, activeSetup *flow.EpochSetup, status *flow.EpochStatus, view uint64) error {
 
 	// We should only have a single epoch setup event per epoch.
 	if status.NextEpoch.SetupID != flow.ZeroID {
@@ -27,8 +30,19 @@ func isValidExtendingEpochSetup(extendingSetup *flow.EpochSetup, activeSetup *fl
 		return protocol.NewInvalidServiceEventError("next epoch setup has invalid counter (%d => %d)", activeSetup.Counter, extendingSetup.Counter)
 	}
 
-	// The first view needs to be exactly one greater than the current epoch final view
-	if extendingSetup.FirstView != activeSetup.FinalView+1 {
+	if status.EmergencyFallbackTriggered {
+		// In fallback mode, the current epoch is extended beyond its final view,
+		// so the next epoch may only start after the current view.
+		if extendingSetup.FirstView <= activeSetup.FinalView || extendingSetup.FirstView <= view {
+			return protocol.NewInvalidServiceEventError(
+				"next epoch first view must be greater than the current epoch final view (%d) and the current view (%d) in fallback mode, got %d",
+				activeSetup.FinalView,
+				view,
+				extendingSetup.FirstView,
+			)
+		}
+	} else if extendingSetup.FirstView != activeSetup.FinalView+1 {
+		// The first view needs to be exactly one greater than the current epoch final view
 		return protocol.NewInvalidServiceEventError(
 			"next epoch first view must be exactly 1 more than current epoch final view (%d != %d+1)",
 			extendingSetup.FirstView,
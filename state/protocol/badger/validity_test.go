@@ -74,7 +74,7 @@ func TestBootstrapInvalidEpochCommit(t *testing.T) {
 		commit := result.ServiceEvents[1].Event.(*flow.EpochCommit)
 		// add an extra QC to commit
 		extraQC := unittest.QuorumCertificateFixture()
-		commit.ClusterQCs = append(commit.ClusterQCs, flow.ClusterQCVoteDataFromQC(extraQC))
+		commit.ClusterQCs = append(commit.ClusterQCs, flow.ClusterQCVoteDataFromQC(extraQC, unittest.IdentifierListFixture(3)))
 
 		err := isValidEpochCommit(commit, setup)
 		require.Error(t, err)
@@ -6,6 +6,8 @@ import (
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 )
 
 // ToEpochSetup converts an Epoch interface instance to the underlying
@@ -74,6 +76,10 @@ func ToEpochCommit(epoch Epoch) (*flow.EpochCommit, error) {
 		}
 		qcs = append(qcs, cluster.RootQC())
 	}
+	clusterQCs, err := ClusterQCVoteDatasFromQCs(clustering, qcs)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert cluster qcs: %w", err)
+	}
 	participants, err := epoch.InitialIdentities()
 	if err != nil {
 		return nil, fmt.Errorf("could not get epoch participants: %w", err)
@@ -89,13 +95,31 @@ func ToEpochCommit(epoch Epoch) (*flow.EpochCommit, error) {
 
 	commit := &flow.EpochCommit{
 		Counter:            counter,
-		ClusterQCs:         flow.ClusterQCVoteDatasFromQCs(qcs),
+		ClusterQCs:         clusterQCs,
 		DKGGroupKey:        dkg.GroupKey(),
 		DKGParticipantKeys: dkgParticipantKeys,
 	}
 	return commit, nil
 }
 
+// ClusterQCVoteDatasFromQCs converts the root QCs of the clusters to the representation
+// used by the smart contract. The i-th QC must be the root QC of the i-th cluster of the
+// clustering, its signer indices are decoded relative to the cluster's members.
+func ClusterQCVoteDatasFromQCs(clustering flow.ClusterList, qcs []*flow.QuorumCertificate) ([]flow.ClusterQCVoteData, error) {
+	if len(clustering) != len(qcs) {
+		return nil, fmt.Errorf("number of clusters (%d) does not match number of qcs (%d)", len(clustering), len(qcs))
+	}
+	qcVotes := make([]flow.ClusterQCVoteData, 0, len(qcs))
+	for i, qc := range qcs {
+		voterIDs, err := signerindices.DecodeToIdentifiers(clustering[i].Sort(order.Canonical).NodeIDs(), qc.SignerIndices)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode signer indices of qc for cluster %d: %w", i, err)
+		}
+		qcVotes = append(qcVotes, flow.ClusterQCVoteDataFromQC(qc, voterIDs))
+	}
+	return qcVotes, nil
+}
+
 // GetDKGParticipantKeys retrieves the canonically ordered list of DKG
 // participant keys from the DKG.
 func GetDKGParticipantKeys(dkg DKG, participants flow.IdentityList) ([]crypto.PublicKey, error) {
@@ -67,4 +67,15 @@ type Consumer interface {
 	//
 	// NOTE: Only called once the phase transition has been finalized.
 	EpochCommittedPhaseStarted(currentEpochCounter uint64, first *flow.Header)
+
+	// EpochEmergencyFallbackTriggered is called when epoch emergency fallback
+	// mode is triggered, because the next epoch could not be set up in time.
+	// The current epoch is then extended beyond its final view, until a valid
+	// next epoch has been committed, in which case EpochTransition is called as
+	// usual once the next epoch starts.
+	//
+	// The block parameter is the first block in fallback mode.
+	//
+	// NOTE: Only called once the block triggering the fallback has been finalized.
+	EpochEmergencyFallbackTriggered(currentEpochCounter uint64, first *flow.Header)
 }
@@ -63,3 +63,11 @@ func (d *Distributor) EpochCommittedPhaseStarted(epoch uint64, first *flow.Heade
 		sub.EpochCommittedPhaseStarted(epoch, first)
 	}
 }
+
+func (d *Distributor) EpochEmergencyFallbackTriggered(epoch uint64, first *flow.Header) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, sub := range d.subscribers {
+		sub.EpochEmergencyFallbackTriggered(epoch, first)
+	}
+}
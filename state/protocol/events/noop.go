@@ -25,3 +25,6 @@ func (n Noop) EpochSetupPhaseStarted(epoch uint64, first *flow.Header) {
 
 func (n Noop) EpochCommittedPhaseStarted(epoch uint64, first *flow.Header) {
 }
+
+func (n Noop) EpochEmergencyFallbackTriggered(epoch uint64, first *flow.Header) {
+}
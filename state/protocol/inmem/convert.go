@@ -47,6 +47,10 @@ func FromSnapshot(from protocol.Snapshot) (*Snapshot, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not get phase: %w", err)
 	}
+	snap.EpochFallbackTriggered, err = from.EpochFallbackTriggered()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch fallback status: %w", err)
+	}
 
 	// convert epochs
 	previous, err := FromEpoch(from.Epochs().Previous())
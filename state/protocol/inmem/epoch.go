@@ -6,6 +6,8 @@ import (
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/state/cluster"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/invalid"
@@ -166,12 +168,19 @@ func (es *committedEpoch) Cluster(index uint) (protocol.Cluster, error) {
 	}
 	rootQCVoteData := qcs[index]
 
+	// the smart contract lists the voters explicitly, we encode them relative to
+	// the canonically ordered cluster members
+	signerIndices, err := signerindices.Encode(members.Sort(order.Canonical).NodeIDs(), rootQCVoteData.VoterIDs)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode signer indices of root qc for cluster %d: %w", index, err)
+	}
+
 	rootBlock := cluster.CanonicalRootBlock(epochCounter, members)
 	rootQC := &flow.QuorumCertificate{
-		View:      rootBlock.Header.View,
-		BlockID:   rootBlock.ID(),
-		SignerIDs: rootQCVoteData.VoterIDs,
-		SigData:   rootQCVoteData.SigData,
+		View:          rootBlock.Header.View,
+		BlockID:       rootBlock.ID(),
+		SignerIndices: signerIndices,
+		SigData:       rootQCVoteData.SigData,
 	}
 
 	cluster, err := ClusterFromEncodable(EncodableCluster{
@@ -67,6 +67,10 @@ func (s Snapshot) Phase() (flow.EpochPhase, error) {
 	return s.enc.Phase, nil
 }
 
+func (s Snapshot) EpochFallbackTriggered() (bool, error) {
+	return s.enc.EpochFallbackTriggered, nil
+}
+
 func (s Snapshot) Seed(indices ...uint32) ([]byte, error) {
 	return seed.FromParentSignature(indices, s.enc.QuorumCertificate.SigData)
 }
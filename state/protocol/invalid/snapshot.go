@@ -27,6 +27,10 @@ func (u *Snapshot) Phase() (flow.EpochPhase, error) {
 	return 0, u.err
 }
 
+func (u *Snapshot) EpochFallbackTriggered() (bool, error) {
+	return false, u.err
+}
+
 func (u *Snapshot) Identities(_ flow.IdentityFilter) (flow.IdentityList, error) {
 	return nil, u.err
 }
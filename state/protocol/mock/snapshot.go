@@ -76,6 +76,27 @@ func (_m *Snapshot) Epochs() protocol.EpochQuery {
 	return r0
 }
 
+// EpochFallbackTriggered provides a mock function with given fields:
+func (_m *Snapshot) EpochFallbackTriggered() (bool, error) {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Head provides a mock function with given fields:
 func (_m *Snapshot) Head() (*flow.Header, error) {
 	ret := _m.Called()
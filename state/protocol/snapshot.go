@@ -94,6 +94,12 @@ type Snapshot interface {
 	// Phase returns the epoch phase for the current epoch, as of the Head block.
 	Phase() (flow.EpochPhase, error)
 
+	// EpochFallbackTriggered returns whether epoch emergency fallback mode is
+	// active as of the Head block. In fallback mode, the current epoch is
+	// extended beyond its final view with the same committee, DKG and cluster
+	// assignment, until a valid next epoch has been committed.
+	EpochFallbackTriggered() (bool, error)
+
 	// Epochs returns a query object enabling querying detailed information about
 	// various epochs.
 	//
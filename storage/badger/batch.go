@@ -1,7 +1,11 @@
 package badger
 
 import (
+	"time"
+
 	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage/badger/transaction"
 )
 
 type Batch struct {
@@ -33,10 +37,12 @@ func (b *Batch) OnSucceed(callback func()) {
 // addition, it will call the callbacks added by
 // OnSucceed
 func (b *Batch) Flush() error {
+	started := time.Now()
 	err := b.writer.Flush()
 	if err != nil {
 		return err
 	}
+	transaction.ObserveCommit("batch", started)
 
 	for _, callback := range b.callbacks {
 		callback()
@@ -0,0 +1,63 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// DKGState stores the progress of the local DKG instances in the secrets
+// database. As the progress is updated with every message processed during the
+// DKG and only read when resuming a DKG instance, it is not cached.
+type DKGState struct {
+	db *badger.DB
+}
+
+func NewDKGState(db *badger.DB) (*DKGState, error) {
+	err := operation.EnsureSecretDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("cannot instantiate dkg state storage in non-secret db: %w", err)
+	}
+
+	return &DKGState{db: db}, nil
+}
+
+func (s *DKGState) InsertDKGProgress(dkgInstanceID string, progress *dkg.Progress) error {
+	return operation.RetryOnConflict(s.db.Update, operation.InsertDKGProgress(instanceKey(dkgInstanceID), progress))
+}
+
+func (s *DKGState) UpdateDKGProgress(dkgInstanceID string, progress *dkg.Progress) error {
+	return operation.RetryOnConflict(s.db.Update, operation.UpdateDKGProgress(instanceKey(dkgInstanceID), progress))
+}
+
+func (s *DKGState) RetrieveDKGProgress(dkgInstanceID string) (*dkg.Progress, error) {
+	var progress dkg.Progress
+	err := s.db.View(operation.RetrieveDKGProgress(instanceKey(dkgInstanceID), &progress))
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+func (s *DKGState) InsertDKGEvent(dkgInstanceID string, index uint64, event *dkg.Event) error {
+	return operation.RetryOnConflict(s.db.Update, operation.InsertDKGEvent(instanceKey(dkgInstanceID), index, event))
+}
+
+func (s *DKGState) RetrieveDKGEvents(dkgInstanceID string) ([]*dkg.Event, error) {
+	var events []*dkg.Event
+	err := s.db.View(operation.RetrieveDKGEvents(instanceKey(dkgInstanceID), &events))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dkg events: %w", err)
+	}
+	return events, nil
+}
+
+// instanceKey maps a DKG instance ID to a fixed-length database key, so that the
+// keys of the events of one instance are never a prefix of those of another.
+func instanceKey(dkgInstanceID string) flow.Identifier {
+	return flow.MakeID(dkgInstanceID)
+}
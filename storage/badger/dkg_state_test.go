@@ -0,0 +1,109 @@
+package badger_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestDKGStateSecretDBRequirement tests that the DKGState constructor will
+// return an error if instantiated using a database not marked with the correct
+// type.
+func TestDKGStateSecretDBRequirement(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		_, err := bstorage.NewDKGState(db)
+		require.Error(t, err)
+	})
+}
+
+func TestDKGProgressInsertUpdateAndRetrieve(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitSecret, func(db *badger.DB) {
+		store, err := bstorage.NewDKGState(db)
+		require.NoError(t, err)
+
+		instanceID := "dkg-flow-testnet-42"
+
+		// attempt to get non-existent progress
+		_, err = store.RetrieveDKGProgress(instanceID)
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		// updating non-existent progress fails
+		progress := &dkg.Progress{
+			Seed:            unittest.SeedFixture(32),
+			Phase1FinalView: 100,
+			Phase2FinalView: 200,
+			Phase3FinalView: 300,
+		}
+		err = store.UpdateDKGProgress(instanceID, progress)
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		err = store.InsertDKGProgress(instanceID, progress)
+		require.NoError(t, err)
+		actual, err := store.RetrieveDKGProgress(instanceID)
+		require.NoError(t, err)
+		assert.Equal(t, progress, actual)
+
+		// inserting the progress again fails
+		err = store.InsertDKGProgress(instanceID, progress)
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		progress.BroadcastOffset = 7
+		progress.SentBroadcasts = 2
+		err = store.UpdateDKGProgress(instanceID, progress)
+		require.NoError(t, err)
+		actual, err = store.RetrieveDKGProgress(instanceID)
+		require.NoError(t, err)
+		assert.Equal(t, progress, actual)
+	})
+}
+
+func TestDKGEventsInsertAndRetrieve(t *testing.T) {
+	unittest.RunWithTypedBadgerDB(t, bstorage.InitSecret, func(db *badger.DB) {
+		store, err := bstorage.NewDKGState(db)
+		require.NoError(t, err)
+
+		// the ID of one instance is a prefix of the ID of the other one
+		instanceID := "dkg-flow-testnet-1"
+		otherInstanceID := "dkg-flow-testnet-12"
+
+		events, err := store.RetrieveDKGEvents(instanceID)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		// insert more than 256 events to check that they are ordered by index
+		var expected []*dkg.Event
+		for i := uint64(0); i < 300; i++ {
+			event := &dkg.Event{
+				Type: dkg.BroadcastMessageEvent,
+				Orig: i % 10,
+				Data: []byte(fmt.Sprintf("message %d", i)),
+			}
+			err = store.InsertDKGEvent(instanceID, i, event)
+			require.NoError(t, err)
+			expected = append(expected, event)
+		}
+		err = store.InsertDKGEvent(otherInstanceID, 0, &dkg.Event{Type: dkg.TimeoutEvent})
+		require.NoError(t, err)
+
+		// inserting an event at an existing index fails
+		err = store.InsertDKGEvent(instanceID, 0, &dkg.Event{Type: dkg.TimeoutEvent})
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		events, err = store.RetrieveDKGEvents(instanceID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, events)
+
+		events, err = store.RetrieveDKGEvents(otherInstanceID)
+		require.NoError(t, err)
+		assert.Equal(t, []*dkg.Event{{Type: dkg.TimeoutEvent}}, events)
+	})
+}
@@ -0,0 +1,151 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// FailedJobs implements storage.FailedJobs, recording the jobs a job consumer failed to process.
+// The indices of the re-queued jobs are kept in memory, so that the consumer can check for them
+// without reading all failed jobs. Therefore, the failed jobs of a consumer must only be modified
+// through a single instance.
+type FailedJobs struct {
+	mu       sync.Mutex
+	db       *badger.DB
+	consumer string              // to distinguish the failed jobs of different consumers
+	requeued map[uint64]struct{} // indices of the re-queued jobs, nil until read from the database
+}
+
+var _ storage.FailedJobs = (*FailedJobs)(nil)
+
+func NewFailedJobs(db *badger.DB, consumer string) *FailedJobs {
+	return &FailedJobs{
+		db:       db,
+		consumer: consumer,
+	}
+}
+
+func (f *FailedJobs) Store(job *storage.FailedJob) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := operation.RetryOnConflict(f.db.Update, func(tx *badger.Txn) error {
+		var existing storage.FailedJob
+		err := operation.RetrieveFailedJob(f.consumer, job.Index, &existing)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return operation.InsertFailedJob(f.consumer, job)(tx)
+		}
+		if err != nil {
+			return err
+		}
+		return operation.UpdateFailedJob(f.consumer, job)(tx)
+	})
+	if err != nil {
+		return fmt.Errorf("could not store failed job %v: %w", job.Index, err)
+	}
+	if f.requeued != nil {
+		if job.Requeued {
+			f.requeued[job.Index] = struct{}{}
+		} else {
+			delete(f.requeued, job.Index)
+		}
+	}
+	return nil
+}
+
+func (f *FailedJobs) ByIndex(index uint64) (*storage.FailedJob, error) {
+	var job storage.FailedJob
+	err := f.db.View(operation.RetrieveFailedJob(f.consumer, index, &job))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve failed job %v: %w", index, err)
+	}
+	return &job, nil
+}
+
+func (f *FailedJobs) All() ([]*storage.FailedJob, error) {
+	var jobs []*storage.FailedJob
+	err := f.db.View(operation.TraverseFailedJobs(f.consumer, &jobs))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve failed jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// Requeued returns the re-queued jobs. The failed jobs are only read from the database on the
+// first call, afterwards only the re-queued jobs are.
+func (f *FailedJobs) Requeued() ([]*storage.FailedJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.requeued == nil {
+		all, err := f.All()
+		if err != nil {
+			return nil, err
+		}
+		f.requeued = make(map[uint64]struct{})
+		for _, job := range all {
+			if job.Requeued {
+				f.requeued[job.Index] = struct{}{}
+			}
+		}
+	}
+
+	indices := make([]uint64, 0, len(f.requeued))
+	for index := range f.requeued {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	jobs := make([]*storage.FailedJob, 0, len(indices))
+	for _, index := range indices {
+		job, err := f.ByIndex(index)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (f *FailedJobs) Requeue(index uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := operation.RetryOnConflict(f.db.Update, func(tx *badger.Txn) error {
+		var job storage.FailedJob
+		err := operation.RetrieveFailedJob(f.consumer, index, &job)(tx)
+		if err != nil {
+			return err
+		}
+		job.Requeued = true
+		return operation.UpdateFailedJob(f.consumer, &job)(tx)
+	})
+	if err != nil {
+		return fmt.Errorf("could not requeue failed job %v: %w", index, err)
+	}
+	if f.requeued != nil {
+		f.requeued[index] = struct{}{}
+	}
+	return nil
+}
+
+func (f *FailedJobs) Remove(index uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := operation.RetryOnConflict(f.db.Update, operation.RemoveFailedJob(f.consumer, index))
+	if err != nil {
+		return fmt.Errorf("could not remove failed job %v: %w", index, err)
+	}
+	if f.requeued != nil {
+		delete(f.requeued, index)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package badger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestFailedJobsStorage(t *testing.T) {
+	withStore := func(t *testing.T, f func(store *bstorage.FailedJobs, other *bstorage.FailedJobs)) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			store := bstorage.NewFailedJobs(db, "consumer")
+			other := bstorage.NewFailedJobs(db, "other")
+
+			f(store, other)
+		})
+	}
+
+	failedJob := func(index uint64) *storage.FailedJob {
+		return &storage.FailedJob{
+			Index:    index,
+			JobID:    unittest.IdentifierFixture().String(),
+			Error:    "could not process job",
+			Attempts: 3,
+			FailedAt: time.Unix(1600000000, 0).UTC(),
+		}
+	}
+
+	requireEqual := func(t *testing.T, expected *storage.FailedJob, actual *storage.FailedJob) {
+		require.True(t, expected.FailedAt.Equal(actual.FailedAt))
+		actual.FailedAt = expected.FailedAt
+		require.Equal(t, expected, actual)
+	}
+
+	t.Run("store and retrieve", func(t *testing.T) {
+		withStore(t, func(store *bstorage.FailedJobs, other *bstorage.FailedJobs) {
+			job := failedJob(5)
+			require.NoError(t, store.Store(job))
+
+			actual, err := store.ByIndex(5)
+			require.NoError(t, err)
+			requireEqual(t, job, actual)
+
+			// failed jobs of different consumers are separated
+			_, err = other.ByIndex(5)
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		})
+	})
+
+	t.Run("store overwrites the same index", func(t *testing.T) {
+		withStore(t, func(store *bstorage.FailedJobs, other *bstorage.FailedJobs) {
+			require.NoError(t, store.Store(failedJob(5)))
+
+			job := failedJob(5)
+			job.Attempts = 6
+			require.NoError(t, store.Store(job))
+
+			actual, err := store.ByIndex(5)
+			require.NoError(t, err)
+			requireEqual(t, job, actual)
+		})
+	})
+
+	t.Run("all ordered by index", func(t *testing.T) {
+		withStore(t, func(store *bstorage.FailedJobs, other *bstorage.FailedJobs) {
+			jobs, err := store.All()
+			require.NoError(t, err)
+			require.Empty(t, jobs)
+
+			for _, index := range []uint64{300, 2, 1000} {
+				require.NoError(t, store.Store(failedJob(index)))
+			}
+			require.NoError(t, other.Store(failedJob(1)))
+
+			jobs, err = store.All()
+			require.NoError(t, err)
+			require.Len(t, jobs, 3)
+			require.Equal(t, uint64(2), jobs[0].Index)
+			require.Equal(t, uint64(300), jobs[1].Index)
+			require.Equal(t, uint64(1000), jobs[2].Index)
+		})
+	})
+
+	t.Run("requeue and remove", func(t *testing.T) {
+		withStore(t, func(store *bstorage.FailedJobs, other *bstorage.FailedJobs) {
+			require.ErrorIs(t, store.Requeue(5), storage.ErrNotFound)
+
+			require.NoError(t, store.Store(failedJob(5)))
+			require.NoError(t, store.Requeue(5))
+
+			actual, err := store.ByIndex(5)
+			require.NoError(t, err)
+			require.True(t, actual.Requeued)
+
+			require.NoError(t, store.Remove(5))
+			_, err = store.ByIndex(5)
+			require.ErrorIs(t, err, storage.ErrNotFound)
+			require.ErrorIs(t, store.Remove(5), storage.ErrNotFound)
+		})
+	})
+
+	t.Run("requeued", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			store := bstorage.NewFailedJobs(db, "consumer")
+			for _, index := range []uint64{3, 5, 7} {
+				require.NoError(t, store.Store(failedJob(index)))
+			}
+			require.NoError(t, store.Requeue(7))
+
+			// re-queued jobs recorded before reading them the first time are read from the database
+			requeued, err := bstorage.NewFailedJobs(db, "consumer").Requeued()
+			require.NoError(t, err)
+			require.Len(t, requeued, 1)
+			require.Equal(t, uint64(7), requeued[0].Index)
+
+			requeued, err = store.Requeued()
+			require.NoError(t, err)
+			require.Len(t, requeued, 1)
+
+			require.NoError(t, store.Requeue(3))
+			require.NoError(t, store.Remove(7))
+			job := failedJob(5)
+			job.Requeued = true
+			require.NoError(t, store.Store(job))
+
+			requeued, err = store.Requeued()
+			require.NoError(t, err)
+			require.Len(t, requeued, 2)
+			require.Equal(t, uint64(3), requeued[0].Index)
+			require.Equal(t, uint64(5), requeued[1].Index)
+
+			// a job which failed again after being re-queued is no longer re-queued
+			require.NoError(t, store.Store(failedJob(3)))
+			requeued, err = store.Requeued()
+			require.NoError(t, err)
+			require.Len(t, requeued, 1)
+			require.Equal(t, uint64(5), requeued[0].Index)
+		})
+	})
+}
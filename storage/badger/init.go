@@ -9,9 +9,10 @@ import (
 )
 
 // InitPublic initializes a public database by checking and setting the database
-// type marker. If an existing, inconsistent type marker is set, this method will
-// return an error. Once a database type marker has been set using these methods,
-// the type cannot be changed.
+// type and format markers. If an existing, inconsistent type marker is set, or the
+// database was written in an unsupported format, this method will return an error.
+// Once a database type marker has been set using these methods, the type cannot be
+// changed.
 func InitPublic(opts badger.Options) (*badger.DB, error) {
 
 	db, err := badger.Open(opts)
@@ -22,6 +23,10 @@ func InitPublic(opts badger.Options) (*badger.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not assert db type: %w", err)
 	}
+	err = db.Update(operation.InsertDBFormatMarker)
+	if err != nil {
+		return nil, fmt.Errorf("could not assert db format: %w", err)
+	}
 
 	return db, nil
 }
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/vmihailenco/msgpack/v4"
@@ -19,6 +20,8 @@ import (
 // in the database it will be overridden
 func batchInsert(key []byte, entity interface{}) func(writeBatch *badger.WriteBatch) error {
 	return func(writeBatch *badger.WriteBatch) error {
+		var size int
+		defer observe(opBatchInsert, key, &size, time.Now())
 
 		// update the maximum key size if the inserted key is bigger
 		if uint32(len(key)) > max {
@@ -35,6 +38,8 @@ func batchInsert(key []byte, entity interface{}) func(writeBatch *badger.WriteBa
 			return fmt.Errorf("could not encode entity: %w", err)
 		}
 
+		size = len(val)
+
 		// persist the entity data into the DB
 		err = writeBatch.Set(key, val)
 		if err != nil {
@@ -49,6 +54,8 @@ func batchInsert(key []byte, entity interface{}) func(writeBatch *badger.WriteBa
 // key already exists.
 func insert(key []byte, entity interface{}) func(*badger.Txn) error {
 	return func(tx *badger.Txn) error {
+		var size int
+		defer observe(opInsert, key, &size, time.Now())
 
 		// update the maximum key size if the inserted key is bigger
 		if uint32(len(key)) > max {
@@ -75,6 +82,8 @@ func insert(key []byte, entity interface{}) func(*badger.Txn) error {
 			return fmt.Errorf("could not encode entity: %w", err)
 		}
 
+		size = len(val)
+
 		// persist the entity data into the DB
 		err = tx.Set(key, val)
 		if err != nil {
@@ -89,6 +98,8 @@ func insert(key []byte, entity interface{}) func(*badger.Txn) error {
 // yet.
 func update(key []byte, entity interface{}) func(*badger.Txn) error {
 	return func(tx *badger.Txn) error {
+		var size int
+		defer observe(opUpdate, key, &size, time.Now())
 
 		// retrieve the item from the key-value store
 		_, err := tx.Get(key)
@@ -105,6 +116,8 @@ func update(key []byte, entity interface{}) func(*badger.Txn) error {
 			return fmt.Errorf("could not encode entity: %w", err)
 		}
 
+		size = len(val)
+
 		// persist the entity data into the DB
 		err = tx.Set(key, val)
 		if err != nil {
@@ -119,14 +132,18 @@ func update(key []byte, entity interface{}) func(*badger.Txn) error {
 // exist, this is a no-op.
 func remove(key []byte) func(*badger.Txn) error {
 	return func(tx *badger.Txn) error {
+		var size int
+		defer observe(opRemove, key, &size, time.Now())
+
 		// retrieve the item from the key-value store
-		_, err := tx.Get(key)
+		item, err := tx.Get(key)
 		if errors.Is(err, badger.ErrKeyNotFound) {
 			return storage.ErrNotFound
 		}
 		if err != nil {
 			return fmt.Errorf("could not check key: %w", err)
 		}
+		size = int(item.ValueSize())
 
 		err = tx.Delete(key)
 		return err
@@ -138,6 +155,8 @@ func remove(key []byte) func(*badger.Txn) error {
 // pointer to an initialized entity of the correct type.
 func retrieve(key []byte, entity interface{}) func(*badger.Txn) error {
 	return func(tx *badger.Txn) error {
+		var size int
+		defer observe(opRetrieve, key, &size, time.Now())
 
 		// retrieve the item from the key-value store
 		item, err := tx.Get(key)
@@ -150,6 +169,7 @@ func retrieve(key []byte, entity interface{}) func(*badger.Txn) error {
 
 		// get the value from the item
 		err = item.Value(func(val []byte) error {
+			size = len(val)
 			err := msgpack.Unmarshal(val, entity)
 			return err
 		})
@@ -221,6 +241,8 @@ func lookup(entityIDs *[]flow.Identifier) func() (checkFunc, createFunc, handleF
 // functions to allow timing functions out.
 func iterate(start []byte, end []byte, iteration iterationFunc) func(*badger.Txn) error {
 	return func(tx *badger.Txn) error {
+		var size int
+		defer observe(opIterate, start, &size, time.Now())
 
 		// initialize the default options and comparison modifier for iteration
 		modifier := 1
@@ -293,6 +315,7 @@ func iterate(start []byte, end []byte, iteration iterationFunc) func(*badger.Txn
 
 			// process the actual item
 			err := item.Value(func(val []byte) error {
+				size += len(val)
 
 				// decode into the entity
 				entity := create()
@@ -326,6 +349,9 @@ func iterate(start []byte, end []byte, iteration iterationFunc) func(*badger.Txn
 // functions specific to processing the given key-value pair.
 func traverse(prefix []byte, iteration iterationFunc) func(*badger.Txn) error {
 	return func(tx *badger.Txn) error {
+		var size int
+		defer observe(opTraverse, prefix, &size, time.Now())
+
 		if len(prefix) == 0 {
 			return fmt.Errorf("prefix must not be empty")
 		}
@@ -355,6 +381,7 @@ func traverse(prefix []byte, iteration iterationFunc) func(*badger.Txn) error {
 
 			// process the actual item
 			err := item.Value(func(val []byte) error {
+				size += len(val)
 
 				// decode into the entity
 				entity := create()
@@ -0,0 +1,50 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertDKGProgress inserts the progress of the local DKG instance with the given ID.
+func InsertDKGProgress(instanceID flow.Identifier, progress *dkg.Progress) func(*badger.Txn) error {
+	return insert(makePrefix(codeDKGProgress, instanceID), progress)
+}
+
+// UpdateDKGProgress updates the progress of the local DKG instance with the given ID.
+func UpdateDKGProgress(instanceID flow.Identifier, progress *dkg.Progress) func(*badger.Txn) error {
+	return update(makePrefix(codeDKGProgress, instanceID), progress)
+}
+
+// RetrieveDKGProgress retrieves the progress of the local DKG instance with the given ID.
+func RetrieveDKGProgress(instanceID flow.Identifier, progress *dkg.Progress) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeDKGProgress, instanceID), progress)
+}
+
+// InsertDKGEvent inserts the event with the given index into the log of the
+// local DKG instance with the given ID.
+func InsertDKGEvent(instanceID flow.Identifier, index uint64, event *dkg.Event) func(*badger.Txn) error {
+	return insert(makePrefix(codeDKGEvent, instanceID, index), event)
+}
+
+// RetrieveDKGEvents retrieves the log of the local DKG instance with the given
+// ID, ordered by index.
+func RetrieveDKGEvents(instanceID flow.Identifier, events *[]*dkg.Event) func(*badger.Txn) error {
+	iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val *dkg.Event
+		create := func() interface{} {
+			val = new(dkg.Event)
+			return val
+		}
+		handle := func() error {
+			*events = append(*events, val)
+			return nil
+		}
+		return check, create, handle
+	}
+	return traverse(makePrefix(codeDKGEvent, instanceID), iterationFunc)
+}
@@ -88,3 +88,83 @@ func ensureDBWithType(db *badger.DB, expectedMarker dbTypeMarker) error {
 func retrieveDBType(marker *dbTypeMarker) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeDBType), marker)
 }
+
+// DBFormat denotes the encoding format of the entities stored in a public database.
+type DBFormat uint
+
+func (format DBFormat) String() string {
+	names := [...]string{
+		"DBFormatSignerIDs",
+		"DBFormatSignerIndices",
+	}
+	// the format is read from the database and might be unknown to this version
+	if format >= DBFormat(len(names)) {
+		return fmt.Sprintf("DBFormat(%d)", format)
+	}
+	return names[format]
+}
+
+const (
+	// DBFormatSignerIDs denotes the legacy format, in which quorum certificates,
+	// guarantees and headers store the full list of signer IDs. Databases in this
+	// format carry no format marker.
+	DBFormatSignerIDs DBFormat = iota
+	// DBFormatSignerIndices denotes the format in which signers are encoded as
+	// signer indices relative to the canonical committee. It is a breaking change
+	// which can only be rolled out with a spork.
+	DBFormatSignerIndices
+)
+
+// CurrentDBFormat is the format of the entities written by this software version.
+const CurrentDBFormat = DBFormatSignerIndices
+
+// InsertDBFormatMarker inserts the current format marker into an empty public
+// database. If the database already contains a format marker, it returns an error
+// if the marker does not match the current format. A database which contains
+// headers but no format marker uses the legacy format.
+//
+// Legacy databases are deliberately not migrated. The signers are part of the
+// block ID, and of the IDs of guarantees and cluster blocks, so re-encoding them
+// would change the IDs all other stored entities and all signatures refer to, and
+// the migrated chain would no longer match the chain of the other nodes. Nodes
+// therefore change to the new format at a spork, starting with an empty database
+// from a root snapshot generated by this software version.
+func InsertDBFormatMarker(txn *badger.Txn) error {
+	var storedFormat DBFormat
+	err := RetrieveDBFormat(&storedFormat)(txn)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not check db format marker: %w", err)
+	}
+
+	// we retrieved a marker from storage
+	if err == nil {
+		if storedFormat != CurrentDBFormat {
+			return fmt.Errorf("unsupported db format (expected: %s, actual: %s)", CurrentDBFormat, storedFormat)
+		}
+		return nil
+	}
+
+	// no marker in storage - a database containing headers uses the legacy format
+	if hasKeyWithPrefix(txn, makePrefix(codeHeader)) {
+		return fmt.Errorf("unsupported db format (expected: %s, actual: %s), the format can only be changed with a spork: "+
+			"start the node with an empty database from a root snapshot of the new spork", CurrentDBFormat, DBFormatSignerIDs)
+	}
+
+	return insert(makePrefix(codeDBFormat), CurrentDBFormat)(txn)
+}
+
+// RetrieveDBFormat retrieves the format marker of the database.
+func RetrieveDBFormat(format *DBFormat) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeDBFormat), format)
+}
+
+// hasKeyWithPrefix returns true if the database contains any key with the given prefix.
+func hasKeyWithPrefix(txn *badger.Txn, prefix []byte) bool {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	opts.Prefix = prefix
+	it := txn.NewIterator(opts)
+	defer it.Close()
+	it.Rewind()
+	return it.Valid()
+}
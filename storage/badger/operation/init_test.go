@@ -5,6 +5,7 @@ import (
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v4"
 
 	"github.com/onflow/flow-go/storage/badger/operation"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -74,3 +75,64 @@ func TestInsertRetrieveDBTypeMarker(t *testing.T) {
 		})
 	})
 }
+
+func TestInsertDBFormatMarker(t *testing.T) {
+	t.Run("should insert current format marker to empty db", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			err := db.Update(operation.InsertDBFormatMarker)
+			require.NoError(t, err)
+			// can insert format marker twice
+			err = db.Update(operation.InsertDBFormatMarker)
+			require.NoError(t, err)
+
+			var format operation.DBFormat
+			err = db.View(operation.RetrieveDBFormat(&format))
+			require.NoError(t, err)
+			require.Equal(t, operation.CurrentDBFormat, format)
+		})
+	})
+
+	t.Run("should reject legacy db containing headers without format marker", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			header := unittest.BlockHeaderFixture()
+			err := db.Update(operation.InsertHeader(header.ID(), &header))
+			require.NoError(t, err)
+
+			err = db.Update(operation.InsertDBFormatMarker)
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("should accept db with headers and current format marker", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			err := db.Update(operation.InsertDBFormatMarker)
+			require.NoError(t, err)
+			header := unittest.BlockHeaderFixture()
+			err = db.Update(operation.InsertHeader(header.ID(), &header))
+			require.NoError(t, err)
+
+			err = db.Update(operation.InsertDBFormatMarker)
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("should reject db with unknown format marker", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			unknown := operation.DBFormat(42)
+			require.Equal(t, "DBFormat(42)", unknown.String())
+
+			// write the marker of a format unknown to this version, e.g. written by a newer version
+			err := db.Update(func(txn *badger.Txn) error {
+				val, err := msgpack.Marshal(unknown)
+				if err != nil {
+					return err
+				}
+				return txn.Set([]byte{3}, val) // codeDBFormat
+			})
+			require.NoError(t, err)
+
+			err = db.Update(operation.InsertDBFormatMarker)
+			require.ErrorContains(t, err, "DBFormat(42)")
+		})
+	})
+}
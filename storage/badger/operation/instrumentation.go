@@ -0,0 +1,148 @@
+package operation
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage/badger/transaction"
+)
+
+// names of the instrumented low-level operations
+const (
+	opInsert      = "insert"
+	opBatchInsert = "batch_insert"
+	opUpdate      = "update"
+	opRemove      = "remove"
+	opRetrieve    = "retrieve"
+	opIterate     = "iterate"
+	opTraverse    = "traverse"
+)
+
+// codeNames maps the key prefix codes to the names reported with the instrumented
+// operations. Codes which are not listed are reported by their number.
+var codeNames = map[byte]string{
+	codeMax:                             "max",
+	codeDBType:                          "db_type",
+	codeDBFormat:                        "db_format",
+	codeStartedView:                     "started_view",
+	codeVotedView:                       "voted_view",
+	codeRootQuorumCertificate:           "root_qc",
+	codeSporkID:                         "spork_id",
+	codeProtocolVersion:                 "protocol_version",
+	codeFinalizedHeight:                 "finalized_height",
+	codeSealedHeight:                    "sealed_height",
+	codeClusterHeight:                   "cluster_height",
+	codeExecutedBlock:                   "executed_block",
+	codeRootHeight:                      "root_height",
+	codeLastCompleteBlockHeight:         "last_complete_block_height",
+	codePrunedHeight:                    "pruned_height",
+	codeHeader:                          "header",
+	codeGuarantee:                       "guarantee",
+	codeSeal:                            "seal",
+	codeTransaction:                     "transaction",
+	codeCollection:                      "collection",
+	codeExecutionResult:                 "execution_result", // shared with codeExecutionReceiptMeta
+	codeResultApproval:                  "result_approval",
+	codeChunk:                           "chunk",
+	codeHeightToBlock:                   "height_to_block",
+	codeBlockToSeal:                     "block_to_seal",
+	codeCollectionReference:             "collection_reference",
+	codeBlockValidity:                   "block_validity",
+	codeBlockChildren:                   "block_children",
+	codePayloadGuarantees:               "payload_guarantees",
+	codePayloadSeals:                    "payload_seals",
+	codeCollectionBlock:                 "collection_block",
+	codeOwnBlockReceipt:                 "own_block_receipt",
+	codeBlockEpochStatus:                "block_epoch_status",
+	codePayloadReceipts:                 "payload_receipts",
+	codePayloadResults:                  "payload_results",
+	codeAllBlockReceipts:                "all_block_receipts",
+	codeIndexBlockByChunkID:             "block_by_chunk",
+	codeEpochSetup:                      "epoch_setup",
+	codeEpochCommit:                     "epoch_commit",
+	codeBeaconPrivateKey:                "beacon_private_key",
+	codeDKGProgress:                     "dkg_progress",
+	codeDKGEvent:                        "dkg_event",
+	codeJobConsumerProcessed:            "job_consumer_processed",
+	codeJobQueue:                        "job_queue",
+	codeJobQueuePointer:                 "job_queue_pointer",
+	codeJobFailed:                       "job_failed",
+	codeChunkDataPack:                   "chunk_data_pack",
+	codeCommit:                          "commit",
+	codeEvent:                           "event",
+	codeExecutionStateInteractions:      "execution_state_interactions",
+	codeTransactionResult:               "transaction_result",
+	codeFinalizedCluster:                "finalized_cluster",
+	codeServiceEvent:                    "service_event",
+	codeIndexCollection:                 "index_collection",
+	codeIndexExecutionResultByBlock:     "result_by_block",
+	codeIndexCollectionByTransaction:    "collection_by_transaction",
+	codeIndexResultApprovalByChunk:      "approval_by_chunk",
+	codeExecutionFork:                   "execution_fork",
+	codeEpochEmergencyFallbackTriggered: "epoch_emergency_fallback",
+}
+
+// instrumentation configures how the low-level operations are instrumented.
+type instrumentation struct {
+	log           zerolog.Logger
+	metrics       bool
+	slowThreshold time.Duration
+}
+
+// instrumented holds the current *instrumentation, operations are not instrumented
+// unless Instrument was called.
+var instrumented atomic.Value
+
+// Instrument enables the instrumentation of the low-level operations of this package.
+// With metrics enabled, the duration and the number of bytes read or written of each
+// operation are reported by key prefix. Operations taking at least the slow threshold
+// are logged, a zero threshold disables logging. With metrics enabled, the duration of
+// committed transactions and write batches is reported as well.
+func Instrument(log zerolog.Logger, metrics bool, slowThreshold time.Duration) {
+	transaction.EnableCommitMetrics(metrics)
+	instrumented.Store(&instrumentation{
+		log:           log.With().Str("component", "storage_operations").Logger(),
+		metrics:       metrics,
+		slowThreshold: slowThreshold,
+	})
+}
+
+// observe reports an operation on the given key which started at the given time. It
+// is meant to be deferred, so the number of bytes is passed by reference.
+func observe(operation string, key []byte, bytes *int, started time.Time) {
+	inst, ok := instrumented.Load().(*instrumentation)
+	if !ok {
+		return
+	}
+
+	took := time.Since(started)
+	prefix := prefixName(key)
+	if inst.metrics {
+		metrics.GetStorageCollector().Operation(operation, prefix, *bytes, took)
+	}
+	if inst.slowThreshold > 0 && took >= inst.slowThreshold {
+		inst.log.Warn().
+			Str("operation", operation).
+			Str("prefix", prefix).
+			Hex("key", key).
+			Int("bytes", *bytes).
+			Dur("duration", took).
+			Msg("slow storage operation")
+	}
+}
+
+// prefixName returns the name of the prefix code of the given key.
+func prefixName(key []byte) string {
+	if len(key) == 0 {
+		return "none"
+	}
+	name, ok := codeNames[key[0]]
+	if !ok {
+		return fmt.Sprintf("code_%d", key[0])
+	}
+	return name
+}
@@ -0,0 +1,77 @@
+package operation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestInstrumentSlowOperations checks that operations taking at least the threshold
+// are logged with their key prefix and the number of bytes read or written.
+func TestInstrumentSlowOperations(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		var buf bytes.Buffer
+		Instrument(zerolog.New(&buf), true, time.Nanosecond)
+		// disable the instrumentation again for the other tests
+		defer Instrument(zerolog.Nop(), false, 0)
+
+		e := Entity{ID: 1337}
+		key := makePrefix(codeHeader, unittest.IdentifierFixture())
+		require.NoError(t, db.Update(insert(key, e)))
+		var retrieved Entity
+		require.NoError(t, db.View(retrieve(key, &retrieved)))
+
+		type logEntry struct {
+			Operation string `json:"operation"`
+			Prefix    string `json:"prefix"`
+			Bytes     int    `json:"bytes"`
+			Message   string `json:"message"`
+		}
+		var entries []logEntry
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var entry logEntry
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+			entries = append(entries, entry)
+		}
+
+		// the first insert also updates the max key size, which is not instrumented
+		require.Len(t, entries, 2)
+		assert.Equal(t, opInsert, entries[0].Operation)
+		assert.Equal(t, opRetrieve, entries[1].Operation)
+		for _, entry := range entries {
+			assert.Equal(t, "header", entry.Prefix)
+			assert.Positive(t, entry.Bytes)
+			assert.Equal(t, "slow storage operation", entry.Message)
+		}
+	})
+}
+
+// TestInstrumentThreshold checks that operations faster than the threshold are not logged.
+func TestInstrumentThreshold(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		var buf bytes.Buffer
+		Instrument(zerolog.New(&buf), false, time.Hour)
+		defer Instrument(zerolog.Nop(), false, 0)
+
+		key := makePrefix(codeHeader, unittest.IdentifierFixture())
+		require.NoError(t, db.Update(insert(key, Entity{ID: 1337})))
+		assert.Zero(t, buf.Len())
+	})
+}
+
+func TestPrefixName(t *testing.T) {
+	assert.Equal(t, "header", prefixName(makePrefix(codeHeader)))
+	assert.Equal(t, "execution_result", prefixName(makePrefix(codeExecutionReceiptMeta)))
+	assert.Equal(t, "code_250", prefixName([]byte{250}))
+	assert.Equal(t, "none", prefixName(nil))
+}
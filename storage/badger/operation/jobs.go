@@ -4,6 +4,7 @@ import (
 	"github.com/dgraph-io/badger/v2"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 )
 
 func RetrieveJobLatestIndex(queue string, index *uint64) func(*badger.Txn) error {
@@ -41,3 +42,43 @@ func InsertProcessedIndex(jobName string, processed uint64) func(*badger.Txn) er
 func SetProcessedIndex(jobName string, processed uint64) func(*badger.Txn) error {
 	return update(makePrefix(codeJobConsumerProcessed, jobName), processed)
 }
+
+// InsertFailedJob inserts the record of a job a job consumer failed to process
+func InsertFailedJob(jobName string, job *storage.FailedJob) func(*badger.Txn) error {
+	return insert(makePrefix(codeJobFailed, jobName, job.Index), job)
+}
+
+// UpdateFailedJob updates the record of a job a job consumer failed to process
+func UpdateFailedJob(jobName string, job *storage.FailedJob) func(*badger.Txn) error {
+	return update(makePrefix(codeJobFailed, jobName, job.Index), job)
+}
+
+// RetrieveFailedJob retrieves the record of the failed job at the given index
+func RetrieveFailedJob(jobName string, index uint64, job *storage.FailedJob) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeJobFailed, jobName, index), job)
+}
+
+// RemoveFailedJob removes the record of the failed job at the given index
+func RemoveFailedJob(jobName string, index uint64) func(*badger.Txn) error {
+	return remove(makePrefix(codeJobFailed, jobName, index))
+}
+
+// TraverseFailedJobs retrieves the records of all jobs a job consumer failed to process, ordered by index
+func TraverseFailedJobs(jobName string, jobs *[]*storage.FailedJob) func(*badger.Txn) error {
+	iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val *storage.FailedJob
+		create := func() interface{} {
+			val = new(storage.FailedJob)
+			return val
+		}
+		handle := func() error {
+			*jobs = append(*jobs, val)
+			return nil
+		}
+		return check, create, handle
+	}
+	return traverse(makePrefix(codeJobFailed, jobName), iterationFunc)
+}
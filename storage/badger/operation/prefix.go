@@ -12,8 +12,9 @@ import (
 const (
 
 	// codes for special database markers
-	codeMax    = 1 // keeps track of the maximum key size
-	codeDBType = 2 // specifies a database type
+	codeMax      = 1 // keeps track of the maximum key size
+	codeDBType   = 2 // specifies a database type
+	codeDBFormat = 3 // specifies the encoding format of the stored entities
 
 	// codes for views with special meaning
 	codeStartedView = 10 // latest view hotstuff started
@@ -31,6 +32,7 @@ const (
 	codeExecutedBlock           = 23 // latest executed block with max height
 	codeRootHeight              = 24 // the height of the first loaded block
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
+	codePrunedHeight            = 26 // the height up to which historical data has been pruned
 
 	// codes for single entity storage
 	// 31 was used for identities before epochs
@@ -67,11 +69,14 @@ const (
 	codeEpochSetup       = 61 // EpochSetup service event, keyed by ID
 	codeEpochCommit      = 62 // EpochCommit service event, keyed by ID
 	codeBeaconPrivateKey = 63 // BeaconPrivateKey, keyed by epoch counter
+	codeDKGProgress      = 64 // progress of a local DKG instance, keyed by DKG instance ID
+	codeDKGEvent         = 65 // log of inputs to a local DKG instance, keyed by DKG instance ID and index
 
 	// job queue consumers and producers
 	codeJobConsumerProcessed = 70
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
+	codeJobFailed            = 73 // jobs a job consumer failed to process, keyed by consumer and job index
 
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
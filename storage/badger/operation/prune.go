@@ -0,0 +1,170 @@
+package operation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// NOTE: The prune operations remove historical data which is no longer needed by
+// the protocol. Contrary to the remove operations, they do not fail if the data
+// does not exist, so that partially pruned blocks can be pruned again.
+
+// PruneStats accumulates the number and the estimated size of the entries removed
+// by the prune operations.
+type PruneStats struct {
+	Entries uint64
+	Bytes   uint64
+}
+
+// Add adds the given stats to the stats.
+func (s *PruneStats) Add(other PruneStats) {
+	s.Entries += other.Entries
+	s.Bytes += other.Bytes
+}
+
+// PruneEvents removes all events and service events emitted within the given block.
+func PruneEvents(blockID flow.Identifier, stats *PruneStats) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		err := pruneByPrefix(makePrefix(codeEvent, blockID), stats)(tx)
+		if err != nil {
+			return fmt.Errorf("could not prune events: %w", err)
+		}
+		err = pruneByPrefix(makePrefix(codeServiceEvent, blockID), stats)(tx)
+		if err != nil {
+			return fmt.Errorf("could not prune service events: %w", err)
+		}
+		return nil
+	}
+}
+
+// PruneTransactionResults removes the results of all transactions executed within the given block.
+func PruneTransactionResults(blockID flow.Identifier, stats *PruneStats) func(*badger.Txn) error {
+	return pruneByPrefix(makePrefix(codeTransactionResult, blockID), stats)
+}
+
+// PruneOwnExecutionReceipt removes the index of the receipt the execution node
+// produced for the given block. The receipt itself is kept, as it might be
+// referenced by block payloads.
+func PruneOwnExecutionReceipt(blockID flow.Identifier, stats *PruneStats) func(*badger.Txn) error {
+	return prune(makePrefix(codeOwnBlockReceipt, blockID), stats)
+}
+
+// PruneChunkDataPack removes the chunk data pack with the given chunk ID.
+func PruneChunkDataPack(chunkID flow.Identifier, stats *PruneStats) func(*badger.Txn) error {
+	return prune(makePrefix(codeChunkDataPack, chunkID), stats)
+}
+
+// PruneCollection removes the light collection with the given ID, together with
+// its transactions and their index to the collection.
+func PruneCollection(collID flow.Identifier, stats *PruneStats) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var collection flow.LightCollection
+		err := RetrieveCollection(collID, &collection)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not retrieve collection: %w", err)
+		}
+
+		for _, txID := range collection.Transactions {
+			err = prune(makePrefix(codeTransaction, txID), stats)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune transaction %x: %w", txID, err)
+			}
+
+			// the same transaction might be included in another collection, only remove
+			// the index if it points to the pruned collection
+			var indexedCollID flow.Identifier
+			err = RetrieveCollectionID(txID, &indexedCollID)(tx)
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("could not retrieve collection index of transaction %x: %w", txID, err)
+			}
+			if indexedCollID != collID {
+				continue
+			}
+			err = prune(makePrefix(codeIndexCollectionByTransaction, txID), stats)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune collection index of transaction %x: %w", txID, err)
+			}
+		}
+
+		return prune(makePrefix(codeCollection, collID), stats)(tx)
+	}
+}
+
+// InsertPrunedHeight inserts the height up to which historical data has been pruned.
+func InsertPrunedHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codePrunedHeight), height)
+}
+
+// UpdatePrunedHeight updates the height up to which historical data has been pruned.
+func UpdatePrunedHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codePrunedHeight), height)
+}
+
+// RetrievePrunedHeight retrieves the height up to which historical data has been pruned.
+func RetrievePrunedHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codePrunedHeight), height)
+}
+
+// prune removes the entry with the given key, if it exists, and records it in the stats.
+func prune(key []byte, stats *PruneStats) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		item, err := tx.Get(key)
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not check key: %w", err)
+		}
+		size := uint64(item.EstimatedSize())
+
+		err = tx.Delete(key)
+		if err != nil {
+			return fmt.Errorf("could not delete key: %w", err)
+		}
+		stats.Entries++
+		stats.Bytes += size
+		return nil
+	}
+}
+
+// pruneByPrefix removes all entries whose key starts with the given prefix and
+// records them in the stats.
+func pruneByPrefix(prefix []byte, stats *PruneStats) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Prefix = prefix
+		it := tx.NewIterator(opts)
+
+		// collect the keys first, deleting while iterating is not supported
+		var keys [][]byte
+		var size uint64
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			keys = append(keys, item.KeyCopy(nil))
+			size += uint64(item.EstimatedSize())
+		}
+		it.Close()
+
+		for _, key := range keys {
+			err := tx.Delete(key)
+			if err != nil {
+				return fmt.Errorf("could not delete key: %w", err)
+			}
+		}
+		stats.Entries += uint64(len(keys))
+		stats.Bytes += size
+		return nil
+	}
+}
@@ -0,0 +1,405 @@
+package badger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// PrunerConfig configures which historical data is pruned and how much of it is retained.
+type PrunerConfig struct {
+	// RetentionHeights is the number of sealed heights below the latest sealed height
+	// for which the data is retained. Pruning is disabled if zero.
+	RetentionHeights uint64
+	// RetentionPeriod additionally retains the data of all blocks younger than the
+	// period, if non-zero.
+	RetentionPeriod time.Duration
+	// BatchSize is the maximum number of heights pruned within one database transaction.
+	BatchSize uint
+	// Interval is the interval between periodic pruning runs. If zero, pruning is only
+	// run on demand.
+	Interval time.Duration
+
+	// the historical data to prune
+	Events              bool
+	TransactionResults  bool
+	ChunkDataPacks      bool
+	OwnExecutionReceipt bool
+	Collections         bool
+}
+
+// DefaultPrunerConfig returns the default pruner configuration, with pruning disabled.
+func DefaultPrunerConfig() PrunerConfig {
+	return PrunerConfig{
+		RetentionHeights: 0,
+		RetentionPeriod:  0,
+		BatchSize:        100,
+		Interval:         10 * time.Minute,
+	}
+}
+
+// PruneResult summarizes a pruning run.
+type PruneResult struct {
+	FromHeight uint64 `json:"from_height"`
+	ToHeight   uint64 `json:"to_height"`
+	Heights    uint64 `json:"heights"`
+	Entries    uint64 `json:"entries"`
+	Bytes      uint64 `json:"bytes"`
+}
+
+// Pruner deletes historical data of sealed blocks which are older than the
+// configured retention, in bounded batches. The height up to which data has been
+// pruned is persisted, so that pruning resumes where it stopped after a restart.
+//
+// Only data of sealed blocks is pruned: results for unsealed blocks might still be
+// verified, which requires their chunk data packs. The retention below the latest
+// sealed height gives verification nodes time to finish fetching the chunk data packs
+// of results which have just been sealed.
+//
+// NOTE: the pruner removes the data from the database only, the storage caches might
+// still serve pruned entries until they are evicted.
+type Pruner struct {
+	unit    *engine.Unit
+	log     zerolog.Logger
+	db      *badger.DB
+	metrics module.PrunerMetrics
+	config  PrunerConfig
+	limits  []func() (uint64, error)
+	mu      sync.Mutex // ensures only one pruning run at a time
+}
+
+// PrunerOption configures the pruner.
+type PrunerOption func(*Pruner)
+
+// WithHeightLimit limits the pruned heights to the height returned by the function,
+// for instance to the highest executed height on execution nodes.
+func WithHeightLimit(limit func() (uint64, error)) PrunerOption {
+	return func(p *Pruner) {
+		p.limits = append(p.limits, limit)
+	}
+}
+
+// NewPruner returns a new pruner for the given database.
+func NewPruner(log zerolog.Logger, db *badger.DB, metrics module.PrunerMetrics, config PrunerConfig, opts ...PrunerOption) (*Pruner, error) {
+	if config.BatchSize == 0 {
+		return nil, fmt.Errorf("pruning batch size must be positive")
+	}
+
+	p := &Pruner{
+		unit:    engine.NewUnit(),
+		log:     log.With().Str("component", "pruner").Logger(),
+		db:      db,
+		metrics: metrics,
+		config:  config,
+	}
+	for _, apply := range opts {
+		apply(p)
+	}
+	return p, nil
+}
+
+// Ready starts the periodic pruning, if enabled.
+func (p *Pruner) Ready() <-chan struct{} {
+	if p.config.RetentionHeights > 0 && p.config.Interval > 0 {
+		p.unit.LaunchPeriodically(p.prunePeriodically, p.config.Interval, p.config.Interval)
+	}
+	return p.unit.Ready()
+}
+
+// Done stops the periodic pruning and waits for a running pruning run to stop.
+func (p *Pruner) Done() <-chan struct{} {
+	return p.unit.Done()
+}
+
+func (p *Pruner) prunePeriodically() {
+	result, err := p.Prune(p.unit.Ctx())
+	if err != nil {
+		p.log.Error().Err(err).Msg("pruning failed")
+		return
+	}
+	if result.Heights > 0 {
+		p.log.Info().
+			Uint64("from_height", result.FromHeight).
+			Uint64("to_height", result.ToHeight).
+			Uint64("entries", result.Entries).
+			Uint64("bytes", result.Bytes).
+			Msg("pruned historical data")
+	}
+}
+
+// Prune deletes the historical data of all sealed heights below the retention, which
+// have not been pruned yet. It stops early if the context is cancelled, after
+// committing the current batch.
+func (p *Pruner) Prune(ctx context.Context) (*PruneResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := &PruneResult{}
+	if p.config.RetentionHeights == 0 {
+		return result, nil
+	}
+
+	next, err := p.nextHeight()
+	if err != nil {
+		return nil, err
+	}
+	target, ok, err := p.targetHeight()
+	if err != nil {
+		return nil, err
+	}
+	if !ok || target < next {
+		return result, nil
+	}
+
+	var cutoff time.Time
+	if p.config.RetentionPeriod > 0 {
+		cutoff = time.Now().Add(-p.config.RetentionPeriod)
+	}
+
+	result.FromHeight = next
+	batchSize := uint64(p.config.BatchSize)
+	for next <= target {
+		select {
+		case <-ctx.Done():
+			return result, nil
+		default:
+		}
+
+		to := next + batchSize - 1
+		if to > target {
+			to = target
+		}
+
+		started := time.Now()
+		var stats operation.PruneStats
+		var pruned uint64
+		err := p.db.Update(func(tx *badger.Txn) error {
+			var err error
+			stats = operation.PruneStats{}
+			pruned, err = p.pruneHeights(next, to, cutoff, &stats)(tx)
+			return err
+		})
+		// a batch which exceeds the transaction limits is retried with fewer heights
+		if errors.Is(err, badger.ErrTxnTooBig) && batchSize > 1 {
+			batchSize /= 2
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not prune heights [%d, %d]: %w", next, to, err)
+		}
+		if pruned == 0 {
+			// the next block is still within the retention period
+			break
+		}
+
+		last := next + pruned - 1
+		p.metrics.PrunedBatch(stats.Entries, stats.Bytes, time.Since(started))
+		p.metrics.PrunedHeight(last)
+		p.log.Debug().
+			Uint64("from_height", next).
+			Uint64("to_height", last).
+			Uint64("entries", stats.Entries).
+			Uint64("bytes", stats.Bytes).
+			Msg("pruned batch")
+
+		result.ToHeight = last
+		result.Heights += pruned
+		result.Entries += stats.Entries
+		result.Bytes += stats.Bytes
+		next = last + 1
+
+		if last < to {
+			// the next block is still within the retention period
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// nextHeight returns the lowest height which has not been pruned yet.
+func (p *Pruner) nextHeight() (uint64, error) {
+	var pruned uint64
+	err := p.db.View(operation.RetrievePrunedHeight(&pruned))
+	if err == nil {
+		return pruned + 1, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("could not retrieve pruned height: %w", err)
+	}
+
+	// nothing has been pruned yet, start at the root block
+	var root uint64
+	err = p.db.View(operation.RetrieveRootHeight(&root))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve root height: %w", err)
+	}
+	return root, nil
+}
+
+// targetHeight returns the highest height which may be pruned, or false if no height
+// may be pruned yet.
+func (p *Pruner) targetHeight() (uint64, bool, error) {
+	var sealed uint64
+	err := p.db.View(operation.RetrieveSealedHeight(&sealed))
+	if err != nil {
+		return 0, false, fmt.Errorf("could not retrieve sealed height: %w", err)
+	}
+	if sealed <= p.config.RetentionHeights {
+		return 0, false, nil
+	}
+	target := sealed - p.config.RetentionHeights
+
+	for _, limit := range p.limits {
+		height, err := limit()
+		if err != nil {
+			return 0, false, fmt.Errorf("could not get pruning height limit: %w", err)
+		}
+		if height < target {
+			target = height
+		}
+	}
+	return target, true, nil
+}
+
+// pruneHeights prunes the blocks finalized at the heights from `from` to `to` and
+// updates the pruned height accordingly. It stops at the first block which is younger
+// than the cutoff and returns the number of pruned heights.
+func (p *Pruner) pruneHeights(from uint64, to uint64, cutoff time.Time, stats *operation.PruneStats) func(*badger.Txn) (uint64, error) {
+	return func(tx *badger.Txn) (uint64, error) {
+		pruned := uint64(0)
+		for height := from; height <= to; height++ {
+			var blockID flow.Identifier
+			err := operation.LookupBlockHeight(height, &blockID)(tx)
+			if err != nil {
+				return 0, fmt.Errorf("could not look up block at height %d: %w", height, err)
+			}
+
+			if !cutoff.IsZero() {
+				var header flow.Header
+				err = operation.RetrieveHeader(blockID, &header)(tx)
+				if err != nil {
+					return 0, fmt.Errorf("could not retrieve header of block %x: %w", blockID, err)
+				}
+				if header.Timestamp.After(cutoff) {
+					break
+				}
+			}
+
+			err = p.pruneBlock(blockID, stats)(tx)
+			if err != nil {
+				return 0, fmt.Errorf("could not prune block %x at height %d: %w", blockID, height, err)
+			}
+			pruned++
+		}
+		if pruned == 0 {
+			return 0, nil
+		}
+
+		err := operation.UpdatePrunedHeight(from + pruned - 1)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			err = operation.InsertPrunedHeight(from + pruned - 1)(tx)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("could not update pruned height: %w", err)
+		}
+		return pruned, nil
+	}
+}
+
+// pruneBlock prunes the configured historical data of the given block.
+func (p *Pruner) pruneBlock(blockID flow.Identifier, stats *operation.PruneStats) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if p.config.Events {
+			err := operation.PruneEvents(blockID, stats)(tx)
+			if err != nil {
+				return err
+			}
+		}
+		if p.config.TransactionResults {
+			err := operation.PruneTransactionResults(blockID, stats)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune transaction results: %w", err)
+			}
+		}
+		if p.config.OwnExecutionReceipt {
+			err := operation.PruneOwnExecutionReceipt(blockID, stats)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune own execution receipt: %w", err)
+			}
+		}
+		if p.config.ChunkDataPacks {
+			err := p.pruneChunkDataPacks(blockID, stats)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune chunk data packs: %w", err)
+			}
+		}
+		if p.config.Collections {
+			err := p.pruneCollections(blockID, stats)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune collections: %w", err)
+			}
+		}
+		return nil
+	}
+}
+
+// pruneChunkDataPacks prunes the chunk data packs of the execution result indexed for the block.
+func (p *Pruner) pruneChunkDataPacks(blockID flow.Identifier, stats *operation.PruneStats) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var resultID flow.Identifier
+		err := operation.LookupExecutionResult(blockID, &resultID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not look up execution result: %w", err)
+		}
+		var result flow.ExecutionResult
+		err = operation.RetrieveExecutionResult(resultID, &result)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve execution result: %w", err)
+		}
+
+		for _, chunk := range result.Chunks {
+			err = operation.PruneChunkDataPack(chunk.ID(), stats)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune chunk data pack of chunk %d: %w", chunk.Index, err)
+			}
+		}
+		return nil
+	}
+}
+
+// pruneCollections prunes the collections guaranteed in the payload of the block.
+func (p *Pruner) pruneCollections(blockID flow.Identifier, stats *operation.PruneStats) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var collIDs []flow.Identifier
+		err := operation.LookupPayloadGuarantees(blockID, &collIDs)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not look up payload guarantees: %w", err)
+		}
+
+		for _, collID := range collIDs {
+			err = operation.PruneCollection(collID, stats)(tx)
+			if err != nil {
+				return fmt.Errorf("could not prune collection %x: %w", collID, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,221 @@
+package badger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// prunableBlock holds the historical data stored for a block in the pruner tests.
+type prunableBlock struct {
+	blockID flow.Identifier
+	txID    flow.Identifier
+	collID  flow.Identifier
+	chunkID flow.Identifier
+}
+
+// storePrunableChain stores a chain of finalized blocks from the root height to the
+// sealed height, with historical data for each block. Blocks below the recent height
+// are older than an hour, the other blocks were just produced.
+func storePrunableChain(t *testing.T, db *badger.DB, root uint64, sealed uint64, recent uint64) map[uint64]prunableBlock {
+	blocks := make(map[uint64]prunableBlock)
+	for height := root; height <= sealed; height++ {
+		header := unittest.BlockHeaderFixture()
+		header.Height = height
+		header.Timestamp = time.Now().UTC()
+		if height < recent {
+			header.Timestamp = header.Timestamp.Add(-time.Hour)
+		}
+		blockID := header.ID()
+
+		txBody := unittest.TransactionBodyFixture()
+		collection := flow.LightCollection{Transactions: []flow.Identifier{txBody.ID()}}
+		event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, txBody.ID(), 0)
+		result := unittest.ExecutionResultFixture(unittest.WithBlock(&flow.Block{Header: &header}))
+		chunkID := result.Chunks[0].ID()
+
+		err := db.Update(func(tx *badger.Txn) error {
+			require.NoError(t, operation.InsertHeader(blockID, &header)(tx))
+			require.NoError(t, operation.IndexBlockHeight(height, blockID)(tx))
+			require.NoError(t, operation.InsertEvent(blockID, event)(tx))
+			require.NoError(t, operation.InsertTransactionResult(blockID, &flow.TransactionResult{TransactionID: txBody.ID()})(tx))
+			require.NoError(t, operation.IndexOwnExecutionReceipt(blockID, unittest.IdentifierFixture())(tx))
+			require.NoError(t, operation.InsertTransaction(txBody.ID(), &txBody)(tx))
+			require.NoError(t, operation.InsertCollection(&collection)(tx))
+			require.NoError(t, operation.IndexCollectionByTransaction(txBody.ID(), collection.ID())(tx))
+			require.NoError(t, operation.IndexPayloadGuarantees(blockID, []flow.Identifier{collection.ID()})(tx))
+			require.NoError(t, operation.InsertExecutionResult(result)(tx))
+			require.NoError(t, operation.IndexExecutionResult(blockID, result.ID())(tx))
+			require.NoError(t, operation.InsertChunkDataPack(&badgermodel.StoredChunkDataPack{ChunkID: chunkID})(tx))
+			return nil
+		})
+		require.NoError(t, err)
+
+		blocks[height] = prunableBlock{
+			blockID: blockID,
+			txID:    txBody.ID(),
+			collID:  collection.ID(),
+			chunkID: chunkID,
+		}
+	}
+
+	err := db.Update(func(tx *badger.Txn) error {
+		require.NoError(t, operation.InsertRootHeight(root)(tx))
+		require.NoError(t, operation.InsertSealedHeight(sealed)(tx))
+		return nil
+	})
+	require.NoError(t, err)
+
+	return blocks
+}
+
+// assertPruned checks whether the historical data of the block has been pruned.
+func assertPruned(t *testing.T, db *badger.DB, block prunableBlock, pruned bool) {
+	check := func(err error) {
+		if pruned {
+			assert.True(t, errors.Is(err, storage.ErrNotFound), "expected data of block %x to be pruned, got: %v", block.blockID, err)
+		} else {
+			assert.NoError(t, err, "expected data of block %x to be retained", block.blockID)
+		}
+	}
+
+	var events []flow.Event
+	require.NoError(t, db.View(operation.LookupEventsByBlockID(block.blockID, &events)))
+	assert.Equal(t, pruned, len(events) == 0)
+
+	var txResult flow.TransactionResult
+	check(db.View(operation.RetrieveTransactionResult(block.blockID, block.txID, &txResult)))
+	var receiptID flow.Identifier
+	check(db.View(operation.LookupOwnExecutionReceipt(block.blockID, &receiptID)))
+	var txBody flow.TransactionBody
+	check(db.View(operation.RetrieveTransaction(block.txID, &txBody)))
+	var collection flow.LightCollection
+	check(db.View(operation.RetrieveCollection(block.collID, &collection)))
+	var collID flow.Identifier
+	check(db.View(operation.RetrieveCollectionID(block.txID, &collID)))
+	var chunkDataPack badgermodel.StoredChunkDataPack
+	check(db.View(operation.RetrieveChunkDataPack(block.chunkID, &chunkDataPack)))
+
+	// protocol data is never pruned
+	var header flow.Header
+	assert.NoError(t, db.View(operation.RetrieveHeader(block.blockID, &header)))
+}
+
+func prunerConfig(retention uint64) badgerstorage.PrunerConfig {
+	config := badgerstorage.DefaultPrunerConfig()
+	config.RetentionHeights = retention
+	config.BatchSize = 3
+	config.Events = true
+	config.TransactionResults = true
+	config.ChunkDataPacks = true
+	config.OwnExecutionReceipt = true
+	config.Collections = true
+	return config
+}
+
+// TestPruner_RetentionHeights checks that the data of all sealed heights below the
+// retention is pruned in batches, and that pruning resumes at the pruned height.
+func TestPruner_RetentionHeights(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		blocks := storePrunableChain(t, db, 10, 30, 0)
+
+		pruner, err := badgerstorage.NewPruner(unittest.Logger(), db, metrics.NewNoopCollector(), prunerConfig(5))
+		require.NoError(t, err)
+
+		result, err := pruner.Prune(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), result.FromHeight)
+		assert.Equal(t, uint64(25), result.ToHeight)
+		assert.Equal(t, uint64(16), result.Heights)
+		assert.Positive(t, result.Entries)
+		assert.Positive(t, result.Bytes)
+
+		for height, block := range blocks {
+			assertPruned(t, db, block, height <= 25)
+		}
+		var pruned uint64
+		require.NoError(t, db.View(operation.RetrievePrunedHeight(&pruned)))
+		assert.Equal(t, uint64(25), pruned)
+
+		// nothing is left to prune until the sealed height increases
+		result, err = pruner.Prune(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, result.Heights)
+
+		require.NoError(t, db.Update(operation.UpdateSealedHeight(32)))
+		result, err = pruner.Prune(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(26), result.FromHeight)
+		assert.Equal(t, uint64(27), result.ToHeight)
+		for height, block := range blocks {
+			assertPruned(t, db, block, height <= 27)
+		}
+	})
+}
+
+// TestPruner_RetentionPeriod checks that blocks younger than the retention period are retained.
+func TestPruner_RetentionPeriod(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		blocks := storePrunableChain(t, db, 10, 30, 15)
+
+		config := prunerConfig(5)
+		config.RetentionPeriod = time.Minute
+		pruner, err := badgerstorage.NewPruner(unittest.Logger(), db, metrics.NewNoopCollector(), config)
+		require.NoError(t, err)
+
+		result, err := pruner.Prune(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(14), result.ToHeight)
+		for height, block := range blocks {
+			assertPruned(t, db, block, height < 15)
+		}
+	})
+}
+
+// TestPruner_HeightLimit checks that the heights above the height limit are retained.
+func TestPruner_HeightLimit(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		blocks := storePrunableChain(t, db, 10, 30, 0)
+
+		limit := func() (uint64, error) { return 20, nil }
+		pruner, err := badgerstorage.NewPruner(unittest.Logger(), db, metrics.NewNoopCollector(), prunerConfig(5), badgerstorage.WithHeightLimit(limit))
+		require.NoError(t, err)
+
+		result, err := pruner.Prune(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(20), result.ToHeight)
+		for height, block := range blocks {
+			assertPruned(t, db, block, height <= 20)
+		}
+	})
+}
+
+// TestPruner_Disabled checks that nothing is pruned without a retention.
+func TestPruner_Disabled(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		blocks := storePrunableChain(t, db, 10, 30, 0)
+
+		pruner, err := badgerstorage.NewPruner(unittest.Logger(), db, metrics.NewNoopCollector(), prunerConfig(0))
+		require.NoError(t, err)
+
+		result, err := pruner.Prune(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, result.Heights)
+		for _, block := range blocks {
+			assertPruned(t, db, block, false)
+		}
+	})
+}
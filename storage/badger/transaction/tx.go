@@ -1,9 +1,36 @@
 package transaction
 
 import (
+	"sync/atomic"
+	"time"
+
 	dbbadger "github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/module/metrics"
 )
 
+// commitMetrics is non-zero if the duration of commits is reported, see EnableCommitMetrics.
+var commitMetrics int32
+
+// EnableCommitMetrics enables or disables reporting the duration of committed transactions
+// and write batches. Commits are not reported unless enabled.
+func EnableCommitMetrics(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&commitMetrics, value)
+}
+
+// ObserveCommit reports a commit of the given kind which started at the given time, if
+// commit metrics are enabled.
+func ObserveCommit(kind string, started time.Time) {
+	if atomic.LoadInt32(&commitMetrics) == 0 {
+		return
+	}
+	metrics.GetStorageCollector().Commit(kind, time.Since(started))
+}
+
 type Tx struct {
 	DBTxn     *dbbadger.Txn
 	callbacks []func()
@@ -30,10 +57,12 @@ func Update(db *dbbadger.DB, f func(*Tx) error) error {
 		return err
 	}
 
+	started := time.Now()
 	err = dbTxn.Commit()
 	if err != nil {
 		return err
 	}
+	ObserveCommit("transaction", started)
 
 	for _, callback := range tx.callbacks {
 		callback()
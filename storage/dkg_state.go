@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/dkg"
+)
+
+// DKGState stores the progress of the local DKG instances, which allows a
+// consensus node restarting during the epoch setup phase to resume its
+// participation in the DKG. The stored data includes the seed of the local
+// Joint-Feldman instance and the private shares received from the other
+// participants, so it must only be kept in the secrets database.
+type DKGState interface {
+
+	// InsertDKGProgress inserts the progress of a new DKG instance.
+	InsertDKGProgress(dkgInstanceID string, progress *dkg.Progress) error
+
+	// UpdateDKGProgress updates the progress of an existing DKG instance.
+	UpdateDKGProgress(dkgInstanceID string, progress *dkg.Progress) error
+
+	// RetrieveDKGProgress retrieves the progress of a DKG instance.
+	RetrieveDKGProgress(dkgInstanceID string) (*dkg.Progress, error)
+
+	// InsertDKGEvent inserts the event with the given index into the log of a
+	// DKG instance.
+	InsertDKGEvent(dkgInstanceID string, index uint64, event *dkg.Event) error
+
+	// RetrieveDKGEvents retrieves the log of a DKG instance, ordered by index.
+	RetrieveDKGEvents(dkgInstanceID string) ([]*dkg.Event, error)
+}
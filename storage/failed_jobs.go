@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"time"
+)
+
+// FailedJob is a job of a job queue which could not be processed by the job consumer,
+// even after retrying it.
+type FailedJob struct {
+	Index    uint64    // index of the job in the job queue
+	JobID    string    // ID of the job
+	Error    string    // error of the last attempt to process the job
+	Attempts uint      // number of attempts to process the job
+	FailedAt time.Time // time of the last failed attempt
+	Requeued bool      // whether the job was re-queued to be processed again
+}
+
+// FailedJobs records the jobs a job consumer failed to process, i.e. it is the
+// dead-letter queue of the consumer.
+type FailedJobs interface {
+	// Store records the given failed job, overwriting the record of a job at the same index.
+	Store(job *FailedJob) error
+
+	// ByIndex returns the failed job at the given index in the job queue.
+	// It returns storage.ErrNotFound if the job at the index has not failed.
+	ByIndex(index uint64) (*FailedJob, error)
+
+	// All returns all failed jobs, ordered by their index.
+	All() ([]*FailedJob, error)
+
+	// Requeued returns the failed jobs which were re-queued, ordered by their index.
+	Requeued() ([]*FailedJob, error)
+
+	// Requeue marks the failed job at the given index to be processed again by the consumer.
+	// It returns storage.ErrNotFound if the job at the index has not failed.
+	Requeue(index uint64) error
+
+	// Remove removes the record of the failed job at the given index, e.g. once it was
+	// processed successfully.
+	Remove(index uint64) error
+}
@@ -71,6 +71,7 @@ import "C"
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -492,6 +493,33 @@ func fatalPanic(err string) {
 // Consider calling explicity before exiting process.
 // Called explicity in tests so as not to wait one second.
 func Dump(dmpNonDefaultName string) {
+	seconds := uint64(time.Now().Unix())
+	fileTmp := fmt.Sprintf("%s/%s.%d.tmp%s", global.dmpPath, global.dmpName, seconds, dmpNonDefaultName)
+	fileNew := fmt.Sprintf("%s/%s%s", global.dmpPath, global.dmpName, dmpNonDefaultName)
+	f, err := os.Create(fileTmp)
+	if err != nil {
+		fatalPanic(fmt.Sprintf("ERROR: BINSTAT: .Create(%s)=%s", fileTmp, err))
+	}
+	err = Snapshot(f)
+	if err != nil {
+		fatalPanic(fmt.Sprintf("ERROR: BINSTAT: .Fprintf()=%s", err))
+	}
+	err = f.Close()
+	if err != nil {
+		fatalPanic(fmt.Sprintf("ERROR: BINSTAT: .Close()=%s", err))
+	}
+	err = os.Rename(fileTmp, fileNew) // atomically rename / move on Linux :-)
+	if err != nil {
+		// sometimes -- very infrequently -- we come here with the error: "no such file or directory"
+		// in theory only one go-routine should write this uniquely named per second file, so how can the file 'disappear' for renaming?
+		// therefore this error results in a warning and not an error / panic, and the next second we just write the file again hopefuly :-)
+		global.log.Warn().Msgf("WARN: .Rename(%s, %s)=%s\n", fileTmp, fileNew, err)
+	}
+}
+
+// Snapshot writes the bins in the binstat text format to the given writer.
+// Used by Dump, and e.g. to take a snapshot on demand without touching the dump file.
+func Snapshot(w io.Writer) error {
 	bs := enterTime("internal-dump")
 	defer leave(bs)
 
@@ -518,32 +546,16 @@ func Dump(dmpNonDefaultName string) {
 		atomic.StoreUint64(&global.accumMono[i], v2)
 	}
 
-	fileTmp := fmt.Sprintf("%s/%s.%d.tmp%s", global.dmpPath, global.dmpName, seconds, dmpNonDefaultName)
-	fileNew := fmt.Sprintf("%s/%s%s", global.dmpPath, global.dmpName, dmpNonDefaultName)
-	f, err := os.Create(fileTmp)
-	if err != nil {
-		fatalPanic(fmt.Sprintf("ERROR: BINSTAT: .Create(%s)=%s", fileTmp, err))
-	}
 	for i := range global.keysArray {
 		// grab these atomically (because they may be atomically updated in parallel) so as not to trigger Golang's "WARNING: DATA RACE"
 		u1 := atomic.LoadUint64(&global.frequency[i])
 		u2 := atomic.LoadUint64(&global.accumMono[i])
-		_, err := fmt.Fprintf(f, "%s=%d %f%s\n", global.keysArray[i], u1, time.Duration(u2).Seconds(), global.keysEgLoc[i])
+		_, err := fmt.Fprintf(w, "%s=%d %f%s\n", global.keysArray[i], u1, time.Duration(u2).Seconds(), global.keysEgLoc[i])
 		if err != nil {
-			fatalPanic(fmt.Sprintf("ERROR: BINSTAT: .Fprintf()=%s", err))
+			return err
 		}
 	}
-	err = f.Close()
-	if err != nil {
-		fatalPanic(fmt.Sprintf("ERROR: BINSTAT: .Close()=%s", err))
-	}
-	err = os.Rename(fileTmp, fileNew) // atomically rename / move on Linux :-)
-	if err != nil {
-		// sometimes -- very infrequently -- we come here with the error: "no such file or directory"
-		// in theory only one go-routine should write this uniquely named per second file, so how can the file 'disappear' for renaming?
-		// therefore this error results in a warning and not an error / panic, and the next second we just write the file again hopefuly :-)
-		global.log.Warn().Msgf("WARN: .Rename(%s, %s)=%s\n", fileTmp, fileNew, err)
-	}
+	return nil
 }
 
 // functions for exposing binstat internals e.g. for running non-production experiments sampling data, etc
@@ -0,0 +1,108 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"github.com/onflow/flow-go/utils/binstat"
+)
+
+// profileSequence numbers the profile files, so profiles captured at the same time don't overwrite each other
+var profileSequence uint64
+
+// profileFile creates a new file for the given profile in the given directory, the file
+// name is the profile name followed by the current time and a sequence number.
+func profileFile(dir string, profile string) (*os.File, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("could not create profile dir: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-%d", profile, time.Now().Format(time.RFC3339Nano), atomic.AddUint64(&profileSequence, 1)))
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s file: %w", profile, err)
+	}
+	return f, nil
+}
+
+// closeProfileFile closes the given profile file, returning the given error if not nil
+// or the error closing the file otherwise.
+func closeProfileFile(f *os.File, err error) error {
+	closeErr := f.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return fmt.Errorf("could not close %s: %w", f.Name(), closeErr)
+	}
+	return nil
+}
+
+// WriteProfile writes the runtime profile with the given name, e.g. "heap", "allocs",
+// "goroutine", "block" or "mutex", to a new file in the given directory. It returns
+// the path of the file.
+func WriteProfile(dir string, profile string) (string, error) {
+	p := pprof.Lookup(profile)
+	if p == nil {
+		return "", fmt.Errorf("unknown profile: %s", profile)
+	}
+
+	f, err := profileFile(dir, profile)
+	if err != nil {
+		return "", err
+	}
+
+	err = p.WriteTo(f, 0)
+	if err != nil {
+		err = fmt.Errorf("could not write %s profile: %w", profile, err)
+	}
+	return f.Name(), closeProfileFile(f, err)
+}
+
+// WriteCPUProfile profiles the CPU for the given duration and writes the profile to a
+// new file in the given directory. Profiling stops early if the context is canceled.
+// It returns the path of the file. Only one CPU profile can be running at a time.
+func WriteCPUProfile(ctx context.Context, dir string, duration time.Duration) (string, error) {
+	f, err := profileFile(dir, "cpu")
+	if err != nil {
+		return "", err
+	}
+
+	err = pprof.StartCPUProfile(f)
+	if err != nil {
+		closeErr := closeProfileFile(f, fmt.Errorf("could not start CPU profile: %w", err))
+		_ = os.Remove(f.Name())
+		return "", closeErr
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	pprof.StopCPUProfile()
+
+	return f.Name(), closeProfileFile(f, nil)
+}
+
+// WriteBinstat writes a snapshot of the binstat bins to a new file in the given directory.
+// It returns the path of the file.
+func WriteBinstat(dir string) (string, error) {
+	f, err := profileFile(dir, "binstat")
+	if err != nil {
+		return "", err
+	}
+
+	err = binstat.Snapshot(f)
+	if err != nil {
+		err = fmt.Errorf("could not write binstat snapshot: %w", err)
+	}
+	return f.Name(), closeProfileFile(f, err)
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
-	"path/filepath"
-	"runtime/pprof"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -60,56 +58,19 @@ func (p *AutoProfiler) start() {
 }
 
 func (p *AutoProfiler) pprof(profile string) {
+	p.log.Debug().Msgf("capturing %s profile", profile)
 
-	path := filepath.Join(p.dir, fmt.Sprintf("%s-%s", profile, time.Now().Format(time.RFC3339)))
-	log := p.log.With().Str("file", path).Logger()
-	log.Debug().Msgf("capturing %s profile", profile)
-
-	f, err := os.Create(path)
+	path, err := WriteProfile(p.dir, profile)
 	if err != nil {
-		p.log.Error().Err(err).Msgf("failed to open %s file", profile)
-		return
-	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			log.Error().Err(err).Msgf("failed to close %s file", profile)
-		}
-	}()
-
-	err = pprof.Lookup(profile).WriteTo(f, 0)
-	if err != nil {
-		p.log.Error().Err(err).Msgf("failed to write to %s file", profile)
+		p.log.Error().Err(err).Str("file", path).Msgf("failed to write %s profile", profile)
 	}
 }
 
 func (p *AutoProfiler) cpu() {
-	path := filepath.Join(p.dir, fmt.Sprintf("cpu-%s", time.Now().Format(time.RFC3339)))
-	log := p.log.With().Str("file", path).Logger()
-	log.Debug().Msg("capturing cpu profile")
-
-	f, err := os.Create(path)
-	if err != nil {
-		p.log.Error().Err(err).Msg("failed to open cpu file")
-		return
-	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			p.log.Error().Err(err).Msgf("failed to close CPU file")
-		}
-	}()
+	p.log.Debug().Msg("capturing cpu profile")
 
-	err = pprof.StartCPUProfile(f)
+	path, err := WriteCPUProfile(p.unit.Ctx(), p.dir, p.duration)
 	if err != nil {
-		p.log.Error().Err(err).Msg("failed to start CPU profile")
-		return
-	}
-
-	defer pprof.StopCPUProfile()
-
-	select {
-	case <-time.After(p.duration):
-	case <-p.unit.Quit():
+		p.log.Error().Err(err).Str("file", path).Msg("failed to write CPU profile")
 	}
 }
@@ -38,23 +38,44 @@ func (c *memRegisterCache) Persist() error {
 	return nil
 }
 
+// fileRegisterCacheHeader is the first line of a register cache file,
+// it identifies the block the registers have been read at
+type fileRegisterCacheHeader struct {
+	BlockID flow.Identifier `json:"block_id"`
+}
+
 type fileRegisterCache struct {
 	filePath string
+	blockID  flow.Identifier
 	data     map[string]flow.RegisterEntry
 }
 
-func newFileRegisterCache(filePath string) *fileRegisterCache {
-	cache := &fileRegisterCache{filePath: filePath}
+// newFileRegisterCache loads the register cache file at the given path, if it exists.
+// It returns an error if the file caches the registers of a different block.
+func newFileRegisterCache(filePath string, blockID flow.Identifier) (*fileRegisterCache, error) {
+	cache := &fileRegisterCache{filePath: filePath, blockID: blockID}
 	data := make(map[string]flow.RegisterEntry)
 
 	if _, err := os.Stat(filePath); err == nil {
 		f, err := os.Open(filePath)
 		if err != nil {
-			fmt.Printf("error opening file: %v\n", err)
-			os.Exit(1)
+			return nil, fmt.Errorf("error opening file: %w", err)
 		}
 		defer f.Close()
 		r := bufio.NewReader(f)
+
+		line, err := r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("could not read register cache header: %w", err)
+		}
+		var header fileRegisterCacheHeader
+		if err := json.Unmarshal([]byte(line), &header); err != nil || header.BlockID == flow.ZeroID {
+			return nil, fmt.Errorf("register cache file %s does not record the block it was created for, delete it to start over", filePath)
+		}
+		if header.BlockID != blockID {
+			return nil, fmt.Errorf("register cache file %s holds registers of block %v, not of block %v", filePath, header.BlockID, blockID)
+		}
+
 		var s string
 		for {
 			s, err = r.ReadString('\n')
@@ -64,19 +85,19 @@ func newFileRegisterCache(filePath string) *fileRegisterCache {
 			if len(s) > 0 {
 				var d flow.RegisterEntry
 				if err := json.Unmarshal([]byte(s), &d); err != nil {
-					panic(err)
+					return nil, fmt.Errorf("could not decode register: %w", err)
 				}
 				owner, err := hex.DecodeString(d.Key.Owner)
 				if err != nil {
-					panic(err)
+					return nil, fmt.Errorf("could not decode register owner: %w", err)
 				}
 				controller, err := hex.DecodeString(d.Key.Controller)
 				if err != nil {
-					panic(err)
+					return nil, fmt.Errorf("could not decode register controller: %w", err)
 				}
 				keyCopy, err := hex.DecodeString(d.Key.Key)
 				if err != nil {
-					panic(err)
+					return nil, fmt.Errorf("could not decode register key: %w", err)
 				}
 				data[string(owner)+"~"+string(controller)+"~"+string(keyCopy)] = d
 			}
@@ -87,7 +108,7 @@ func newFileRegisterCache(filePath string) *fileRegisterCache {
 	}
 
 	cache.data = data
-	return cache
+	return cache, nil
 }
 
 func (f *fileRegisterCache) Get(owner, controller, key string) ([]byte, bool) {
@@ -101,7 +122,6 @@ func (f *fileRegisterCache) Get(owner, controller, key string) ([]byte, bool) {
 func (f *fileRegisterCache) Set(owner, controller, key string, value []byte) {
 	valueCopy := make([]byte, len(value))
 	copy(valueCopy, value)
-	fmt.Println(hex.EncodeToString([]byte(owner)), hex.EncodeToString([]byte(key)), len(value))
 	f.data[owner+"~"+controller+"~"+key] = flow.RegisterEntry{
 		Key: flow.NewRegisterID(hex.EncodeToString([]byte(owner)),
 			hex.EncodeToString([]byte(controller)),
@@ -117,6 +137,14 @@ func (c *fileRegisterCache) Persist() error {
 	}
 	defer f.Close()
 	w := bufio.NewWriter(f)
+	header, err := json.Marshal(fileRegisterCacheHeader{BlockID: c.blockID})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(header, '\n'))
+	if err != nil {
+		return err
+	}
 	for _, v := range c.data {
 		fltV, err := json.Marshal(v)
 		if err != nil {
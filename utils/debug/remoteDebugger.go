@@ -1,6 +1,8 @@
 package debug
 
 import (
+	"fmt"
+
 	"github.com/onflow/cadence"
 	"github.com/rs/zerolog"
 
@@ -17,22 +19,28 @@ type RemoteDebugger struct {
 
 // Warning : make sure you use the proper flow-go version, same version as the network you are collecting registers
 // from, otherwise the execution might differ from the way runs on the network
+//
+// By default signatures are not verified and fees are not deducted, opts are applied on top of
+// the defaults and can be used to change this, e.g. with fvm.WithTransactionProcessors
+// and fvm.WithTransactionFeesEnabled.
 func NewRemoteDebugger(grpcAddress string,
 	chain flow.Chain,
-	logger zerolog.Logger) *RemoteDebugger {
+	logger zerolog.Logger,
+	opts ...fvm.Option) *RemoteDebugger {
 	vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
 
 	// no signature processor here
-	// TODO Maybe we add fee-deduction step as well
 	ctx := fvm.NewContext(
 		logger,
-		fvm.WithChain(chain),
-		fvm.WithTransactionProcessors(
-			fvm.NewTransactionAccountFrozenChecker(),
-			fvm.NewTransactionSequenceNumberChecker(),
-			fvm.NewTransactionAccountFrozenEnabler(),
-			fvm.NewTransactionInvoker(logger),
-		),
+		append([]fvm.Option{
+			fvm.WithChain(chain),
+			fvm.WithTransactionProcessors(
+				fvm.NewTransactionAccountFrozenChecker(),
+				fvm.NewTransactionSequenceNumberChecker(),
+				fvm.NewTransactionAccountFrozenEnabler(),
+				fvm.NewTransactionInvoker(logger),
+			),
+		}, opts...)...,
 	)
 
 	return &RemoteDebugger{
@@ -57,12 +65,17 @@ func (d *RemoteDebugger) RunTransaction(txBody *flow.TransactionBody) (txErr, pr
 // RunTransaction runs the transaction and tries to collect the registers at the given blockID
 // note that it would be very likely that block is far in the past and you can't find the trie to
 // read the registers from
-// if regCachePath is empty, the register values won't be cached
+// if regCachePath is empty, the register values won't be cached, the register cache file can only be used for
+// the block it was created for
 func (d *RemoteDebugger) RunTransactionAtBlockID(txBody *flow.TransactionBody, blockID flow.Identifier, regCachePath string) (txErr, processError error) {
 	view := NewRemoteView(d.grpcAddress, WithBlockID(blockID))
 	blockCtx := fvm.NewContextFromParent(d.ctx, fvm.WithBlockHeader(d.ctx.BlockHeader))
 	if len(regCachePath) > 0 {
-		view.Cache = newFileRegisterCache(regCachePath)
+		cache, err := newFileRegisterCache(regCachePath, blockID)
+		if err != nil {
+			return nil, err
+		}
+		view.Cache = cache
 	}
 	tx := fvm.Transaction(txBody, 0)
 	err := d.vm.Run(blockCtx, tx, view, programs.NewEmptyPrograms())
@@ -80,6 +93,58 @@ func (d *RemoteDebugger) RunTransactionAtBlockID(txBody *flow.TransactionBody, b
 	return tx.Err, nil
 }
 
+// TransactionReplay is the outcome of replaying a transaction against the registers of a remote execution node
+type TransactionReplay struct {
+	// Procedure holds the events, logs, computation used and error of the transaction
+	Procedure *fvm.TransactionProcedure
+	// RegistersRead are the registers read by the transaction, which were not written by it before
+	RegistersRead []flow.RegisterID
+	// RegisterUpdates are the registers written by the transaction with their new values
+	RegisterUpdates []flow.RegisterEntry
+}
+
+// ReplayTransactionAtBlockID runs the transaction against the registers at the given blockID, using the
+// header of the block for the execution, and returns the outcome of the transaction and the registers it touched.
+// Registers are fetched lazily from the execution node. If regCachePath is not empty, registers are first looked
+// up in the register cache file, and fetched registers are added to it, so repeated runs don't fetch them again.
+// The register cache file records the block it was created for, and can't be used for other blocks.
+func (d *RemoteDebugger) ReplayTransactionAtBlockID(txBody *flow.TransactionBody, blockID flow.Identifier, regCachePath string) (*TransactionReplay, error) {
+	view := NewRemoteView(d.grpcAddress, WithBlockID(blockID))
+	defer view.Done()
+
+	if len(regCachePath) > 0 {
+		cache, err := newFileRegisterCache(regCachePath, blockID)
+		if err != nil {
+			return nil, fmt.Errorf("could not load register cache: %w", err)
+		}
+		view.Cache = cache
+	}
+
+	blockCtx := fvm.NewContextFromParent(d.ctx, fvm.WithBlockHeader(view.BlockHeader))
+	tx := fvm.Transaction(txBody, 0)
+	err := d.vm.Run(blockCtx, tx, view, programs.NewEmptyPrograms())
+	if err != nil {
+		return nil, fmt.Errorf("could not run transaction: %w", err)
+	}
+
+	err = view.Cache.Persist()
+	if err != nil {
+		return nil, fmt.Errorf("could not persist register cache: %w", err)
+	}
+
+	ids, values := view.RegisterUpdates()
+	updates := make([]flow.RegisterEntry, len(ids))
+	for i, id := range ids {
+		updates[i] = flow.RegisterEntry{Key: id, Value: values[i]}
+	}
+
+	return &TransactionReplay{
+		Procedure:       tx,
+		RegistersRead:   view.RegistersRead(),
+		RegisterUpdates: updates,
+	}, nil
+}
+
 func (d *RemoteDebugger) RunScript(code []byte, arguments [][]byte) (value cadence.Value, scriptError, processError error) {
 	view := NewRemoteView(d.grpcAddress)
 	scriptCtx := fvm.NewContextFromParent(d.ctx, fvm.WithBlockHeader(d.ctx.BlockHeader))
@@ -3,6 +3,7 @@ package debug
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"google.golang.org/grpc"
 
@@ -14,11 +15,12 @@ import (
 
 // RemoteView provides a view connected to a live execution node to read the registers
 // writen values are kept inside a map
-//
-// TODO implement register touches
 type RemoteView struct {
-	Parent             *RemoteView
-	Delta              map[string]flow.RegisterValue
+	Parent *RemoteView
+	Delta  map[string]flow.RegisterValue
+	// reads and writes hold the IDs of the registers read and written through this view, keyed like Delta
+	reads              map[string]flow.RegisterID
+	writes             map[string]flow.RegisterID
 	Cache              registerCache
 	BlockID            []byte
 	BlockHeader        *flow.Header
@@ -63,6 +65,8 @@ func NewRemoteView(grpcAddress string, opts ...RemoteViewOption) *RemoteView {
 		connection:         conn,
 		executionAPIclient: execution.NewExecutionAPIClient(conn),
 		Delta:              make(map[string]flow.RegisterValue),
+		reads:              make(map[string]flow.RegisterID),
+		writes:             make(map[string]flow.RegisterID),
 		Cache:              newMemRegisterCache(),
 	}
 
@@ -126,6 +130,8 @@ func (v *RemoteView) NewChild() state.View {
 		connection:         v.connection,
 		Cache:              newMemRegisterCache(),
 		Delta:              make(map[string][]byte),
+		reads:              make(map[string]flow.RegisterID),
+		writes:             make(map[string]flow.RegisterID),
 	}
 }
 
@@ -139,26 +145,44 @@ func (v *RemoteView) MergeView(o state.View) error {
 	for k, value := range other.Delta {
 		v.Delta[k] = value
 	}
+	for k, id := range other.writes {
+		v.writes[k] = id
+	}
+	for k, id := range other.reads {
+		v.reads[k] = id
+	}
 	return nil
 }
 
 func (v *RemoteView) DropDelta() {
 	v.Delta = make(map[string]flow.RegisterValue)
+	v.writes = make(map[string]flow.RegisterID)
 }
 
 func (v *RemoteView) Set(owner, controller, key string, value flow.RegisterValue) error {
-	v.Delta[owner+"~"+controller+"~"+key] = value
+	k := owner + "~" + controller + "~" + key
+	v.Delta[k] = value
+	v.writes[k] = flow.NewRegisterID(owner, controller, key)
 	return nil
 }
 
 func (v *RemoteView) Get(owner, controller, key string) (flow.RegisterValue, error) {
 
 	// first check the delta
-	value, found := v.Delta[owner+"~"+controller+"~"+key]
+	k := owner + "~" + controller + "~" + key
+	value, found := v.Delta[k]
+	if found {
+		return value, nil
+	}
+
+	// registers written by a parent view are not read from the state
+	value, found = v.parentDelta(k)
 	if found {
 		return value, nil
 	}
 
+	v.reads[k] = flow.NewRegisterID(owner, controller, key)
+
 	// then check the read cache
 	value, found = v.Cache.Get(owner, controller, key)
 	if found {
@@ -191,13 +215,56 @@ func (v *RemoteView) Get(owner, controller, key string) (flow.RegisterValue, err
 	return resp.Value, nil
 }
 
+// parentDelta returns the value of the register with the given key written by the closest parent view
+func (v *RemoteView) parentDelta(k string) (flow.RegisterValue, bool) {
+	for parent := v.Parent; parent != nil; parent = parent.Parent {
+		value, found := parent.Delta[k]
+		if found {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
 // returns all the registers that has been touched
 func (v *RemoteView) AllRegisters() []flow.RegisterID {
-	panic("Not implemented yet")
+	touched := make(map[string]flow.RegisterID, len(v.reads)+len(v.writes))
+	for k, id := range v.reads {
+		touched[k] = id
+	}
+	for k, id := range v.writes {
+		touched[k] = id
+	}
+	return sortedRegisterIDs(touched)
+}
+
+// RegistersRead returns the registers that have been read without being written before, sorted by ID
+func (v *RemoteView) RegistersRead() []flow.RegisterID {
+	return sortedRegisterIDs(v.reads)
 }
 
+// RegisterUpdates returns the registers that have been written and their new values, sorted by ID
 func (v *RemoteView) RegisterUpdates() ([]flow.RegisterID, []flow.RegisterValue) {
-	panic("Not implemented yet")
+	ids := sortedRegisterIDs(v.writes)
+	values := make([]flow.RegisterValue, len(ids))
+	for i, id := range ids {
+		values[i] = v.Delta[id.Owner+"~"+id.Controller+"~"+id.Key]
+	}
+	return ids, values
+}
+
+func sortedRegisterIDs(registers map[string]flow.RegisterID) []flow.RegisterID {
+	keys := make([]string, 0, len(registers))
+	for k := range registers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ids := make([]flow.RegisterID, len(keys))
+	for i, k := range keys {
+		ids[i] = registers[k]
+	}
+	return ids
 }
 
 func (v *RemoteView) Touch(owner, controller, key string) error {
@@ -206,6 +273,5 @@ func (v *RemoteView) Touch(owner, controller, key string) error {
 }
 
 func (v *RemoteView) Delete(owner, controller, key string) error {
-	v.Delta[owner+"~"+controller+"~"+key] = nil
-	return nil
+	return v.Set(owner, controller, key, nil)
 }
@@ -0,0 +1,154 @@
+package debug
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// newTestRemoteView returns a remote view reading the given registers, keyed by register key,
+// from a mocked execution node
+func newTestRemoteView(t *testing.T, registers map[string]flow.RegisterValue) (*RemoteView, *accessmock.ExecutionAPIClient) {
+	client := new(accessmock.ExecutionAPIClient)
+	client.On("GetRegisterAtBlockID", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *execution.GetRegisterAtBlockIDRequest, _ ...grpc.CallOption) *execution.GetRegisterAtBlockIDResponse {
+			value, ok := registers[string(req.RegisterKey)]
+			require.True(t, ok, "unexpected register %s", req.RegisterKey)
+			return &execution.GetRegisterAtBlockIDResponse{Value: value}
+		},
+		nil,
+	)
+
+	blockID := unittest.IdentifierFixture()
+	view := &RemoteView{
+		Delta:              make(map[string]flow.RegisterValue),
+		reads:              make(map[string]flow.RegisterID),
+		writes:             make(map[string]flow.RegisterID),
+		Cache:              newMemRegisterCache(),
+		BlockID:            blockID[:],
+		executionAPIclient: client,
+	}
+	return view, client
+}
+
+func register(key string) flow.RegisterID {
+	return flow.NewRegisterID("owner", "", key)
+}
+
+func TestRemoteView(t *testing.T) {
+
+	t.Run("registers read and written through children", func(t *testing.T) {
+		view, client := newTestRemoteView(t, map[string]flow.RegisterValue{
+			"a": []byte("a"),
+			"c": []byte("c"),
+		})
+
+		value, err := view.Get("owner", "", "a")
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("a"), value)
+		require.NoError(t, view.Set("owner", "", "b", []byte("b")))
+
+		child := view.NewChild()
+
+		// served from the delta of the parent, so not read from the state
+		value, err = child.Get("owner", "", "b")
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("b"), value)
+
+		// served from the cache of the parent
+		value, err = child.Get("owner", "", "a")
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("a"), value)
+
+		value, err = child.Get("owner", "", "c")
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("c"), value)
+
+		require.NoError(t, child.Set("owner", "", "d", []byte("d")))
+		require.NoError(t, child.Set("owner", "", "b", []byte("b2")))
+
+		require.NoError(t, view.MergeView(child))
+
+		assert.Equal(t, []flow.RegisterID{register("a"), register("c")}, view.RegistersRead())
+
+		ids, values := view.RegisterUpdates()
+		assert.Equal(t, []flow.RegisterID{register("b"), register("d")}, ids)
+		assert.Equal(t, []flow.RegisterValue{[]byte("b2"), []byte("d")}, values)
+
+		assert.Equal(t,
+			[]flow.RegisterID{register("a"), register("b"), register("c"), register("d")},
+			view.AllRegisters(),
+		)
+
+		// every register is only fetched once
+		client.AssertNumberOfCalls(t, "GetRegisterAtBlockID", 2)
+	})
+
+	t.Run("registers written before they are read", func(t *testing.T) {
+		view, client := newTestRemoteView(t, nil)
+
+		child := view.NewChild()
+		require.NoError(t, child.Set("owner", "", "a", []byte("a")))
+
+		grandchild := child.NewChild()
+		value, err := grandchild.Get("owner", "", "a")
+		require.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("a"), value)
+
+		require.NoError(t, child.MergeView(grandchild))
+		require.NoError(t, view.MergeView(child))
+
+		assert.Empty(t, view.RegistersRead())
+		ids, _ := view.RegisterUpdates()
+		assert.Equal(t, []flow.RegisterID{register("a")}, ids)
+		client.AssertNotCalled(t, "GetRegisterAtBlockID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dropped writes", func(t *testing.T) {
+		view, _ := newTestRemoteView(t, map[string]flow.RegisterValue{
+			"a": []byte("a"),
+		})
+
+		_, err := view.Get("owner", "", "a")
+		require.NoError(t, err)
+		require.NoError(t, view.Set("owner", "", "b", []byte("b")))
+		view.DropDelta()
+
+		assert.Equal(t, []flow.RegisterID{register("a")}, view.RegistersRead())
+		ids, _ := view.RegisterUpdates()
+		assert.Empty(t, ids)
+	})
+}
+
+func TestFileRegisterCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registers")
+	blockID := unittest.IdentifierFixture()
+
+	cache, err := newFileRegisterCache(path, blockID)
+	require.NoError(t, err)
+	cache.Set("owner", "", "a", []byte("a"))
+	require.NoError(t, cache.Persist())
+
+	t.Run("same block", func(t *testing.T) {
+		cache, err := newFileRegisterCache(path, blockID)
+		require.NoError(t, err)
+		value, found := cache.Get("owner", "", "a")
+		require.True(t, found)
+		assert.Equal(t, []byte("a"), value)
+	})
+
+	t.Run("different block", func(t *testing.T) {
+		_, err := newFileRegisterCache(path, unittest.IdentifierFixture())
+		assert.Error(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	crand "crypto/rand"
 	"fmt"
 	"math/rand"
+	"sort"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/model/flow/signerindices"
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module/mempool/entity"
@@ -295,7 +297,7 @@ func BlockWithParentAndProposerFixture(parent *flow.Header, proposer flow.Identi
 	block := BlockWithParentFixture(parent)
 
 	block.Header.ProposerID = proposer
-	block.Header.ParentVoterIDs = []flow.Identifier{proposer}
+	block.Header.ParentVoterIndices = SignerIndicesByIdentifiers([]flow.Identifier{proposer}, []flow.Identifier{proposer})
 
 	return *block
 }
@@ -388,7 +390,7 @@ func BlockHeaderWithParentFixture(parent *flow.Header) flow.Header {
 		PayloadHash:        IdentifierFixture(),
 		Timestamp:          time.Now().UTC(),
 		View:               view,
-		ParentVoterIDs:     IdentifierListFixture(4),
+		ParentVoterIndices: SignerIndicesFixture(4),
 		ParentVoterSigData: CombinedSignatureFixture(2),
 		ProposerID:         IdentifierFixture(),
 		ProposerSigData:    SignatureFixture(),
@@ -453,9 +455,9 @@ func WithCollection(collection *flow.Collection) func(guarantee *flow.Collection
 
 func CollectionGuaranteeFixture(options ...func(*flow.CollectionGuarantee)) *flow.CollectionGuarantee {
 	guarantee := &flow.CollectionGuarantee{
-		CollectionID: IdentifierFixture(),
-		SignerIDs:    IdentifierListFixture(16),
-		Signature:    SignatureFixture(),
+		CollectionID:  IdentifierFixture(),
+		SignerIndices: SignerIndicesFixture(16),
+		Signature:     SignatureFixture(),
 	}
 	for _, option := range options {
 		option(guarantee)
@@ -546,7 +548,7 @@ func ExecutableBlockFixtureWithParent(collectionsSignerIDs [][]flow.Identifier,
 
 	for _, signerIDs := range collectionsSignerIDs {
 		completeCollection := CompleteCollectionFixture()
-		completeCollection.Guarantee.SignerIDs = signerIDs
+		completeCollection.Guarantee.SignerIndices = SignerIndicesByIdentifiers(signerIDs, signerIDs)
 		block.Payload.Guarantees = append(block.Payload.Guarantees, completeCollection.Guarantee)
 		completeCollections[completeCollection.Guarantee.CollectionID] = completeCollection
 	}
@@ -1472,10 +1474,10 @@ func PublicKeysFixture(n int, algo crypto.SigningAlgorithm) []crypto.PublicKey {
 
 func QuorumCertificateFixture(opts ...func(*flow.QuorumCertificate)) *flow.QuorumCertificate {
 	qc := flow.QuorumCertificate{
-		View:      uint64(rand.Uint32()),
-		BlockID:   IdentifierFixture(),
-		SignerIDs: IdentifierListFixture(3),
-		SigData:   CombinedSignatureFixture(2),
+		View:          uint64(rand.Uint32()),
+		BlockID:       IdentifierFixture(),
+		SignerIndices: SignerIndicesFixture(3),
+		SigData:       CombinedSignatureFixture(2),
 	}
 	for _, apply := range opts {
 		apply(&qc)
@@ -1497,12 +1499,31 @@ func QCWithBlockID(blockID flow.Identifier) func(*flow.QuorumCertificate) {
 	}
 }
 
-func QCWithSignerIDs(signerIDs []flow.Identifier) func(*flow.QuorumCertificate) {
+func QCWithSignerIndices(signerIndices []byte) func(*flow.QuorumCertificate) {
 	return func(qc *flow.QuorumCertificate) {
-		qc.SignerIDs = signerIDs
+		qc.SignerIndices = signerIndices
 	}
 }
 
+// SignerIndicesFixture returns signer indices of n signers, encoded relative to a
+// random committee of n members.
+func SignerIndicesFixture(n int) []byte {
+	committee := IdentityListFixture(n).Sort(order.Canonical).NodeIDs()
+	return SignerIndicesByIdentifiers(committee, committee)
+}
+
+// SignerIndicesByIdentifiers returns the signer indices of the given signers, encoded
+// relative to the committee, which is brought into canonical order first.
+func SignerIndicesByIdentifiers(committee flow.IdentifierList, signerIDs flow.IdentifierList) []byte {
+	canonical := committee.Copy()
+	sort.Sort(canonical)
+	signerIndices, err := signerindices.Encode(canonical, signerIDs)
+	if err != nil {
+		panic(err)
+	}
+	return signerIndices
+}
+
 func VoteFixture() *hotstuff.Vote {
 	return &hotstuff.Vote{
 		View:     uint64(rand.Uint32()),
@@ -1594,12 +1615,13 @@ func WithDKGFromParticipants(participants flow.IdentityList) func(*flow.EpochCom
 }
 
 func WithClusterQCsFromAssignments(assignments flow.AssignmentList) func(*flow.EpochCommit) {
-	qcs := make([]*flow.QuorumCertificate, 0, len(assignments))
+	qcs := make([]flow.ClusterQCVoteData, 0, len(assignments))
 	for _, cluster := range assignments {
-		qcs = append(qcs, QuorumCertificateFixture(QCWithSignerIDs(cluster)))
+		qc := QuorumCertificateFixture(QCWithSignerIndices(SignerIndicesByIdentifiers(cluster, cluster)))
+		qcs = append(qcs, flow.ClusterQCVoteDataFromQC(qc, cluster))
 	}
 	return func(commit *flow.EpochCommit) {
-		commit.ClusterQCs = flow.ClusterQCVoteDatasFromQCs(qcs)
+		commit.ClusterQCs = qcs
 	}
 }
 
@@ -1623,7 +1645,7 @@ func CommitWithCounter(counter uint64) func(*flow.EpochCommit) {
 func EpochCommitFixture(opts ...func(*flow.EpochCommit)) *flow.EpochCommit {
 	commit := &flow.EpochCommit{
 		Counter:            uint64(rand.Uint32()),
-		ClusterQCs:         flow.ClusterQCVoteDatasFromQCs(QuorumCertificatesFixtures(1)),
+		ClusterQCs:         []flow.ClusterQCVoteData{flow.ClusterQCVoteDataFromQC(QuorumCertificateFixture(), IdentifierListFixture(3))},
 		DKGGroupKey:        KeyFixture(crypto.BLSBLS12381).PublicKey(),
 		DKGParticipantKeys: PublicKeysFixture(2, crypto.BLSBLS12381),
 	}